@@ -54,6 +54,8 @@ func main() {
 	if err := e.Shutdown(ctx); err != nil {
 		e.Logger.Fatal(err)
 	}
+	// 応答した後に続けている処理を落とさないよう、終わるのを待ってから終了する
+	app.Stop()
 
 	log.Println("Process finished.")
 }
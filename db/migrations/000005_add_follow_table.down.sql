@@ -0,0 +1 @@
+DROP TABLE IF EXISTS follow;
\ No newline at end of file
@@ -0,0 +1,8 @@
+CREATE TABLE follow (
+    follower_id VARCHAR(255) NOT NULL,
+    followee_id VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (follower_id, followee_id)
+);
+
+CREATE INDEX idx_follow_followee_id ON follow (followee_id);
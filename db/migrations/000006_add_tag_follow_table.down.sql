@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS tag_new_work;
+DROP TABLE IF EXISTS tag_follow;
\ No newline at end of file
@@ -0,0 +1,16 @@
+CREATE TABLE tag_follow (
+    user_id VARCHAR(255) NOT NULL,
+    tag_id VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, tag_id)
+);
+
+CREATE INDEX idx_tag_follow_tag_id ON tag_follow (tag_id);
+
+CREATE TABLE tag_new_work (
+    user_id VARCHAR(255) NOT NULL,
+    work_id VARCHAR(255) NOT NULL,
+    seen_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, work_id)
+);
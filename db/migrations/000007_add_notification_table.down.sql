@@ -0,0 +1 @@
+DROP TABLE IF EXISTS notification;
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE notification (
+    id VARCHAR(255) PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id VARCHAR(255) NOT NULL,
+    actor_id VARCHAR(255) NOT NULL,
+    type VARCHAR(32) NOT NULL CHECK (type IN ('favorite', 'comment', 'reply')),
+    work_id VARCHAR(255) NOT NULL,
+    comment_id VARCHAR(255),
+    read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_notification_user_id_created_at ON notification (user_id, created_at DESC);
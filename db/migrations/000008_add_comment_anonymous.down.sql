@@ -0,0 +1,3 @@
+ALTER TABLE comment DROP COLUMN fingerprint;
+ALTER TABLE comment DROP COLUMN anonymous_name;
+ALTER TABLE comment DROP COLUMN anonymous;
@@ -0,0 +1,7 @@
+ALTER TABLE comment ADD COLUMN anonymous BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE comment ADD COLUMN anonymous_name VARCHAR(255);
+ALTER TABLE comment ADD COLUMN fingerprint VARCHAR(64);
+
+-- これまでuser_idなしで投稿されたコメントは匿名コメントとして扱う
+UPDATE comment SET anonymous = TRUE
+WHERE user_id IS NULL OR user_id = '' OR user_id = '00000000-0000-0000-0000-000000000000';
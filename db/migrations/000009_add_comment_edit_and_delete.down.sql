@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_comment_reply_at;
+
+ALTER TABLE comment DROP COLUMN deleted_at;
+ALTER TABLE comment DROP COLUMN edited_at;
@@ -0,0 +1,4 @@
+ALTER TABLE comment ADD COLUMN edited_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE comment ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_comment_reply_at ON comment (reply_at);
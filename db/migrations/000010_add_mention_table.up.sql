@@ -0,0 +1,12 @@
+CREATE TABLE mention (
+    id VARCHAR(255) PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id VARCHAR(255) NOT NULL,
+    actor_id VARCHAR(255) NOT NULL,
+    work_id VARCHAR(255) NOT NULL,
+    comment_id VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- 同じ作品の説明文やコメントから同じユーザーへのメンションは一度だけ記録する
+CREATE UNIQUE INDEX idx_mention_source ON mention (user_id, work_id, COALESCE(comment_id, ''));
+CREATE INDEX idx_mention_user_id_created_at ON mention (user_id, created_at DESC);
@@ -0,0 +1,10 @@
+CREATE TABLE reaction (
+    target_type VARCHAR(16) NOT NULL,
+    target_id VARCHAR(255) NOT NULL,
+    user_id VARCHAR(255) NOT NULL,
+    emoji VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (target_type, target_id, user_id, emoji)
+);
+
+CREATE INDEX idx_reaction_target ON reaction (target_type, target_id);
@@ -0,0 +1,12 @@
+CREATE TABLE asset_variant (
+    id VARCHAR(255) PRIMARY KEY DEFAULT gen_random_uuid(),
+    asset_id VARCHAR(255) NOT NULL,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    url VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- 1つのアセットにつき同じ幅の派生画像は1つだけ持つ
+CREATE UNIQUE INDEX idx_asset_variant_asset_id_width ON asset_variant (asset_id, width);
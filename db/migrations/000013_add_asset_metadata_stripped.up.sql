@@ -0,0 +1,2 @@
+-- 保存前に EXIF・XMP・GPS などのメタデータを取り除いたかどうかを記録する
+ALTER TABLE asset ADD COLUMN metadata_stripped BOOLEAN NOT NULL DEFAULT false;
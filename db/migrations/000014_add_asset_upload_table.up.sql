@@ -0,0 +1,12 @@
+CREATE TABLE asset_upload (
+    id VARCHAR(255) PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL,
+    extension VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    size BIGINT NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- 期限切れのアップロードを掃除するときに使う
+CREATE INDEX idx_asset_upload_expires_at ON asset_upload (expires_at);
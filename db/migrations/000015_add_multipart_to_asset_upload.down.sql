@@ -0,0 +1,2 @@
+ALTER TABLE asset_upload DROP COLUMN IF EXISTS part_size;
+ALTER TABLE asset_upload DROP COLUMN IF EXISTS multipart_upload_id;
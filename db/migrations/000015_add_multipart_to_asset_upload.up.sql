@@ -0,0 +1,2 @@
+ALTER TABLE asset_upload ADD COLUMN multipart_upload_id VARCHAR(1024) NOT NULL DEFAULT '';
+ALTER TABLE asset_upload ADD COLUMN part_size BIGINT NOT NULL DEFAULT 0;
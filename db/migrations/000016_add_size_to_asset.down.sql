@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_asset_user_id;
+ALTER TABLE asset DROP COLUMN size;
@@ -0,0 +1,3 @@
+-- 既存のアセットはサイズが分からないため 0 とする
+ALTER TABLE asset ADD COLUMN size BIGINT NOT NULL DEFAULT 0;
+CREATE INDEX idx_asset_user_id ON asset (user_id);
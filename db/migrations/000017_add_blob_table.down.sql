@@ -0,0 +1,2 @@
+ALTER TABLE asset DROP COLUMN hash;
+DROP TABLE IF EXISTS blob;
@@ -0,0 +1,13 @@
+-- 同じ内容のファイルを一度だけ保存するため、SHA-256 ごとに保存先と参照しているアセットの数を記録する
+CREATE TABLE blob (
+    hash CHAR(64) PRIMARY KEY,
+    storage_key VARCHAR(1024) NOT NULL,
+    size BIGINT NOT NULL,
+    ref_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_blob_storage_key ON blob (storage_key);
+
+-- 既存のアセットと直接アップロードした画像以外のファイルは空文字
+ALTER TABLE asset ADD COLUMN hash VARCHAR(64) NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+ALTER TABLE asset DROP COLUMN zip_manifest;
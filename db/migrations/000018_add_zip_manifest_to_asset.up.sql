@@ -0,0 +1,2 @@
+-- ZIP アセットに含まれるファイルの一覧。ZIP 以外と既存のアセットは NULL
+ALTER TABLE asset ADD COLUMN zip_manifest JSONB;
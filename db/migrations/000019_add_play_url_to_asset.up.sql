@@ -0,0 +1,2 @@
+-- ZIP から展開したブラウザで遊べるビルドの index.html の URL。ビルドを含まないアセットは空文字
+ALTER TABLE asset ADD COLUMN play_url TEXT NOT NULL DEFAULT '';
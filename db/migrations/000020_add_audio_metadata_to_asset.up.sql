@@ -0,0 +1,2 @@
+-- 音声アセットの再生時間・タグ・波形。音声以外と読み取れなかったアセットは NULL
+ALTER TABLE asset ADD COLUMN audio_metadata JSONB;
@@ -0,0 +1,2 @@
+-- glTF・GLB の頂点数・マテリアル・大きさ。それ以外のアセットは NULL
+ALTER TABLE asset ADD COLUMN model_stats JSONB;
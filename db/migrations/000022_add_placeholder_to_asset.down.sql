@@ -0,0 +1,4 @@
+ALTER TABLE asset DROP COLUMN dominant_color;
+ALTER TABLE asset DROP COLUMN blurhash;
+ALTER TABLE asset DROP COLUMN height;
+ALTER TABLE asset DROP COLUMN width;
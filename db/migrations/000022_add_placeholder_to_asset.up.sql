@@ -0,0 +1,5 @@
+-- 画像アセットを読み込むまでの間に表示するプレースホルダー。画像以外と、まだ作成していない画像は NULL
+ALTER TABLE asset ADD COLUMN width INTEGER;
+ALTER TABLE asset ADD COLUMN height INTEGER;
+ALTER TABLE asset ADD COLUMN blurhash TEXT;
+ALTER TABLE asset ADD COLUMN dominant_color TEXT;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_asset_user_id_hash;
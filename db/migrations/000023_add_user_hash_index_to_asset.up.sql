@@ -0,0 +1,2 @@
+-- 同じ内容のファイルを既にアップロードしているかを、ユーザーごとに内容の SHA-256 で探す
+CREATE INDEX idx_asset_user_id_hash ON asset (user_id, hash);
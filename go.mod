@@ -26,6 +26,7 @@ require (
 	github.com/golang-jwt/jwt/v5 v5.2.2
 	github.com/golang-migrate/migrate/v4 v4.19.0
 	github.com/testcontainers/testcontainers-go v0.40.0
+	golang.org/x/image v0.34.0
 )
 
 require (
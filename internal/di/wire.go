@@ -5,25 +5,41 @@
 package di
 
 import (
+	"context"
+	"log"
 	"time"
 
-	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/google/uuid"
 	"github.com/google/wire"
 	"github.com/labstack/echo/v4"
 	"github.com/uptrace/bun"
 
 	"github.com/simesaba80/toybox-back/internal/domain/repository"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/config"
 	"github.com/simesaba80/toybox-back/internal/infrastructure/database/asset"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/assetupload"
 	"github.com/simesaba80/toybox-back/internal/infrastructure/database/comment"
 	"github.com/simesaba80/toybox-back/internal/infrastructure/database/favorite"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/follow"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/mention"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/notification"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/reaction"
 	"github.com/simesaba80/toybox-back/internal/infrastructure/database/tag"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/tagfollow"
 	"github.com/simesaba80/toybox-back/internal/infrastructure/database/token"
 	"github.com/simesaba80/toybox-back/internal/infrastructure/database/user"
 	"github.com/simesaba80/toybox-back/internal/infrastructure/database/work"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/external/audiometa"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/external/blobstore"
 	customejwt "github.com/simesaba80/toybox-back/internal/infrastructure/external/custome-jwt"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/external/eventbroker"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/external/gltfmodel"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/external/imageproc"
 	"github.com/simesaba80/toybox-back/internal/infrastructure/external/oauth"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/external/ziparchive"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/janitor"
 	"github.com/simesaba80/toybox-back/internal/infrastructure/router"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/worker"
 	"github.com/simesaba80/toybox-back/internal/interface/controller"
 	"github.com/simesaba80/toybox-back/internal/usecase"
 	"github.com/simesaba80/toybox-back/pkg/db"
@@ -43,21 +59,40 @@ var RepositorySet = wire.NewSet(
 	wire.Bind(new(repository.TokenRepository), new(*token.TokenRepository)),
 	asset.NewAssetRepository,
 	wire.Bind(new(repository.AssetRepository), new(*asset.AssetRepository)),
+	assetupload.NewAssetUploadRepository,
+	wire.Bind(new(repository.AssetUploadRepository), new(*assetupload.AssetUploadRepository)),
 	favorite.NewFavoriteRepository,
 	wire.Bind(new(repository.FavoriteRepository), new(*favorite.FavoriteRepository)),
 	tag.NewTagRepository,
 	wire.Bind(new(repository.TagRepository), new(*tag.TagRepository)),
+	follow.NewFollowRepository,
+	wire.Bind(new(repository.FollowRepository), new(*follow.FollowRepository)),
+	tagfollow.NewTagFollowRepository,
+	wire.Bind(new(repository.TagFollowRepository), new(*tagfollow.TagFollowRepository)),
+	notification.NewNotificationRepository,
+	wire.Bind(new(repository.NotificationRepository), new(*notification.NotificationRepository)),
+	mention.NewMentionRepository,
+	wire.Bind(new(repository.MentionRepository), new(*mention.MentionRepository)),
+	reaction.NewReactionRepository,
+	wire.Bind(new(repository.ReactionRepository), new(*reaction.ReactionRepository)),
 )
 
 var UseCaseSet = wire.NewSet(
 	ProvideUserUseCase,
 	ProvideWorkUseCase,
+	ProvideCommentFilters,
 	ProvideCommentUseCase,
 	ProvideAuthUseCase,
 	ProvideTokenProvider,
 	ProvideAssetUseCase,
 	ProvideFavoriteUseCase,
 	ProvideTagUseCase,
+	ProvideFollowUseCase,
+	ProvideTagFollowUseCase,
+	ProvideNotificationUseCase,
+	ProvideEventUseCase,
+	ProvideMentionUseCase,
+	ProvideReactionUseCase,
 )
 
 var ControllerSet = wire.NewSet(
@@ -68,11 +103,30 @@ var ControllerSet = wire.NewSet(
 	controller.NewAssetController,
 	controller.NewFavoriteController,
 	controller.NewTagController,
+	controller.NewFollowController,
+	controller.NewTagFollowController,
+	controller.NewNotificationController,
+	controller.NewEventController,
+	controller.NewMentionController,
+	controller.NewReactionController,
 )
 
 var InfrastructureSet = wire.NewSet(
 	ProvideDatabase,
-	ProvideS3Client,
+	ProvideBlobStore,
+	ProvideEventBroker,
+	wire.Bind(new(repository.EventBroker), new(*eventbroker.MemoryBroker)),
+	ProvideImageProcessor,
+	wire.Bind(new(repository.ImageProcessor), new(*imageproc.Processor)),
+	ProvideArchiveInspector,
+	wire.Bind(new(repository.ArchiveInspector), new(*ziparchive.Inspector)),
+	ProvideAudioAnalyzer,
+	wire.Bind(new(repository.AudioAnalyzer), new(*audiometa.Analyzer)),
+	ProvideModelInspector,
+	wire.Bind(new(repository.ModelInspector), new(*gltfmodel.Inspector)),
+	ProvideUploadJanitor,
+	ProvideTaskWorker,
+	wire.Bind(new(repository.TaskRunner), new(*worker.Worker)),
 	router.NewRouter,
 	ProvideEcho,
 )
@@ -92,9 +146,23 @@ func ProvideDatabase() *bun.DB {
 	return db.DB
 }
 
-func ProvideS3Client() *s3.Client {
+// ProvideBlobStore は STORAGE_BACKEND で選んだファイルの保存先を提供します
+func ProvideBlobStore() repository.BlobStore {
+	if config.STORAGE_BACKEND == config.StorageBackendLocal {
+		return blobstore.NewLocalStore(config.LOCAL_STORAGE_DIR, config.LOCAL_STORAGE_BASE_URL)
+	}
 	s3_client.Init()
-	return s3_client.Client
+	return blobstore.NewS3Store(s3_client.Client, config.S3_BUCKET, config.S3_BASE_URL)
+}
+
+// ProvideEventBroker はイベントのブローカーを提供します
+func ProvideEventBroker() *eventbroker.MemoryBroker {
+	return eventbroker.NewMemoryBroker(256)
+}
+
+// ProvideTaskWorker は応答した後も続ける処理を実行するワーカーを提供します
+func ProvideTaskWorker() *worker.Worker {
+	return worker.NewWorker()
 }
 
 // ProvideUserUseCase はUserUseCaseを提供します
@@ -103,13 +171,27 @@ func ProvideUserUseCase(repo repository.UserRepository) usecase.IUserUseCase {
 }
 
 // ProvideWorkUseCase はWorkUseCaseを提供します
-func ProvideWorkUseCase(workRepo repository.WorkRepository, tagRepo repository.TagRepository) usecase.IWorkUseCase {
-	return usecase.NewWorkUseCase(workRepo, tagRepo)
+func ProvideWorkUseCase(workRepo repository.WorkRepository, tagRepo repository.TagRepository, tagFollowRepo repository.TagFollowRepository, userRepo repository.UserRepository, mentionRepo repository.MentionRepository, reactionRepo repository.ReactionRepository, tasks repository.TaskRunner) usecase.IWorkUseCase {
+	return usecase.NewWorkUseCase(workRepo, tagRepo, tagFollowRepo, userRepo, mentionRepo, reactionRepo, tasks)
 }
 
 // ProvideCommentUseCase はCommentUseCaseを提供します
-func ProvideCommentUseCase(commentRepo repository.CommentRepository, workRepo repository.WorkRepository) usecase.ICommentUsecase {
-	return usecase.NewCommentUsecase(commentRepo, workRepo, 30*time.Second)
+func ProvideCommentUseCase(commentRepo repository.CommentRepository, workRepo repository.WorkRepository, notificationRepo repository.NotificationRepository, eventBroker repository.EventBroker, userRepo repository.UserRepository, mentionRepo repository.MentionRepository, reactionRepo repository.ReactionRepository, filters []usecase.CommentFilter) usecase.ICommentUsecase {
+	anonymousComment := usecase.AnonymousCommentConfig{
+		Enabled: config.ALLOW_ANONYMOUS_COMMENT,
+		Salt:    config.ANONYMOUS_COMMENT_SALT,
+	}
+	return usecase.NewCommentUsecase(commentRepo, workRepo, notificationRepo, eventBroker, userRepo, mentionRepo, reactionRepo, filters, anonymousComment, 30*time.Second)
+}
+
+// ProvideCommentFilters はコメントの投稿時に適用するフィルターを提供します
+func ProvideCommentFilters() []usecase.CommentFilter {
+	return []usecase.CommentFilter{
+		usecase.NewRateLimitFilter(config.COMMENT_RATE_LIMIT, config.COMMENT_RATE_WINDOW),
+		usecase.NewNGWordFilter(config.COMMENT_NG_WORDS),
+		usecase.NewLinkLimitFilter(config.COMMENT_MAX_LINKS),
+		usecase.NewDuplicateFilter(config.COMMENT_DUPLICATE_WINDOW),
+	}
 }
 
 // ProvideDiscordUseCase はDiscordUseCaseを提供します
@@ -129,13 +211,30 @@ func (f tokenProviderFunc) GenerateToken(userID uuid.UUID) (string, error) {
 }
 
 // ProvideAssetUseCase はAssetUseCaseを提供します
-func ProvideAssetUseCase(assetRepo repository.AssetRepository) usecase.IAssetUseCase {
-	return usecase.NewAssetUseCase(assetRepo)
+func ProvideAssetUseCase(assetRepo repository.AssetRepository, assetUploadRepo repository.AssetUploadRepository, imageProcessor repository.ImageProcessor, archiveInspector repository.ArchiveInspector, audioAnalyzer repository.AudioAnalyzer, modelInspector repository.ModelInspector) usecase.IAssetUseCase {
+	processors := usecase.AssetProcessors{
+		ImageProcessor:   imageProcessor,
+		ArchiveInspector: archiveInspector,
+		AudioAnalyzer:    audioAnalyzer,
+		ModelInspector:   modelInspector,
+	}
+	return usecase.NewAssetUseCase(assetRepo, assetUploadRepo, processors, config.ASSET_UPLOAD_URL_TTL, config.ASSET_MULTIPART_UPLOAD_TTL, config.ASSET_STORAGE_QUOTA)
+}
+
+// ProvideUploadJanitor は期限切れのアップロードを定期的に片付けるジャニターを提供します
+func ProvideUploadJanitor(assetUseCase usecase.IAssetUseCase) *janitor.Janitor {
+	return janitor.NewJanitor("アップロードの片付け", config.ASSET_UPLOAD_JANITOR_INTERVAL, func(ctx context.Context) error {
+		cleaned, err := assetUseCase.AbortExpiredUploads(ctx)
+		if cleaned > 0 {
+			log.Printf("期限切れのアップロードを%d件片付けました", cleaned)
+		}
+		return err
+	})
 }
 
 // ProvideFavoriteUseCase はFavoriteUseCaseを提供します
-func ProvideFavoriteUseCase(favoriteRepo repository.FavoriteRepository) usecase.IFavoriteUsecase {
-	return usecase.NewFavoriteUsecase(favoriteRepo)
+func ProvideFavoriteUseCase(favoriteRepo repository.FavoriteRepository, workRepo repository.WorkRepository, notificationRepo repository.NotificationRepository, eventBroker repository.EventBroker) usecase.IFavoriteUsecase {
+	return usecase.NewFavoriteUsecase(favoriteRepo, workRepo, notificationRepo, eventBroker)
 }
 
 // ProvideTagUseCase はTagUseCaseを提供します
@@ -143,17 +242,73 @@ func ProvideTagUseCase(tagRepo repository.TagRepository) usecase.ITagUseCase {
 	return usecase.NewTagUseCase(tagRepo)
 }
 
+// ProvideFollowUseCase はFollowUseCaseを提供します
+func ProvideFollowUseCase(followRepo repository.FollowRepository, userRepo repository.UserRepository) usecase.IFollowUsecase {
+	return usecase.NewFollowUsecase(followRepo, userRepo)
+}
+
+// ProvideTagFollowUseCase はTagFollowUseCaseを提供します
+func ProvideTagFollowUseCase(tagFollowRepo repository.TagFollowRepository, tagRepo repository.TagRepository) usecase.ITagFollowUsecase {
+	return usecase.NewTagFollowUsecase(tagFollowRepo, tagRepo)
+}
+
+// ProvideNotificationUseCase はNotificationUseCaseを提供します
+func ProvideNotificationUseCase(notificationRepo repository.NotificationRepository) usecase.INotificationUsecase {
+	return usecase.NewNotificationUsecase(notificationRepo)
+}
+
+// ProvideEventUseCase はEventUseCaseを提供します
+func ProvideEventUseCase(eventBroker repository.EventBroker) usecase.IEventUsecase {
+	return usecase.NewEventUsecase(eventBroker)
+}
+
+// ProvideMentionUseCase はMentionUseCaseを提供します
+func ProvideMentionUseCase(mentionRepo repository.MentionRepository) usecase.IMentionUsecase {
+	return usecase.NewMentionUsecase(mentionRepo)
+}
+
+// ProvideReactionUseCase はReactionUseCaseを提供します
+func ProvideReactionUseCase(reactionRepo repository.ReactionRepository, workRepo repository.WorkRepository, commentRepo repository.CommentRepository) usecase.IReactionUsecase {
+	return usecase.NewReactionUsecase(reactionRepo, workRepo, commentRepo, config.REACTION_EMOJIS)
+}
+
+// ProvideImageProcessor は画像の加工処理を提供します
+// ASSET_IMAGE_MAX_PIXELS を超える画像はデコード時のメモリ消費が大きいため派生画像を作りません
+func ProvideImageProcessor() *imageproc.Processor {
+	return imageproc.NewProcessor(config.ASSET_IMAGE_MAX_PIXELS, config.ASSET_IMAGE_QUALITY)
+}
+
+// ProvideArchiveInspector は ZIP の検査処理を提供します
+// 展開後の大きさや圧縮率が ASSET_ZIP_* の上限を超えるものは ZIP 爆弾として断ります
+func ProvideArchiveInspector() *ziparchive.Inspector {
+	return ziparchive.NewInspector(config.ASSET_ZIP_MAX_ENTRIES, config.ASSET_ZIP_MAX_TOTAL_SIZE, config.ASSET_ZIP_MAX_RATIO)
+}
+
+// ProvideAudioAnalyzer は音声の読み取り処理を提供します
+// 波形は ASSET_AUDIO_PEAK_COUNT 区間に分けます
+func ProvideAudioAnalyzer() *audiometa.Analyzer {
+	return audiometa.NewAnalyzer(config.ASSET_AUDIO_PEAK_COUNT)
+}
+
+// ProvideModelInspector は 3D モデルの検査処理を提供します
+// シーンの辿り方が膨れ上がるモデルを断るため、ノードを辿る回数は ASSET_MODEL_MAX_NODE_VISITS までとします
+func ProvideModelInspector() *gltfmodel.Inspector {
+	return gltfmodel.NewInspector(config.ASSET_MODEL_MAX_NODE_VISITS)
+}
+
 // ProvideEcho はEchoインスタンスを提供します
 func ProvideEcho() *echo.Echo {
 	return echo.New()
 }
 
 // NewApp はAppインスタンスを作成します
-func NewApp(router *router.Router, database *bun.DB, s3Client *s3.Client) *App {
+func NewApp(router *router.Router, database *bun.DB, eventBroker *eventbroker.MemoryBroker, uploadJanitor *janitor.Janitor, taskWorker *worker.Worker) *App {
 	return &App{
-		Router:   router,
-		Database: database,
-		S3Client: s3Client,
+		Router:        router,
+		Database:      database,
+		EventBroker:   eventBroker,
+		UploadJanitor: uploadJanitor,
+		TaskWorker:    taskWorker,
 	}
 }
 
@@ -164,14 +319,28 @@ func InitializeApp() (*App, func(), error) {
 }
 
 type App struct {
-	Router   *router.Router
-	Database *bun.DB
-	S3Client *s3.Client
+	Router        *router.Router
+	Database      *bun.DB
+	EventBroker   *eventbroker.MemoryBroker
+	UploadJanitor *janitor.Janitor
+	TaskWorker    *worker.Worker
 }
 
 // Start アプリケーションの開始
 func (app *App) Start() *echo.Echo {
-	return app.Router.Setup()
+	e := app.Router.Setup()
+	// SSEの接続はShutdownを待っても終わらないため、停止時にブローカーを閉じて接続を終わらせる
+	e.Server.RegisterOnShutdown(app.EventBroker.Close)
+	app.UploadJanitor.Start()
+	e.Server.RegisterOnShutdown(app.UploadJanitor.Stop)
+	return e
+}
+
+// Stop サーバーの停止後にバックグラウンドの処理の終了を待ちます
+// RegisterOnShutdown に登録した関数は Shutdown が終了を待たないため、データベースを閉じる前にここで待ちます
+func (app *App) Stop() {
+	app.UploadJanitor.Stop()
+	app.TaskWorker.Stop()
 }
 
 // Cleanup アプリケーションのクリーンアップ
@@ -6,6 +6,12 @@ import (
 	"github.com/google/uuid"
 )
 
+// AssetTypeImage は画像アセットの種類です
+const AssetTypeImage = "image"
+
+// AssetTypeMusic は音声アセットの種類です
+const AssetTypeMusic = "music"
+
 type Asset struct {
 	ID        uuid.UUID
 	WorkID    uuid.UUID
@@ -13,8 +19,26 @@ type Asset struct {
 	UserID    uuid.UUID
 	Extension string
 	URL       string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	// Size は保存した元ファイルのバイト数
+	Size int64
+	// Hash は受け取ったままの元ファイルの SHA-256 (16 進数)。メタデータを取り除いた画像も取り除く前の内容で計算する。
+	// ハッシュを記録する前からあるアセットは空
+	Hash     string
+	Variants []*AssetVariant
+	// ZipManifest は ZIP に含まれるファイルの一覧。作成時にだけ使い、取得した Asset には含まない
+	ZipManifest *ZipManifest
+	// PlayURL はブラウザで遊べるビルドを展開した index.html の URL。ZIP にビルドが含まれない場合は空
+	PlayURL string
+	// AudioMetadata は音声アセットの再生時間や波形。音声以外と読み取れなかった場合は nil
+	AudioMetadata *AudioMetadata
+	// ModelStats は glTF・GLB の頂点数や大きさ。それ以外の形式の場合は nil
+	ModelStats *ModelStats
+	// Placeholder は画像アセットを読み込むまでの間に表示するプレースホルダー。画像以外と作成できなかった場合は nil
+	Placeholder *ImagePlaceholder
+	// MetadataStripped は保存前に EXIF などのメタデータを取り除いたかどうか
+	MetadataStripped bool
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
 }
 
 func NewAsset(assetType string, userID uuid.UUID, extension string, url string) *Asset {
@@ -0,0 +1,106 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// マルチパートアップロードのパートの大きさです。
+// S3 は最後以外のパートに 5MiB 以上を求め、1 つのアップロードのパートは 10000 個までです。
+const (
+	MultipartMinPartSize     int64 = 5 << 20
+	MultipartDefaultPartSize int64 = 16 << 20
+	MultipartMaxParts              = 10000
+)
+
+// AssetUpload はクライアントが署名付き URL で S3 へ直接アップロードしているアセットです。
+// アップロードの完了を確認したら同じ ID でアセットを登録し、AssetUpload は削除します。
+// MultipartUploadID が空でない場合はパートに分けてアップロードしています。
+type AssetUpload struct {
+	ID                uuid.UUID
+	UserID            uuid.UUID
+	Extension         string
+	ContentType       string
+	Size              int64
+	MultipartUploadID string
+	PartSize          int64
+	ExpiresAt         time.Time
+	CreatedAt         time.Time
+}
+
+func NewAssetUpload(userID uuid.UUID, extension string, contentType string, size int64, ttl time.Duration) *AssetUpload {
+	now := time.Now()
+	return &AssetUpload{
+		ID:          uuid.New(),
+		UserID:      userID,
+		Extension:   extension,
+		ContentType: contentType,
+		Size:        size,
+		ExpiresAt:   now.Add(ttl),
+		CreatedAt:   now,
+	}
+}
+
+// NewMultipartAssetUpload はパートに分けてアップロードするアセットを作ります。
+// パートの大きさは既定値を基本とし、パートの数が上限を超える場合だけ大きくします。
+func NewMultipartAssetUpload(userID uuid.UUID, extension string, contentType string, size int64, ttl time.Duration) *AssetUpload {
+	upload := NewAssetUpload(userID, extension, contentType, size, ttl)
+	partSize := MultipartDefaultPartSize
+	if minSize := (size + MultipartMaxParts - 1) / MultipartMaxParts; minSize > partSize {
+		// 1MiB 単位に切り上げる
+		partSize = (minSize + 1<<20 - 1) / (1 << 20) * (1 << 20)
+	}
+	upload.PartSize = partSize
+	return upload
+}
+
+// IsMultipart はパートに分けてアップロードしているかどうかを返します
+func (u *AssetUpload) IsMultipart() bool {
+	return u.PartSize > 0
+}
+
+// PartCount はアップロードに必要なパートの数を返します
+func (u *AssetUpload) PartCount() int32 {
+	if !u.IsMultipart() {
+		return 0
+	}
+	return int32((u.Size + u.PartSize - 1) / u.PartSize)
+}
+
+// PartLength はパートの大きさを返します。最後のパートだけは残りの大きさになります。
+// パート番号が範囲外の場合は false を返します。
+func (u *AssetUpload) PartLength(partNumber int32) (int64, bool) {
+	if partNumber < 1 || partNumber > u.PartCount() {
+		return 0, false
+	}
+	if partNumber == u.PartCount() {
+		return u.Size - int64(partNumber-1)*u.PartSize, true
+	}
+	return u.PartSize, true
+}
+
+// IsExpired は署名付き URL の有効期限が切れているかどうかを返します
+func (u *AssetUpload) IsExpired(now time.Time) bool {
+	return now.After(u.ExpiresAt)
+}
+
+// StoredObject はストレージに保存されているファイルの情報です
+type StoredObject struct {
+	Size        int64
+	ContentType string
+}
+
+// UploadedPart はマルチパートアップロードでアップロード済みのパートです
+type UploadedPart struct {
+	PartNumber int32
+	ETag       string
+	Size       int64
+}
+
+// PresignedPart はクライアントがパートを直接 PUT するための署名付き URL です
+type PresignedPart struct {
+	PartNumber int32
+	Size       int64
+	URL        string
+}
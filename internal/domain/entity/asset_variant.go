@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ImageVariantWidths は画像アセットから生成する派生画像の幅です
+var ImageVariantWidths = []int{320, 640, 1280}
+
+// AssetVariant は画像アセットから生成した縮小版の画像です。
+// 元画像と同じディレクトリに保存し、一覧画面のサムネイルなどで使います。
+type AssetVariant struct {
+	ID          uuid.UUID
+	AssetID     uuid.UUID
+	Width       int
+	Height      int
+	ContentType string
+	URL         string
+	CreatedAt   time.Time
+}
+
+func NewAssetVariant(assetID uuid.UUID, width int, height int, contentType string, url string) *AssetVariant {
+	return &AssetVariant{
+		ID:          uuid.New(),
+		AssetID:     assetID,
+		Width:       width,
+		Height:      height,
+		ContentType: contentType,
+		URL:         url,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// ImageVariant は保存前の派生画像のデータです
+type ImageVariant struct {
+	Width       int
+	Height      int
+	ContentType string
+	Extension   string
+	Data        []byte
+}
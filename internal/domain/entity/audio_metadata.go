@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// AudioMetadata は音声アセットから読み取った情報です。
+// 音声を読み込む前に再生時間や波形を表示できるよう、アップロード時に作成します。
+type AudioMetadata struct {
+	Duration   time.Duration
+	SampleRate int
+	// Bitrate は平均のビットレート (bps) です
+	Bitrate  int
+	Channels int
+	// Title と Artist はタグに書かれている曲名とアーティスト名です。ない場合は空です
+	Title  string
+	Artist string
+	// Peaks は曲を等しい長さの区間に分け、区間ごとの振幅の最大値を 0〜1 で表したものです
+	Peaks []float64
+}
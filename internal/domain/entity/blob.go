@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// Blob はブロブストレージに保存されているファイルの情報です。
+// Key は "/" 区切りのパスです。
+type Blob struct {
+	Key          string
+	Size         int64
+	ContentType  string
+	LastModified time.Time
+}
+
+// UploadedFile はアセットの元ファイルとして保存したファイルの情報です。
+// Hash はメタデータの除去などで書き換える前の、受け取ったままのファイルの SHA-256 (16 進数) です。
+// 同じ内容のファイルが既に保存されていた場合は新しく保存せず、Deduplicated が true になり URL は既存のファイルを指します。
+type UploadedFile struct {
+	URL          string
+	AssetType    string
+	Hash         string
+	Size         int64
+	Deduplicated bool
+}
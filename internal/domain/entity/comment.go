@@ -7,12 +7,26 @@ import (
 )
 
 type Comment struct {
-	ID        uuid.UUID
-	Content   string
-	WorkID    uuid.UUID
-	UserID    uuid.UUID
-	ReplyAt   string
-	User      *User
+	ID      uuid.UUID
+	Content string
+	WorkID  uuid.UUID
+	UserID  uuid.UUID
+	ReplyAt string
+	User    *User
+	// Anonymous はログインせずに投稿されたコメントかどうかを表します。
+	// 匿名コメントはUserIDを持たず、AnonymousNameとFingerprintで投稿者を表示します。
+	Anonymous     bool
+	AnonymousName string
+	Fingerprint   string
+	// ClientFingerprint はログインしているユーザーの投稿元IPアドレスのフィンガープリントです。
+	// アカウントを使い分けた連投を防ぐためにフィルターで使うだけで、保存も表示もしません。
+	ClientFingerprint string
+	EditedAt          *time.Time
+	// DeletedAt は返信が付いたまま削除されたコメントに設定され、スレッドを保つために本文を消した跡だけを残します。
+	DeletedAt *time.Time
+	// Mentions は本文中のメンションです。保存はせず、取得時に本文から解決します。
+	Mentions  []*MentionSpan
+	Reactions []*ReactionSummary
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -28,3 +42,25 @@ func NewComment(content string, workID uuid.UUID, userID uuid.UUID, replyAt stri
 		UpdatedAt: time.Now(),
 	}
 }
+
+// NewAnonymousComment はログインしていない投稿者のコメントを作成します。
+// fingerprintは投稿元IPアドレスのハッシュで、同じ投稿者のコメントを見分けるために使います。
+func NewAnonymousComment(content string, workID uuid.UUID, anonymousName string, fingerprint string, replyAt string) *Comment {
+	comment := NewComment(content, workID, uuid.Nil, replyAt)
+	comment.Anonymous = true
+	comment.AnonymousName = anonymousName
+	comment.Fingerprint = fingerprint
+	return comment
+}
+
+// Edit は本文を更新し、編集済みであることを記録します。
+func (c *Comment) Edit(content string) {
+	now := time.Now()
+	c.Content = content
+	c.EditedAt = &now
+	c.UpdatedAt = now
+}
+
+func (c *Comment) IsDeleted() bool {
+	return c.DeletedAt != nil
+}
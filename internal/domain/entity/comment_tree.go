@@ -0,0 +1,47 @@
+package entity
+
+// CommentNode はスレッド表示用に、コメントとその返信をまとめたものです。
+// 深さの上限に達したノードはRepliesを持ちませんが、ReplyCountで返信があることを示します。
+type CommentNode struct {
+	Comment    *Comment
+	Replies    []*CommentNode
+	ReplyCount int
+}
+
+// BuildCommentTree は作品のコメント一覧から、parentIDに付いた返信をmaxDepth階層までの木にします。
+// parentIDが空の場合はトップレベルのコメントを根にします。
+// 返信先が一覧に見つからないコメントは、失われないようにトップレベルとして扱います。
+// commentsは並べたい順に渡してください。
+func BuildCommentTree(comments []*Comment, parentID string, maxDepth int) []*CommentNode {
+	exists := make(map[string]bool, len(comments))
+	for _, comment := range comments {
+		exists[comment.ID.String()] = true
+	}
+
+	children := make(map[string][]*Comment)
+	for _, comment := range comments {
+		key := comment.ReplyAt
+		if key != "" && !exists[key] {
+			key = ""
+		}
+		children[key] = append(children[key], comment)
+	}
+
+	var build func(parentID string, depth int) []*CommentNode
+	build = func(parentID string, depth int) []*CommentNode {
+		nodes := make([]*CommentNode, 0, len(children[parentID]))
+		for _, comment := range children[parentID] {
+			id := comment.ID.String()
+			node := &CommentNode{
+				Comment:    comment,
+				ReplyCount: len(children[id]),
+			}
+			if depth < maxDepth {
+				node.Replies = build(id, depth+1)
+			}
+			nodes = append(nodes, node)
+		}
+		return nodes
+	}
+	return build(parentID, 1)
+}
@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EventType string
+
+const (
+	// EventTypeFavoriteCreated は自分の作品がいいねされたことを表します
+	EventTypeFavoriteCreated EventType = "favorite.created"
+	// EventTypeCommentCreated は自分の作品にコメントが付いたことを表します
+	EventTypeCommentCreated EventType = "comment.created"
+)
+
+// Event は作品の作者へリアルタイムに届けるアクティビティです。
+// IDは配信時にブローカーが採番し、クライアントが接続を再開する位置として使います。
+type Event struct {
+	ID        string
+	UserID    uuid.UUID
+	Type      EventType
+	ActorID   uuid.UUID
+	WorkID    uuid.UUID
+	CommentID *uuid.UUID
+	CreatedAt time.Time
+}
+
+// NewEvent はactorの操作をuserIDのユーザーへ届けるイベントを作成します。
+func NewEvent(userID uuid.UUID, actorID uuid.UUID, eventType EventType, workID uuid.UUID, commentID *uuid.UUID) *Event {
+	return &Event{
+		UserID:    userID,
+		Type:      eventType,
+		ActorID:   actorID,
+		WorkID:    workID,
+		CommentID: commentID,
+		CreatedAt: time.Now(),
+	}
+}
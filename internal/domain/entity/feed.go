@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+)
+
+// FeedCursor はフィードのページングに使うカーソルです。
+// 作成日時とIDの組で位置を表すため、途中で作品が増えても続きの取得結果がずれません。
+type FeedCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+func NewFeedCursor(work *Work) *FeedCursor {
+	return &FeedCursor{
+		CreatedAt: work.CreatedAt,
+		ID:        work.ID,
+	}
+}
+
+// Encode はカーソルをクエリパラメータで受け渡せる文字列に変換します。
+func (c *FeedCursor) Encode() string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeFeedCursor はEncodeで作成した文字列をカーソルに戻します。
+func DecodeFeedCursor(s string) (*FeedCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, domainerrors.ErrInvalidFeedCursor
+	}
+	createdAtStr, idStr, found := strings.Cut(string(raw), "|")
+	if !found {
+		return nil, domainerrors.ErrInvalidFeedCursor
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, createdAtStr)
+	if err != nil {
+		return nil, domainerrors.ErrInvalidFeedCursor
+	}
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return nil, domainerrors.ErrInvalidFeedCursor
+	}
+	return &FeedCursor{
+		CreatedAt: createdAt,
+		ID:        id,
+	}, nil
+}
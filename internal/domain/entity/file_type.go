@@ -0,0 +1,113 @@
+package entity
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+)
+
+// FileSignatureLength はファイル形式の判定に読み込む先頭バイト数
+const FileSignatureLength = 512
+
+const (
+	megabyte int64 = 1 << 20
+
+	maxImageSize int64 = 20 * megabyte
+	maxVideoSize int64 = 500 * megabyte
+	maxMusicSize int64 = 50 * megabyte
+	maxZipSize   int64 = 500 * megabyte
+	maxModelSize int64 = 100 * megabyte
+)
+
+// FileType はアップロードを受け付けるファイル形式を表す
+type FileType struct {
+	Extension   string
+	ContentType string
+	MaxSize     int64
+	// 先頭バイトが形式のシグネチャと一致するかを判定する
+	matches func(head []byte) bool
+}
+
+// MatchContent はファイルの先頭バイトがこの形式のものかどうかを返す
+func (ft *FileType) MatchContent(head []byte) bool {
+	return ft.matches(head)
+}
+
+var fileTypes = map[string]*FileType{
+	"png":  {Extension: "png", ContentType: "image/png", MaxSize: maxImageSize, matches: hasPrefix("\x89PNG\r\n\x1a\n")},
+	"jpeg": {Extension: "jpeg", ContentType: "image/jpeg", MaxSize: maxImageSize, matches: hasPrefix("\xff\xd8\xff")},
+	"jpg":  {Extension: "jpg", ContentType: "image/jpeg", MaxSize: maxImageSize, matches: hasPrefix("\xff\xd8\xff")},
+	"bmp":  {Extension: "bmp", ContentType: "image/bmp", MaxSize: maxImageSize, matches: hasPrefix("BM")},
+	"gif":  {Extension: "gif", ContentType: "image/gif", MaxSize: maxImageSize, matches: hasPrefix("GIF87a", "GIF89a")},
+	"webp": {Extension: "webp", ContentType: "image/webp", MaxSize: maxImageSize, matches: isRIFF("WEBP")},
+	"mp4":  {Extension: "mp4", ContentType: "video/mp4", MaxSize: maxVideoSize, matches: hasBox("ftyp")},
+	"mov":  {Extension: "mov", ContentType: "video/quicktime", MaxSize: maxVideoSize, matches: hasBox("ftyp", "moov", "mdat", "wide", "free")},
+	"avi":  {Extension: "avi", ContentType: "video/x-msvideo", MaxSize: maxVideoSize, matches: isRIFF("AVI ")},
+	"flv":  {Extension: "flv", ContentType: "video/x-flv", MaxSize: maxVideoSize, matches: hasPrefix("FLV")},
+	"mp3":  {Extension: "mp3", ContentType: "audio/mpeg", MaxSize: maxMusicSize, matches: isMP3},
+	"wav":  {Extension: "wav", ContentType: "audio/wav", MaxSize: maxMusicSize, matches: isRIFF("WAVE")},
+	"m4a":  {Extension: "m4a", ContentType: "audio/mp4", MaxSize: maxMusicSize, matches: hasBox("ftyp")},
+	"zip":  {Extension: "zip", ContentType: "application/zip", MaxSize: maxZipSize, matches: hasPrefix("PK\x03\x04", "PK\x05\x06")},
+	"gltf": {Extension: "gltf", ContentType: "model/gltf+json", MaxSize: maxModelSize, matches: isJSONObject},
+	"glb":  {Extension: "glb", ContentType: "model/gltf-binary", MaxSize: maxModelSize, matches: hasPrefix("glTF")},
+	"fbx":  {Extension: "fbx", ContentType: "application/octet-stream", MaxSize: maxModelSize, matches: hasPrefix("Kaydara FBX Binary  \x00", "; FBX")},
+}
+
+// FileExtension はファイル名から小文字の拡張子を取り出す。拡張子がない場合は空文字を返す
+func FileExtension(filename string) string {
+	return strings.ToLower(strings.TrimPrefix(filepath.Ext(filename), "."))
+}
+
+// LookupFileType は拡張子に対応するファイル形式を返す
+func LookupFileType(extension string) (*FileType, bool) {
+	fileType, ok := fileTypes[strings.ToLower(extension)]
+	return fileType, ok
+}
+
+func hasPrefix(signatures ...string) func([]byte) bool {
+	return func(head []byte) bool {
+		for _, signature := range signatures {
+			if bytes.HasPrefix(head, []byte(signature)) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// isRIFF は RIFF コンテナのうちフォームタイプが一致するものを判定する
+func isRIFF(form string) func([]byte) bool {
+	return func(head []byte) bool {
+		return len(head) >= 12 && string(head[:4]) == "RIFF" && string(head[8:12]) == form
+	}
+}
+
+// hasBox は ISO BMFF (MP4/QuickTime) の先頭ボックスの種類を判定する
+func hasBox(boxTypes ...string) func([]byte) bool {
+	return func(head []byte) bool {
+		if len(head) < 8 {
+			return false
+		}
+		for _, boxType := range boxTypes {
+			if string(head[4:8]) == boxType {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// isMP3 は ID3 タグまたは MPEG オーディオのフレーム同期で始まるかを判定する
+func isMP3(head []byte) bool {
+	if bytes.HasPrefix(head, []byte("ID3")) {
+		return true
+	}
+	return len(head) >= 2 && head[0] == 0xff && head[1]&0xe0 == 0xe0
+}
+
+// isJSONObject は BOM と空白を除いた先頭が JSON オブジェクトかを判定する
+func isJSONObject(head []byte) bool {
+	head = bytes.TrimPrefix(head, []byte("\xef\xbb\xbf"))
+	head = bytes.TrimLeft(head, " \t\r\n")
+	return len(head) > 0 && head[0] == '{'
+}
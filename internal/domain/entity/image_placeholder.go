@@ -0,0 +1,28 @@
+package entity
+
+import "github.com/google/uuid"
+
+// ImagePlaceholder は画像を読み込むまでの間に表示するプレースホルダーです。
+// 一覧で画像の場所が白く抜けないよう、アップロード時に作成します。
+type ImagePlaceholder struct {
+	// Width と Height は EXIF の向きを反映した、表示される向きでの画像の大きさです
+	Width  int
+	Height int
+	// BlurHash は画像をぼかしたものを短い文字列で表したものです (https://blurha.sh)
+	BlurHash string
+	// DominantColor は画像で最も多く使われている色の "#rrggbb" 形式の表記です
+	DominantColor string
+}
+
+// PlaceholderBackfill はプレースホルダーのない画像アセットに後から作成した結果です。
+type PlaceholderBackfill struct {
+	Updated []*Asset
+	Failed  []*PlaceholderBackfillFailure
+}
+
+// PlaceholderBackfillFailure はプレースホルダーを作成できなかった画像アセットです。
+type PlaceholderBackfillFailure struct {
+	AssetID uuid.UUID
+	URL     string
+	Err     error
+}
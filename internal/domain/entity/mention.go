@@ -0,0 +1,115 @@
+package entity
+
+import (
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// Mention は作品の説明文やコメントでユーザーが@nameで呼ばれたことを表します。
+// CommentIDがnilの場合は作品の説明文でのメンションです。
+type Mention struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	ActorID   uuid.UUID
+	Actor     *User
+	WorkID    uuid.UUID
+	CommentID *uuid.UUID
+	CreatedAt time.Time
+}
+
+func NewMention(userID uuid.UUID, actorID uuid.UUID, workID uuid.UUID, commentID *uuid.UUID) *Mention {
+	return &Mention{
+		ID:        uuid.New(),
+		UserID:    userID,
+		ActorID:   actorID,
+		WorkID:    workID,
+		CommentID: commentID,
+		CreatedAt: time.Now(),
+	}
+}
+
+// MentionSpan は本文中で@nameが書かれている範囲です。
+// StartとEndは文字（Unicodeのコードポイント）単位の位置で、Endの文字は含みません。
+type MentionSpan struct {
+	Start  int
+	End    int
+	Name   string
+	UserID uuid.UUID
+}
+
+// mentionNameMaxLength はDiscordのユーザー名の最大文字数です
+const mentionNameMaxLength = 32
+
+// ParseMentions は本文から@nameの形式で書かれた部分を取り出します。
+// メールアドレスのように直前が英数字の@は対象外です。この時点ではユーザーを解決していないためUserIDは空です。
+func ParseMentions(text string) []*MentionSpan {
+	runes := []rune(text)
+	var spans []*MentionSpan
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '@' || (i > 0 && isMentionNameRune(runes[i-1])) {
+			continue
+		}
+		end := i + 1
+		for end < len(runes) && end-i-1 < mentionNameMaxLength && isMentionNameRune(runes[end]) {
+			end++
+		}
+		// 文末の句点として書かれた"."は名前に含めない
+		for end > i+1 && runes[end-1] == '.' {
+			end--
+		}
+		if end == i+1 {
+			continue
+		}
+		spans = append(spans, &MentionSpan{
+			Start: i,
+			End:   end,
+			Name:  string(runes[i+1 : end]),
+		})
+		i = end - 1
+	}
+	return spans
+}
+
+// MentionNames はspansに含まれる名前を重複なく返します。
+func MentionNames(spans []*MentionSpan) []string {
+	seen := make(map[string]bool, len(spans))
+	names := make([]string, 0, len(spans))
+	for _, span := range spans {
+		if seen[span.Name] {
+			continue
+		}
+		seen[span.Name] = true
+		names = append(names, span.Name)
+	}
+	return names
+}
+
+// ResolveMentionSpans はspansのうちusersの名前に一致するものにUserIDを設定して返します。
+// 存在しないユーザーへの@nameはメンションとして扱いません。
+func ResolveMentionSpans(spans []*MentionSpan, users []*User) []*MentionSpan {
+	userIDs := make(map[string]uuid.UUID, len(users))
+	for _, user := range users {
+		userIDs[user.Name] = user.ID
+	}
+
+	resolved := make([]*MentionSpan, 0, len(spans))
+	for _, span := range spans {
+		userID, ok := userIDs[span.Name]
+		if !ok {
+			continue
+		}
+		resolved = append(resolved, &MentionSpan{
+			Start:  span.Start,
+			End:    span.End,
+			Name:   span.Name,
+			UserID: userID,
+		})
+	}
+	return resolved
+}
+
+func isMentionNameRune(r rune) bool {
+	return r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.')
+}
@@ -0,0 +1,19 @@
+package entity
+
+// ModelStats は 3D モデルアセットの頂点数などの情報です。
+// ビューアーで読み込む前に表示できるよう、アップロード時に作成します。
+type ModelStats struct {
+	// VertexCount と TriangleCount はシーンに配置されたメッシュの合計です
+	VertexCount   int64
+	TriangleCount int64
+	// Materials はマテリアルの名前です。名前のないマテリアルは空文字です
+	Materials []string
+	// BoundingBox はシーン全体を囲む直方体です。頂点がない場合は nil です
+	BoundingBox *BoundingBox
+}
+
+// BoundingBox は座標軸に沿った直方体です
+type BoundingBox struct {
+	Min [3]float64
+	Max [3]float64
+}
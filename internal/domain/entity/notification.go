@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotificationType string
+
+const (
+	// NotificationTypeFavorite は自分の作品がいいねされたことを表します
+	NotificationTypeFavorite NotificationType = "favorite"
+	// NotificationTypeComment は自分の作品にコメントが付いたことを表します
+	NotificationTypeComment NotificationType = "comment"
+	// NotificationTypeReply は自分のコメントに返信が付いたことを表します
+	NotificationTypeReply NotificationType = "reply"
+)
+
+type Notification struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	ActorID   uuid.UUID
+	Actor     *User
+	Type      NotificationType
+	WorkID    uuid.UUID
+	CommentID *uuid.UUID
+	ReadAt    *time.Time
+	CreatedAt time.Time
+}
+
+// NewNotification はactorの操作をuserIDのユーザーへ知らせる通知を作成します。
+func NewNotification(userID uuid.UUID, actorID uuid.UUID, notificationType NotificationType, workID uuid.UUID, commentID *uuid.UUID) *Notification {
+	return &Notification{
+		ID:        uuid.New(),
+		UserID:    userID,
+		ActorID:   actorID,
+		Type:      notificationType,
+		WorkID:    workID,
+		CommentID: commentID,
+		CreatedAt: time.Now(),
+	}
+}
@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StoredFile はストレージに保存されているファイルです。
+// アセットのファイルではない場合 (アバターなど) は AssetID が uuid.Nil になります。
+type StoredFile struct {
+	Key          string
+	AssetID      uuid.UUID
+	Size         int64
+	LastModified time.Time
+}
+
+// OrphanReport はどの作品にも使われていないまま放置されたアセットとファイルの一覧です。
+// DryRun の場合は削除せずに、削除の対象だけを報告します。
+type OrphanReport struct {
+	DryRun bool
+	Assets []*Asset
+	Files  []*StoredFile
+}
+
+// FileBytes は削除した (DryRun の場合は削除する) ファイルの合計サイズを返します
+func (r *OrphanReport) FileBytes() int64 {
+	var total int64
+	for _, file := range r.Files {
+		total += file.Size
+	}
+	return total
+}
@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReactionTargetType はリアクションを付ける対象の種類です
+type ReactionTargetType string
+
+const (
+	ReactionTargetWork    ReactionTargetType = "work"
+	ReactionTargetComment ReactionTargetType = "comment"
+)
+
+// Reaction はユーザーが作品やコメントに付けた絵文字のリアクションです。
+// 同じ対象に複数の絵文字を付けられますが、同じ絵文字は一人一回までです。
+type Reaction struct {
+	TargetType ReactionTargetType
+	TargetID   uuid.UUID
+	UserID     uuid.UUID
+	Emoji      string
+	CreatedAt  time.Time
+}
+
+func NewReaction(targetType ReactionTargetType, targetID uuid.UUID, userID uuid.UUID, emoji string) *Reaction {
+	return &Reaction{
+		TargetType: targetType,
+		TargetID:   targetID,
+		UserID:     userID,
+		Emoji:      emoji,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// ReactionSummary は対象に付いたリアクションを絵文字ごとに集計したものです。
+// Reacted は閲覧しているユーザー自身がその絵文字を付けているかどうかです。
+type ReactionSummary struct {
+	Emoji   string
+	Count   int
+	Reacted bool
+}
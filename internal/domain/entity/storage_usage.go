@@ -0,0 +1,34 @@
+package entity
+
+// AssetTypeUsage はアセットの種類ごとのストレージの使用量です
+type AssetTypeUsage struct {
+	AssetType string
+	Count     int
+	Bytes     int64
+}
+
+// StorageUsage はユーザーのストレージの使用量です。
+// PendingBytes は直接アップロードで申告され、まだ完了していないファイルの合計サイズです。
+// Quota が 0 以下の場合は上限がありません。
+type StorageUsage struct {
+	Quota        int64
+	PendingBytes int64
+	ByType       []*AssetTypeUsage
+}
+
+// UsedBytes は保存済みのアセットの合計サイズを返します
+func (u *StorageUsage) UsedBytes() int64 {
+	var total int64
+	for _, usage := range u.ByType {
+		total += usage.Bytes
+	}
+	return total
+}
+
+// CanStore は size バイトのファイルを追加で保存しても上限を超えないかを返します
+func (u *StorageUsage) CanStore(size int64) bool {
+	if u.Quota <= 0 {
+		return true
+	}
+	return u.UsedBytes()+u.PendingBytes+size <= u.Quota
+}
@@ -0,0 +1,9 @@
+package entity
+
+// StrippedImage はメタデータを取り除いた画像です。
+type StrippedImage struct {
+	Data []byte
+	// ConvertedExtension は元の形式のまま保存できず、別の形式に変換した場合の拡張子です。変換していない場合は空文字です。
+	// WebP は純粋な Go ではエンコードできないため、向きを画素に反映した WebP は PNG になります。
+	ConvertedExtension string
+}
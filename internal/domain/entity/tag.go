@@ -26,8 +26,14 @@ func NewTag(name string) *Tag {
 }
 
 func (t *Tag) NormalizeName() {
+	t.Name = NormalizeText(t.Name)
+}
+
+// NormalizeText は文字幅と大文字・小文字の違いをなくした文字列を返します。
+// 英数字や記号は半角の小文字にそろえますが、カタカナは半角・全角のまま残します。
+func NormalizeText(text string) string {
 	var builder strings.Builder
-	for _, r := range t.Name {
+	for _, r := range text {
 		// カタカナはそのまま保持（半角・全角どちらも変換しない）
 		if unicode.In(r, unicode.Katakana) {
 			builder.WriteRune(r)
@@ -42,5 +48,5 @@ func (t *Tag) NormalizeName() {
 			builder.WriteRune(narrow)
 		}
 	}
-	t.Name = strings.ToLower(builder.String())
+	return strings.ToLower(builder.String())
 }
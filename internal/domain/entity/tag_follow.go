@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TagFollow struct {
+	UserID    uuid.UUID
+	TagID     uuid.UUID
+	CreatedAt time.Time
+}
+
+func NewTagFollow(userID uuid.UUID, tagID uuid.UUID) *TagFollow {
+	return &TagFollow{
+		UserID:    userID,
+		TagID:     tagID,
+		CreatedAt: time.Now(),
+	}
+}
+
+// TagNewWork はフォロー中のタグが付いた新着作品をユーザーごとに記録したものです。
+// SeenAtがnilのものが未読です。
+type TagNewWork struct {
+	UserID    uuid.UUID
+	WorkID    uuid.UUID
+	Work      *Work
+	SeenAt    *time.Time
+	CreatedAt time.Time
+}
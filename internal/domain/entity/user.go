@@ -16,8 +16,11 @@ type User struct {
 	AvatarURL     string
 	TwitterID     string
 	GithubID      string
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	// FollowerCount と FollowingCount は取得時に集計される値で、保存はされません
+	FollowerCount  int
+	FollowingCount int
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
 }
 
 func NewUser(name string, email string, displayName string, discordUserID string, avatarURL string) *User {
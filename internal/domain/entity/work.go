@@ -7,21 +7,25 @@ import (
 )
 
 type Work struct {
-	ID               uuid.UUID
-	Title            string
-	Description      string
-	DescriptionHTML  string
-	UserID           uuid.UUID
-	User             *User
-	Visibility       string
-	ThumbnailAssetID uuid.UUID
-	ThumbnailURL     string
-	Assets           []*Asset
-	URLs             []*string
-	TagIDs           []uuid.UUID
-	Tags             []*Tag
-	CreatedAt        time.Time
-	UpdatedAt        time.Time
+	ID                uuid.UUID
+	Title             string
+	Description       string
+	DescriptionHTML   string
+	UserID            uuid.UUID
+	User              *User
+	Visibility        string
+	ThumbnailAssetID  uuid.UUID
+	ThumbnailURL      string
+	ThumbnailVariants []*AssetVariant
+	// ThumbnailPlaceholder はサムネイル画像を読み込むまでの間に表示するプレースホルダー。作成していない場合は nil
+	ThumbnailPlaceholder *ImagePlaceholder
+	Assets               []*Asset
+	URLs                 []*string
+	TagIDs               []uuid.UUID
+	Tags                 []*Tag
+	Reactions            []*ReactionSummary
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
 }
 
 func NewWork(title string, description string, userID uuid.UUID, visibility string, thumbnailAssetID uuid.UUID, assets []*Asset, urls []*string, tagIDs []uuid.UUID, tags []*Tag) *Work {
@@ -40,3 +44,13 @@ func NewWork(title string, description string, userID uuid.UUID, visibility stri
 		UpdatedAt:        time.Now(),
 	}
 }
+
+// PlayURL は作品のアセットのうち、ブラウザで遊べるビルドを最初に含むものの URL を返します。ない場合は空です
+func (w *Work) PlayURL() string {
+	for _, asset := range w.Assets {
+		if asset != nil && asset.PlayURL != "" {
+			return asset.PlayURL
+		}
+	}
+	return ""
+}
@@ -0,0 +1,58 @@
+package entity
+
+import "strings"
+
+// ZIP の中身から推定した内容の種類です
+const (
+	ZipContentUnityWebGL    = "unity_webgl"
+	ZipContentExecutable    = "executable"
+	ZipContentSourceProject = "source_project"
+)
+
+// ZipEntry は ZIP に含まれるファイルです。Path は "/" 区切りの相対パスです
+type ZipEntry struct {
+	Path           string
+	Size           int64
+	CompressedSize int64
+}
+
+// ZipManifest は ZIP アセットに含まれるファイルの一覧です。
+// ダウンロードする前に中身を確認できるよう、アップロード時に作成します。
+type ZipManifest struct {
+	Entries []*ZipEntry
+	// TotalSize は展開後の合計バイト数です
+	TotalSize int64
+	// Contents は推定した内容の種類です。当てはまるものがない場合は空です
+	Contents []string
+}
+
+// HasContent は推定した内容の種類に kind が含まれるかを返します
+func (m *ZipManifest) HasContent(kind string) bool {
+	for _, content := range m.Contents {
+		if content == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// PlayIndexFile はブラウザで遊べるビルドの入口になるファイルです
+const PlayIndexFile = "index.html"
+
+// PlayRoot はブラウザで遊べるビルドの入口の index.html があるディレクトリを返します。
+// index.html は ZIP の直下か、最上位のディレクトリ 1 つの直下にあるものだけを入口とみなします。
+// ZIP の直下にある場合の dir は空です
+func (m *ZipManifest) PlayRoot() (dir string, ok bool) {
+	for _, entry := range m.Entries {
+		if entry.Path == PlayIndexFile {
+			return "", true
+		}
+	}
+	for _, entry := range m.Entries {
+		root, name, found := strings.Cut(entry.Path, "/")
+		if found && name == PlayIndexFile {
+			return root, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,38 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrCommentRejected はコメントがフィルターで拒否されたことを表します。
+// 拒否の理由はCommentRejectedErrorから取り出せます。
+var ErrCommentRejected = errors.New("comment rejected")
+
+// CommentRejectReason はコメントが拒否された理由です
+type CommentRejectReason string
+
+const (
+	CommentRejectNGWord       CommentRejectReason = "ng_word"
+	CommentRejectTooManyLinks CommentRejectReason = "too_many_links"
+	CommentRejectDuplicate    CommentRejectReason = "duplicate"
+	CommentRejectRateLimited  CommentRejectReason = "rate_limited"
+)
+
+// CommentRejectedError はコメントがフィルターで拒否されたときのエラーです。
+// errors.Is(err, ErrCommentRejected) で拒否されたかどうかを判定できます。
+type CommentRejectedError struct {
+	Reason CommentRejectReason
+}
+
+func NewCommentRejectedError(reason CommentRejectReason) *CommentRejectedError {
+	return &CommentRejectedError{Reason: reason}
+}
+
+func (e *CommentRejectedError) Error() string {
+	return fmt.Sprintf("comment rejected: %s", e.Reason)
+}
+
+func (e *CommentRejectedError) Unwrap() error {
+	return ErrCommentRejected
+}
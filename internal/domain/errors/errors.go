@@ -30,6 +30,7 @@ var (
 	ErrUserAlreadyExists  = errors.New("user already exists")
 	ErrFailedToCreateUser = errors.New("failed to create user")
 	ErrFailedToUpdateUser = errors.New("failed to update user")
+	ErrFailedToGetUsers   = errors.New("failed to get users")
 )
 
 // 作品関連のエラー定義
@@ -47,6 +48,9 @@ var (
 	ErrFailedToCreateURL                   = errors.New("failed to create url")
 	ErrFailedToCreateTagging               = errors.New("failed to create tagging")
 	ErrFailedToGetWorksByUserID            = errors.New("failed to get works by user id")
+	ErrFailedToGetFeed                     = errors.New("failed to get feed")
+	ErrInvalidFeedCursor                   = errors.New("invalid feed cursor")
+	ErrFailedToGetFollowingFeed            = errors.New("failed to get following feed")
 )
 
 // コメント関連のエラー定義
@@ -55,13 +59,64 @@ var (
 	ErrFailedToGetCommentById      = errors.New("failed to get comment by id")
 	ErrCommentNotFound             = errors.New("comment not found")
 	ErrFailedToCreateComment       = errors.New("failed to create comment")
+	ErrAnonymousCommentDisabled    = errors.New("anonymous comment is disabled")
+	ErrFailedToUpdateComment       = errors.New("failed to update comment")
+	ErrFailedToDeleteComment       = errors.New("failed to delete comment")
+	ErrCommentForbidden            = errors.New("comment operation is not allowed")
+	ErrInvalidReplyTarget          = errors.New("reply target is not a comment on the same work")
 )
 
 // アセット関連のエラー定義
 var (
-	ErrFailedToOpenFile    = errors.New("failed to open file")
-	ErrFailedToUploadFile  = errors.New("failed to upload file")
-	ErrFailedToCreateAsset = errors.New("failed to create asset")
+	ErrFailedToOpenFile            = errors.New("failed to open file")
+	ErrFailedToUploadFile          = errors.New("failed to upload file")
+	ErrFailedToCreateAsset         = errors.New("failed to create asset")
+	ErrUnsupportedFileType         = errors.New("unsupported file type")
+	ErrFileTypeMismatch            = errors.New("file content does not match its extension")
+	ErrFileTooLarge                = errors.New("file is too large")
+	ErrFailedToCreateAssetVariants = errors.New("failed to create asset variants")
+	ErrImageTooLarge               = errors.New("image dimensions are too large")
+	ErrFailedToStripMetadata       = errors.New("failed to strip image metadata")
+	ErrFailedToPresignUpload       = errors.New("failed to presign upload")
+	ErrFailedToCreateAssetUpload   = errors.New("failed to create asset upload")
+	ErrFailedToGetAssetUpload      = errors.New("failed to get asset upload")
+	ErrFailedToDeleteAssetUpload   = errors.New("failed to delete asset upload")
+	ErrAssetUploadNotFound         = errors.New("asset upload not found")
+	ErrAssetUploadExpired          = errors.New("asset upload expired")
+	ErrUploadedFileNotFound        = errors.New("uploaded file not found")
+	ErrUploadedFileMismatch        = errors.New("uploaded file does not match the requested upload")
+	ErrFailedToCreateMultipart     = errors.New("failed to create multipart upload")
+	ErrFailedToListUploadedParts   = errors.New("failed to list uploaded parts")
+	ErrFailedToCompleteMultipart   = errors.New("failed to complete multipart upload")
+	ErrFailedToAbortMultipart      = errors.New("failed to abort multipart upload")
+	ErrFailedToListAssetUploads    = errors.New("failed to list asset uploads")
+	ErrInvalidPartNumber           = errors.New("invalid part number")
+	ErrIncompleteMultipartUpload   = errors.New("multipart upload has missing parts")
+	ErrFailedToListOrphanAssets    = errors.New("failed to list orphan assets")
+	ErrFailedToDeleteAssets        = errors.New("failed to delete assets")
+	ErrFailedToGetAssets           = errors.New("failed to get assets")
+	ErrDirectUploadNotSupported    = errors.New("direct upload is not supported by the blob store")
+	ErrStorageQuotaExceeded        = errors.New("storage quota exceeded")
+	ErrFailedToGetStorageUsage     = errors.New("failed to get storage usage")
+	ErrInvalidZip                  = errors.New("invalid zip archive")
+	ErrZipBomb                     = errors.New("zip archive expands too much")
+	ErrUnsafeZipPath               = errors.New("zip archive contains unsafe paths")
+	ErrAssetNotFound               = errors.New("asset not found")
+	ErrZipManifestNotFound         = errors.New("zip manifest not found")
+	ErrFailedToGetZipManifest      = errors.New("failed to get zip manifest")
+	ErrInvalidAudio                = errors.New("invalid audio file")
+	ErrInvalidModel                = errors.New("invalid 3d model")
+	ErrExternalModelBuffer         = errors.New("3d model references external buffers")
+	ErrFailedToListImageAssets     = errors.New("failed to list image assets")
+	ErrFailedToUpdatePlaceholder   = errors.New("failed to update image placeholder")
+)
+
+// ストレージ関連のエラー定義
+var (
+	ErrBlobNotFound         = errors.New("blob not found")
+	ErrFailedToRegisterBlob = errors.New("failed to register blob")
+	ErrFailedToReleaseBlob  = errors.New("failed to release blob")
+	ErrFailedToFindBlobKeys = errors.New("failed to find blob keys")
 )
 
 // いいね関連のエラー定義
@@ -81,3 +136,53 @@ var (
 	ErrTagAlreadyExists   = errors.New("tag already exists")
 	ErrInvalidTagName     = errors.New("invalid tag name")
 )
+
+// フォロー関連のエラー定義
+var (
+	ErrFailedToCreateFollow = errors.New("failed to create follow")
+	ErrFailedToDeleteFollow = errors.New("failed to delete follow")
+	ErrFailedToGetFollowers = errors.New("failed to get followers")
+	ErrFailedToGetFollowing = errors.New("failed to get following")
+	ErrFollowAlreadyExists  = errors.New("follow already exists")
+	ErrFollowNotFound       = errors.New("follow not found")
+	ErrCannotFollowYourself = errors.New("cannot follow yourself")
+)
+
+// タグフォロー関連のエラー定義
+var (
+	ErrFailedToCreateTagFollow       = errors.New("failed to create tag follow")
+	ErrFailedToDeleteTagFollow       = errors.New("failed to delete tag follow")
+	ErrTagFollowAlreadyExists        = errors.New("tag follow already exists")
+	ErrTagFollowNotFound             = errors.New("tag follow not found")
+	ErrFailedToCreateTagNewWorks     = errors.New("failed to create tag new works")
+	ErrFailedToGetTagNewWorks        = errors.New("failed to get tag new works")
+	ErrFailedToMarkTagNewWorksAsSeen = errors.New("failed to mark tag new works as seen")
+)
+
+// 通知関連のエラー定義
+var (
+	ErrFailedToCreateNotification       = errors.New("failed to create notification")
+	ErrFailedToGetNotifications         = errors.New("failed to get notifications")
+	ErrFailedToCountUnreadNotifications = errors.New("failed to count unread notifications")
+	ErrFailedToMarkNotificationsAsRead  = errors.New("failed to mark notifications as read")
+)
+
+// リアクション関連のエラー定義
+var (
+	ErrFailedToCreateReaction  = errors.New("failed to create reaction")
+	ErrFailedToDeleteReaction  = errors.New("failed to delete reaction")
+	ErrFailedToGetReactions    = errors.New("failed to get reactions")
+	ErrReactionEmojiNotAllowed = errors.New("reaction emoji not allowed")
+)
+
+// メンション関連のエラー定義
+var (
+	ErrFailedToCreateMentions = errors.New("failed to create mentions")
+	ErrFailedToGetMentions    = errors.New("failed to get mentions")
+	ErrFailedToDeleteMentions = errors.New("failed to delete mentions")
+)
+
+// イベント配信関連のエラー定義
+var (
+	ErrEventBrokerClosed = errors.New("event broker is closed")
+)
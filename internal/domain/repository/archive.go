@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"io"
+
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+)
+
+// ArchiveInspector はアーカイブの中身を検査します。
+type ArchiveInspector interface {
+	// InspectZip は ZIP に含まれるファイルの一覧を作り、内容の種類を推定します。
+	// 展開後のサイズが大きすぎる場合は ErrZipBomb、展開先の外を指すパスがある場合は ErrUnsafeZipPath、
+	// ZIP として読めない場合は ErrInvalidZip を返します。
+	InspectZip(ctx context.Context, src io.Reader) (*entity.ZipManifest, error)
+	// ExtractZip は ZIP のうち dir の下にあるファイルを、dir からの "/" 区切りの相対パスとともに順に fn へ渡します。
+	// dir が空の場合はすべてのファイルを渡します。InspectZip で検査した ZIP に対して呼び出します。
+	ExtractZip(ctx context.Context, src io.Reader, dir string, fn func(filePath string, body io.Reader) error) error
+}
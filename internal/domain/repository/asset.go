@@ -2,7 +2,8 @@ package repository
 
 import (
 	"context"
-	"mime/multipart"
+	"io"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/simesaba80/toybox-back/internal/domain/entity"
@@ -10,6 +11,69 @@ import (
 
 type AssetRepository interface {
 	Create(ctx context.Context, asset *entity.Asset) (*entity.Asset, error)
-	UploadFile(ctx context.Context, file *multipart.FileHeader, assetUUID uuid.UUID, extension string) (assetURL *string, assetType *string, err error)
+	// UploadFile は元ファイルを保存します。hash はメタデータの除去などで書き換える前の、受け取ったままのファイルの SHA-256 (16 進数) です。
+	// hash が同じファイルが既に保存されている場合は、保存せずに既存のファイルの参照を増やします。参照は ReleaseBlob で手放します
+	UploadFile(ctx context.Context, body io.ReadSeeker, assetUUID uuid.UUID, extension string, contentType string, hash string) (*entity.UploadedFile, error)
+	// RegisterBlob はクライアントが直接保存した元ファイルを、内容の SHA-256 で登録します。
+	// 同じ内容のファイルが既に保存されている場合は、保存されたファイルを削除して既存のファイルの参照を増やします
+	RegisterBlob(ctx context.Context, assetUUID uuid.UUID, extension string, hash string, size int64) (*entity.UploadedFile, error)
+	// ReleaseBlob は UploadFile・RegisterBlob で増やしたファイルの参照を減らし、参照がなくなったファイルを削除します
+	ReleaseBlob(ctx context.Context, hash string) error
+	// FindBlobKeys は keys のうちアセットから参照されているファイルのキーを返します
+	FindBlobKeys(ctx context.Context, keys []string) (map[string]bool, error)
+	// UploadVariant は派生画像を元画像と同じディレクトリに保存します
+	UploadVariant(ctx context.Context, assetUUID uuid.UUID, fileName string, data []byte, contentType string) (variantURL *string, err error)
+	CreateVariants(ctx context.Context, variants []*entity.AssetVariant) error
+	// PresignUploadFile はクライアントが元ファイルを直接 PUT するための署名付き URL を発行します。
+	// ストレージが直接アップロードに対応していない場合は ErrDirectUploadNotSupported を返します
+	PresignUploadFile(ctx context.Context, assetUUID uuid.UUID, extension string, contentType string, size int64, expires time.Duration) (uploadURL string, err error)
+	// HeadFile は保存されている元ファイルの情報を返します。ファイルがない場合は ErrUploadedFileNotFound を返します
+	HeadFile(ctx context.Context, assetUUID uuid.UUID, extension string) (*entity.StoredObject, error)
+	OpenFile(ctx context.Context, assetUUID uuid.UUID, extension string) (io.ReadCloser, error)
+	DeleteFile(ctx context.Context, assetUUID uuid.UUID, extension string) error
+	// CreateMultipartUpload は元ファイルをパートに分けてアップロードする準備をし、ストレージのアップロード ID を返します。
+	// ストレージがマルチパートアップロードに対応していない場合は ErrDirectUploadNotSupported を返します
+	CreateMultipartUpload(ctx context.Context, assetUUID uuid.UUID, extension string, contentType string) (multipartUploadID string, err error)
+	// PresignUploadPart はクライアントがパートを直接 PUT するための署名付き URL を発行します
+	PresignUploadPart(ctx context.Context, assetUUID uuid.UUID, extension string, multipartUploadID string, partNumber int32, size int64, expires time.Duration) (uploadURL string, err error)
+	// ListUploadedParts はアップロード済みのパートをパート番号の順に返します。
+	// アップロードが既に完了・中止されている場合は ErrUploadedFileNotFound を返します
+	ListUploadedParts(ctx context.Context, assetUUID uuid.UUID, extension string, multipartUploadID string) ([]*entity.UploadedPart, error)
+	CompleteMultipartUpload(ctx context.Context, assetUUID uuid.UUID, extension string, multipartUploadID string, parts []*entity.UploadedPart) error
+	// AbortMultipartUpload はアップロード済みのパートを破棄します。既に完了・中止されている場合は何もしません
+	AbortMultipartUpload(ctx context.Context, assetUUID uuid.UUID, extension string, multipartUploadID string) error
+	// FileURL は元ファイルの公開 URL と保存先のアセットの種類を返します
+	FileURL(assetUUID uuid.UUID, extension string) (assetURL string, assetType string)
+	// ListOrphans は createdBefore より前に作られ、どの作品にも使われていないアセットを返します
+	ListOrphans(ctx context.Context, createdBefore time.Time) ([]*entity.Asset, error)
+	// DeleteOrphans はアセットと派生画像の行を削除してファイルの参照を減らし、削除したアセットの ID を返します。
+	// 削除までの間に作品に使われたアセットは削除しません
+	DeleteOrphans(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error)
+	// FindByHash はユーザーのアセットのうち、元ファイルの SHA-256 が hash のものを返します。ない場合は ErrAssetNotFound を返します
+	FindByHash(ctx context.Context, userID uuid.UUID, hash string) (*entity.Asset, error)
+	// FindExistingIDs は ids のうち行が存在するアセットの ID を返します
+	FindExistingIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]bool, error)
+	// WalkFiles はストレージ上のアセットのディレクトリにあるファイルを、ページごとに fn へ渡します
+	WalkFiles(ctx context.Context, fn func(files []*entity.StoredFile) error) error
+	DeleteFiles(ctx context.Context, keys []string) error
+	// GetStorageUsage はユーザーのアセットの数と合計サイズを種類ごとに返します
+	GetStorageUsage(ctx context.Context, userID uuid.UUID) ([]*entity.AssetTypeUsage, error)
+	// GetZipManifest は ZIP アセットに含まれるファイルの一覧を返します。
+	// アセットがない場合は ErrAssetNotFound、一覧がない場合は ErrZipManifestNotFound を返します
+	GetZipManifest(ctx context.Context, assetID uuid.UUID) (*entity.ZipManifest, error)
+	// UploadPlayFile は ZIP から展開したブラウザで遊べるビルドのファイルを、配信時のヘッダーを付けて保存します。
+	// filePath はビルドの入口の index.html からの "/" 区切りの相対パスです
+	UploadPlayFile(ctx context.Context, assetUUID uuid.UUID, filePath string, body io.Reader) error
+	// PlayURL は UploadPlayFile で保存したファイルの公開 URL を返します
+	PlayURL(assetUUID uuid.UUID, filePath string) string
+	// DeletePlayFiles はアセットから展開したビルドのファイルをすべて削除します
+	DeletePlayFiles(ctx context.Context, assetUUID uuid.UUID) error
+	// ListImagesWithoutPlaceholder はプレースホルダーのない画像アセットを、ID が afterID より後のものから ID の順に limit 件返します
+	ListImagesWithoutPlaceholder(ctx context.Context, afterID uuid.UUID, limit int) ([]*entity.Asset, error)
+	// UpdatePlaceholder は画像アセットのプレースホルダーを保存します
+	UpdatePlaceholder(ctx context.Context, assetID uuid.UUID, placeholder *entity.ImagePlaceholder) error
+	// OpenURL はアセットの URL が指すファイルを開きます。移行前からあるアセットのように、
+	// ストレージの外にあるファイルは URL から取得します
+	OpenURL(ctx context.Context, assetURL string) (io.ReadCloser, error)
 	UploadAvatar(ctx context.Context, discordUserID string, avatarHash string) (avatarURL *string, err error)
 }
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+)
+
+type AssetUploadRepository interface {
+	Create(ctx context.Context, upload *entity.AssetUpload) error
+	// CreateWithinQuota はユーザーのアセットと有効期限内のアップロードの合計に upload.Size を加えても quota 以下の場合に限り、アップロードを記録します。
+	// 確認と記録はユーザーごとに排他して行うため、同時に始めたアップロードでも上限を超えません。超える場合は ErrStorageQuotaExceeded を返します
+	CreateWithinQuota(ctx context.Context, upload *entity.AssetUpload, quota int64) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.AssetUpload, error)
+	// ListExpired は now の時点で有効期限が切れているアップロードを期限の古い順に返します
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]*entity.AssetUpload, error)
+	// FindExistingIDs は ids のうち行が存在するアップロードの ID を返します
+	FindExistingIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]bool, error)
+	// SumPendingSize はユーザーの有効期限内のアップロードで申告されたサイズの合計を返します
+	SumPendingSize(ctx context.Context, userID uuid.UUID, now time.Time) (int64, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
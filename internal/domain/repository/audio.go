@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"io"
+
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+)
+
+// AudioAnalyzer は音声アセットの中身を読み取ります。
+type AudioAnalyzer interface {
+	// AnalyzeAudio は拡張子が extension の音声から再生時間・タグ・波形を読み取ります。
+	// 音声として読めない場合は ErrInvalidAudio を返します。
+	AnalyzeAudio(ctx context.Context, src io.Reader, extension string) (*entity.AudioMetadata, error)
+}
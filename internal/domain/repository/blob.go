@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+)
+
+// BlobStore はファイルを保存するストレージです。
+// キーは "/" 区切りのパスで、保存先の実装 (S3・ローカルディスクなど) によらず同じキーを使います。
+type BlobStore interface {
+	Put(ctx context.Context, key string, body io.Reader, contentType string) error
+	// PutEncoded は圧縮済みのファイルを、配信時にブラウザが展開するよう Content-Encoding を付けて保存します
+	PutEncoded(ctx context.Context, key string, body io.Reader, contentType string, contentEncoding string) error
+	// Get はファイルの内容を返します。ファイルがない場合は ErrBlobNotFound を返します
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete はファイルを削除します。存在しないキーは無視します
+	Delete(ctx context.Context, keys ...string) error
+	// Head はファイルの情報を返します。ファイルがない場合は ErrBlobNotFound を返します
+	Head(ctx context.Context, key string) (*entity.Blob, error)
+	// List は prefix から始まるキーのファイルを、ページごとに fn へ渡します
+	List(ctx context.Context, prefix string, fn func(blobs []*entity.Blob) error) error
+	// PresignGet は expires の間だけファイルを取得できる URL を返します
+	PresignGet(ctx context.Context, key string, expires time.Duration) (string, error)
+	// URL はファイルの公開 URL を返します
+	URL(key string) string
+}
+
+// UploadPresigner はクライアントがファイルを直接アップロードするための URL を発行できる BlobStore です。
+type UploadPresigner interface {
+	// PresignPut は Content-Type と大きさを固定して PUT できる URL を発行します
+	PresignPut(ctx context.Context, key string, contentType string, size int64, expires time.Duration) (string, error)
+}
+
+// MultipartBlobStore はファイルをパートに分けてアップロードできる BlobStore です。
+type MultipartBlobStore interface {
+	CreateMultipart(ctx context.Context, key string, contentType string) (multipartUploadID string, err error)
+	PresignPart(ctx context.Context, key string, multipartUploadID string, partNumber int32, size int64, expires time.Duration) (string, error)
+	// ListParts はアップロード済みのパートをパート番号の順に返します。
+	// アップロードが既に完了・中止されている場合は ErrBlobNotFound を返します
+	ListParts(ctx context.Context, key string, multipartUploadID string) ([]*entity.UploadedPart, error)
+	CompleteMultipart(ctx context.Context, key string, multipartUploadID string, parts []*entity.UploadedPart) error
+	// AbortMultipart はアップロード済みのパートを破棄します。既に完了・中止されている場合は何もしません
+	AbortMultipart(ctx context.Context, key string, multipartUploadID string) error
+}
@@ -11,4 +11,9 @@ type CommentRepository interface {
 	FindByWorkID(ctx context.Context, workID uuid.UUID) ([]*entity.Comment, error)
 	FindByID(ctx context.Context, id uuid.UUID) (*entity.Comment, error)
 	Create(ctx context.Context, comment *entity.Comment) (*entity.Comment, error)
+	Update(ctx context.Context, comment *entity.Comment) (*entity.Comment, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	// Tombstone は本文と投稿者の表示情報を消し、削除済みの跡としてコメントを残します。
+	Tombstone(ctx context.Context, id uuid.UUID) error
+	HasReplies(ctx context.Context, id uuid.UUID) (bool, error)
 }
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+)
+
+// EventBroker はイベントを宛先のユーザーの購読者へ配信します。
+// 現在はプロセス内で配信していますが、複数台で動かす場合はPostgreSQLのLISTEN/NOTIFYで実装する想定です。
+type EventBroker interface {
+	Publish(ctx context.Context, event *entity.Event) error
+	// Subscribe はuserID宛てのイベントを受け取るチャネルと購読を解除する関数を返します。
+	// lastEventIDを指定すると、それより後に配信されたイベントのうち保持しているものを先に送ります。
+	// チャネルは購読を解除したとき、購読者の受信が追いつかないとき、ブローカーが停止したときに閉じられます。
+	Subscribe(ctx context.Context, userID uuid.UUID, lastEventID string) (<-chan *entity.Event, func(), error)
+}
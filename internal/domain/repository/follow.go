@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+)
+
+type FollowRepository interface {
+	Create(ctx context.Context, follow *entity.Follow) (*entity.Follow, error)
+	Delete(ctx context.Context, follow *entity.Follow) error
+	Exists(ctx context.Context, follow *entity.Follow) bool
+	GetFollowers(ctx context.Context, userID uuid.UUID) ([]*entity.User, error)
+	GetFollowing(ctx context.Context, userID uuid.UUID) ([]*entity.User, error)
+}
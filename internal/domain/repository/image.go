@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"io"
+
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+)
+
+// ImageProcessor は画像アセットの加工を行います。
+type ImageProcessor interface {
+	// GenerateVariants は画像を指定した幅に縮小した派生画像を生成します。
+	// 元画像の幅以上のサイズは生成しません。
+	GenerateVariants(ctx context.Context, src io.Reader, widths []int) ([]*entity.ImageVariant, error)
+	// StripMetadata は EXIF・XMP・GPS などのメタデータを取り除いた画像を返します。
+	// EXIF の向きは画素に反映するため、見た目の向きは変わりません。
+	// 向きを反映した画像を元の形式で書き出せない場合は、別の形式に変換して ConvertedExtension に拡張子を入れます。
+	StripMetadata(ctx context.Context, data []byte, contentType string) (*entity.StrippedImage, error)
+	// GeneratePlaceholder は画像を読み込むまでの間に表示する BlurHash と主要な色を求めます。
+	// 大きさは EXIF の向きを反映した、表示される向きのものを返します。
+	GeneratePlaceholder(ctx context.Context, src io.Reader) (*entity.ImagePlaceholder, error)
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+)
+
+type MentionRepository interface {
+	// CreateMany はメンションをまとめて保存します。既に記録済みのメンションは無視します。
+	CreateMany(ctx context.Context, mentions []*entity.Mention) error
+	GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entity.Mention, int, error)
+	// DeleteByCommentID はコメントからのメンションのうち、keepUserIDsに含まれないユーザーへのメンションを削除します。
+	DeleteByCommentID(ctx context.Context, commentID uuid.UUID, keepUserIDs []uuid.UUID) error
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"io"
+
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+)
+
+// ModelInspector は 3D モデルアセットの中身を検査します。
+type ModelInspector interface {
+	// InspectModel は拡張子が extension の glTF・GLB の構造を検査し、頂点数やマテリアルを読み取ります。
+	// 構造が正しくない場合は ErrInvalidModel、アップロードされていない外部のバッファを参照している場合は
+	// ErrExternalModelBuffer を返します。
+	InspectModel(ctx context.Context, src io.Reader, extension string) (*entity.ModelStats, error)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+)
+
+type NotificationRepository interface {
+	Create(ctx context.Context, notification *entity.Notification) (*entity.Notification, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]*entity.Notification, int, error)
+	CountUnread(ctx context.Context, userID uuid.UUID) (int, error)
+	MarkAsRead(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) error
+}
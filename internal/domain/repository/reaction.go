@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+)
+
+type ReactionRepository interface {
+	// Create はリアクションを保存します。既に同じリアクションがある場合は何もしません。
+	Create(ctx context.Context, reaction *entity.Reaction) error
+	Delete(ctx context.Context, reaction *entity.Reaction) error
+	// Summarize は対象ごとのリアクションを絵文字ごとに集計します。viewerIDがuuid.Nilの場合、Reactedは常にfalseです。
+	Summarize(ctx context.Context, targetType entity.ReactionTargetType, targetIDs []uuid.UUID, viewerID uuid.UUID) (map[uuid.UUID][]*entity.ReactionSummary, error)
+}
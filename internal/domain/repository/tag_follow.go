@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+)
+
+type TagFollowRepository interface {
+	Create(ctx context.Context, tagFollow *entity.TagFollow) (*entity.TagFollow, error)
+	Delete(ctx context.Context, tagFollow *entity.TagFollow) error
+	Exists(ctx context.Context, tagFollow *entity.TagFollow) bool
+	CreateNewWorkEntries(ctx context.Context, workID uuid.UUID) error
+	GetNewWorks(ctx context.Context, userID uuid.UUID, unseenOnly bool, limit, offset int) ([]*entity.TagNewWork, int, error)
+	MarkNewWorksAsSeen(ctx context.Context, userID uuid.UUID, workIDs []uuid.UUID) error
+}
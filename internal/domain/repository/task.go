@@ -0,0 +1,10 @@
+package repository
+
+import "context"
+
+// TaskRunner はリクエストに応答した後も続ける処理をバックグラウンドで実行します。
+// 実装は停止するときに実行中の処理の終了を待ち、処理を落とさないようにします。
+type TaskRunner interface {
+	// Go は task をバックグラウンドで実行します。task にはリクエストが終わっても取り消されない ctx を渡します
+	Go(ctx context.Context, task func(ctx context.Context))
+}
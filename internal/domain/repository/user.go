@@ -11,6 +11,7 @@ type UserRepository interface {
 	Create(ctx context.Context, user *entity.User) (*entity.User, error)
 	GetAll(ctx context.Context) ([]*entity.User, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.User, error)
+	GetByNames(ctx context.Context, names []string) ([]*entity.User, error)
 	GetUserByDiscordUserID(ctx context.Context, discordUserID string) (*entity.User, error)
 	Update(ctx context.Context, user *entity.User) (*entity.User, error)
 }
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/simesaba80/toybox-back/internal/domain/entity"
@@ -14,4 +15,6 @@ type WorkRepository interface {
 	GetByUserID(ctx context.Context, userID uuid.UUID, public bool) ([]*entity.Work, error)
 	ExistsById(ctx context.Context, id uuid.UUID) (bool, error)
 	Create(ctx context.Context, work *entity.Work) (*entity.Work, error)
+	GetFeed(ctx context.Context, userID uuid.UUID, cursor *entity.FeedCursor, limit int, trendingSince time.Time, trendingMinFavorites int) ([]*entity.Work, error)
+	GetFollowingFeed(ctx context.Context, userID uuid.UUID, cursor *entity.FeedCursor, limit int) ([]*entity.Work, error)
 }
@@ -2,8 +2,11 @@ package config
 
 import (
 	"log"
+	"net"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -25,8 +28,67 @@ var (
 	S3_DIR                string
 	S3_BASE_URL           string
 	REGION_NAME           string
+	// STORAGE_BACKEND はファイルの保存先です。"s3" か "local" を指定します
+	STORAGE_BACKEND string
+	// LOCAL_STORAGE_DIR は STORAGE_BACKEND が "local" の場合にファイルを保存するディレクトリです
+	LOCAL_STORAGE_DIR string
+	// LOCAL_STORAGE_BASE_URL はローカルに保存したファイルを配信する URL です。URL のパスで静的ファイルを配信します
+	LOCAL_STORAGE_BASE_URL string
+	// ALLOW_ANONYMOUS_COMMENT がtrueの場合、ログインしていないユーザーもコメントできます
+	ALLOW_ANONYMOUS_COMMENT bool
+	// ANONYMOUS_COMMENT_SALT は匿名コメントの投稿元IPアドレスをハッシュ化するときのソルトです。
+	// IPv4 のアドレスは総当たりできるため、ALLOW_ANONYMOUS_COMMENT が true の場合は推測できない値を必ず設定します
+	ANONYMOUS_COMMENT_SALT string
+	// TRUSTED_PROXIES はX-Forwarded-Forを信頼するリバースプロキシのアドレスの範囲です。空の場合は接続元のアドレスを投稿元とみなします
+	TRUSTED_PROXIES []*net.IPNet
+	// REACTION_EMOJIS はリアクションに使える絵文字の一覧です
+	REACTION_EMOJIS []string
+	// COMMENT_NG_WORDS はコメントに含めることができない語の一覧です
+	COMMENT_NG_WORDS []string
+	// COMMENT_MAX_LINKS は1件のコメントに含められるリンクの数です
+	COMMENT_MAX_LINKS int
+	// COMMENT_DUPLICATE_WINDOW の間に同じ投稿者が同じ内容をコメントすることはできません
+	COMMENT_DUPLICATE_WINDOW time.Duration
+	// COMMENT_RATE_WINDOW の間に同じ投稿者がコメントできるのはCOMMENT_RATE_LIMIT件までです
+	COMMENT_RATE_LIMIT  int
+	COMMENT_RATE_WINDOW time.Duration
+	// ASSET_UPLOAD_URL_TTL はS3へ直接アップロードするための署名付きURLの有効期間です
+	ASSET_UPLOAD_URL_TTL time.Duration
+	// ASSET_MULTIPART_UPLOAD_TTL を過ぎても完了しないマルチパートアップロードは放置されたものとして中止します
+	ASSET_MULTIPART_UPLOAD_TTL time.Duration
+	// ASSET_UPLOAD_JANITOR_INTERVAL は期限切れのアップロードを片付ける間隔です
+	ASSET_UPLOAD_JANITOR_INTERVAL time.Duration
+	// ASSET_ORPHAN_TTL を過ぎても作品に使われていないアセットは tools/assetgc で削除します
+	ASSET_ORPHAN_TTL time.Duration
+	// ASSET_STORAGE_QUOTA はユーザーごとに保存できるファイルの合計バイト数です。0 の場合は上限がありません
+	ASSET_STORAGE_QUOTA int64
+	// ASSET_IMAGE_MAX_PIXELS を超える画素数の画像はデコード時のメモリ消費が大きいため加工しません
+	ASSET_IMAGE_MAX_PIXELS int
+	// ASSET_IMAGE_QUALITY は派生画像を作るときの品質 (1〜100) です
+	ASSET_IMAGE_QUALITY int
+	// ASSET_ZIP_MAX_ENTRIES・ASSET_ZIP_MAX_TOTAL_SIZE・ASSET_ZIP_MAX_RATIO を超える ZIP は
+	// ファイルの数・展開後の合計バイト数・圧縮率が大きすぎる ZIP 爆弾として断ります
+	ASSET_ZIP_MAX_ENTRIES    int
+	ASSET_ZIP_MAX_TOTAL_SIZE int64
+	ASSET_ZIP_MAX_RATIO      int64
+	// ASSET_AUDIO_PEAK_COUNT は音声の波形を分ける区間の数です
+	ASSET_AUDIO_PEAK_COUNT int
+	// ASSET_MODEL_MAX_NODE_VISITS は 3D モデルのシーンのノードを辿る回数の上限です
+	ASSET_MODEL_MAX_NODE_VISITS int
 )
 
+// STORAGE_BACKEND に指定できる値です
+const (
+	StorageBackendS3    = "s3"
+	StorageBackendLocal = "local"
+)
+
+// minAnonymousCommentSaltLength は ANONYMOUS_COMMENT_SALT に求める最低の長さです
+const minAnonymousCommentSaltLength = 32
+
+// defaultReactionEmojis はREACTION_EMOJISが設定されていない場合にリアクションに使える絵文字です
+var defaultReactionEmojis = []string{"👍", "❤️", "😂", "😮", "🔥", "👏", "🎨"}
+
 // .envを呼び出します。
 func LoadEnv() {
 	err := godotenv.Load()
@@ -51,4 +113,104 @@ func LoadEnv() {
 	S3_DIR = os.Getenv("S3_DIR")
 	S3_BASE_URL = os.Getenv("S3_BASE_URL")
 	REGION_NAME = os.Getenv("REGION_NAME")
+	STORAGE_BACKEND = getEnvString("STORAGE_BACKEND", StorageBackendS3)
+	if STORAGE_BACKEND != StorageBackendS3 && STORAGE_BACKEND != StorageBackendLocal {
+		log.Printf("STORAGE_BACKENDの値が不正なため既定値%sを使います: %s", StorageBackendS3, STORAGE_BACKEND)
+		STORAGE_BACKEND = StorageBackendS3
+	}
+	LOCAL_STORAGE_DIR = getEnvString("LOCAL_STORAGE_DIR", "./storage")
+	LOCAL_STORAGE_BASE_URL = getEnvString("LOCAL_STORAGE_BASE_URL", "http://localhost:8080/storage")
+	ALLOW_ANONYMOUS_COMMENT = os.Getenv("ALLOW_ANONYMOUS_COMMENT") == "true"
+	ANONYMOUS_COMMENT_SALT = os.Getenv("ANONYMOUS_COMMENT_SALT")
+	if ALLOW_ANONYMOUS_COMMENT && len(ANONYMOUS_COMMENT_SALT) < minAnonymousCommentSaltLength {
+		// ソルトが空や短いと、投稿者の識別子からすべての IP アドレスを試して元のアドレスを割り出せてしまう
+		log.Fatalf("ALLOW_ANONYMOUS_COMMENTを有効にする場合はANONYMOUS_COMMENT_SALTに%d文字以上のランダムな値を設定してください", minAnonymousCommentSaltLength)
+	}
+	TRUSTED_PROXIES = getEnvCIDRs("TRUSTED_PROXIES")
+	REACTION_EMOJIS = getEnvList("REACTION_EMOJIS")
+	if len(REACTION_EMOJIS) == 0 {
+		REACTION_EMOJIS = defaultReactionEmojis
+	}
+	COMMENT_NG_WORDS = getEnvList("COMMENT_NG_WORDS")
+	COMMENT_MAX_LINKS = getEnvInt("COMMENT_MAX_LINKS", 2)
+	COMMENT_DUPLICATE_WINDOW = getEnvDuration("COMMENT_DUPLICATE_WINDOW", 10*time.Minute)
+	COMMENT_RATE_LIMIT = getEnvInt("COMMENT_RATE_LIMIT", 5)
+	COMMENT_RATE_WINDOW = getEnvDuration("COMMENT_RATE_WINDOW", time.Minute)
+	ASSET_UPLOAD_URL_TTL = getEnvDuration("ASSET_UPLOAD_URL_TTL", 15*time.Minute)
+	ASSET_MULTIPART_UPLOAD_TTL = getEnvDuration("ASSET_MULTIPART_UPLOAD_TTL", 24*time.Hour)
+	ASSET_UPLOAD_JANITOR_INTERVAL = getEnvDuration("ASSET_UPLOAD_JANITOR_INTERVAL", 10*time.Minute)
+	ASSET_ORPHAN_TTL = getEnvDuration("ASSET_ORPHAN_TTL", 24*time.Hour)
+	ASSET_STORAGE_QUOTA = int64(getEnvInt("ASSET_STORAGE_QUOTA", 5<<30))
+	ASSET_IMAGE_MAX_PIXELS = getEnvInt("ASSET_IMAGE_MAX_PIXELS", 40_000_000)
+	ASSET_IMAGE_QUALITY = getEnvInt("ASSET_IMAGE_QUALITY", 82)
+	ASSET_ZIP_MAX_ENTRIES = getEnvInt("ASSET_ZIP_MAX_ENTRIES", 10_000)
+	ASSET_ZIP_MAX_TOTAL_SIZE = int64(getEnvInt("ASSET_ZIP_MAX_TOTAL_SIZE", 2<<30))
+	ASSET_ZIP_MAX_RATIO = int64(getEnvInt("ASSET_ZIP_MAX_RATIO", 100))
+	ASSET_AUDIO_PEAK_COUNT = getEnvInt("ASSET_AUDIO_PEAK_COUNT", 200)
+	ASSET_MODEL_MAX_NODE_VISITS = getEnvInt("ASSET_MODEL_MAX_NODE_VISITS", 100_000)
+}
+
+// getEnvString は環境変数を読み込みます。設定されていない場合はdefaultValueを返します。
+func getEnvString(key string, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// getEnvInt は環境変数を整数として読み込みます。設定されていないか不正な値の場合はdefaultValueを返します。
+func getEnvInt(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("%sの値が不正なため既定値%dを使います: %v", key, defaultValue, err)
+		return defaultValue
+	}
+	return i
+}
+
+// getEnvList は環境変数をカンマ区切りの一覧として読み込みます。前後の空白は取り除き、空の要素は無視します。
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getEnvCIDRs は環境変数をカンマ区切りの"10.0.0.0/8"のようなアドレスの範囲として読み込みます。不正な範囲は無視します。
+func getEnvCIDRs(key string) []*net.IPNet {
+	value := os.Getenv(key)
+	if value == "" {
+		return nil
+	}
+	var ipNets []*net.IPNet
+	for _, cidr := range strings.Split(value, ",") {
+		_, ipNet, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			log.Printf("%sの値が不正なため無視します: %v", key, err)
+			continue
+		}
+		ipNets = append(ipNets, ipNet)
+	}
+	return ipNets
+}
+
+// getEnvDuration は環境変数を"10m"のような期間として読み込みます。設定されていないか不正な値の場合はdefaultValueを返します。
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("%sの値が不正なため既定値%sを使います: %v", key, defaultValue, err)
+		return defaultValue
+	}
+	return d
 }
@@ -3,45 +3,34 @@ package asset
 import (
 	"bytes"
 	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"mime/multipart"
 	"net/http"
+	"strings"
+	"time"
 
-	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/google/uuid"
 	"github.com/simesaba80/toybox-back/internal/domain/entity"
 	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/domain/repository"
 	"github.com/simesaba80/toybox-back/internal/infrastructure/config"
 	"github.com/simesaba80/toybox-back/internal/infrastructure/database/dto"
 	"github.com/simesaba80/toybox-back/internal/infrastructure/database/types"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/external/blobstore"
 	"github.com/uptrace/bun"
 )
 
-type mimeType string
-
-const (
-	png             mimeType = "image/png"
-	jpeg            mimeType = "image/jpeg"
-	jpg             mimeType = "image/jpge"
-	bmp             mimeType = "image/bmp"
-	gif             mimeType = "image/gif"
-	webp            mimeType = "image/webp"
-	mp4             mimeType = "video/mp4"
-	mov             mimeType = "video/quicktime"
-	avi             mimeType = "video/x-msvideo"
-	flv             mimeType = "video/x-flv"
-	mp3             mimeType = "audio/mpeg"
-	wav             mimeType = "audio/wav"
-	m4a             mimeType = "audio/aac"
-	zip             mimeType = "application/zip"
-	gilf            mimeType = "model/gltf+json"
-	defaultMimeType mimeType = "application/octet-stream"
-)
+// Discord のアバターは webp で取得して保存する
+const avatarContentType = "image/webp"
 
 const discordAvatarEndpointFormat = "https://cdn.discordapp.com/avatars/%s/%s.webp?size=256"
 
+// downloadTimeout は外部の URL からファイルを取得するときの上限時間です。応答の本文を読み終えるまでを含みます
+const downloadTimeout = 30 * time.Second
+
 var ExtensionToDirName = map[string]string{
 	"png":  "image",
 	"jpeg": "image",
@@ -58,18 +47,22 @@ var ExtensionToDirName = map[string]string{
 	"m4a":  "music",
 	"zip":  "zip",
 	"gltf": "model",
+	"glb":  "model",
 	"fbx":  "model",
 }
 
 type AssetRepository struct {
-	db *bun.DB
-	s3 *s3.Client
+	db         *bun.DB
+	blobs      repository.BlobStore
+	httpClient *http.Client
 }
 
-func NewAssetRepository(db *bun.DB, s3 *s3.Client) *AssetRepository {
+func NewAssetRepository(db *bun.DB, blobs repository.BlobStore) *AssetRepository {
 	return &AssetRepository{
-		db: db,
-		s3: s3,
+		db:    db,
+		blobs: blobs,
+		// 応答しないサーバーから取得し続けて、バックフィルやログインが止まらないようにする
+		httpClient: &http.Client{Timeout: downloadTimeout},
 	}
 }
 
@@ -77,47 +70,639 @@ func (r *AssetRepository) Create(ctx context.Context, asset *entity.Asset) (*ent
 	dtoAsset := dto.ToAssetDTO(asset)
 
 	dtoAsset.AssetType = types.AssetType(ExtensionToDirName[asset.Extension])
-	_, err := r.db.NewInsert().Model(dtoAsset).Exec(ctx)
+	query := r.db.NewInsert().Model(dtoAsset)
+	if asset.ZipManifest != nil {
+		manifest, err := json.Marshal(dto.ToZipManifestDTO(asset.ZipManifest))
+		if err != nil {
+			return nil, domainerrors.ErrFailedToCreateAsset
+		}
+		query = query.Value("zip_manifest", "?::jsonb", string(manifest))
+	}
+	_, err := query.Exec(ctx)
 	if err != nil {
 		return nil, domainerrors.ErrFailedToCreateAsset
 	}
 	return dtoAsset.ToAssetEntity(), nil
 }
 
-func (r *AssetRepository) UploadFile(ctx context.Context, file *multipart.FileHeader, assetUUID uuid.UUID, extension string) (assetURL *string, assetType *string, err error) {
-	openFile, err := file.Open()
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to open file: %w", err)
-	}
-	defer openFile.Close()
-
-	//ファイルのmimeTypeと保存場所を指定
-	mimeType := defineMimeType(extension)
+// dirNameOf は拡張子からファイルの保存先のディレクトリ名を決める
+func dirNameOf(extension string) string {
 	dirName := ExtensionToDirName[extension]
 	if dirName == "" {
 		dirName = "other"
 	}
+	return dirName
+}
+
+// originKey は元ファイルを保存するストレージのキーを返す
+func originKey(assetUUID uuid.UUID, extension string) string {
+	return config.S3_DIR + "/" + dirNameOf(extension) + "/" + assetUUID.String() + "/origin." + extension
+}
+
+func (r *AssetRepository) UploadFile(ctx context.Context, body io.ReadSeeker, assetUUID uuid.UUID, extension string, contentType string, hash string) (*entity.UploadedFile, error) {
+	size, err := body.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	if _, err := body.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	uploaded := &entity.UploadedFile{
+		AssetType: dirNameOf(extension),
+		Hash:      hash,
+		Size:      size,
+	}
+
+	// 同じ内容のファイルが既にあれば保存せずに参照する
+	var existingKeys []string
+	err = r.db.NewUpdate().
+		Model((*dto.Blob)(nil)).
+		Set("ref_count = ref_count + 1").
+		Where("hash = ?", uploaded.Hash).
+		Returning("storage_key").
+		Scan(ctx, &existingKeys)
+	if err != nil {
+		return nil, domainerrors.ErrFailedToRegisterBlob
+	}
+	if len(existingKeys) > 0 {
+		uploaded.URL = r.blobs.URL(existingKeys[0])
+		uploaded.Deduplicated = true
+		return uploaded, nil
+	}
+
+	key := originKey(assetUUID, extension)
+	if err := r.blobs.Put(ctx, key, body, contentType); err != nil {
+		return nil, fmt.Errorf("failed to upload file: %w", err)
+	}
+	if err := r.registerBlob(ctx, uploaded, key); err != nil {
+		return nil, err
+	}
+	return uploaded, nil
+}
+
+func (r *AssetRepository) RegisterBlob(ctx context.Context, assetUUID uuid.UUID, extension string, hash string, size int64) (*entity.UploadedFile, error) {
+	uploaded := &entity.UploadedFile{
+		AssetType: dirNameOf(extension),
+		Hash:      hash,
+		Size:      size,
+	}
+	if err := r.registerBlob(ctx, uploaded, originKey(assetUUID, extension)); err != nil {
+		return nil, err
+	}
+	return uploaded, nil
+}
+
+// registerBlob は key に保存したファイルを登録し、uploaded の URL を埋める。
+// 同じ内容のファイルが既に登録されている場合は、key のファイルを消して先に登録された方を使う
+func (r *AssetRepository) registerBlob(ctx context.Context, uploaded *entity.UploadedFile, key string) error {
+	var registeredKeys []string
+	err := r.db.NewInsert().
+		Model(&dto.Blob{
+			Hash:       uploaded.Hash,
+			StorageKey: key,
+			Size:       uploaded.Size,
+			RefCount:   1,
+			CreatedAt:  time.Now(),
+		}).
+		On("CONFLICT (hash) DO UPDATE").
+		Set("ref_count = blob.ref_count + 1").
+		Returning("storage_key").
+		Scan(ctx, &registeredKeys)
+	if err != nil || len(registeredKeys) == 0 {
+		return domainerrors.ErrFailedToRegisterBlob
+	}
+	if registeredKeys[0] != key {
+		// 消せなかったファイルは使われないだけなので、アップロードは成功とする
+		_ = r.blobs.Delete(ctx, key)
+		uploaded.Deduplicated = true
+	}
+	uploaded.URL = r.blobs.URL(registeredKeys[0])
+	return nil
+}
+
+func (r *AssetRepository) ReleaseBlob(ctx context.Context, hash string) error {
+	if hash == "" {
+		return nil
+	}
+	_, err := r.db.NewUpdate().
+		Model((*dto.Blob)(nil)).
+		Set("ref_count = ref_count - 1").
+		Where("hash = ?", hash).
+		Exec(ctx)
+	if err != nil {
+		return domainerrors.ErrFailedToReleaseBlob
+	}
+	// 参照が残っていないことを確かめてから消し、同時に参照を増やしたアップロードのファイルを消さないようにする
+	var releasedKeys []string
+	err = r.db.NewDelete().
+		Model((*dto.Blob)(nil)).
+		Where("hash = ?", hash).
+		Where("ref_count <= 0").
+		Returning("storage_key").
+		Scan(ctx, &releasedKeys)
+	if err != nil {
+		return domainerrors.ErrFailedToReleaseBlob
+	}
+	if len(releasedKeys) > 0 {
+		if err := r.blobs.Delete(ctx, releasedKeys...); err != nil {
+			return fmt.Errorf("failed to delete released file: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *AssetRepository) FindBlobKeys(ctx context.Context, keys []string) (map[string]bool, error) {
+	referenced := make(map[string]bool, len(keys))
+	if len(keys) == 0 {
+		return referenced, nil
+	}
+	var referencedKeys []string
+	err := r.db.NewSelect().
+		Model((*dto.Blob)(nil)).
+		Column("storage_key").
+		Where("storage_key IN (?)", bun.In(keys)).
+		Scan(ctx, &referencedKeys)
+	if err != nil {
+		return nil, domainerrors.ErrFailedToFindBlobKeys
+	}
+	for _, key := range referencedKeys {
+		referenced[key] = true
+	}
+	return referenced, nil
+}
+
+func (r *AssetRepository) FileURL(assetUUID uuid.UUID, extension string) (assetURL string, assetType string) {
+	return r.blobs.URL(originKey(assetUUID, extension)), dirNameOf(extension)
+}
+
+func (r *AssetRepository) PresignUploadFile(ctx context.Context, assetUUID uuid.UUID, extension string, contentType string, size int64, expires time.Duration) (uploadURL string, err error) {
+	presigner, ok := r.blobs.(repository.UploadPresigner)
+	if !ok {
+		return "", domainerrors.ErrDirectUploadNotSupported
+	}
+	uploadURL, err = presigner.PresignPut(ctx, originKey(assetUUID, extension), contentType, size, expires)
+	if err != nil {
+		return "", domainerrors.ErrFailedToPresignUpload
+	}
+	return uploadURL, nil
+}
+
+func (r *AssetRepository) HeadFile(ctx context.Context, assetUUID uuid.UUID, extension string) (*entity.StoredObject, error) {
+	blob, err := r.blobs.Head(ctx, originKey(assetUUID, extension))
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrBlobNotFound) {
+			return nil, domainerrors.ErrUploadedFileNotFound
+		}
+		return nil, fmt.Errorf("failed to head file: %w", err)
+	}
+	return &entity.StoredObject{
+		Size:        blob.Size,
+		ContentType: blob.ContentType,
+	}, nil
+}
+
+func (r *AssetRepository) OpenFile(ctx context.Context, assetUUID uuid.UUID, extension string) (io.ReadCloser, error) {
+	return r.openBlob(ctx, originKey(assetUUID, extension))
+}
+
+func (r *AssetRepository) openBlob(ctx context.Context, key string) (io.ReadCloser, error) {
+	body, err := r.blobs.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrBlobNotFound) {
+			return nil, domainerrors.ErrUploadedFileNotFound
+		}
+		return nil, fmt.Errorf("failed to get file: %w", err)
+	}
+	return body, nil
+}
+
+func (r *AssetRepository) DeleteFile(ctx context.Context, assetUUID uuid.UUID, extension string) error {
+	if err := r.blobs.Delete(ctx, originKey(assetUUID, extension)); err != nil {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	return nil
+}
+
+// multipartStore はマルチパートアップロードに対応したストレージを返す
+func (r *AssetRepository) multipartStore() (repository.MultipartBlobStore, error) {
+	store, ok := r.blobs.(repository.MultipartBlobStore)
+	if !ok {
+		return nil, domainerrors.ErrDirectUploadNotSupported
+	}
+	return store, nil
+}
+
+func (r *AssetRepository) CreateMultipartUpload(ctx context.Context, assetUUID uuid.UUID, extension string, contentType string) (string, error) {
+	store, err := r.multipartStore()
+	if err != nil {
+		return "", err
+	}
+	multipartUploadID, err := store.CreateMultipart(ctx, originKey(assetUUID, extension), contentType)
+	if err != nil {
+		return "", domainerrors.ErrFailedToCreateMultipart
+	}
+	return multipartUploadID, nil
+}
+
+func (r *AssetRepository) PresignUploadPart(ctx context.Context, assetUUID uuid.UUID, extension string, multipartUploadID string, partNumber int32, size int64, expires time.Duration) (string, error) {
+	store, err := r.multipartStore()
+	if err != nil {
+		return "", err
+	}
+	uploadURL, err := store.PresignPart(ctx, originKey(assetUUID, extension), multipartUploadID, partNumber, size, expires)
+	if err != nil {
+		return "", domainerrors.ErrFailedToPresignUpload
+	}
+	return uploadURL, nil
+}
+
+func (r *AssetRepository) ListUploadedParts(ctx context.Context, assetUUID uuid.UUID, extension string, multipartUploadID string) ([]*entity.UploadedPart, error) {
+	store, err := r.multipartStore()
+	if err != nil {
+		return nil, err
+	}
+	parts, err := store.ListParts(ctx, originKey(assetUUID, extension), multipartUploadID)
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrBlobNotFound) {
+			return nil, domainerrors.ErrUploadedFileNotFound
+		}
+		return nil, domainerrors.ErrFailedToListUploadedParts
+	}
+	return parts, nil
+}
+
+func (r *AssetRepository) CompleteMultipartUpload(ctx context.Context, assetUUID uuid.UUID, extension string, multipartUploadID string, parts []*entity.UploadedPart) error {
+	store, err := r.multipartStore()
+	if err != nil {
+		return err
+	}
+	if err := store.CompleteMultipart(ctx, originKey(assetUUID, extension), multipartUploadID, parts); err != nil {
+		return domainerrors.ErrFailedToCompleteMultipart
+	}
+	return nil
+}
+
+func (r *AssetRepository) AbortMultipartUpload(ctx context.Context, assetUUID uuid.UUID, extension string, multipartUploadID string) error {
+	store, err := r.multipartStore()
+	if err != nil {
+		return err
+	}
+	if err := store.AbortMultipart(ctx, originKey(assetUUID, extension), multipartUploadID); err != nil {
+		return domainerrors.ErrFailedToAbortMultipart
+	}
+	return nil
+}
+
+func (r *AssetRepository) UploadVariant(ctx context.Context, assetUUID uuid.UUID, fileName string, data []byte, contentType string) (variantURL *string, err error) {
+	// 派生画像は画像アセットからのみ作るため、元画像と同じ image ディレクトリに置く
+	key := config.S3_DIR + "/" + entity.AssetTypeImage + "/" + assetUUID.String() + "/" + fileName
+	if err := r.blobs.Put(ctx, key, bytes.NewReader(data), contentType); err != nil {
+		return nil, fmt.Errorf("failed to upload variant: %w", err)
+	}
+	newVariantURL := r.blobs.URL(key)
+
+	return &newVariantURL, nil
+}
+
+// playDirName はブラウザで遊べるビルドを展開するディレクトリ名
+const playDirName = "play"
+
+// playKey は展開したビルドのファイルを保存するストレージのキーを返す
+func playKey(assetUUID uuid.UUID, filePath string) string {
+	return config.S3_DIR + "/" + playDirName + "/" + assetUUID.String() + "/" + filePath
+}
+
+func (r *AssetRepository) UploadPlayFile(ctx context.Context, assetUUID uuid.UUID, filePath string, body io.Reader) error {
+	key := playKey(assetUUID, filePath)
+	contentType, contentEncoding := blobstore.ContentHeaders(key)
+	if contentEncoding == "" {
+		err := r.blobs.Put(ctx, key, body, contentType)
+		if err != nil {
+			return fmt.Errorf("failed to upload play file: %w", err)
+		}
+		return nil
+	}
+	if err := r.blobs.PutEncoded(ctx, key, body, contentType, contentEncoding); err != nil {
+		return fmt.Errorf("failed to upload play file: %w", err)
+	}
+	return nil
+}
+
+func (r *AssetRepository) PlayURL(assetUUID uuid.UUID, filePath string) string {
+	return r.blobs.URL(playKey(assetUUID, filePath))
+}
 
-	_, err = r.s3.PutObject(ctx, &s3.PutObjectInput{
-		Bucket:      aws.String(config.S3_BUCKET),
-		Key:         aws.String(config.S3_DIR + "/" + dirName + "/" + assetUUID.String() + "/origin." + extension),
-		Body:        openFile,
-		ContentType: aws.String(string(mimeType)),
+func (r *AssetRepository) DeletePlayFiles(ctx context.Context, assetUUID uuid.UUID) error {
+	err := r.blobs.List(ctx, playKey(assetUUID, ""), func(blobs []*entity.Blob) error {
+		keys := make([]string, len(blobs))
+		for i, blob := range blobs {
+			keys[i] = blob.Key
+		}
+		return r.blobs.Delete(ctx, keys...)
 	})
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to upload file: %w", err)
+		return fmt.Errorf("failed to delete play files: %w", err)
 	}
-	newAssetURL := config.S3_BASE_URL + "/" + config.S3_BUCKET + "/" + config.S3_DIR + "/" + dirName + "/" + assetUUID.String() + "/origin." + extension
+	return nil
+}
 
-	return &newAssetURL, &dirName, nil
+func (r *AssetRepository) CreateVariants(ctx context.Context, variants []*entity.AssetVariant) error {
+	if len(variants) == 0 {
+		return nil
+	}
+	dtoVariants := make([]*dto.AssetVariant, len(variants))
+	for i, variant := range variants {
+		dtoVariants[i] = dto.ToAssetVariantDTO(variant)
+	}
+	_, err := r.db.NewInsert().Model(&dtoVariants).On("CONFLICT (asset_id, width) DO NOTHING").Exec(ctx)
+	if err != nil {
+		return domainerrors.ErrFailedToCreateAssetVariants
+	}
+	return nil
 }
 
-func defineMimeType(extension string) mimeType {
-	mimeType := mimeType(extension)
-	if mimeType == "" {
-		mimeType = defaultMimeType
+// isUnattached は作品に紐づいていないアセットの条件。
+// 作品に紐づく前のアセットの work_id には NULL ではなく uuid.Nil が入る
+const isUnattached = "(asset.work_id IS NULL OR asset.work_id = ?)"
+
+func (r *AssetRepository) ListOrphans(ctx context.Context, createdBefore time.Time) ([]*entity.Asset, error) {
+	var dtoAssets []*dto.Asset
+	err := r.db.NewSelect().
+		Model(&dtoAssets).
+		Where(isUnattached, uuid.Nil).
+		Where("asset.created_at < ?", createdBefore).
+		Where("NOT EXISTS (SELECT 1 FROM thumbnail WHERE thumbnail.asset_id = asset.id)").
+		Order("asset.created_at ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, domainerrors.ErrFailedToListOrphanAssets
+	}
+	assets := make([]*entity.Asset, len(dtoAssets))
+	for i, dtoAsset := range dtoAssets {
+		assets[i] = dtoAsset.ToAssetEntity()
 	}
-	return mimeType
+	return assets, nil
+}
+
+func (r *AssetRepository) DeleteOrphans(ctx context.Context, ids []uuid.UUID) (deletedIDs []uuid.UUID, err error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, domainerrors.ErrFailedToBeginTransaction
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// 一覧を取ってから削除するまでに作品に使われたアセットは残す
+	var deletedAssets []dto.Asset
+	err = tx.NewDelete().
+		Model((*dto.Asset)(nil)).
+		Where("asset.id IN (?)", bun.In(ids)).
+		Where(isUnattached, uuid.Nil).
+		Where("NOT EXISTS (SELECT 1 FROM thumbnail WHERE thumbnail.asset_id = asset.id)").
+		Returning("asset.id, asset.hash").
+		Scan(ctx, &deletedAssets)
+	if err != nil {
+		return nil, domainerrors.ErrFailedToDeleteAssets
+	}
+	releasedRefs := make(map[string]int)
+	for _, deletedAsset := range deletedAssets {
+		deletedIDs = append(deletedIDs, deletedAsset.ID)
+		if deletedAsset.Hash != "" {
+			releasedRefs[deletedAsset.Hash]++
+		}
+	}
+	if len(deletedIDs) > 0 {
+		_, err = tx.NewDelete().
+			Model((*dto.AssetVariant)(nil)).
+			Where("asset_id IN (?)", bun.In(deletedIDs)).
+			Exec(ctx)
+		if err != nil {
+			return nil, domainerrors.ErrFailedToDeleteAssets
+		}
+	}
+	if len(releasedRefs) > 0 {
+		// 参照がなくなったファイルは行を消し、ファイルの確認で削除の対象にする
+		hashes := make([]string, 0, len(releasedRefs))
+		for hash, count := range releasedRefs {
+			_, err = tx.NewUpdate().
+				Model((*dto.Blob)(nil)).
+				Set("ref_count = ref_count - ?", count).
+				Where("hash = ?", hash).
+				Exec(ctx)
+			if err != nil {
+				return nil, domainerrors.ErrFailedToReleaseBlob
+			}
+			hashes = append(hashes, hash)
+		}
+		_, err = tx.NewDelete().
+			Model((*dto.Blob)(nil)).
+			Where("hash IN (?)", bun.In(hashes)).
+			Where("ref_count <= 0").
+			Exec(ctx)
+		if err != nil {
+			return nil, domainerrors.ErrFailedToReleaseBlob
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, domainerrors.ErrFailedToCommitTransaction
+	}
+	return deletedIDs, nil
+}
+
+func (r *AssetRepository) FindByHash(ctx context.Context, userID uuid.UUID, hash string) (*entity.Asset, error) {
+	dtoAsset := new(dto.Asset)
+	err := r.db.NewSelect().
+		Model(dtoAsset).
+		Where("asset.user_id = ?", userID).
+		Where("asset.hash = ?", hash).
+		Order("asset.created_at DESC").
+		Limit(1).
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domainerrors.ErrAssetNotFound
+		}
+		return nil, domainerrors.ErrFailedToGetAssets
+	}
+	return dtoAsset.ToAssetEntity(), nil
+}
+
+func (r *AssetRepository) FindExistingIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]bool, error) {
+	existing := make(map[uuid.UUID]bool, len(ids))
+	if len(ids) == 0 {
+		return existing, nil
+	}
+	var existingIDs []uuid.UUID
+	err := r.db.NewSelect().
+		Model((*dto.Asset)(nil)).
+		Column("id").
+		Where("id IN (?)", bun.In(ids)).
+		Scan(ctx, &existingIDs)
+	if err != nil {
+		return nil, domainerrors.ErrFailedToGetAssets
+	}
+	for _, id := range existingIDs {
+		existing[id] = true
+	}
+	return existing, nil
+}
+
+// assetIDOfKey は S3_DIR/<種類>/<アセットの ID>/<ファイル名> のキーと、
+// S3_DIR/play/<アセットの ID>/<ビルド内のパス> のキーからアセットの ID を取り出す。
+// アセットのファイルではないキーの場合は uuid.Nil を返す
+func assetIDOfKey(key string) uuid.UUID {
+	segments := strings.Split(strings.TrimPrefix(key, config.S3_DIR+"/"), "/")
+	switch {
+	case len(segments) >= 3 && segments[0] == playDirName:
+	case len(segments) == 3 && isAssetDirName(segments[0]):
+	default:
+		return uuid.Nil
+	}
+	assetID, err := uuid.Parse(segments[1])
+	if err != nil {
+		return uuid.Nil
+	}
+	return assetID
+}
+
+func isAssetDirName(dirName string) bool {
+	if dirName == "other" {
+		return true
+	}
+	for _, assetDirName := range ExtensionToDirName {
+		if dirName == assetDirName {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *AssetRepository) WalkFiles(ctx context.Context, fn func(files []*entity.StoredFile) error) error {
+	return r.blobs.List(ctx, config.S3_DIR+"/", func(blobs []*entity.Blob) error {
+		files := make([]*entity.StoredFile, len(blobs))
+		for i, blob := range blobs {
+			files[i] = &entity.StoredFile{
+				Key:          blob.Key,
+				AssetID:      assetIDOfKey(blob.Key),
+				Size:         blob.Size,
+				LastModified: blob.LastModified,
+			}
+		}
+		return fn(files)
+	})
+}
+
+func (r *AssetRepository) DeleteFiles(ctx context.Context, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	if err := r.blobs.Delete(ctx, keys...); err != nil {
+		return fmt.Errorf("failed to delete files: %w", err)
+	}
+	return nil
+}
+
+func (r *AssetRepository) GetStorageUsage(ctx context.Context, userID uuid.UUID) ([]*entity.AssetTypeUsage, error) {
+	var usages []dto.AssetTypeUsage
+	err := r.db.NewSelect().
+		Model((*dto.Asset)(nil)).
+		ColumnExpr("asset.asset_type").
+		ColumnExpr("COUNT(*) AS count").
+		ColumnExpr("COALESCE(SUM(asset.size), 0) AS bytes").
+		Where("asset.user_id = ?", userID).
+		Group("asset.asset_type").
+		Order("asset.asset_type ASC").
+		Scan(ctx, &usages)
+	if err != nil {
+		return nil, domainerrors.ErrFailedToGetStorageUsage
+	}
+	entities := make([]*entity.AssetTypeUsage, len(usages))
+	for i, usage := range usages {
+		entities[i] = usage.ToAssetTypeUsageEntity()
+	}
+	return entities, nil
+}
+
+func (r *AssetRepository) GetZipManifest(ctx context.Context, assetID uuid.UUID) (*entity.ZipManifest, error) {
+	var row dto.AssetZipManifest
+	err := r.db.NewSelect().
+		Model(&row).
+		Where("id = ?", assetID).
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domainerrors.ErrAssetNotFound
+		}
+		return nil, domainerrors.ErrFailedToGetZipManifest
+	}
+	if row.ZipManifest == nil {
+		return nil, domainerrors.ErrZipManifestNotFound
+	}
+	return row.ZipManifest.ToZipManifestEntity(), nil
+}
+
+func (r *AssetRepository) ListImagesWithoutPlaceholder(ctx context.Context, afterID uuid.UUID, limit int) ([]*entity.Asset, error) {
+	var dtoAssets []*dto.Asset
+	err := r.db.NewSelect().
+		Model(&dtoAssets).
+		Where("asset.asset_type = ?", types.AssetTypeImage).
+		Where("asset.blurhash IS NULL").
+		Where("asset.id > ?", afterID).
+		Order("asset.id ASC").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, domainerrors.ErrFailedToListImageAssets
+	}
+	assets := make([]*entity.Asset, len(dtoAssets))
+	for i, dtoAsset := range dtoAssets {
+		assets[i] = dtoAsset.ToAssetEntity()
+	}
+	return assets, nil
+}
+
+func (r *AssetRepository) UpdatePlaceholder(ctx context.Context, assetID uuid.UUID, placeholder *entity.ImagePlaceholder) error {
+	_, err := r.db.NewUpdate().
+		Model((*dto.Asset)(nil)).
+		Set("width = ?", placeholder.Width).
+		Set("height = ?", placeholder.Height).
+		Set("blurhash = ?", placeholder.BlurHash).
+		Set("dominant_color = ?", placeholder.DominantColor).
+		Where("id = ?", assetID).
+		Exec(ctx)
+	if err != nil {
+		return domainerrors.ErrFailedToUpdatePlaceholder
+	}
+	return nil
+}
+
+func (r *AssetRepository) OpenURL(ctx context.Context, assetURL string) (io.ReadCloser, error) {
+	if key, ok := strings.CutPrefix(assetURL, r.blobs.URL("")); ok {
+		return r.openBlob(ctx, key)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, assetURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file request: %w", err)
+	}
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, domainerrors.ErrUploadedFileNotFound
+		}
+		return nil, fmt.Errorf("failed to download file: status %d", resp.StatusCode)
+	}
+	return resp.Body, nil
 }
 
 func (r *AssetRepository) UploadAvatar(ctx context.Context, discordUserID string, avatarHash string) (avatarURL *string, err error) {
@@ -131,7 +716,7 @@ func (r *AssetRepository) UploadAvatar(ctx context.Context, discordUserID string
 		return nil, fmt.Errorf("failed to create discord avatar request: %w", err)
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := r.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to download discord avatar: %w", err)
 	}
@@ -146,18 +731,11 @@ func (r *AssetRepository) UploadAvatar(ctx context.Context, discordUserID string
 		return nil, fmt.Errorf("failed to read discord avatar: %w", err)
 	}
 
-	s3Key := fmt.Sprintf("%s/avatar/%s.webp", config.S3_DIR, avatarHash)
-	_, err = r.s3.PutObject(ctx, &s3.PutObjectInput{
-		Bucket:        aws.String(config.S3_BUCKET),
-		Key:           aws.String(s3Key),
-		Body:          bytes.NewReader(data),
-		ContentType:   aws.String(string(webp)),
-		ContentLength: aws.Int64(int64(len(data))),
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to upload avatar to s3: %w", err)
+	key := fmt.Sprintf("%s/avatar/%s.webp", config.S3_DIR, avatarHash)
+	if err := r.blobs.Put(ctx, key, bytes.NewReader(data), avatarContentType); err != nil {
+		return nil, fmt.Errorf("failed to upload avatar: %w", err)
 	}
 
-	newAvatarURL := fmt.Sprintf("%s/%s/%s", config.S3_BASE_URL, config.S3_BUCKET, s3Key)
+	newAvatarURL := r.blobs.URL(key)
 	return &newAvatarURL, nil
 }
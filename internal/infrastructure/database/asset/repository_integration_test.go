@@ -5,11 +5,13 @@ package asset_test
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"io"
-	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -19,10 +21,12 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
 	"github.com/simesaba80/toybox-back/internal/infrastructure/config"
 	"github.com/simesaba80/toybox-back/internal/infrastructure/database/asset"
 	"github.com/simesaba80/toybox-back/internal/infrastructure/database/dto"
 	"github.com/simesaba80/toybox-back/internal/infrastructure/database/testutil"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/external/blobstore"
 )
 
 func TestMain(m *testing.M) {
@@ -32,22 +36,29 @@ func TestMain(m *testing.M) {
 	os.Exit(code)
 }
 
+// sha256Hex は data の SHA-256 を 16 進数で返す
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
 func TestAssetRepository_Create(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	s3Client := testutil.SetupTestS3(t)
-	repo := asset.NewAssetRepository(db, s3Client)
+	repo := asset.NewAssetRepository(db, blobstore.NewS3Store(s3Client, config.S3_BUCKET, config.S3_BASE_URL))
 
 	ctx := context.Background()
 	userID := uuid.New()
 	now := time.Now().UTC().Truncate(time.Second)
 	testAsset := &entity.Asset{
-		ID:        uuid.New(),
-		WorkID:    uuid.Nil,
-		UserID:    userID,
-		Extension: "png",
-		URL:       "https://example.com/assets/sample.png",
-		CreatedAt: now,
-		UpdatedAt: now,
+		ID:               uuid.New(),
+		WorkID:           uuid.Nil,
+		UserID:           userID,
+		Extension:        "png",
+		URL:              "https://example.com/assets/sample.png",
+		MetadataStripped: true,
+		CreatedAt:        now,
+		UpdatedAt:        now,
 	}
 
 	created, err := repo.Create(ctx, testAsset)
@@ -64,26 +75,57 @@ func TestAssetRepository_Create(t *testing.T) {
 	require.Equal(t, testAsset.Extension, stored.Extension)
 	require.Equal(t, testAsset.UserID, stored.UserID)
 	require.Equal(t, "image", string(stored.AssetType))
+	require.True(t, stored.MetadataStripped)
+}
+
+func TestAssetRepository_CreateAndGetZipManifest(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := asset.NewAssetRepository(db, blobstore.NewLocalStore(t.TempDir(), "http://localhost:8080/storage"))
+
+	ctx := context.Background()
+	zipAsset := entity.NewAsset("", uuid.New(), "zip", "https://example.com/game.zip")
+	zipAsset.ZipManifest = &entity.ZipManifest{
+		Entries: []*entity.ZipEntry{
+			{Path: "game/index.html", Size: 100, CompressedSize: 60},
+			{Path: "game/Build/game.loader.js", Size: 200, CompressedSize: 80},
+		},
+		TotalSize: 300,
+		Contents:  []string{entity.ZipContentUnityWebGL},
+	}
+	_, err := repo.Create(ctx, zipAsset)
+	require.NoError(t, err)
+	imageAsset := entity.NewAsset("", uuid.New(), "png", "https://example.com/image.png")
+	_, err = repo.Create(ctx, imageAsset)
+	require.NoError(t, err)
+
+	manifest, err := repo.GetZipManifest(ctx, zipAsset.ID)
+	require.NoError(t, err)
+	require.Equal(t, zipAsset.ZipManifest, manifest)
+
+	_, err = repo.GetZipManifest(ctx, imageAsset.ID)
+	require.ErrorIs(t, err, domainerrors.ErrZipManifestNotFound)
+	_, err = repo.GetZipManifest(ctx, uuid.New())
+	require.ErrorIs(t, err, domainerrors.ErrAssetNotFound)
 }
 
 func TestAssetRepository_UploadFile(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	s3Client := testutil.SetupTestS3(t)
-	repo := asset.NewAssetRepository(db, s3Client)
+	repo := asset.NewAssetRepository(db, blobstore.NewS3Store(s3Client, config.S3_BUCKET, config.S3_BASE_URL))
 
 	ctx := context.Background()
-	fileHeader := newTestFileHeader(t, "test.png", []byte("dummy data"))
 	assetID := uuid.New()
 
-	assetURL, assetType, err := repo.UploadFile(ctx, fileHeader, assetID, "png")
+	uploaded, err := repo.UploadFile(ctx, bytes.NewReader([]byte("dummy data")), assetID, "png", "image/png", sha256Hex([]byte("dummy data")))
 	require.NoError(t, err)
-	require.NotNil(t, assetURL)
-	require.NotNil(t, assetType)
-	require.Equal(t, "image", *assetType)
+	require.Equal(t, "image", uploaded.AssetType)
+	require.Equal(t, int64(len("dummy data")), uploaded.Size)
+	require.Equal(t, sha256Hex([]byte("dummy data")), uploaded.Hash)
+	require.False(t, uploaded.Deduplicated)
 
 	expectedKey := config.S3_DIR + "/image/" + assetID.String() + "/origin.png"
 	expectedURL := config.S3_BASE_URL + "/" + config.S3_BUCKET + "/" + expectedKey
-	require.Equal(t, expectedURL, *assetURL)
+	require.Equal(t, expectedURL, uploaded.URL)
 
 	resp, err := s3Client.GetObject(ctx, &s3.GetObjectInput{
 		Bucket: aws.String(config.S3_BUCKET),
@@ -97,23 +139,587 @@ func TestAssetRepository_UploadFile(t *testing.T) {
 	require.Equal(t, []byte("dummy data"), content)
 }
 
-func newTestFileHeader(t *testing.T, filename string, content []byte) *multipart.FileHeader {
-	t.Helper()
+func TestAssetRepository_UploadFileToLocalStore(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	store := blobstore.NewLocalStore(t.TempDir(), "http://localhost:8080/storage")
+	repo := asset.NewAssetRepository(db, store)
+
+	ctx := context.Background()
+	assetID := uuid.New()
 
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
-	part, err := writer.CreateFormFile("file", filename)
+	uploaded, err := repo.UploadFile(ctx, bytes.NewReader([]byte("dummy data")), assetID, "png", "image/png", sha256Hex([]byte("dummy data")))
 	require.NoError(t, err)
-	_, err = part.Write(content)
+	require.Equal(t, "image", uploaded.AssetType)
+	expectedKey := config.S3_DIR + "/image/" + assetID.String() + "/origin.png"
+	require.Equal(t, "http://localhost:8080/storage/"+expectedKey, uploaded.URL)
+
+	stored, err := repo.HeadFile(ctx, assetID, "png")
 	require.NoError(t, err)
-	require.NoError(t, writer.Close())
+	require.Equal(t, int64(len("dummy data")), stored.Size)
+	require.Equal(t, "image/png", stored.ContentType)
 
-	req := httptest.NewRequest(http.MethodPost, "/", body)
-	req.Header.Set("Content-Type", writer.FormDataContentType())
-	require.NoError(t, req.ParseMultipartForm(int64(len(content))+1024))
+	var files []*entity.StoredFile
+	require.NoError(t, repo.WalkFiles(ctx, func(page []*entity.StoredFile) error {
+		files = append(files, page...)
+		return nil
+	}))
+	require.Len(t, files, 1)
+	require.Equal(t, expectedKey, files[0].Key)
+	require.Equal(t, assetID, files[0].AssetID)
 
-	_, fileHeader, err := req.FormFile("file")
+	// ローカルのストレージにはクライアントから直接アップロードできない
+	_, err = repo.PresignUploadFile(ctx, assetID, "png", "image/png", 10, time.Minute)
+	require.ErrorIs(t, err, domainerrors.ErrDirectUploadNotSupported)
+	_, err = repo.CreateMultipartUpload(ctx, assetID, "png", "image/png")
+	require.ErrorIs(t, err, domainerrors.ErrDirectUploadNotSupported)
+
+	require.NoError(t, repo.DeleteFile(ctx, assetID, "png"))
+	_, err = repo.HeadFile(ctx, assetID, "png")
+	require.ErrorIs(t, err, domainerrors.ErrUploadedFileNotFound)
+}
+
+func TestAssetRepository_UploadFileDeduplicatesSameContent(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	store := blobstore.NewLocalStore(t.TempDir(), "http://localhost:8080/storage")
+	repo := asset.NewAssetRepository(db, store)
+
+	ctx := context.Background()
+	firstID := uuid.New()
+	secondID := uuid.New()
+
+	first, err := repo.UploadFile(ctx, bytes.NewReader([]byte("same content")), firstID, "png", "image/png", sha256Hex([]byte("same content")))
+	require.NoError(t, err)
+	second, err := repo.UploadFile(ctx, bytes.NewReader([]byte("same content")), secondID, "png", "image/png", sha256Hex([]byte("same content")))
 	require.NoError(t, err)
+	require.True(t, second.Deduplicated)
+	require.Equal(t, first.Hash, second.Hash)
+	require.Equal(t, first.URL, second.URL)
+
+	// 2つ目のファイルは保存しない
+	_, err = repo.HeadFile(ctx, secondID, "png")
+	require.ErrorIs(t, err, domainerrors.ErrUploadedFileNotFound)
+
+	var blob dto.Blob
+	require.NoError(t, db.NewSelect().Model(&blob).Where("hash = ?", first.Hash).Scan(ctx))
+	require.Equal(t, 2, blob.RefCount)
+	require.Equal(t, int64(len("same content")), blob.Size)
+
+	firstKey := config.S3_DIR + "/image/" + firstID.String() + "/origin.png"
+	referenced, err := repo.FindBlobKeys(ctx, []string{firstKey, "unknown"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]bool{firstKey: true}, referenced)
+
+	// 参照が残っている間はファイルを消さない
+	require.NoError(t, repo.ReleaseBlob(ctx, first.Hash))
+	_, err = repo.HeadFile(ctx, firstID, "png")
+	require.NoError(t, err)
+
+	require.NoError(t, repo.ReleaseBlob(ctx, first.Hash))
+	_, err = repo.HeadFile(ctx, firstID, "png")
+	require.ErrorIs(t, err, domainerrors.ErrUploadedFileNotFound)
+	count, err := db.NewSelect().Model((*dto.Blob)(nil)).Where("hash = ?", first.Hash).Count(ctx)
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
+func TestAssetRepository_RegisterBlobDeduplicatesUploadedFile(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	store := blobstore.NewLocalStore(t.TempDir(), "http://localhost:8080/storage")
+	repo := asset.NewAssetRepository(db, store)
+
+	ctx := context.Background()
+	firstID := uuid.New()
+	secondID := uuid.New()
+	content := []byte("same video")
+	hash := "ab" + strings.Repeat("0", 62)
+
+	// クライアントが直接アップロードしたファイルを登録する
+	firstKey := config.S3_DIR + "/video/" + firstID.String() + "/origin.mp4"
+	secondKey := config.S3_DIR + "/video/" + secondID.String() + "/origin.mp4"
+	require.NoError(t, store.Put(ctx, firstKey, bytes.NewReader(content), "video/mp4"))
+	require.NoError(t, store.Put(ctx, secondKey, bytes.NewReader(content), "video/mp4"))
+
+	first, err := repo.RegisterBlob(ctx, firstID, "mp4", hash, int64(len(content)))
+	require.NoError(t, err)
+	require.False(t, first.Deduplicated)
+	require.Equal(t, "video", first.AssetType)
+	require.Equal(t, "http://localhost:8080/storage/"+firstKey, first.URL)
+
+	second, err := repo.RegisterBlob(ctx, secondID, "mp4", hash, int64(len(content)))
+	require.NoError(t, err)
+	require.True(t, second.Deduplicated)
+	require.Equal(t, first.URL, second.URL)
+
+	// 重複したファイルは消す
+	_, err = repo.HeadFile(ctx, secondID, "mp4")
+	require.ErrorIs(t, err, domainerrors.ErrUploadedFileNotFound)
+	_, err = repo.HeadFile(ctx, firstID, "mp4")
+	require.NoError(t, err)
+
+	var blob dto.Blob
+	require.NoError(t, db.NewSelect().Model(&blob).Where("hash = ?", hash).Scan(ctx))
+	require.Equal(t, 2, blob.RefCount)
+	require.Equal(t, firstKey, blob.StorageKey)
+}
+
+func TestAssetRepository_FindByHash(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := asset.NewAssetRepository(db, blobstore.NewLocalStore(t.TempDir(), "http://localhost:8080/storage"))
+
+	ctx := context.Background()
+	userID := uuid.New()
+	hash := strings.Repeat("cd", 32)
+
+	a := entity.NewAsset("", userID, "mp4", "https://example.com/video/origin.mp4")
+	a.Hash = hash
+	_, err := repo.Create(ctx, a)
+	require.NoError(t, err)
+
+	found, err := repo.FindByHash(ctx, userID, hash)
+	require.NoError(t, err)
+	require.Equal(t, a.ID, found.ID)
+	require.Equal(t, hash, found.Hash)
+
+	// 他のユーザーのアセットは返さない
+	_, err = repo.FindByHash(ctx, uuid.New(), hash)
+	require.ErrorIs(t, err, domainerrors.ErrAssetNotFound)
 
-	return fileHeader
+	_, err = repo.FindByHash(ctx, userID, strings.Repeat("ef", 32))
+	require.ErrorIs(t, err, domainerrors.ErrAssetNotFound)
+}
+
+func TestAssetRepository_DeleteOrphansReleasesBlobs(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := asset.NewAssetRepository(db, blobstore.NewLocalStore(t.TempDir(), "http://localhost:8080/storage"))
+
+	ctx := context.Background()
+	userID := uuid.New()
+	ids := []uuid.UUID{uuid.New(), uuid.New()}
+	for _, id := range ids {
+		uploaded, err := repo.UploadFile(ctx, bytes.NewReader([]byte("orphan content")), id, "zip", "application/zip", sha256Hex([]byte("orphan content")))
+		require.NoError(t, err)
+		a := entity.NewAsset("", userID, "zip", uploaded.URL)
+		a.ID = id
+		a.Hash = uploaded.Hash
+		_, err = repo.Create(ctx, a)
+		require.NoError(t, err)
+	}
+
+	deletedIDs, err := repo.DeleteOrphans(ctx, ids[:1])
+	require.NoError(t, err)
+	require.Len(t, deletedIDs, 1)
+	var blob dto.Blob
+	require.NoError(t, db.NewSelect().Model(&blob).Scan(ctx))
+	require.Equal(t, 1, blob.RefCount)
+
+	// 参照がなくなった記録は消し、ファイルはファイルの確認で片付ける
+	_, err = repo.DeleteOrphans(ctx, ids[1:])
+	require.NoError(t, err)
+	count, err := db.NewSelect().Model((*dto.Blob)(nil)).Count(ctx)
+	require.NoError(t, err)
+	require.Zero(t, count)
+	referenced, err := repo.FindBlobKeys(ctx, []string{blob.StorageKey})
+	require.NoError(t, err)
+	require.Empty(t, referenced)
+}
+
+func TestAssetRepository_UploadVariantAndCreateVariants(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	s3Client := testutil.SetupTestS3(t)
+	repo := asset.NewAssetRepository(db, blobstore.NewS3Store(s3Client, config.S3_BUCKET, config.S3_BASE_URL))
+
+	ctx := context.Background()
+	assetID := uuid.New()
+
+	variantURL, err := repo.UploadVariant(ctx, assetID, "320w.jpg", []byte("variant"), "image/jpeg")
+	require.NoError(t, err)
+
+	expectedKey := config.S3_DIR + "/image/" + assetID.String() + "/320w.jpg"
+	require.Equal(t, config.S3_BASE_URL+"/"+config.S3_BUCKET+"/"+expectedKey, *variantURL)
+
+	resp, err := s3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(config.S3_BUCKET),
+		Key:    aws.String(expectedKey),
+	})
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, "image/jpeg", aws.ToString(resp.ContentType))
+
+	variants := []*entity.AssetVariant{
+		entity.NewAssetVariant(assetID, 640, 320, "image/jpeg", "https://example.com/640w.jpg"),
+		entity.NewAssetVariant(assetID, 320, 160, "image/jpeg", *variantURL),
+	}
+	require.NoError(t, repo.CreateVariants(ctx, variants))
+	// 同じ幅の派生画像は重複して登録しない
+	duplicated := entity.NewAssetVariant(assetID, 640, 320, "image/jpeg", "https://example.com/640w-2.jpg")
+	require.NoError(t, repo.CreateVariants(ctx, []*entity.AssetVariant{duplicated}))
+
+	var stored []*dto.AssetVariant
+	err = db.NewSelect().Model(&stored).Where("asset_id = ?", assetID).Order("width ASC").Scan(ctx)
+	require.NoError(t, err)
+	require.Len(t, stored, 2)
+	require.Equal(t, 320, stored[0].Width)
+	require.Equal(t, 640, stored[1].Width)
+}
+
+func TestAssetRepository_PresignUploadFile(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	s3Client := testutil.SetupTestS3(t)
+	repo := asset.NewAssetRepository(db, blobstore.NewS3Store(s3Client, config.S3_BUCKET, config.S3_BASE_URL))
+
+	ctx := context.Background()
+	assetID := uuid.New()
+	content := []byte("\x00\x00\x00\x18ftypisom")
+
+	uploadURL, err := repo.PresignUploadFile(ctx, assetID, "mp4", "video/mp4", int64(len(content)), 5*time.Minute)
+	require.NoError(t, err)
+
+	_, err = repo.HeadFile(ctx, assetID, "mp4")
+	require.ErrorIs(t, err, domainerrors.ErrUploadedFileNotFound)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, uploadURL, bytes.NewReader(content))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "video/mp4")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	object, err := repo.HeadFile(ctx, assetID, "mp4")
+	require.NoError(t, err)
+	require.Equal(t, int64(len(content)), object.Size)
+	require.Equal(t, "video/mp4", object.ContentType)
+
+	body, err := repo.OpenFile(ctx, assetID, "mp4")
+	require.NoError(t, err)
+	stored, err := io.ReadAll(body)
+	body.Close()
+	require.NoError(t, err)
+	require.Equal(t, content, stored)
+
+	assetURL, assetType := repo.FileURL(assetID, "mp4")
+	expectedKey := config.S3_DIR + "/video/" + assetID.String() + "/origin.mp4"
+	require.Equal(t, config.S3_BASE_URL+"/"+config.S3_BUCKET+"/"+expectedKey, assetURL)
+	require.Equal(t, "video", assetType)
+
+	require.NoError(t, repo.DeleteFile(ctx, assetID, "mp4"))
+	_, err = repo.HeadFile(ctx, assetID, "mp4")
+	require.ErrorIs(t, err, domainerrors.ErrUploadedFileNotFound)
+}
+
+func TestAssetRepository_MultipartUpload(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	s3Client := testutil.SetupTestS3(t)
+	repo := asset.NewAssetRepository(db, blobstore.NewS3Store(s3Client, config.S3_BUCKET, config.S3_BASE_URL))
+
+	ctx := context.Background()
+	assetID := uuid.New()
+	// S3 は最後以外のパートに 5MiB 以上を求める
+	first := bytes.Repeat([]byte{'a'}, int(entity.MultipartMinPartSize))
+	second := []byte("last part")
+
+	multipartUploadID, err := repo.CreateMultipartUpload(ctx, assetID, "zip", "application/zip")
+	require.NoError(t, err)
+	require.NotEmpty(t, multipartUploadID)
+
+	putPart := func(partNumber int32, content []byte) {
+		uploadURL, err := repo.PresignUploadPart(ctx, assetID, "zip", multipartUploadID, partNumber, int64(len(content)), 5*time.Minute)
+		require.NoError(t, err)
+		req, err := http.NewRequestWithContext(ctx, http.MethodPut, uploadURL, bytes.NewReader(content))
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+	putPart(2, second)
+	putPart(1, first)
+
+	parts, err := repo.ListUploadedParts(ctx, assetID, "zip", multipartUploadID)
+	require.NoError(t, err)
+	require.Len(t, parts, 2)
+	require.Equal(t, int32(1), parts[0].PartNumber)
+	require.Equal(t, int64(len(first)), parts[0].Size)
+	require.Equal(t, int32(2), parts[1].PartNumber)
+	require.Equal(t, int64(len(second)), parts[1].Size)
+
+	require.NoError(t, repo.CompleteMultipartUpload(ctx, assetID, "zip", multipartUploadID, parts))
+
+	object, err := repo.HeadFile(ctx, assetID, "zip")
+	require.NoError(t, err)
+	require.Equal(t, int64(len(first)+len(second)), object.Size)
+	require.Equal(t, "application/zip", object.ContentType)
+
+	// 完了したアップロードのパートは一覧できず、中止しても何も起きない
+	_, err = repo.ListUploadedParts(ctx, assetID, "zip", multipartUploadID)
+	require.ErrorIs(t, err, domainerrors.ErrUploadedFileNotFound)
+	require.NoError(t, repo.AbortMultipartUpload(ctx, assetID, "zip", multipartUploadID))
+}
+
+func TestAssetRepository_AbortMultipartUpload(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	s3Client := testutil.SetupTestS3(t)
+	repo := asset.NewAssetRepository(db, blobstore.NewS3Store(s3Client, config.S3_BUCKET, config.S3_BASE_URL))
+
+	ctx := context.Background()
+	assetID := uuid.New()
+
+	multipartUploadID, err := repo.CreateMultipartUpload(ctx, assetID, "mp4", "video/mp4")
+	require.NoError(t, err)
+
+	require.NoError(t, repo.AbortMultipartUpload(ctx, assetID, "mp4", multipartUploadID))
+
+	_, err = repo.ListUploadedParts(ctx, assetID, "mp4", multipartUploadID)
+	require.ErrorIs(t, err, domainerrors.ErrUploadedFileNotFound)
+	_, err = repo.HeadFile(ctx, assetID, "mp4")
+	require.ErrorIs(t, err, domainerrors.ErrUploadedFileNotFound)
+}
+
+func TestAssetRepository_ListAndDeleteOrphans(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	s3Client := testutil.SetupTestS3(t)
+	repo := asset.NewAssetRepository(db, blobstore.NewS3Store(s3Client, config.S3_BUCKET, config.S3_BASE_URL))
+
+	ctx := context.Background()
+	old := time.Now().Add(-48 * time.Hour).UTC().Truncate(time.Second)
+
+	newAsset := func(workID uuid.UUID, createdAt time.Time) *entity.Asset {
+		a := entity.NewAsset("", uuid.New(), "png", "https://example.com/origin.png")
+		a.WorkID = workID
+		a.CreatedAt = createdAt
+		a.UpdatedAt = createdAt
+		_, err := repo.Create(ctx, a)
+		require.NoError(t, err)
+		return a
+	}
+	orphan := newAsset(uuid.Nil, old)
+	attached := newAsset(uuid.New(), old)
+	recent := newAsset(uuid.Nil, time.Now())
+	thumbnail := newAsset(uuid.Nil, old)
+	_, err := db.NewInsert().Model(&dto.Thumbnail{WorkID: uuid.New(), AssetID: thumbnail.ID}).Exec(ctx)
+	require.NoError(t, err)
+	require.NoError(t, repo.CreateVariants(ctx, []*entity.AssetVariant{
+		entity.NewAssetVariant(orphan.ID, 320, 160, "image/jpeg", "https://example.com/320w.jpg"),
+	}))
+
+	orphans, err := repo.ListOrphans(ctx, time.Now().Add(-24*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, orphans, 1)
+	require.Equal(t, orphan.ID, orphans[0].ID)
+
+	// 作品に使われているアセットは指定されても削除しない
+	deletedIDs, err := repo.DeleteOrphans(ctx, []uuid.UUID{orphan.ID, attached.ID})
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{orphan.ID}, deletedIDs)
+
+	existing, err := repo.FindExistingIDs(ctx, []uuid.UUID{orphan.ID, attached.ID, recent.ID})
+	require.NoError(t, err)
+	require.Equal(t, map[uuid.UUID]bool{attached.ID: true, recent.ID: true}, existing)
+
+	count, err := db.NewSelect().Model((*dto.AssetVariant)(nil)).Where("asset_id = ?", orphan.ID).Count(ctx)
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
+func TestAssetRepository_WalkAndDeleteFiles(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	s3Client := testutil.SetupTestS3(t)
+	repo := asset.NewAssetRepository(db, blobstore.NewS3Store(s3Client, config.S3_BUCKET, config.S3_BASE_URL))
+
+	ctx := context.Background()
+	assetID := uuid.New()
+
+	_, err := repo.UploadFile(ctx, bytes.NewReader([]byte("origin")), assetID, "png", "image/png", sha256Hex([]byte("origin")))
+	require.NoError(t, err)
+	_, err = repo.UploadVariant(ctx, assetID, "320w.jpg", []byte("variant"), "image/jpeg")
+	require.NoError(t, err)
+	avatarKey := config.S3_DIR + "/avatar/hash.webp"
+	_, err = s3Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(config.S3_BUCKET),
+		Key:    aws.String(avatarKey),
+		Body:   bytes.NewReader([]byte("avatar")),
+	})
+	require.NoError(t, err)
+
+	collect := func() map[string]*entity.StoredFile {
+		files := make(map[string]*entity.StoredFile)
+		require.NoError(t, repo.WalkFiles(ctx, func(page []*entity.StoredFile) error {
+			for _, file := range page {
+				files[file.Key] = file
+			}
+			return nil
+		}))
+		return files
+	}
+
+	originKey := config.S3_DIR + "/image/" + assetID.String() + "/origin.png"
+	variantKey := config.S3_DIR + "/image/" + assetID.String() + "/320w.jpg"
+	files := collect()
+	require.Equal(t, assetID, files[originKey].AssetID)
+	require.Equal(t, int64(len("origin")), files[originKey].Size)
+	require.Equal(t, assetID, files[variantKey].AssetID)
+	// アバターはアセットのファイルとして扱わない
+	require.Equal(t, uuid.Nil, files[avatarKey].AssetID)
+
+	require.NoError(t, repo.DeleteFiles(ctx, []string{originKey, variantKey}))
+
+	files = collect()
+	require.NotContains(t, files, originKey)
+	require.NotContains(t, files, variantKey)
+	require.Contains(t, files, avatarKey)
+}
+
+func TestAssetRepository_UploadAndDeletePlayFiles(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	s3Client := testutil.SetupTestS3(t)
+	repo := asset.NewAssetRepository(db, blobstore.NewS3Store(s3Client, config.S3_BUCKET, config.S3_BASE_URL))
+
+	ctx := context.Background()
+	assetID := uuid.New()
+
+	require.NoError(t, repo.UploadPlayFile(ctx, assetID, "index.html", bytes.NewReader([]byte("<html></html>"))))
+	require.NoError(t, repo.UploadPlayFile(ctx, assetID, "Build/game.wasm.br", bytes.NewReader([]byte("wasm"))))
+
+	indexKey := config.S3_DIR + "/play/" + assetID.String() + "/index.html"
+	wasmKey := config.S3_DIR + "/play/" + assetID.String() + "/Build/game.wasm.br"
+	require.Equal(t, config.S3_BASE_URL+"/"+config.S3_BUCKET+"/"+indexKey, repo.PlayURL(assetID, "index.html"))
+
+	// Unity の圧縮済みファイルはブラウザが展開できるよう Content-Encoding を付ける
+	resp, err := s3Client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(config.S3_BUCKET),
+		Key:    aws.String(wasmKey),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "application/wasm", aws.ToString(resp.ContentType))
+	require.Equal(t, "br", aws.ToString(resp.ContentEncoding))
+
+	// 展開したファイルもアセットのファイルとして扱う
+	files := make(map[string]*entity.StoredFile)
+	require.NoError(t, repo.WalkFiles(ctx, func(page []*entity.StoredFile) error {
+		for _, file := range page {
+			files[file.Key] = file
+		}
+		return nil
+	}))
+	require.Equal(t, assetID, files[indexKey].AssetID)
+	require.Equal(t, assetID, files[wasmKey].AssetID)
+
+	require.NoError(t, repo.DeletePlayFiles(ctx, assetID))
+	_, err = s3Client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(config.S3_BUCKET),
+		Key:    aws.String(indexKey),
+	})
+	require.Error(t, err)
+}
+
+func TestAssetRepository_GetStorageUsage(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := asset.NewAssetRepository(db, blobstore.NewLocalStore(t.TempDir(), "http://localhost:8080/storage"))
+
+	ctx := context.Background()
+	userID := uuid.New()
+	assets := []*entity.Asset{
+		entity.NewAsset("", userID, "png", "https://example.com/a.png"),
+		entity.NewAsset("", userID, "jpg", "https://example.com/b.jpg"),
+		entity.NewAsset("", userID, "zip", "https://example.com/c.zip"),
+		entity.NewAsset("", uuid.New(), "zip", "https://example.com/d.zip"),
+	}
+	for i, a := range assets {
+		a.Size = int64(100 * (i + 1))
+		_, err := repo.Create(ctx, a)
+		require.NoError(t, err)
+	}
+
+	usages, err := repo.GetStorageUsage(ctx, userID)
+	require.NoError(t, err)
+	require.Equal(t, []*entity.AssetTypeUsage{
+		{AssetType: "image", Count: 2, Bytes: 300},
+		{AssetType: "zip", Count: 1, Bytes: 300},
+	}, usages)
+
+	usages, err = repo.GetStorageUsage(ctx, uuid.New())
+	require.NoError(t, err)
+	require.Empty(t, usages)
+}
+
+func TestAssetRepository_ListAndUpdatePlaceholder(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := asset.NewAssetRepository(db, blobstore.NewLocalStore(t.TempDir(), "http://localhost:8080/storage"))
+
+	ctx := context.Background()
+	migrated := entity.NewAsset("", uuid.New(), "png", "https://example.com/migrated.png")
+	_, err := repo.Create(ctx, migrated)
+	require.NoError(t, err)
+	uploaded := entity.NewAsset("", uuid.New(), "jpg", "https://example.com/uploaded.jpg")
+	uploaded.Placeholder = &entity.ImagePlaceholder{Width: 640, Height: 480, BlurHash: "LEHV6nWB2yk8pyo0adR*.7kCMdnj", DominantColor: "#c86432"}
+	_, err = repo.Create(ctx, uploaded)
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, entity.NewAsset("", uuid.New(), "zip", "https://example.com/game.zip"))
+	require.NoError(t, err)
+
+	// プレースホルダーのない画像だけを返す
+	assets, err := repo.ListImagesWithoutPlaceholder(ctx, uuid.Nil, 10)
+	require.NoError(t, err)
+	require.Len(t, assets, 1)
+	require.Equal(t, migrated.ID, assets[0].ID)
+	require.Nil(t, assets[0].Placeholder)
+	assets, err = repo.ListImagesWithoutPlaceholder(ctx, migrated.ID, 10)
+	require.NoError(t, err)
+	require.Empty(t, assets)
+
+	placeholder := &entity.ImagePlaceholder{Width: 10, Height: 20, BlurHash: "L5M|T9-9fQ-9}Xj@fQj@fQfQfQfQ", DominantColor: "#ffffff"}
+	require.NoError(t, repo.UpdatePlaceholder(ctx, migrated.ID, placeholder))
+	assets, err = repo.ListImagesWithoutPlaceholder(ctx, uuid.Nil, 10)
+	require.NoError(t, err)
+	require.Empty(t, assets)
+
+	var stored dto.Asset
+	require.NoError(t, db.NewSelect().Model(&stored).Where("id = ?", migrated.ID).Scan(ctx))
+	require.Equal(t, placeholder, stored.ToImagePlaceholderEntity())
+	stored = dto.Asset{}
+	require.NoError(t, db.NewSelect().Model(&stored).Where("id = ?", uploaded.ID).Scan(ctx))
+	require.Equal(t, uploaded.Placeholder, stored.ToImagePlaceholderEntity())
+}
+
+func TestAssetRepository_OpenURL(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := asset.NewAssetRepository(db, blobstore.NewLocalStore(t.TempDir(), "http://localhost:8080/storage"))
+
+	ctx := context.Background()
+
+	t.Run("ストレージのファイルはストレージから読む", func(t *testing.T) {
+		uploaded, err := repo.UploadFile(ctx, bytes.NewReader([]byte("stored image")), uuid.New(), "png", "image/png", sha256Hex([]byte("stored image")))
+		require.NoError(t, err)
+
+		body, err := repo.OpenURL(ctx, uploaded.URL)
+		require.NoError(t, err)
+		defer body.Close()
+		data, err := io.ReadAll(body)
+		require.NoError(t, err)
+		require.Equal(t, "stored image", string(data))
+
+		_, err = repo.OpenURL(ctx, "http://localhost:8080/storage/"+config.S3_DIR+"/image/"+uuid.New().String()+"/origin.png")
+		require.ErrorIs(t, err, domainerrors.ErrUploadedFileNotFound)
+	})
+
+	t.Run("ストレージの外のファイルは URL から取得する", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/legacy.png" {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = w.Write([]byte("legacy image"))
+		}))
+		defer server.Close()
+
+		body, err := repo.OpenURL(ctx, server.URL+"/legacy.png")
+		require.NoError(t, err)
+		defer body.Close()
+		data, err := io.ReadAll(body)
+		require.NoError(t, err)
+		require.Equal(t, "legacy image", string(data))
+
+		_, err = repo.OpenURL(ctx, server.URL+"/missing.png")
+		require.ErrorIs(t, err, domainerrors.ErrUploadedFileNotFound)
+	})
 }
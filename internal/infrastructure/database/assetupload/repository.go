@@ -0,0 +1,147 @@
+package assetupload
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/dto"
+	"github.com/uptrace/bun"
+)
+
+type AssetUploadRepository struct {
+	db *bun.DB
+}
+
+func NewAssetUploadRepository(db *bun.DB) *AssetUploadRepository {
+	return &AssetUploadRepository{
+		db: db,
+	}
+}
+
+func (r *AssetUploadRepository) Create(ctx context.Context, upload *entity.AssetUpload) error {
+	_, err := r.db.NewInsert().Model(dto.ToAssetUploadDTO(upload)).Exec(ctx)
+	if err != nil {
+		return domainerrors.ErrFailedToCreateAssetUpload
+	}
+	return nil
+}
+
+func (r *AssetUploadRepository) CreateWithinQuota(ctx context.Context, upload *entity.AssetUpload, quota int64) (err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domainerrors.ErrFailedToBeginTransaction
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// 同じユーザーの使用量の確認と記録が重ならないよう、トランザクションが終わるまでユーザーごとのロックを取る
+	_, err = tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtextextended(?, 0))", upload.UserID.String())
+	if err != nil {
+		return domainerrors.ErrFailedToGetStorageUsage
+	}
+	var used int64
+	err = tx.NewSelect().
+		ColumnExpr("(SELECT COALESCE(SUM(size), 0) FROM asset WHERE user_id = ?) + (SELECT COALESCE(SUM(size), 0) FROM asset_upload WHERE user_id = ? AND expires_at >= ?)", upload.UserID, upload.UserID, time.Now()).
+		Scan(ctx, &used)
+	if err != nil {
+		return domainerrors.ErrFailedToGetStorageUsage
+	}
+	if used+upload.Size > quota {
+		return domainerrors.ErrStorageQuotaExceeded
+	}
+
+	_, err = tx.NewInsert().Model(dto.ToAssetUploadDTO(upload)).Exec(ctx)
+	if err != nil {
+		return domainerrors.ErrFailedToCreateAssetUpload
+	}
+	if err = tx.Commit(); err != nil {
+		return domainerrors.ErrFailedToCommitTransaction
+	}
+	return nil
+}
+
+func (r *AssetUploadRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.AssetUpload, error) {
+	var dtoUpload dto.AssetUpload
+	err := r.db.NewSelect().
+		Model(&dtoUpload).
+		Where("id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domainerrors.ErrAssetUploadNotFound
+		}
+		return nil, domainerrors.ErrFailedToGetAssetUpload
+	}
+	return dtoUpload.ToAssetUploadEntity(), nil
+}
+
+func (r *AssetUploadRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*entity.AssetUpload, error) {
+	var dtoUploads []*dto.AssetUpload
+	err := r.db.NewSelect().
+		Model(&dtoUploads).
+		Where("expires_at < ?", now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, domainerrors.ErrFailedToListAssetUploads
+	}
+	uploads := make([]*entity.AssetUpload, len(dtoUploads))
+	for i, dtoUpload := range dtoUploads {
+		uploads[i] = dtoUpload.ToAssetUploadEntity()
+	}
+	return uploads, nil
+}
+
+func (r *AssetUploadRepository) FindExistingIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]bool, error) {
+	existing := make(map[uuid.UUID]bool, len(ids))
+	if len(ids) == 0 {
+		return existing, nil
+	}
+	var existingIDs []uuid.UUID
+	err := r.db.NewSelect().
+		Model((*dto.AssetUpload)(nil)).
+		Column("id").
+		Where("id IN (?)", bun.In(ids)).
+		Scan(ctx, &existingIDs)
+	if err != nil {
+		return nil, domainerrors.ErrFailedToGetAssetUpload
+	}
+	for _, id := range existingIDs {
+		existing[id] = true
+	}
+	return existing, nil
+}
+
+func (r *AssetUploadRepository) SumPendingSize(ctx context.Context, userID uuid.UUID, now time.Time) (int64, error) {
+	var total int64
+	err := r.db.NewSelect().
+		Model((*dto.AssetUpload)(nil)).
+		ColumnExpr("COALESCE(SUM(size), 0)").
+		Where("user_id = ?", userID).
+		Where("expires_at >= ?", now).
+		Scan(ctx, &total)
+	if err != nil {
+		return 0, domainerrors.ErrFailedToGetStorageUsage
+	}
+	return total, nil
+}
+
+func (r *AssetUploadRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.NewDelete().
+		Model((*dto.AssetUpload)(nil)).
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return domainerrors.ErrFailedToDeleteAssetUpload
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+//go:build integration
+
+package assetupload_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/assetupload"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/testutil"
+)
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	testutil.Teardown()
+	os.Exit(code)
+}
+
+func TestAssetUploadRepository_CreateGetAndDelete(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := assetupload.NewAssetUploadRepository(db)
+
+	ctx := context.Background()
+	upload := entity.NewAssetUpload(uuid.New(), "mp4", "video/mp4", 1024, 15*time.Minute)
+
+	require.NoError(t, repo.Create(ctx, upload))
+
+	got, err := repo.GetByID(ctx, upload.ID)
+	require.NoError(t, err)
+	require.Equal(t, upload.UserID, got.UserID)
+	require.Equal(t, "mp4", got.Extension)
+	require.Equal(t, "video/mp4", got.ContentType)
+	require.Equal(t, int64(1024), got.Size)
+	require.WithinDuration(t, upload.ExpiresAt, got.ExpiresAt, time.Second)
+
+	require.NoError(t, repo.Delete(ctx, upload.ID))
+
+	_, err = repo.GetByID(ctx, upload.ID)
+	require.ErrorIs(t, err, domainerrors.ErrAssetUploadNotFound)
+}
+
+func TestAssetUploadRepository_ListExpired(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := assetupload.NewAssetUploadRepository(db)
+
+	ctx := context.Background()
+	now := time.Now()
+
+	older := entity.NewMultipartAssetUpload(uuid.New(), "zip", "application/zip", 40<<20, time.Hour)
+	older.MultipartUploadID = "multipart-upload-id"
+	older.ExpiresAt = now.Add(-2 * time.Hour)
+	newer := entity.NewAssetUpload(uuid.New(), "png", "image/png", 10, time.Hour)
+	newer.ExpiresAt = now.Add(-time.Hour)
+	active := entity.NewAssetUpload(uuid.New(), "png", "image/png", 10, time.Hour)
+
+	for _, upload := range []*entity.AssetUpload{newer, active, older} {
+		require.NoError(t, repo.Create(ctx, upload))
+	}
+
+	expired, err := repo.ListExpired(ctx, now, 10)
+	require.NoError(t, err)
+	require.Len(t, expired, 2)
+	require.Equal(t, older.ID, expired[0].ID)
+	require.Equal(t, "multipart-upload-id", expired[0].MultipartUploadID)
+	require.Equal(t, int64(16<<20), expired[0].PartSize)
+	require.Equal(t, newer.ID, expired[1].ID)
+
+	limited, err := repo.ListExpired(ctx, now, 1)
+	require.NoError(t, err)
+	require.Len(t, limited, 1)
+}
+
+func TestAssetUploadRepository_FindExistingIDs(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := assetupload.NewAssetUploadRepository(db)
+
+	ctx := context.Background()
+	upload := entity.NewAssetUpload(uuid.New(), "png", "image/png", 10, time.Hour)
+	require.NoError(t, repo.Create(ctx, upload))
+
+	existing, err := repo.FindExistingIDs(ctx, []uuid.UUID{upload.ID, uuid.New()})
+	require.NoError(t, err)
+	require.Equal(t, map[uuid.UUID]bool{upload.ID: true}, existing)
+
+	existing, err = repo.FindExistingIDs(ctx, nil)
+	require.NoError(t, err)
+	require.Empty(t, existing)
+}
+
+func TestAssetUploadRepository_SumPendingSize(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := assetupload.NewAssetUploadRepository(db)
+
+	ctx := context.Background()
+	now := time.Now()
+	userID := uuid.New()
+
+	active := entity.NewAssetUpload(userID, "mp4", "video/mp4", 100, time.Hour)
+	multipart := entity.NewMultipartAssetUpload(userID, "zip", "application/zip", 40<<20, time.Hour)
+	expired := entity.NewAssetUpload(userID, "mp4", "video/mp4", 1000, time.Hour)
+	expired.ExpiresAt = now.Add(-time.Minute)
+	otherUser := entity.NewAssetUpload(uuid.New(), "mp4", "video/mp4", 10000, time.Hour)
+	for _, upload := range []*entity.AssetUpload{active, multipart, expired, otherUser} {
+		require.NoError(t, repo.Create(ctx, upload))
+	}
+
+	total, err := repo.SumPendingSize(ctx, userID, now)
+	require.NoError(t, err)
+	require.Equal(t, int64(100+40<<20), total)
+
+	total, err = repo.SumPendingSize(ctx, uuid.New(), now)
+	require.NoError(t, err)
+	require.Zero(t, total)
+}
+
+func TestAssetUploadRepository_CreateWithinQuota(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := assetupload.NewAssetUploadRepository(db)
+
+	ctx := context.Background()
+	userID := uuid.New()
+
+	first := entity.NewAssetUpload(userID, "mp4", "video/mp4", 600, time.Hour)
+	require.NoError(t, repo.CreateWithinQuota(ctx, first, 1000))
+
+	over := entity.NewAssetUpload(userID, "mp4", "video/mp4", 401, time.Hour)
+	require.ErrorIs(t, repo.CreateWithinQuota(ctx, over, 1000), domainerrors.ErrStorageQuotaExceeded)
+	_, err := repo.GetByID(ctx, over.ID)
+	require.ErrorIs(t, err, domainerrors.ErrAssetUploadNotFound)
+
+	fits := entity.NewAssetUpload(userID, "mp4", "video/mp4", 400, time.Hour)
+	require.NoError(t, repo.CreateWithinQuota(ctx, fits, 1000))
+
+	// 他のユーザーの使用量は数えない
+	other := entity.NewAssetUpload(uuid.New(), "mp4", "video/mp4", 1000, time.Hour)
+	require.NoError(t, repo.CreateWithinQuota(ctx, other, 1000))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
@@ -90,3 +91,81 @@ func (r *CommentRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity
 
 	return dtoComment.ToCommentEntity(), nil
 }
+
+func (r *CommentRepository) Update(ctx context.Context, comment *entity.Comment) (*entity.Comment, error) {
+	dtoComment := dto.ToCommentDTO(comment)
+
+	_, err := r.db.NewUpdate().
+		Model(dtoComment).
+		Column("content", "edited_at", "updated_at").
+		WherePK().
+		Exec(ctx)
+	if err != nil {
+		return nil, domainerrors.ErrFailedToUpdateComment
+	}
+
+	return dtoComment.ToCommentEntity(), nil
+}
+
+// Delete はコメントと、そのコメントに付いたリアクションを削除します。
+// reaction は対象の種類を問わず target_id を持つため外部キーで消えず、同じトランザクションで消します。
+func (r *CommentRepository) Delete(ctx context.Context, id uuid.UUID) (err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domainerrors.ErrFailedToBeginTransaction
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	_, err = tx.NewDelete().
+		Model((*dto.Reaction)(nil)).
+		Where("target_type = ?", string(entity.ReactionTargetComment)).
+		Where("target_id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return domainerrors.ErrFailedToDeleteComment
+	}
+	_, err = tx.NewDelete().
+		Model((*dto.Comment)(nil)).
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return domainerrors.ErrFailedToDeleteComment
+	}
+
+	if err = tx.Commit(); err != nil {
+		return domainerrors.ErrFailedToCommitTransaction
+	}
+	return nil
+}
+
+func (r *CommentRepository) Tombstone(ctx context.Context, id uuid.UUID) error {
+	now := time.Now()
+	_, err := r.db.NewUpdate().
+		Model((*dto.Comment)(nil)).
+		Set("content = ''").
+		Set("anonymous_name = NULL").
+		Set("fingerprint = NULL").
+		Set("deleted_at = ?", now).
+		Set("updated_at = ?", now).
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return domainerrors.ErrFailedToDeleteComment
+	}
+	return nil
+}
+
+func (r *CommentRepository) HasReplies(ctx context.Context, id uuid.UUID) (bool, error) {
+	exists, err := r.db.NewSelect().
+		Model((*dto.Comment)(nil)).
+		Where("reply_at = ?", id.String()).
+		Exists(ctx)
+	if err != nil {
+		return false, domainerrors.ErrFailedToGetCommentById
+	}
+	return exists, nil
+}
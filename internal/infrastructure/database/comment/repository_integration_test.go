@@ -10,6 +10,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
 	"github.com/simesaba80/toybox-back/internal/infrastructure/database/comment"
 	"github.com/simesaba80/toybox-back/internal/infrastructure/database/dto"
 	"github.com/simesaba80/toybox-back/internal/infrastructure/database/testutil"
@@ -43,17 +44,16 @@ func TestCommentRepository_Create(t *testing.T) {
 	_, err := commentRepo.Create(ctx, comment)
 	require.NoError(t, err)
 
-	anonymousComment := &entity.Comment{
-		ID:        uuid.New(),
-		Content:   "anonymous-content",
-		WorkID:    workUUID,
-		UserID:    uuid.Nil,
-		ReplyAt:   "",
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}
+	anonymousComment := entity.NewAnonymousComment("anonymous-content", workUUID, "guest", "0123456789abcdef", "")
 	_, err = commentRepo.Create(ctx, anonymousComment)
 	require.NoError(t, err)
+
+	found, err := commentRepo.FindByID(ctx, anonymousComment.ID)
+	require.NoError(t, err)
+	require.True(t, found.Anonymous)
+	require.Equal(t, "guest", found.AnonymousName)
+	require.Equal(t, "0123456789abcdef", found.Fingerprint)
+	require.Nil(t, found.User)
 }
 
 func TestCommentRepository_FindByWorkID(t *testing.T) {
@@ -196,3 +196,62 @@ func insertTestAsset(t *testing.T, db *bun.DB, userID uuid.UUID) *entity.Asset {
 
 	return asset
 }
+
+func TestCommentRepository_UpdateAndDelete(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	commentRepo := comment.NewCommentRepository(db)
+
+	ctx := context.Background()
+	work := insertTestWork(t, db)
+	user := insertTestUser(t, db)
+
+	parent, err := commentRepo.Create(ctx, entity.NewComment("parent", work.ID, user.ID, ""))
+	require.NoError(t, err)
+	reply, err := commentRepo.Create(ctx, entity.NewComment("reply", work.ID, user.ID, parent.ID.String()))
+	require.NoError(t, err)
+
+	parent.Edit("edited")
+	_, err = commentRepo.Update(ctx, parent)
+	require.NoError(t, err)
+
+	found, err := commentRepo.FindByID(ctx, parent.ID)
+	require.NoError(t, err)
+	require.Equal(t, "edited", found.Content)
+	require.NotNil(t, found.EditedAt)
+
+	hasReplies, err := commentRepo.HasReplies(ctx, parent.ID)
+	require.NoError(t, err)
+	require.True(t, hasReplies)
+	hasReplies, err = commentRepo.HasReplies(ctx, reply.ID)
+	require.NoError(t, err)
+	require.False(t, hasReplies)
+
+	require.NoError(t, commentRepo.Tombstone(ctx, parent.ID))
+	found, err = commentRepo.FindByID(ctx, parent.ID)
+	require.NoError(t, err)
+	require.True(t, found.IsDeleted())
+	require.Empty(t, found.Content)
+
+	reactions := []*dto.Reaction{
+		dto.ToReactionDTO(entity.NewReaction(entity.ReactionTargetComment, reply.ID, user.ID, "👍")),
+		dto.ToReactionDTO(entity.NewReaction(entity.ReactionTargetComment, parent.ID, user.ID, "👍")),
+	}
+	_, err = db.NewInsert().Model(&reactions).Exec(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, commentRepo.Delete(ctx, reply.ID))
+	_, err = commentRepo.FindByID(ctx, reply.ID)
+	require.ErrorIs(t, err, domainerrors.ErrCommentNotFound)
+
+	// 削除したコメントのリアクションだけを消す
+	var remaining []dto.Reaction
+	require.NoError(t, db.NewSelect().Model(&remaining).Scan(ctx))
+	require.Len(t, remaining, 1)
+	require.Equal(t, parent.ID, remaining[0].TargetID)
+
+	// 削除済みの跡は一覧にも残る
+	comments, err := commentRepo.FindByWorkID(ctx, work.ID)
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	require.True(t, comments[0].IsDeleted())
+}
@@ -10,40 +10,100 @@ import (
 )
 
 type Asset struct {
-	bun.BaseModel `bun:"table:asset"`
-	ID            uuid.UUID       `bun:"id,pk"`
-	WorkID        uuid.UUID       `bun:"work_id"`
-	AssetType     types.AssetType `bun:"asset_type,notnull"`
-	UserID        uuid.UUID       `bun:"user_id,notnull"`
-	Extension     string          `bun:"extension,notnull"`
-	URL           string          `bun:"url,notnull"`
-	CreatedAt     time.Time       `bun:"created_at,notnull"`
-	UpdatedAt     time.Time       `bun:"updated_at,notnull"`
+	bun.BaseModel    `bun:"table:asset"`
+	ID               uuid.UUID       `bun:"id,pk"`
+	WorkID           uuid.UUID       `bun:"work_id"`
+	AssetType        types.AssetType `bun:"asset_type,notnull"`
+	UserID           uuid.UUID       `bun:"user_id,notnull"`
+	Extension        string          `bun:"extension,notnull"`
+	URL              string          `bun:"url,notnull"`
+	Size             int64           `bun:"size,notnull"`
+	Hash             string          `bun:"hash,notnull"`
+	PlayURL          string          `bun:"play_url,notnull"`
+	AudioMetadata    *AudioMetadata  `bun:"audio_metadata,type:jsonb"`
+	ModelStats       *ModelStats     `bun:"model_stats,type:jsonb"`
+	Width            int             `bun:"width,nullzero"`
+	Height           int             `bun:"height,nullzero"`
+	BlurHash         string          `bun:"blurhash,nullzero"`
+	DominantColor    string          `bun:"dominant_color,nullzero"`
+	Variants         []*AssetVariant `bun:"rel:has-many,join:id=asset_id"`
+	MetadataStripped bool            `bun:"metadata_stripped,notnull"`
+	CreatedAt        time.Time       `bun:"created_at,notnull"`
+	UpdatedAt        time.Time       `bun:"updated_at,notnull"`
+}
+
+// AssetTypeUsage はアセットの数と合計サイズを種類ごとに集計した結果です
+type AssetTypeUsage struct {
+	AssetType types.AssetType `bun:"asset_type"`
+	Count     int             `bun:"count"`
+	Bytes     int64           `bun:"bytes"`
 }
 
 func (a *Asset) ToAssetEntity() *entity.Asset {
 	return &entity.Asset{
-		ID:        a.ID,
-		WorkID:    a.WorkID,
-		UserID:    a.UserID,
-		AssetType: string(a.AssetType),
-		Extension: a.Extension,
-		URL:       a.URL,
-		CreatedAt: a.CreatedAt,
-		UpdatedAt: a.UpdatedAt,
+		ID:               a.ID,
+		WorkID:           a.WorkID,
+		UserID:           a.UserID,
+		AssetType:        string(a.AssetType),
+		Extension:        a.Extension,
+		URL:              a.URL,
+		Size:             a.Size,
+		Hash:             a.Hash,
+		PlayURL:          a.PlayURL,
+		AudioMetadata:    a.AudioMetadata.ToAudioMetadataEntity(),
+		ModelStats:       a.ModelStats.ToModelStatsEntity(),
+		Placeholder:      a.ToImagePlaceholderEntity(),
+		Variants:         ToAssetVariantEntities(a.Variants),
+		MetadataStripped: a.MetadataStripped,
+		CreatedAt:        a.CreatedAt,
+		UpdatedAt:        a.UpdatedAt,
 	}
 }
 
 func ToAssetDTO(entity *entity.Asset) *Asset {
 
-	return &Asset{
-		ID:        entity.ID,
-		WorkID:    entity.WorkID,
-		UserID:    entity.UserID,
-		AssetType: types.AssetType(entity.AssetType),
-		Extension: entity.Extension,
-		URL:       entity.URL,
-		CreatedAt: entity.CreatedAt,
-		UpdatedAt: entity.UpdatedAt,
+	asset := &Asset{
+		ID:               entity.ID,
+		WorkID:           entity.WorkID,
+		UserID:           entity.UserID,
+		AssetType:        types.AssetType(entity.AssetType),
+		Extension:        entity.Extension,
+		URL:              entity.URL,
+		Size:             entity.Size,
+		Hash:             entity.Hash,
+		PlayURL:          entity.PlayURL,
+		AudioMetadata:    ToAudioMetadataDTO(entity.AudioMetadata),
+		ModelStats:       ToModelStatsDTO(entity.ModelStats),
+		MetadataStripped: entity.MetadataStripped,
+		CreatedAt:        entity.CreatedAt,
+		UpdatedAt:        entity.UpdatedAt,
+	}
+	if entity.Placeholder != nil {
+		asset.Width = entity.Placeholder.Width
+		asset.Height = entity.Placeholder.Height
+		asset.BlurHash = entity.Placeholder.BlurHash
+		asset.DominantColor = entity.Placeholder.DominantColor
+	}
+	return asset
+}
+
+// ToImagePlaceholderEntity は画像アセットのプレースホルダーを返します。まだ作成していない場合は nil です
+func (a *Asset) ToImagePlaceholderEntity() *entity.ImagePlaceholder {
+	if a.BlurHash == "" {
+		return nil
+	}
+	return &entity.ImagePlaceholder{
+		Width:         a.Width,
+		Height:        a.Height,
+		BlurHash:      a.BlurHash,
+		DominantColor: a.DominantColor,
+	}
+}
+
+func (u *AssetTypeUsage) ToAssetTypeUsageEntity() *entity.AssetTypeUsage {
+	return &entity.AssetTypeUsage{
+		AssetType: string(u.AssetType),
+		Count:     u.Count,
+		Bytes:     u.Bytes,
 	}
 }
@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	"github.com/uptrace/bun"
+)
+
+type AssetUpload struct {
+	bun.BaseModel     `bun:"table:asset_upload"`
+	ID                uuid.UUID `bun:"id,pk"`
+	UserID            uuid.UUID `bun:"user_id,notnull"`
+	Extension         string    `bun:"extension,notnull"`
+	ContentType       string    `bun:"content_type,notnull"`
+	Size              int64     `bun:"size,notnull"`
+	MultipartUploadID string    `bun:"multipart_upload_id,notnull"`
+	PartSize          int64     `bun:"part_size,notnull"`
+	ExpiresAt         time.Time `bun:"expires_at,notnull"`
+	CreatedAt         time.Time `bun:"created_at,notnull"`
+}
+
+func (u *AssetUpload) ToAssetUploadEntity() *entity.AssetUpload {
+	return &entity.AssetUpload{
+		ID:                u.ID,
+		UserID:            u.UserID,
+		Extension:         u.Extension,
+		ContentType:       u.ContentType,
+		Size:              u.Size,
+		MultipartUploadID: u.MultipartUploadID,
+		PartSize:          u.PartSize,
+		ExpiresAt:         u.ExpiresAt,
+		CreatedAt:         u.CreatedAt,
+	}
+}
+
+func ToAssetUploadDTO(entity *entity.AssetUpload) *AssetUpload {
+	return &AssetUpload{
+		ID:                entity.ID,
+		UserID:            entity.UserID,
+		Extension:         entity.Extension,
+		ContentType:       entity.ContentType,
+		Size:              entity.Size,
+		MultipartUploadID: entity.MultipartUploadID,
+		PartSize:          entity.PartSize,
+		ExpiresAt:         entity.ExpiresAt,
+		CreatedAt:         entity.CreatedAt,
+	}
+}
@@ -0,0 +1,60 @@
+package dto
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	"github.com/uptrace/bun"
+)
+
+type AssetVariant struct {
+	bun.BaseModel `bun:"table:asset_variant"`
+	ID            uuid.UUID `bun:"id,pk"`
+	AssetID       uuid.UUID `bun:"asset_id,notnull"`
+	Width         int       `bun:"width,notnull"`
+	Height        int       `bun:"height,notnull"`
+	ContentType   string    `bun:"content_type,notnull"`
+	URL           string    `bun:"url,notnull"`
+	CreatedAt     time.Time `bun:"created_at,notnull"`
+}
+
+func (v *AssetVariant) ToAssetVariantEntity() *entity.AssetVariant {
+	return &entity.AssetVariant{
+		ID:          v.ID,
+		AssetID:     v.AssetID,
+		Width:       v.Width,
+		Height:      v.Height,
+		ContentType: v.ContentType,
+		URL:         v.URL,
+		CreatedAt:   v.CreatedAt,
+	}
+}
+
+func ToAssetVariantDTO(entity *entity.AssetVariant) *AssetVariant {
+	return &AssetVariant{
+		ID:          entity.ID,
+		AssetID:     entity.AssetID,
+		Width:       entity.Width,
+		Height:      entity.Height,
+		ContentType: entity.ContentType,
+		URL:         entity.URL,
+		CreatedAt:   entity.CreatedAt,
+	}
+}
+
+// ToAssetVariantEntities は派生画像を幅の小さい順に並べて返す
+func ToAssetVariantEntities(variants []*AssetVariant) []*entity.AssetVariant {
+	if len(variants) == 0 {
+		return nil
+	}
+	entities := make([]*entity.AssetVariant, len(variants))
+	for i, variant := range variants {
+		entities[i] = variant.ToAssetVariantEntity()
+	}
+	sort.Slice(entities, func(i, j int) bool {
+		return entities[i].Width < entities[j].Width
+	})
+	return entities
+}
@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+)
+
+type AudioMetadata struct {
+	DurationMs int64     `json:"duration_ms"`
+	SampleRate int       `json:"sample_rate"`
+	Bitrate    int       `json:"bitrate"`
+	Channels   int       `json:"channels"`
+	Title      string    `json:"title"`
+	Artist     string    `json:"artist"`
+	Peaks      []float64 `json:"peaks"`
+}
+
+func (m *AudioMetadata) ToAudioMetadataEntity() *entity.AudioMetadata {
+	if m == nil {
+		return nil
+	}
+	return &entity.AudioMetadata{
+		Duration:   time.Duration(m.DurationMs) * time.Millisecond,
+		SampleRate: m.SampleRate,
+		Bitrate:    m.Bitrate,
+		Channels:   m.Channels,
+		Title:      m.Title,
+		Artist:     m.Artist,
+		Peaks:      m.Peaks,
+	}
+}
+
+func ToAudioMetadataDTO(metadata *entity.AudioMetadata) *AudioMetadata {
+	if metadata == nil {
+		return nil
+	}
+	peaks := metadata.Peaks
+	if peaks == nil {
+		peaks = []float64{}
+	}
+	return &AudioMetadata{
+		DurationMs: metadata.Duration.Milliseconds(),
+		SampleRate: metadata.SampleRate,
+		Bitrate:    metadata.Bitrate,
+		Channels:   metadata.Channels,
+		Title:      metadata.Title,
+		Artist:     metadata.Artist,
+		Peaks:      peaks,
+	}
+}
@@ -0,0 +1,17 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Blob は内容の SHA-256 ごとに保存したファイルと、それを参照しているアセットの数です
+type Blob struct {
+	bun.BaseModel `bun:"table:blob"`
+	Hash          string    `bun:"hash,pk"`
+	StorageKey    string    `bun:"storage_key,notnull"`
+	Size          int64     `bun:"size,notnull"`
+	RefCount      int       `bun:"ref_count,notnull"`
+	CreatedAt     time.Time `bun:"created_at,notnull"`
+}
@@ -11,14 +11,19 @@ import (
 
 type Comment struct {
 	bun.BaseModel `bun:"table:comment"`
-	ID            uuid.UUID        `json:"id" bun:"id,pk"`
-	Content       string           `json:"content" bun:"content,notnull"`
-	WorkID        uuid.UUID        `json:"work_id" bun:"work_id,notnull"`
-	UserID        uuid.UUID        `json:"user_id" bun:"user_id"`
-	ReplyAt       string           `json:"reply_at" bun:"reply_at"`
-	User          *User            `bun:"rel:belongs-to,join:user_id=id"`
-	CreatedAt     time.Time        `json:"created_at" bun:"created_at,notnull"`
-	UpdatedAt     time.Time        `json:"updated_at" bun:"updated_at,notnull"`
+	ID            uuid.UUID  `json:"id" bun:"id,pk"`
+	Content       string     `json:"content" bun:"content,notnull"`
+	WorkID        uuid.UUID  `json:"work_id" bun:"work_id,notnull"`
+	UserID        uuid.UUID  `json:"user_id" bun:"user_id"`
+	ReplyAt       string     `json:"reply_at" bun:"reply_at"`
+	User          *User      `bun:"rel:belongs-to,join:user_id=id"`
+	Anonymous     bool       `json:"anonymous" bun:"anonymous,notnull"`
+	AnonymousName string     `json:"anonymous_name" bun:"anonymous_name,nullzero"`
+	Fingerprint   string     `json:"fingerprint" bun:"fingerprint,nullzero"`
+	EditedAt      *time.Time `json:"edited_at" bun:"edited_at"`
+	DeletedAt     *time.Time `json:"deleted_at" bun:"deleted_at"`
+	CreatedAt     time.Time  `json:"created_at" bun:"created_at,notnull"`
+	UpdatedAt     time.Time  `json:"updated_at" bun:"updated_at,notnull"`
 }
 
 func (c *Comment) ToCommentEntity() *entity.Comment {
@@ -28,25 +33,35 @@ func (c *Comment) ToCommentEntity() *entity.Comment {
 	}
 
 	return &entity.Comment{
-		ID:         c.ID,
-		Content:    c.Content,
-		WorkID:     c.WorkID,
-		UserID:     c.UserID,
-		ReplyAt:    c.ReplyAt,
-		User:       user,
-		CreatedAt:  c.CreatedAt,
-		UpdatedAt:  c.UpdatedAt,
+		ID:            c.ID,
+		Content:       c.Content,
+		WorkID:        c.WorkID,
+		UserID:        c.UserID,
+		ReplyAt:       c.ReplyAt,
+		User:          user,
+		Anonymous:     c.Anonymous,
+		AnonymousName: c.AnonymousName,
+		Fingerprint:   c.Fingerprint,
+		EditedAt:      c.EditedAt,
+		DeletedAt:     c.DeletedAt,
+		CreatedAt:     c.CreatedAt,
+		UpdatedAt:     c.UpdatedAt,
 	}
 }
 
 func ToCommentDTO(e *entity.Comment) *Comment {
 	return &Comment{
-		ID:        e.ID,
-		Content:   e.Content,
-		WorkID:    e.WorkID,
-		UserID:    e.UserID,
-		ReplyAt:   e.ReplyAt,
-		CreatedAt: e.CreatedAt,
-		UpdatedAt: e.UpdatedAt,
+		ID:            e.ID,
+		Content:       e.Content,
+		WorkID:        e.WorkID,
+		UserID:        e.UserID,
+		ReplyAt:       e.ReplyAt,
+		Anonymous:     e.Anonymous,
+		AnonymousName: e.AnonymousName,
+		Fingerprint:   e.Fingerprint,
+		EditedAt:      e.EditedAt,
+		DeletedAt:     e.DeletedAt,
+		CreatedAt:     e.CreatedAt,
+		UpdatedAt:     e.UpdatedAt,
 	}
 }
@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	"github.com/uptrace/bun"
+)
+
+type Follow struct {
+	bun.BaseModel `bun:"table:follow"`
+	FollowerID    uuid.UUID `json:"follower_id" bun:"follower_id,pk"`
+	FolloweeID    uuid.UUID `json:"followee_id" bun:"followee_id,pk"`
+	CreatedAt     time.Time `json:"created_at" bun:"created_at,notnull"`
+}
+
+func (f *Follow) ToFollowEntity() *entity.Follow {
+	return &entity.Follow{
+		FollowerID: f.FollowerID,
+		FolloweeID: f.FolloweeID,
+		CreatedAt:  f.CreatedAt,
+	}
+}
+
+func ToFollowDTO(entity *entity.Follow) *Follow {
+	return &Follow{
+		FollowerID: entity.FollowerID,
+		FolloweeID: entity.FolloweeID,
+		CreatedAt:  entity.CreatedAt,
+	}
+}
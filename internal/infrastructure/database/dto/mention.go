@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+)
+
+type Mention struct {
+	bun.BaseModel `bun:"table:mention"`
+	ID            uuid.UUID  `json:"id" bun:"id,pk"`
+	UserID        uuid.UUID  `json:"user_id" bun:"user_id,notnull"`
+	ActorID       uuid.UUID  `json:"actor_id" bun:"actor_id,notnull"`
+	Actor         *User      `bun:"rel:belongs-to,join:actor_id=id"`
+	WorkID        uuid.UUID  `json:"work_id" bun:"work_id,notnull"`
+	CommentID     *uuid.UUID `json:"comment_id" bun:"comment_id"`
+	CreatedAt     time.Time  `json:"created_at" bun:"created_at,notnull"`
+}
+
+func (m *Mention) ToMentionEntity() *entity.Mention {
+	var actor *entity.User
+	if m.Actor != nil && m.Actor.ID != uuid.Nil {
+		actor = m.Actor.ToUserEntity()
+	}
+
+	return &entity.Mention{
+		ID:        m.ID,
+		UserID:    m.UserID,
+		ActorID:   m.ActorID,
+		Actor:     actor,
+		WorkID:    m.WorkID,
+		CommentID: m.CommentID,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+func ToMentionDTO(entity *entity.Mention) *Mention {
+	return &Mention{
+		ID:        entity.ID,
+		UserID:    entity.UserID,
+		ActorID:   entity.ActorID,
+		WorkID:    entity.WorkID,
+		CommentID: entity.CommentID,
+		CreatedAt: entity.CreatedAt,
+	}
+}
@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+)
+
+type ModelStats struct {
+	VertexCount   int64        `json:"vertex_count"`
+	TriangleCount int64        `json:"triangle_count"`
+	Materials     []string     `json:"materials"`
+	BoundingBox   *BoundingBox `json:"bounding_box"`
+}
+
+type BoundingBox struct {
+	Min [3]float64 `json:"min"`
+	Max [3]float64 `json:"max"`
+}
+
+func (s *ModelStats) ToModelStatsEntity() *entity.ModelStats {
+	if s == nil {
+		return nil
+	}
+	var box *entity.BoundingBox
+	if s.BoundingBox != nil {
+		box = &entity.BoundingBox{Min: s.BoundingBox.Min, Max: s.BoundingBox.Max}
+	}
+	return &entity.ModelStats{
+		VertexCount:   s.VertexCount,
+		TriangleCount: s.TriangleCount,
+		Materials:     s.Materials,
+		BoundingBox:   box,
+	}
+}
+
+func ToModelStatsDTO(stats *entity.ModelStats) *ModelStats {
+	if stats == nil {
+		return nil
+	}
+	materials := stats.Materials
+	if materials == nil {
+		materials = []string{}
+	}
+	var box *BoundingBox
+	if stats.BoundingBox != nil {
+		box = &BoundingBox{Min: stats.BoundingBox.Min, Max: stats.BoundingBox.Max}
+	}
+	return &ModelStats{
+		VertexCount:   stats.VertexCount,
+		TriangleCount: stats.TriangleCount,
+		Materials:     materials,
+		BoundingBox:   box,
+	}
+}
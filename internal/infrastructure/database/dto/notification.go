@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+)
+
+type Notification struct {
+	bun.BaseModel `bun:"table:notification"`
+	ID            uuid.UUID  `json:"id" bun:"id,pk"`
+	UserID        uuid.UUID  `json:"user_id" bun:"user_id,notnull"`
+	ActorID       uuid.UUID  `json:"actor_id" bun:"actor_id,notnull"`
+	Actor         *User      `bun:"rel:belongs-to,join:actor_id=id"`
+	Type          string     `json:"type" bun:"type,notnull"`
+	WorkID        uuid.UUID  `json:"work_id" bun:"work_id,notnull"`
+	CommentID     *uuid.UUID `json:"comment_id" bun:"comment_id"`
+	ReadAt        *time.Time `json:"read_at" bun:"read_at"`
+	CreatedAt     time.Time  `json:"created_at" bun:"created_at,notnull"`
+}
+
+func (n *Notification) ToNotificationEntity() *entity.Notification {
+	var actor *entity.User
+	if n.Actor != nil && n.Actor.ID != uuid.Nil {
+		actor = n.Actor.ToUserEntity()
+	}
+
+	return &entity.Notification{
+		ID:        n.ID,
+		UserID:    n.UserID,
+		ActorID:   n.ActorID,
+		Actor:     actor,
+		Type:      entity.NotificationType(n.Type),
+		WorkID:    n.WorkID,
+		CommentID: n.CommentID,
+		ReadAt:    n.ReadAt,
+		CreatedAt: n.CreatedAt,
+	}
+}
+
+func ToNotificationDTO(entity *entity.Notification) *Notification {
+	return &Notification{
+		ID:        entity.ID,
+		UserID:    entity.UserID,
+		ActorID:   entity.ActorID,
+		Type:      string(entity.Type),
+		WorkID:    entity.WorkID,
+		CommentID: entity.CommentID,
+		ReadAt:    entity.ReadAt,
+		CreatedAt: entity.CreatedAt,
+	}
+}
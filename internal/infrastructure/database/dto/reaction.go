@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	"github.com/uptrace/bun"
+)
+
+type Reaction struct {
+	bun.BaseModel `bun:"table:reaction"`
+	TargetType    string    `json:"target_type" bun:"target_type,pk"`
+	TargetID      uuid.UUID `json:"target_id" bun:"target_id,pk"`
+	UserID        uuid.UUID `json:"user_id" bun:"user_id,pk"`
+	Emoji         string    `json:"emoji" bun:"emoji,pk"`
+	CreatedAt     time.Time `json:"created_at" bun:"created_at,notnull"`
+}
+
+// ReactionCount はリアクションを対象と絵文字ごとに集計した結果です
+type ReactionCount struct {
+	TargetID uuid.UUID `bun:"target_id"`
+	Emoji    string    `bun:"emoji"`
+	Count    int       `bun:"count"`
+	Reacted  bool      `bun:"reacted"`
+}
+
+func (r *Reaction) ToReactionEntity() *entity.Reaction {
+	return &entity.Reaction{
+		TargetType: entity.ReactionTargetType(r.TargetType),
+		TargetID:   r.TargetID,
+		UserID:     r.UserID,
+		Emoji:      r.Emoji,
+		CreatedAt:  r.CreatedAt,
+	}
+}
+
+func ToReactionDTO(entity *entity.Reaction) *Reaction {
+	return &Reaction{
+		TargetType: string(entity.TargetType),
+		TargetID:   entity.TargetID,
+		UserID:     entity.UserID,
+		Emoji:      entity.Emoji,
+		CreatedAt:  entity.CreatedAt,
+	}
+}
+
+func (c *ReactionCount) ToReactionSummaryEntity() *entity.ReactionSummary {
+	return &entity.ReactionSummary{
+		Emoji:   c.Emoji,
+		Count:   c.Count,
+		Reacted: c.Reacted,
+	}
+}
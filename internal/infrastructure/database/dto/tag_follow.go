@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	"github.com/uptrace/bun"
+)
+
+type TagFollow struct {
+	bun.BaseModel `bun:"table:tag_follow"`
+	UserID        uuid.UUID `json:"user_id" bun:"user_id,pk"`
+	TagID         uuid.UUID `json:"tag_id" bun:"tag_id,pk"`
+	CreatedAt     time.Time `json:"created_at" bun:"created_at,notnull"`
+}
+
+func (f *TagFollow) ToTagFollowEntity() *entity.TagFollow {
+	return &entity.TagFollow{
+		UserID:    f.UserID,
+		TagID:     f.TagID,
+		CreatedAt: f.CreatedAt,
+	}
+}
+
+func ToTagFollowDTO(entity *entity.TagFollow) *TagFollow {
+	return &TagFollow{
+		UserID:    entity.UserID,
+		TagID:     entity.TagID,
+		CreatedAt: entity.CreatedAt,
+	}
+}
+
+type TagNewWork struct {
+	bun.BaseModel `bun:"table:tag_new_work"`
+	UserID        uuid.UUID  `json:"user_id" bun:"user_id,pk"`
+	WorkID        uuid.UUID  `json:"work_id" bun:"work_id,pk"`
+	SeenAt        *time.Time `json:"seen_at" bun:"seen_at"`
+	CreatedAt     time.Time  `json:"created_at" bun:"created_at,notnull"`
+}
+
+func (n *TagNewWork) ToTagNewWorkEntity() *entity.TagNewWork {
+	return &entity.TagNewWork{
+		UserID:    n.UserID,
+		WorkID:    n.WorkID,
+		SeenAt:    n.SeenAt,
+		CreatedAt: n.CreatedAt,
+	}
+}
@@ -54,28 +54,34 @@ func (w *Work) ToWorkEntity() *entity.Work {
 
 	var thumbnailAssetID uuid.UUID
 	var thumbnailURL string
+	var thumbnailVariants []*entity.AssetVariant
+	var thumbnailPlaceholder *entity.ImagePlaceholder
 	if w.Thumbnail != nil {
 		thumbnailAssetID = w.Thumbnail.AssetID
 		if w.Thumbnail.Asset != nil {
 			thumbnailURL = w.Thumbnail.Asset.URL
+			thumbnailVariants = ToAssetVariantEntities(w.Thumbnail.Asset.Variants)
+			thumbnailPlaceholder = w.Thumbnail.Asset.ToImagePlaceholderEntity()
 		}
 	}
 
 	return &entity.Work{
-		ID:               w.ID,
-		Title:            w.Title,
-		Description:      w.Description,
-		UserID:           w.UserID,
-		User:             userEntity,
-		Visibility:       string(w.Visibility),
-		ThumbnailAssetID: thumbnailAssetID,
-		ThumbnailURL:     thumbnailURL,
-		Assets:           assets,
-		URLs:             urls,
-		TagIDs:           tagIDs,
-		Tags:             entityTags,
-		CreatedAt:        w.CreatedAt,
-		UpdatedAt:        w.UpdatedAt,
+		ID:                   w.ID,
+		Title:                w.Title,
+		Description:          w.Description,
+		UserID:               w.UserID,
+		User:                 userEntity,
+		Visibility:           string(w.Visibility),
+		ThumbnailAssetID:     thumbnailAssetID,
+		ThumbnailURL:         thumbnailURL,
+		ThumbnailVariants:    thumbnailVariants,
+		ThumbnailPlaceholder: thumbnailPlaceholder,
+		Assets:               assets,
+		URLs:                 urls,
+		TagIDs:               tagIDs,
+		Tags:                 entityTags,
+		CreatedAt:            w.CreatedAt,
+		UpdatedAt:            w.UpdatedAt,
 	}
 }
 
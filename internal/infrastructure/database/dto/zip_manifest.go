@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	"github.com/uptrace/bun"
+)
+
+// AssetZipManifest は asset テーブルの ZIP の中身の一覧です。
+// 一覧が大きくなることがあるため、作品の取得などで使う Asset には含めずにこのモデルで読み込みます
+type AssetZipManifest struct {
+	bun.BaseModel `bun:"table:asset"`
+	ID            uuid.UUID    `bun:"id,pk"`
+	ZipManifest   *ZipManifest `bun:"zip_manifest,type:jsonb"`
+}
+
+type ZipManifest struct {
+	Entries   []*ZipEntry `json:"entries"`
+	TotalSize int64       `json:"total_size"`
+	Contents  []string    `json:"contents"`
+}
+
+type ZipEntry struct {
+	Path           string `json:"path"`
+	Size           int64  `json:"size"`
+	CompressedSize int64  `json:"compressed_size"`
+}
+
+func (m *ZipManifest) ToZipManifestEntity() *entity.ZipManifest {
+	entries := make([]*entity.ZipEntry, len(m.Entries))
+	for i, e := range m.Entries {
+		entries[i] = &entity.ZipEntry{
+			Path:           e.Path,
+			Size:           e.Size,
+			CompressedSize: e.CompressedSize,
+		}
+	}
+	return &entity.ZipManifest{
+		Entries:   entries,
+		TotalSize: m.TotalSize,
+		Contents:  m.Contents,
+	}
+}
+
+func ToZipManifestDTO(manifest *entity.ZipManifest) *ZipManifest {
+	entries := make([]*ZipEntry, len(manifest.Entries))
+	for i, e := range manifest.Entries {
+		entries[i] = &ZipEntry{
+			Path:           e.Path,
+			Size:           e.Size,
+			CompressedSize: e.CompressedSize,
+		}
+	}
+	contents := manifest.Contents
+	if contents == nil {
+		contents = []string{}
+	}
+	return &ZipManifest{
+		Entries:   entries,
+		TotalSize: manifest.TotalSize,
+		Contents:  contents,
+	}
+}
@@ -0,0 +1,87 @@
+package follow
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/dto"
+	"github.com/uptrace/bun"
+)
+
+type FollowRepository struct {
+	db *bun.DB
+}
+
+func NewFollowRepository(db *bun.DB) *FollowRepository {
+	return &FollowRepository{
+		db: db,
+	}
+}
+
+func (r *FollowRepository) Create(ctx context.Context, follow *entity.Follow) (*entity.Follow, error) {
+	dtoFollow := dto.ToFollowDTO(follow)
+
+	_, err := r.db.NewInsert().Model(dtoFollow).Exec(ctx)
+	if err != nil {
+		return nil, domainerrors.ErrFailedToCreateFollow
+	}
+	return dtoFollow.ToFollowEntity(), nil
+}
+
+func (r *FollowRepository) Delete(ctx context.Context, follow *entity.Follow) error {
+	dtoFollow := dto.ToFollowDTO(follow)
+	_, err := r.db.NewDelete().Model(dtoFollow).Where("follower_id = ? AND followee_id = ?", dtoFollow.FollowerID, dtoFollow.FolloweeID).Exec(ctx)
+	if err != nil {
+		return domainerrors.ErrFailedToDeleteFollow
+	}
+	return nil
+}
+
+func (r *FollowRepository) Exists(ctx context.Context, follow *entity.Follow) bool {
+	exists, err := r.db.NewSelect().Model(&dto.Follow{}).Where("follower_id = ? AND followee_id = ?", follow.FollowerID, follow.FolloweeID).Exists(ctx)
+	if err != nil {
+		return false
+	}
+	return exists
+}
+
+func (r *FollowRepository) GetFollowers(ctx context.Context, userID uuid.UUID) ([]*entity.User, error) {
+	users, err := r.getUsers(ctx, "follow.follower_id", "follow.followee_id", userID)
+	if err != nil {
+		return nil, domainerrors.ErrFailedToGetFollowers
+	}
+	return users, nil
+}
+
+func (r *FollowRepository) GetFollowing(ctx context.Context, userID uuid.UUID) ([]*entity.User, error) {
+	users, err := r.getUsers(ctx, "follow.followee_id", "follow.follower_id", userID)
+	if err != nil {
+		return nil, domainerrors.ErrFailedToGetFollowing
+	}
+	return users, nil
+}
+
+// getUsers はfollowテーブルのmatchColumnがuserIDである行について、joinColumn側のユーザーをフォローした新しい順に取得します。
+func (r *FollowRepository) getUsers(ctx context.Context, joinColumn string, matchColumn string, userID uuid.UUID) ([]*entity.User, error) {
+	dtoUsers := make([]*dto.User, 0)
+	err := r.db.NewSelect().
+		Model(&dtoUsers).
+		ColumnExpr(`"user".*`).
+		ColumnExpr(`(SELECT COUNT(*) FROM follow AS fr WHERE fr.followee_id = "user".id) AS follower_count`).
+		ColumnExpr(`(SELECT COUNT(*) FROM follow AS fg WHERE fg.follower_id = "user".id) AS following_count`).
+		Join(`JOIN follow ON ? = "user".id`, bun.Ident(joinColumn)).
+		Where("? = ?", bun.Ident(matchColumn), userID).
+		OrderExpr("follow.created_at DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entityUsers := make([]*entity.User, len(dtoUsers))
+	for i, dtoUser := range dtoUsers {
+		entityUsers[i] = dtoUser.ToUserEntity()
+	}
+	return entityUsers, nil
+}
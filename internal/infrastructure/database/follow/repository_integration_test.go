@@ -0,0 +1,145 @@
+//go:build integration
+
+package follow_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/dto"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/follow"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/testutil"
+)
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	testutil.Teardown()
+	os.Exit(code)
+}
+
+func TestFollowRepository_Create(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := follow.NewFollowRepository(db)
+
+	ctx := context.Background()
+
+	follower := insertTestUser(t, db)
+	followee := insertTestUser(t, db)
+	f := entity.NewFollow(follower.ID, followee.ID)
+
+	created, err := repo.Create(ctx, f)
+	require.NoError(t, err)
+	require.Equal(t, follower.ID, created.FollowerID)
+	require.Equal(t, followee.ID, created.FolloweeID)
+
+	require.True(t, repo.Exists(ctx, f))
+	require.False(t, repo.Exists(ctx, entity.NewFollow(followee.ID, follower.ID)))
+}
+
+func TestFollowRepository_Create_Duplicate(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := follow.NewFollowRepository(db)
+
+	ctx := context.Background()
+
+	follower := insertTestUser(t, db)
+	followee := insertTestUser(t, db)
+	f := entity.NewFollow(follower.ID, followee.ID)
+
+	_, err := repo.Create(ctx, f)
+	require.NoError(t, err)
+
+	_, err = repo.Create(ctx, f)
+	require.ErrorIs(t, err, domainerrors.ErrFailedToCreateFollow)
+}
+
+func TestFollowRepository_Delete(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := follow.NewFollowRepository(db)
+
+	ctx := context.Background()
+
+	follower := insertTestUser(t, db)
+	followee := insertTestUser(t, db)
+	f := entity.NewFollow(follower.ID, followee.ID)
+
+	_, err := repo.Create(ctx, f)
+	require.NoError(t, err)
+
+	err = repo.Delete(ctx, f)
+	require.NoError(t, err)
+	require.False(t, repo.Exists(ctx, f))
+}
+
+func TestFollowRepository_GetFollowersAndFollowing(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := follow.NewFollowRepository(db)
+
+	ctx := context.Background()
+
+	target := insertTestUser(t, db)
+	older := insertTestUser(t, db)
+	newer := insertTestUser(t, db)
+	followee := insertTestUser(t, db)
+
+	now := time.Now()
+	createFollow(t, repo, older.ID, target.ID, now.Add(-time.Hour))
+	createFollow(t, repo, newer.ID, target.ID, now)
+	createFollow(t, repo, target.ID, followee.ID, now)
+	createFollow(t, repo, newer.ID, followee.ID, now)
+
+	followers, err := repo.GetFollowers(ctx, target.ID)
+	require.NoError(t, err)
+	require.Len(t, followers, 2)
+	require.Equal(t, newer.ID, followers[0].ID)
+	require.Equal(t, older.ID, followers[1].ID)
+	require.Equal(t, 0, followers[0].FollowerCount)
+	require.Equal(t, 2, followers[0].FollowingCount)
+
+	following, err := repo.GetFollowing(ctx, target.ID)
+	require.NoError(t, err)
+	require.Len(t, following, 1)
+	require.Equal(t, followee.ID, following[0].ID)
+	require.Equal(t, 2, following[0].FollowerCount)
+	require.Equal(t, 0, following[0].FollowingCount)
+}
+
+func createFollow(t *testing.T, repo *follow.FollowRepository, followerID, followeeID uuid.UUID, createdAt time.Time) {
+	t.Helper()
+
+	f := entity.NewFollow(followerID, followeeID)
+	f.CreatedAt = createdAt
+	_, err := repo.Create(context.Background(), f)
+	require.NoError(t, err)
+}
+
+func insertTestUser(t *testing.T, db *bun.DB) *entity.User {
+	t.Helper()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	shortID := uuid.New().String()[:8]
+	user := &entity.User{
+		ID:            uuid.New(),
+		Name:          fmt.Sprintf("user-%s", shortID),
+		Email:         fmt.Sprintf("test-%s@example.com", uuid.New().String()),
+		DisplayName:   fmt.Sprintf("tester-%s", shortID),
+		DiscordUserID: fmt.Sprintf("discord-%s", shortID),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	dtoUser := dto.ToUserDTO(user)
+	_, err := db.NewInsert().Model(dtoUser).Exec(context.Background())
+	require.NoError(t, err)
+
+	return user
+}
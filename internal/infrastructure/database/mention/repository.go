@@ -0,0 +1,77 @@
+package mention
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/dto"
+)
+
+type MentionRepository struct {
+	db *bun.DB
+}
+
+func NewMentionRepository(db *bun.DB) *MentionRepository {
+	return &MentionRepository{
+		db: db,
+	}
+}
+
+func (r *MentionRepository) CreateMany(ctx context.Context, mentions []*entity.Mention) error {
+	if len(mentions) == 0 {
+		return nil
+	}
+
+	dtoMentions := make([]*dto.Mention, len(mentions))
+	for i, mention := range mentions {
+		dtoMentions[i] = dto.ToMentionDTO(mention)
+	}
+
+	// コメントの編集で同じメンションを再度記録しようとした場合は無視する
+	_, err := r.db.NewInsert().
+		Model(&dtoMentions).
+		On("CONFLICT DO NOTHING").
+		Exec(ctx)
+	if err != nil {
+		return domainerrors.ErrFailedToCreateMentions
+	}
+	return nil
+}
+
+func (r *MentionRepository) GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entity.Mention, int, error) {
+	var dtoMentions []*dto.Mention
+	total, err := r.db.NewSelect().
+		Model(&dtoMentions).
+		Relation("Actor").
+		Where("mention.user_id = ?", userID).
+		OrderExpr("mention.created_at DESC, mention.id DESC").
+		Limit(limit).
+		Offset(offset).
+		ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, domainerrors.ErrFailedToGetMentions
+	}
+
+	mentions := make([]*entity.Mention, len(dtoMentions))
+	for i, dtoMention := range dtoMentions {
+		mentions[i] = dtoMention.ToMentionEntity()
+	}
+	return mentions, total, nil
+}
+
+func (r *MentionRepository) DeleteByCommentID(ctx context.Context, commentID uuid.UUID, keepUserIDs []uuid.UUID) error {
+	query := r.db.NewDelete().
+		Model((*dto.Mention)(nil)).
+		Where("comment_id = ?", commentID)
+	if len(keepUserIDs) > 0 {
+		query = query.Where("user_id NOT IN (?)", bun.In(keepUserIDs))
+	}
+	if _, err := query.Exec(ctx); err != nil {
+		return domainerrors.ErrFailedToDeleteMentions
+	}
+	return nil
+}
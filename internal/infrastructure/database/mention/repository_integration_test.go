@@ -0,0 +1,146 @@
+//go:build integration
+
+package mention_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/dto"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/mention"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/testutil"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/types"
+)
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	testutil.Teardown()
+	os.Exit(code)
+}
+
+func TestMentionRepository_CreateManyAndGet(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := mention.NewMentionRepository(db)
+
+	ctx := context.Background()
+
+	author := insertTestUser(t, db)
+	mentioned := insertTestUser(t, db)
+	other := insertTestUser(t, db)
+	work := insertTestWork(t, db, author.ID)
+	commentID := uuid.New()
+
+	require.NoError(t, repo.CreateMany(ctx, []*entity.Mention{
+		entity.NewMention(mentioned.ID, author.ID, work.ID, nil),
+		entity.NewMention(other.ID, author.ID, work.ID, nil),
+	}))
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, repo.CreateMany(ctx, []*entity.Mention{
+		entity.NewMention(mentioned.ID, other.ID, work.ID, &commentID),
+	}))
+	// 同じコメントからのメンションを再度記録しても重複しない
+	require.NoError(t, repo.CreateMany(ctx, []*entity.Mention{
+		entity.NewMention(mentioned.ID, other.ID, work.ID, &commentID),
+	}))
+	require.NoError(t, repo.CreateMany(ctx, nil))
+
+	mentions, total, err := repo.GetByUserID(ctx, mentioned.ID, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, total)
+	require.Len(t, mentions, 2)
+	require.NotNil(t, mentions[0].CommentID)
+	require.Equal(t, commentID, *mentions[0].CommentID)
+	require.NotNil(t, mentions[0].Actor)
+	require.Equal(t, other.ID, mentions[0].Actor.ID)
+	require.Nil(t, mentions[1].CommentID)
+	require.Equal(t, author.ID, mentions[1].ActorID)
+
+	// メンションはメンションされたユーザーにだけ返る
+	_, total, err = repo.GetByUserID(ctx, author.ID, 10, 0)
+	require.NoError(t, err)
+	require.Zero(t, total)
+}
+
+func TestMentionRepository_DeleteByCommentID(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := mention.NewMentionRepository(db)
+
+	ctx := context.Background()
+
+	author := insertTestUser(t, db)
+	kept := insertTestUser(t, db)
+	removed := insertTestUser(t, db)
+	work := insertTestWork(t, db, author.ID)
+	commentID := uuid.New()
+	otherCommentID := uuid.New()
+
+	require.NoError(t, repo.CreateMany(ctx, []*entity.Mention{
+		entity.NewMention(kept.ID, author.ID, work.ID, &commentID),
+		entity.NewMention(removed.ID, author.ID, work.ID, &commentID),
+		entity.NewMention(removed.ID, author.ID, work.ID, &otherCommentID),
+		entity.NewMention(removed.ID, author.ID, work.ID, nil),
+	}))
+
+	// 残すユーザー以外へのメンションだけを消し、別のコメントや作品の説明文からのメンションは残す
+	require.NoError(t, repo.DeleteByCommentID(ctx, commentID, []uuid.UUID{kept.ID}))
+	_, total, err := repo.GetByUserID(ctx, kept.ID, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	_, total, err = repo.GetByUserID(ctx, removed.ID, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, total)
+
+	require.NoError(t, repo.DeleteByCommentID(ctx, commentID, nil))
+	_, total, err = repo.GetByUserID(ctx, kept.ID, 10, 0)
+	require.NoError(t, err)
+	require.Zero(t, total)
+}
+
+func insertTestUser(t *testing.T, db *bun.DB) *entity.User {
+	t.Helper()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	shortID := uuid.New().String()[:8]
+	user := &entity.User{
+		ID:            uuid.New(),
+		Name:          fmt.Sprintf("user-%s", shortID),
+		Email:         fmt.Sprintf("test-%s@example.com", uuid.New().String()),
+		DisplayName:   fmt.Sprintf("tester-%s", shortID),
+		DiscordUserID: fmt.Sprintf("discord-%s", shortID),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	dtoUser := dto.ToUserDTO(user)
+	_, err := db.NewInsert().Model(dtoUser).Exec(context.Background())
+	require.NoError(t, err)
+
+	return user
+}
+
+func insertTestWork(t *testing.T, db *bun.DB, userID uuid.UUID) *dto.Work {
+	t.Helper()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	work := &dto.Work{
+		ID:          uuid.New(),
+		Title:       fmt.Sprintf("test-work-%s", uuid.New().String()[:8]),
+		Description: "description",
+		UserID:      userID,
+		Visibility:  types.VisibilityPublic,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	_, err := db.NewInsert().Model(work).Exec(context.Background())
+	require.NoError(t, err)
+
+	return work
+}
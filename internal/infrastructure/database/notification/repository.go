@@ -0,0 +1,88 @@
+package notification
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/dto"
+)
+
+type NotificationRepository struct {
+	db *bun.DB
+}
+
+func NewNotificationRepository(db *bun.DB) *NotificationRepository {
+	return &NotificationRepository{
+		db: db,
+	}
+}
+
+func (r *NotificationRepository) Create(ctx context.Context, notification *entity.Notification) (*entity.Notification, error) {
+	dtoNotification := dto.ToNotificationDTO(notification)
+
+	_, err := r.db.NewInsert().Model(dtoNotification).Exec(ctx)
+	if err != nil {
+		return nil, domainerrors.ErrFailedToCreateNotification
+	}
+	return dtoNotification.ToNotificationEntity(), nil
+}
+
+func (r *NotificationRepository) GetByUserID(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, offset int) ([]*entity.Notification, int, error) {
+	var dtoNotifications []*dto.Notification
+	query := r.db.NewSelect().
+		Model(&dtoNotifications).
+		Relation("Actor").
+		Where("notification.user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("notification.read_at IS NULL")
+	}
+
+	total, err := query.
+		OrderExpr("notification.created_at DESC, notification.id DESC").
+		Limit(limit).
+		Offset(offset).
+		ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, domainerrors.ErrFailedToGetNotifications
+	}
+
+	notifications := make([]*entity.Notification, len(dtoNotifications))
+	for i, dtoNotification := range dtoNotifications {
+		notifications[i] = dtoNotification.ToNotificationEntity()
+	}
+	return notifications, total, nil
+}
+
+func (r *NotificationRepository) CountUnread(ctx context.Context, userID uuid.UUID) (int, error) {
+	count, err := r.db.NewSelect().
+		Model((*dto.Notification)(nil)).
+		Where("user_id = ?", userID).
+		Where("read_at IS NULL").
+		Count(ctx)
+	if err != nil {
+		return 0, domainerrors.ErrFailedToCountUnreadNotifications
+	}
+	return count, nil
+}
+
+// MarkAsRead は指定した通知を既読にします。idsが空の場合は全ての通知を既読にします。
+func (r *NotificationRepository) MarkAsRead(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) error {
+	query := r.db.NewUpdate().
+		Model((*dto.Notification)(nil)).
+		Set("read_at = ?", time.Now()).
+		Where("user_id = ?", userID).
+		Where("read_at IS NULL")
+	if len(ids) > 0 {
+		query = query.Where("id IN (?)", bun.In(ids))
+	}
+	_, err := query.Exec(ctx)
+	if err != nil {
+		return domainerrors.ErrFailedToMarkNotificationsAsRead
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+//go:build integration
+
+package notification_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/dto"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/notification"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/testutil"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/types"
+)
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	testutil.Teardown()
+	os.Exit(code)
+}
+
+func TestNotificationRepository_CreateAndGet(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := notification.NewNotificationRepository(db)
+
+	ctx := context.Background()
+
+	owner := insertTestUser(t, db)
+	actor := insertTestUser(t, db)
+	work := insertTestWork(t, db, owner.ID)
+
+	first, err := repo.Create(ctx, entity.NewNotification(owner.ID, actor.ID, entity.NotificationTypeFavorite, work.ID, nil))
+	require.NoError(t, err)
+	time.Sleep(10 * time.Millisecond)
+	second, err := repo.Create(ctx, entity.NewNotification(owner.ID, actor.ID, entity.NotificationTypeFavorite, work.ID, nil))
+	require.NoError(t, err)
+
+	notifications, total, err := repo.GetByUserID(ctx, owner.ID, false, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, total)
+	require.Len(t, notifications, 2)
+	require.Equal(t, second.ID, notifications[0].ID)
+	require.Equal(t, first.ID, notifications[1].ID)
+	require.NotNil(t, notifications[0].Actor)
+	require.Equal(t, actor.ID, notifications[0].Actor.ID)
+
+	// 通知は受け取ったユーザーにだけ返る
+	_, total, err = repo.GetByUserID(ctx, actor.ID, false, 10, 0)
+	require.NoError(t, err)
+	require.Zero(t, total)
+}
+
+func TestNotificationRepository_MarkAsRead(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := notification.NewNotificationRepository(db)
+
+	ctx := context.Background()
+
+	owner := insertTestUser(t, db)
+	actor := insertTestUser(t, db)
+	work := insertTestWork(t, db, owner.ID)
+
+	first, err := repo.Create(ctx, entity.NewNotification(owner.ID, actor.ID, entity.NotificationTypeFavorite, work.ID, nil))
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, entity.NewNotification(owner.ID, actor.ID, entity.NotificationTypeFavorite, work.ID, nil))
+	require.NoError(t, err)
+
+	count, err := repo.CountUnread(ctx, owner.ID)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	require.NoError(t, repo.MarkAsRead(ctx, owner.ID, []uuid.UUID{first.ID}))
+
+	count, err = repo.CountUnread(ctx, owner.ID)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	unread, total, err := repo.GetByUserID(ctx, owner.ID, true, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	require.NotEqual(t, first.ID, unread[0].ID)
+
+	// 他のユーザーの通知は既読にできない
+	require.NoError(t, repo.MarkAsRead(ctx, actor.ID, nil))
+	count, err = repo.CountUnread(ctx, owner.ID)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	require.NoError(t, repo.MarkAsRead(ctx, owner.ID, nil))
+	count, err = repo.CountUnread(ctx, owner.ID)
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
+func insertTestUser(t *testing.T, db *bun.DB) *entity.User {
+	t.Helper()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	shortID := uuid.New().String()[:8]
+	user := &entity.User{
+		ID:            uuid.New(),
+		Name:          fmt.Sprintf("user-%s", shortID),
+		Email:         fmt.Sprintf("test-%s@example.com", uuid.New().String()),
+		DisplayName:   fmt.Sprintf("tester-%s", shortID),
+		DiscordUserID: fmt.Sprintf("discord-%s", shortID),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	dtoUser := dto.ToUserDTO(user)
+	_, err := db.NewInsert().Model(dtoUser).Exec(context.Background())
+	require.NoError(t, err)
+
+	return user
+}
+
+func insertTestWork(t *testing.T, db *bun.DB, userID uuid.UUID) *dto.Work {
+	t.Helper()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	work := &dto.Work{
+		ID:          uuid.New(),
+		Title:       fmt.Sprintf("test-work-%s", uuid.New().String()[:8]),
+		Description: "description",
+		UserID:      userID,
+		Visibility:  types.VisibilityPublic,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	_, err := db.NewInsert().Model(work).Exec(context.Background())
+	require.NoError(t, err)
+
+	return work
+}
@@ -0,0 +1,71 @@
+package reaction
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/dto"
+	"github.com/uptrace/bun"
+)
+
+type ReactionRepository struct {
+	db *bun.DB
+}
+
+func NewReactionRepository(db *bun.DB) *ReactionRepository {
+	return &ReactionRepository{
+		db: db,
+	}
+}
+
+func (r *ReactionRepository) Create(ctx context.Context, reaction *entity.Reaction) error {
+	_, err := r.db.NewInsert().
+		Model(dto.ToReactionDTO(reaction)).
+		On("CONFLICT DO NOTHING").
+		Exec(ctx)
+	if err != nil {
+		return domainerrors.ErrFailedToCreateReaction
+	}
+	return nil
+}
+
+func (r *ReactionRepository) Delete(ctx context.Context, reaction *entity.Reaction) error {
+	_, err := r.db.NewDelete().
+		Model((*dto.Reaction)(nil)).
+		Where("target_type = ? AND target_id = ? AND user_id = ? AND emoji = ?", string(reaction.TargetType), reaction.TargetID, reaction.UserID, reaction.Emoji).
+		Exec(ctx)
+	if err != nil {
+		return domainerrors.ErrFailedToDeleteReaction
+	}
+	return nil
+}
+
+func (r *ReactionRepository) Summarize(ctx context.Context, targetType entity.ReactionTargetType, targetIDs []uuid.UUID, viewerID uuid.UUID) (map[uuid.UUID][]*entity.ReactionSummary, error) {
+	summaries := make(map[uuid.UUID][]*entity.ReactionSummary, len(targetIDs))
+	if len(targetIDs) == 0 {
+		return summaries, nil
+	}
+
+	var counts []dto.ReactionCount
+	err := r.db.NewSelect().
+		Model((*dto.Reaction)(nil)).
+		Column("target_id", "emoji").
+		ColumnExpr("COUNT(*) AS count").
+		ColumnExpr("BOOL_OR(user_id = ?) AS reacted", viewerID).
+		Where("target_type = ?", string(targetType)).
+		Where("target_id IN (?)", bun.In(targetIDs)).
+		Group("target_id", "emoji").
+		// 同じ数のリアクションは先に付けられた絵文字を前にする
+		OrderExpr("count DESC, MIN(created_at) ASC").
+		Scan(ctx, &counts)
+	if err != nil {
+		return nil, domainerrors.ErrFailedToGetReactions
+	}
+
+	for _, count := range counts {
+		summaries[count.TargetID] = append(summaries[count.TargetID], count.ToReactionSummaryEntity())
+	}
+	return summaries, nil
+}
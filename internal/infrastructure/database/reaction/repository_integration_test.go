@@ -0,0 +1,59 @@
+//go:build integration
+
+package reaction_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/reaction"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/testutil"
+)
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	testutil.Teardown()
+	os.Exit(code)
+}
+
+func TestReactionRepository_CreateDeleteAndSummarize(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := reaction.NewReactionRepository(db)
+
+	ctx := context.Background()
+
+	viewer := uuid.New()
+	other := uuid.New()
+	workID := uuid.New()
+	otherWorkID := uuid.New()
+
+	require.NoError(t, repo.Create(ctx, entity.NewReaction(entity.ReactionTargetWork, workID, viewer, "🔥")))
+	require.NoError(t, repo.Create(ctx, entity.NewReaction(entity.ReactionTargetWork, workID, other, "🔥")))
+	require.NoError(t, repo.Create(ctx, entity.NewReaction(entity.ReactionTargetWork, workID, other, "👍")))
+	// 同じリアクションを再度付けても重複しない
+	require.NoError(t, repo.Create(ctx, entity.NewReaction(entity.ReactionTargetWork, workID, viewer, "🔥")))
+	// 同じIDでも種類が違う対象のリアクションは集計しない
+	require.NoError(t, repo.Create(ctx, entity.NewReaction(entity.ReactionTargetComment, workID, viewer, "👍")))
+
+	summaries, err := repo.Summarize(ctx, entity.ReactionTargetWork, []uuid.UUID{workID, otherWorkID}, viewer)
+	require.NoError(t, err)
+	require.Len(t, summaries[workID], 2)
+	require.Equal(t, &entity.ReactionSummary{Emoji: "🔥", Count: 2, Reacted: true}, summaries[workID][0])
+	require.Equal(t, &entity.ReactionSummary{Emoji: "👍", Count: 1, Reacted: false}, summaries[workID][1])
+	require.Empty(t, summaries[otherWorkID])
+
+	require.NoError(t, repo.Delete(ctx, entity.NewReaction(entity.ReactionTargetWork, workID, viewer, "🔥")))
+
+	summaries, err = repo.Summarize(ctx, entity.ReactionTargetWork, []uuid.UUID{workID}, uuid.Nil)
+	require.NoError(t, err)
+	require.Len(t, summaries[workID], 2)
+	for _, summary := range summaries[workID] {
+		require.Equal(t, 1, summary.Count)
+		require.False(t, summary.Reacted)
+	}
+}
@@ -0,0 +1,142 @@
+package tagfollow
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/dto"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/types"
+	"github.com/uptrace/bun"
+)
+
+type TagFollowRepository struct {
+	db *bun.DB
+}
+
+func NewTagFollowRepository(db *bun.DB) *TagFollowRepository {
+	return &TagFollowRepository{
+		db: db,
+	}
+}
+
+func (r *TagFollowRepository) Create(ctx context.Context, tagFollow *entity.TagFollow) (*entity.TagFollow, error) {
+	dtoTagFollow := dto.ToTagFollowDTO(tagFollow)
+
+	_, err := r.db.NewInsert().Model(dtoTagFollow).Exec(ctx)
+	if err != nil {
+		return nil, domainerrors.ErrFailedToCreateTagFollow
+	}
+	return dtoTagFollow.ToTagFollowEntity(), nil
+}
+
+func (r *TagFollowRepository) Delete(ctx context.Context, tagFollow *entity.TagFollow) error {
+	dtoTagFollow := dto.ToTagFollowDTO(tagFollow)
+	_, err := r.db.NewDelete().Model(dtoTagFollow).Where("user_id = ? AND tag_id = ?", dtoTagFollow.UserID, dtoTagFollow.TagID).Exec(ctx)
+	if err != nil {
+		return domainerrors.ErrFailedToDeleteTagFollow
+	}
+	return nil
+}
+
+func (r *TagFollowRepository) Exists(ctx context.Context, tagFollow *entity.TagFollow) bool {
+	exists, err := r.db.NewSelect().Model(&dto.TagFollow{}).Where("user_id = ? AND tag_id = ?", tagFollow.UserID, tagFollow.TagID).Exists(ctx)
+	if err != nil {
+		return false
+	}
+	return exists
+}
+
+// CreateNewWorkEntries は作品に付いたタグのフォロワー全員に未読の新着を記録します。
+// 下書きの作品と作者自身は対象外です。
+func (r *TagFollowRepository) CreateNewWorkEntries(ctx context.Context, workID uuid.UUID) error {
+	_, err := r.db.NewRaw(`
+		INSERT INTO tag_new_work (user_id, work_id, created_at)
+		SELECT DISTINCT tag_follow.user_id, work.id, work.created_at
+		FROM tag_follow
+		JOIN tagging ON tagging.tag_id = tag_follow.tag_id
+		JOIN work ON work.id = tagging.work_id
+		WHERE work.id = ? AND work.visibility != ? AND tag_follow.user_id != work.user_id
+		ON CONFLICT DO NOTHING`,
+		workID, types.VisibilityDraft,
+	).Exec(ctx)
+	if err != nil {
+		return domainerrors.ErrFailedToCreateTagNewWorks
+	}
+	return nil
+}
+
+func (r *TagFollowRepository) GetNewWorks(ctx context.Context, userID uuid.UUID, unseenOnly bool, limit, offset int) ([]*entity.TagNewWork, int, error) {
+	var dtoNewWorks []*dto.TagNewWork
+	query := r.db.NewSelect().
+		Model(&dtoNewWorks).
+		Where("user_id = ?", userID)
+	if unseenOnly {
+		query = query.Where("seen_at IS NULL")
+	}
+	total, err := query.
+		OrderExpr("created_at DESC, work_id DESC").
+		Limit(limit).
+		Offset(offset).
+		ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, domainerrors.ErrFailedToGetTagNewWorks
+	}
+	if len(dtoNewWorks) == 0 {
+		return []*entity.TagNewWork{}, total, nil
+	}
+
+	workIDs := make([]uuid.UUID, len(dtoNewWorks))
+	for i, newWork := range dtoNewWorks {
+		workIDs[i] = newWork.WorkID
+	}
+	var dtoWorks []*dto.Work
+	err = r.db.NewSelect().
+		Model(&dtoWorks).
+		Where("work.id IN (?)", bun.In(workIDs)).
+		Relation("Assets.Variants").
+		Relation("URLs").
+		Relation("Tags").
+		Relation("User").
+		Relation("Thumbnail.Asset.Variants").
+		Scan(ctx)
+	if err != nil {
+		return nil, 0, domainerrors.ErrFailedToGetTagNewWorks
+	}
+	worksByID := make(map[uuid.UUID]*entity.Work, len(dtoWorks))
+	for _, dtoWork := range dtoWorks {
+		worksByID[dtoWork.ID] = dtoWork.ToWorkEntity()
+	}
+
+	// 記録後に削除された作品は一覧から除く
+	newWorks := make([]*entity.TagNewWork, 0, len(dtoNewWorks))
+	for _, dtoNewWork := range dtoNewWorks {
+		work, ok := worksByID[dtoNewWork.WorkID]
+		if !ok {
+			continue
+		}
+		newWork := dtoNewWork.ToTagNewWorkEntity()
+		newWork.Work = work
+		newWorks = append(newWorks, newWork)
+	}
+	return newWorks, total, nil
+}
+
+// MarkNewWorksAsSeen は指定した作品の新着を既読にします。workIDsが空の場合は全件を既読にします。
+func (r *TagFollowRepository) MarkNewWorksAsSeen(ctx context.Context, userID uuid.UUID, workIDs []uuid.UUID) error {
+	query := r.db.NewUpdate().
+		Model((*dto.TagNewWork)(nil)).
+		Set("seen_at = ?", time.Now()).
+		Where("user_id = ?", userID).
+		Where("seen_at IS NULL")
+	if len(workIDs) > 0 {
+		query = query.Where("work_id IN (?)", bun.In(workIDs))
+	}
+	_, err := query.Exec(ctx)
+	if err != nil {
+		return domainerrors.ErrFailedToMarkTagNewWorksAsSeen
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+//go:build integration
+
+package tagfollow_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/dto"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/tagfollow"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/testutil"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/types"
+)
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	testutil.Teardown()
+	os.Exit(code)
+}
+
+func TestTagFollowRepository_CreateAndDelete(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := tagfollow.NewTagFollowRepository(db)
+
+	ctx := context.Background()
+
+	user := insertTestUser(t, db)
+	tag := insertTestTag(t, db)
+	tagFollow := entity.NewTagFollow(user.ID, tag.ID)
+
+	created, err := repo.Create(ctx, tagFollow)
+	require.NoError(t, err)
+	require.Equal(t, user.ID, created.UserID)
+	require.Equal(t, tag.ID, created.TagID)
+	require.True(t, repo.Exists(ctx, tagFollow))
+
+	_, err = repo.Create(ctx, tagFollow)
+	require.ErrorIs(t, err, domainerrors.ErrFailedToCreateTagFollow)
+
+	err = repo.Delete(ctx, tagFollow)
+	require.NoError(t, err)
+	require.False(t, repo.Exists(ctx, tagFollow))
+}
+
+func TestTagFollowRepository_NewWorks(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := tagfollow.NewTagFollowRepository(db)
+
+	ctx := context.Background()
+
+	author := insertTestUser(t, db)
+	follower := insertTestUser(t, db)
+	otherUser := insertTestUser(t, db)
+	followedTag := insertTestTag(t, db)
+	otherTag := insertTestTag(t, db)
+
+	_, err := repo.Create(ctx, entity.NewTagFollow(follower.ID, followedTag.ID))
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, entity.NewTagFollow(author.ID, followedTag.ID))
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, entity.NewTagFollow(otherUser.ID, otherTag.ID))
+	require.NoError(t, err)
+
+	publicWork := insertTestWork(t, db, author.ID, types.VisibilityPublic, followedTag.ID)
+	draftWork := insertTestWork(t, db, author.ID, types.VisibilityDraft, followedTag.ID)
+
+	require.NoError(t, repo.CreateNewWorkEntries(ctx, publicWork.ID))
+	require.NoError(t, repo.CreateNewWorkEntries(ctx, draftWork.ID))
+	// 同じ作品を再度記録しても重複しない
+	require.NoError(t, repo.CreateNewWorkEntries(ctx, publicWork.ID))
+
+	newWorks, total, err := repo.GetNewWorks(ctx, follower.ID, true, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	require.Len(t, newWorks, 1)
+	require.Equal(t, publicWork.ID, newWorks[0].WorkID)
+	require.Equal(t, publicWork.ID, newWorks[0].Work.ID)
+	require.Nil(t, newWorks[0].SeenAt)
+
+	// 作者自身と別のタグのフォロワーには記録されない
+	for _, userID := range []uuid.UUID{author.ID, otherUser.ID} {
+		_, total, err := repo.GetNewWorks(ctx, userID, false, 10, 0)
+		require.NoError(t, err)
+		require.Zero(t, total)
+	}
+
+	require.NoError(t, repo.MarkNewWorksAsSeen(ctx, follower.ID, nil))
+
+	_, total, err = repo.GetNewWorks(ctx, follower.ID, true, 10, 0)
+	require.NoError(t, err)
+	require.Zero(t, total)
+
+	newWorks, total, err = repo.GetNewWorks(ctx, follower.ID, false, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+	require.NotNil(t, newWorks[0].SeenAt)
+}
+
+func insertTestUser(t *testing.T, db *bun.DB) *entity.User {
+	t.Helper()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	shortID := uuid.New().String()[:8]
+	user := &entity.User{
+		ID:            uuid.New(),
+		Name:          fmt.Sprintf("user-%s", shortID),
+		Email:         fmt.Sprintf("test-%s@example.com", uuid.New().String()),
+		DisplayName:   fmt.Sprintf("tester-%s", shortID),
+		DiscordUserID: fmt.Sprintf("discord-%s", shortID),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	dtoUser := dto.ToUserDTO(user)
+	_, err := db.NewInsert().Model(dtoUser).Exec(context.Background())
+	require.NoError(t, err)
+
+	return user
+}
+
+func insertTestTag(t *testing.T, db *bun.DB) *entity.Tag {
+	t.Helper()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	tag := &entity.Tag{
+		ID:        uuid.New(),
+		Name:      fmt.Sprintf("tag-%s", uuid.New().String()[:8]),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	_, err := db.NewInsert().Model(dto.ToTagDTO(tag)).Exec(context.Background())
+	require.NoError(t, err)
+
+	return tag
+}
+
+func insertTestWork(t *testing.T, db *bun.DB, userID uuid.UUID, visibility types.Visibility, tagID uuid.UUID) *dto.Work {
+	t.Helper()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	work := &dto.Work{
+		ID:          uuid.New(),
+		Title:       fmt.Sprintf("test-work-%s", uuid.New().String()[:8]),
+		Description: "description",
+		UserID:      userID,
+		Visibility:  visibility,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	_, err := db.NewInsert().Model(work).Exec(context.Background())
+	require.NoError(t, err)
+
+	_, err = db.NewInsert().Model(&dto.Tagging{WorkID: work.ID, TagID: tagID}).Exec(context.Background())
+	require.NoError(t, err)
+
+	return work
+}
@@ -162,7 +162,16 @@ func ResetTables(tb testing.TB) {
 		"urlinfo",
 		"comment",
 		"asset",
+		"asset_variant",
+		"asset_upload",
+		"blob",
 		"favorite",
+		"follow",
+		"tag_follow",
+		"tag_new_work",
+		"notification",
+		"mention",
+		"reaction",
 		"work",
 		`"user"`,
 		"token",
@@ -35,7 +35,7 @@ func (r *UserRepository) Create(ctx context.Context, user *entity.User) (*entity
 
 func (r *UserRepository) GetAll(ctx context.Context) ([]*entity.User, error) {
 	dtoUsers := make([]*dto.User, 0)
-	err := r.db.NewSelect().Model(&dtoUsers).Scan(ctx)
+	err := r.db.NewSelect().Model(&dtoUsers).Apply(withFollowCounts).Scan(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -50,13 +50,31 @@ func (r *UserRepository) GetAll(ctx context.Context) ([]*entity.User, error) {
 
 func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
 	dtoUser := new(dto.User)
-	err := r.db.NewSelect().Model(dtoUser).Where("id = ?", id).Scan(ctx)
+	err := r.db.NewSelect().Model(dtoUser).Apply(withFollowCounts).Where(`"user".id = ?`, id).Scan(ctx)
 	if err != nil {
 		return nil, domainerrors.ErrUserNotFound
 	}
 	return dtoUser.ToUserEntity(), nil
 }
 
+func (r *UserRepository) GetByNames(ctx context.Context, names []string) ([]*entity.User, error) {
+	if len(names) == 0 {
+		return []*entity.User{}, nil
+	}
+
+	dtoUsers := make([]*dto.User, 0)
+	err := r.db.NewSelect().Model(&dtoUsers).Where(`"user".name IN (?)`, bun.In(names)).Scan(ctx)
+	if err != nil {
+		return nil, domainerrors.ErrFailedToGetUsers
+	}
+
+	entityUsers := make([]*entity.User, len(dtoUsers))
+	for i, dtoUser := range dtoUsers {
+		entityUsers[i] = dtoUser.ToUserEntity()
+	}
+	return entityUsers, nil
+}
+
 func (r *UserRepository) GetUserByDiscordUserID(ctx context.Context, discordUserID string) (*entity.User, error) {
 	dtoUser := new(dto.User)
 	err := r.db.NewSelect().Model(dtoUser).Where("discord_user_id = ?", discordUserID).Scan(ctx)
@@ -77,3 +95,11 @@ func (r *UserRepository) Update(ctx context.Context, user *entity.User) (*entity
 	}
 	return dtoUser.ToUserEntity(), nil
 }
+
+// withFollowCounts はユーザーの取得時にフォロワー数とフォロー数を併せて集計します。
+func withFollowCounts(q *bun.SelectQuery) *bun.SelectQuery {
+	return q.
+		ColumnExpr(`"user".*`).
+		ColumnExpr(`(SELECT COUNT(*) FROM follow WHERE follow.followee_id = "user".id) AS follower_count`).
+		ColumnExpr(`(SELECT COUNT(*) FROM follow WHERE follow.follower_id = "user".id) AS following_count`)
+}
@@ -7,10 +7,12 @@ import (
 	"os"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/simesaba80/toybox-back/internal/domain/entity"
 	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/dto"
 	"github.com/simesaba80/toybox-back/internal/infrastructure/database/testutil"
 	"github.com/simesaba80/toybox-back/internal/infrastructure/database/user"
 	"github.com/stretchr/testify/require"
@@ -61,6 +63,33 @@ func TestUserRepository_GetAll(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestUserRepository_GetByNames(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := user.NewUserRepository(db)
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		user := &entity.User{
+			ID:            uuid.New(),
+			Name:          "testuser" + strconv.Itoa(i),
+			Email:         "testuser" + strconv.Itoa(i) + "@example.com",
+			DisplayName:   "testuser",
+			AvatarURL:     "https://example.com/avatar.png",
+			DiscordUserID: "testuser" + strconv.Itoa(i),
+		}
+		_, err := repo.Create(ctx, user)
+		require.NoError(t, err)
+	}
+
+	found, err := repo.GetByNames(ctx, []string{"testuser0", "testuser2", "unknown"})
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+
+	found, err = repo.GetByNames(ctx, nil)
+	require.NoError(t, err)
+	require.Empty(t, found)
+}
+
 func TestUserRepository_GetByID(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	repo := user.NewUserRepository(db)
@@ -87,6 +116,38 @@ func TestUserRepository_GetByID(t *testing.T) {
 	require.Equal(t, created.DiscordUserID, found.DiscordUserID)
 }
 
+func TestUserRepository_GetByID_WithFollowCounts(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := user.NewUserRepository(db)
+
+	ctx := context.Background()
+	users := make([]*entity.User, 3)
+	for i := range users {
+		created, err := repo.Create(ctx, &entity.User{
+			ID:            uuid.New(),
+			Name:          "followuser" + strconv.Itoa(i),
+			Email:         "followuser" + strconv.Itoa(i) + "@example.com",
+			DisplayName:   "followuser",
+			DiscordUserID: "followuser" + strconv.Itoa(i),
+		})
+		require.NoError(t, err)
+		users[i] = created
+	}
+
+	follows := []*dto.Follow{
+		{FollowerID: users[1].ID, FolloweeID: users[0].ID, CreatedAt: time.Now()},
+		{FollowerID: users[2].ID, FolloweeID: users[0].ID, CreatedAt: time.Now()},
+		{FollowerID: users[0].ID, FolloweeID: users[1].ID, CreatedAt: time.Now()},
+	}
+	_, err := db.NewInsert().Model(&follows).Exec(ctx)
+	require.NoError(t, err)
+
+	found, err := repo.GetByID(ctx, users[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, 2, found.FollowerCount)
+	require.Equal(t, 1, found.FollowingCount)
+}
+
 func TestUserRepository_GetUserByDiscordUserID(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	repo := user.NewUserRepository(db)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
@@ -55,11 +56,11 @@ func (r *WorkRepository) GetAll(ctx context.Context, limit, offset int, tagIDs [
 	}
 
 	err = selectQuery.
-		Relation("Assets").
+		Relation("Assets.Variants").
 		Relation("URLs").
 		Relation("Tags").
 		Relation("User").
-		Relation("Thumbnail.Asset").
+		Relation("Thumbnail.Asset.Variants").
 		Order("created_at DESC").
 		Limit(limit).
 		Offset(offset).
@@ -110,11 +111,11 @@ func (r *WorkRepository) GetAllPublic(ctx context.Context, limit, offset int, ta
 	}
 
 	err = selectQuery.
-		Relation("Assets").
+		Relation("Assets.Variants").
 		Relation("URLs").
 		Relation("Tags").
 		Relation("User").
-		Relation("Thumbnail.Asset").
+		Relation("Thumbnail.Asset.Variants").
 		Order("created_at DESC").
 		Limit(limit).
 		Offset(offset).
@@ -138,11 +139,11 @@ func (r *WorkRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Wor
 	var dtoWork dto.Work
 	err := r.db.NewSelect().
 		Model(&dtoWork).
-		Relation("Assets").
+		Relation("Assets.Variants").
 		Relation("Tags").
 		Relation("URLs").
 		Relation("User").
-		Relation("Thumbnail.Asset").
+		Relation("Thumbnail.Asset.Variants").
 		Where("work.id = ?", id).
 		Scan(ctx)
 	if err != nil {
@@ -164,11 +165,11 @@ func (r *WorkRepository) GetByUserID(ctx context.Context, userID uuid.UUID, publ
 			Where("visibility IN (?)", bun.In([]types.Visibility{types.VisibilityPublic})).
 			Where("EXISTS (SELECT 1 FROM asset WHERE asset.work_id = work.id)").
 			Where("EXISTS (SELECT 1 FROM tagging WHERE tagging.work_id = work.id)").
-			Relation("Assets").
+			Relation("Assets.Variants").
 			Relation("URLs").
 			Relation("Tags").
 			Relation("User").
-			Relation("Thumbnail.Asset").
+			Relation("Thumbnail.Asset.Variants").
 			Scan(ctx)
 		if err != nil {
 			return nil, domainerrors.ErrFailedToGetWorksByUserID
@@ -180,11 +181,11 @@ func (r *WorkRepository) GetByUserID(ctx context.Context, userID uuid.UUID, publ
 			Where("visibility IN (?)", bun.In([]types.Visibility{types.VisibilityPublic, types.VisibilityPrivate})).
 			Where("EXISTS (SELECT 1 FROM asset WHERE asset.work_id = work.id)").
 			Where("EXISTS (SELECT 1 FROM tagging WHERE tagging.work_id = work.id)").
-			Relation("Assets").
+			Relation("Assets.Variants").
 			Relation("URLs").
 			Relation("Tags").
 			Relation("User").
-			Relation("Thumbnail.Asset").
+			Relation("Thumbnail.Asset.Variants").
 			Scan(ctx)
 		if err != nil {
 			return nil, domainerrors.ErrFailedToGetWorksByUserID
@@ -209,6 +210,89 @@ func (r *WorkRepository) ExistsById(ctx context.Context, id uuid.UUID) (bool, er
 	return exists, nil
 }
 
+// GetFeed はユーザー向けのおすすめフィードを作成日時の新しい順に取得します。
+// 過去にいいね・投稿した作品のタグを持つ作品、いいねした作品の作者の作品、最近いいねが集まっている作品を対象とし、
+// 自分の作品といいね済みの作品は除外します。
+func (r *WorkRepository) GetFeed(ctx context.Context, userID uuid.UUID, cursor *entity.FeedCursor, limit int, trendingSince time.Time, trendingMinFavorites int) ([]*entity.Work, error) {
+	var dtoWorks []*dto.Work
+
+	query := r.db.NewSelect().
+		Model(&dtoWorks).
+		Where("visibility IN (?)", bun.In([]types.Visibility{types.VisibilityPublic, types.VisibilityPrivate})).
+		Where("EXISTS (SELECT 1 FROM asset WHERE asset.work_id = work.id)").
+		Where("EXISTS (SELECT 1 FROM tagging WHERE tagging.work_id = work.id)").
+		Where("work.user_id != ?", userID).
+		Where("NOT EXISTS (SELECT 1 FROM favorite WHERE favorite.work_id = work.id AND favorite.user_id = ?)", userID).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				// いいね・投稿した作品に付いているタグの新着
+				WhereOr(`EXISTS (SELECT 1 FROM tagging WHERE tagging.work_id = work.id AND tagging.tag_id IN (
+					SELECT ft.tag_id FROM tagging AS ft JOIN favorite AS f ON f.work_id = ft.work_id WHERE f.user_id = ?
+					UNION
+					SELECT pt.tag_id FROM tagging AS pt JOIN work AS pw ON pw.id = pt.work_id WHERE pw.user_id = ?
+				))`, userID, userID).
+				// いいねした作品の作者の新着
+				WhereOr("work.user_id IN (SELECT fw.user_id FROM favorite AS f JOIN work AS fw ON fw.id = f.work_id WHERE f.user_id = ?)", userID).
+				// 最近いいねが集まっている作品
+				WhereOr("work.id IN (SELECT f.work_id FROM favorite AS f WHERE f.created_at >= ? GROUP BY f.work_id HAVING COUNT(*) >= ?)", trendingSince, trendingMinFavorites)
+		})
+
+	if cursor != nil {
+		query = query.Where("(work.created_at, work.id) < (?, ?)", cursor.CreatedAt, cursor.ID)
+	}
+
+	err := query.
+		Relation("Assets.Variants").
+		Relation("URLs").
+		Relation("Tags").
+		Relation("User").
+		Relation("Thumbnail.Asset.Variants").
+		OrderExpr("work.created_at DESC, work.id DESC").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, domainerrors.ErrFailedToGetFeed
+	}
+
+	entityWorks := make([]*entity.Work, len(dtoWorks))
+	for i, dtoWork := range dtoWorks {
+		entityWorks[i] = dtoWork.ToWorkEntity()
+	}
+	return entityWorks, nil
+}
+
+func (r *WorkRepository) GetFollowingFeed(ctx context.Context, userID uuid.UUID, cursor *entity.FeedCursor, limit int) ([]*entity.Work, error) {
+	var dtoWorks []*dto.Work
+
+	query := r.db.NewSelect().
+		Model(&dtoWorks).
+		Where("visibility IN (?)", bun.In([]types.Visibility{types.VisibilityPublic, types.VisibilityPrivate})).
+		Where("work.user_id IN (SELECT follow.followee_id FROM follow WHERE follow.follower_id = ?)", userID)
+
+	if cursor != nil {
+		query = query.Where("(work.created_at, work.id) < (?, ?)", cursor.CreatedAt, cursor.ID)
+	}
+
+	err := query.
+		Relation("Assets.Variants").
+		Relation("URLs").
+		Relation("Tags").
+		Relation("User").
+		Relation("Thumbnail.Asset.Variants").
+		OrderExpr("work.created_at DESC, work.id DESC").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, domainerrors.ErrFailedToGetFollowingFeed
+	}
+
+	entityWorks := make([]*entity.Work, len(dtoWorks))
+	for i, dtoWork := range dtoWorks {
+		entityWorks[i] = dtoWork.ToWorkEntity()
+	}
+	return entityWorks, nil
+}
+
 func (r *WorkRepository) Create(ctx context.Context, work *entity.Work) (*entity.Work, error) {
 
 	tx, err := r.db.BeginTx(ctx, nil)
@@ -599,6 +599,115 @@ func TestWorkRepository_ExistsByID(t *testing.T) {
 	require.False(t, exists)
 }
 
+func TestWorkRepository_GetFeed(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := work.NewWorkRepository(db)
+
+	ctx := context.Background()
+	viewer := insertTestUser(t, db)
+	favoritedAuthor := insertTestUser(t, db)
+	otherAuthor := insertTestUser(t, db)
+	unrelatedAuthor := insertTestUser(t, db)
+	fans := []*entity.User{insertTestUser(t, db), insertTestUser(t, db), insertTestUser(t, db)}
+
+	favoritedTag := insertTestTag(t, db, "favorited-tag")
+	postedTag := insertTestTag(t, db, "posted-tag")
+	otherTag := insertTestTag(t, db, "other-tag")
+
+	base := time.Now().UTC().Truncate(time.Second)
+	createWork := func(userID uuid.UUID, tag *entity.Tag, minutes int) *entity.Work {
+		asset := insertTestAsset(t, db, userID)
+		thumbnailAsset := insertTestAsset(t, db, userID)
+		w := newTestWork(userID, "feed-title-"+uuid.NewString())
+		w.Assets = []*entity.Asset{asset}
+		w.ThumbnailAssetID = thumbnailAsset.ID
+		w.TagIDs = []uuid.UUID{tag.ID}
+		w.Tags = []*entity.Tag{tag}
+		w.CreatedAt = base.Add(time.Duration(minutes) * time.Minute)
+		w.UpdatedAt = w.CreatedAt
+		created, err := repo.Create(ctx, w)
+		require.NoError(t, err)
+		return created
+	}
+
+	// いいね済みの作品は除外されるが、そのタグと作者はおすすめの根拠になる
+	favoritedWork := createWork(favoritedAuthor.ID, favoritedTag, 0)
+	insertTestFavorite(t, db, favoritedWork.ID, viewer.ID, base)
+	// 自分の作品は除外されるが、そのタグはおすすめの根拠になる
+	createWork(viewer.ID, postedTag, 1)
+
+	byFavoritedAuthor := createWork(favoritedAuthor.ID, otherTag, 2)
+	withFavoritedTag := createWork(otherAuthor.ID, favoritedTag, 3)
+	withPostedTag := createWork(unrelatedAuthor.ID, postedTag, 4)
+	trending := createWork(unrelatedAuthor.ID, otherTag, 5)
+	for _, fan := range fans {
+		insertTestFavorite(t, db, trending.ID, fan.ID, time.Now())
+	}
+	// どの条件にも当てはまらない作品
+	createWork(unrelatedAuthor.ID, otherTag, 6)
+
+	firstPage, err := repo.GetFeed(ctx, viewer.ID, nil, 2, time.Now().Add(-time.Hour), 3)
+	require.NoError(t, err)
+	require.Len(t, firstPage, 2)
+	require.Equal(t, trending.ID, firstPage[0].ID)
+	require.Equal(t, withPostedTag.ID, firstPage[1].ID)
+
+	secondPage, err := repo.GetFeed(ctx, viewer.ID, entity.NewFeedCursor(firstPage[1]), 2, time.Now().Add(-time.Hour), 3)
+	require.NoError(t, err)
+	require.Len(t, secondPage, 2)
+	require.Equal(t, withFavoritedTag.ID, secondPage[0].ID)
+	require.Equal(t, byFavoritedAuthor.ID, secondPage[1].ID)
+
+	lastPage, err := repo.GetFeed(ctx, viewer.ID, entity.NewFeedCursor(secondPage[1]), 2, time.Now().Add(-time.Hour), 3)
+	require.NoError(t, err)
+	require.Empty(t, lastPage)
+}
+
+func TestWorkRepository_GetFollowingFeed(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	repo := work.NewWorkRepository(db)
+
+	ctx := context.Background()
+	viewer := insertTestUser(t, db)
+	followee := insertTestUser(t, db)
+	stranger := insertTestUser(t, db)
+
+	_, err := db.NewInsert().Model(&dto.Follow{
+		FollowerID: viewer.ID,
+		FolloweeID: followee.ID,
+		CreatedAt:  time.Now(),
+	}).Exec(ctx)
+	require.NoError(t, err)
+
+	base := time.Now().UTC().Truncate(time.Second)
+	createWork := func(userID uuid.UUID, visibility string, minutes int) *entity.Work {
+		thumbnailAsset := insertTestAsset(t, db, userID)
+		w := newTestWork(userID, "following-title-"+uuid.NewString())
+		w.Visibility = visibility
+		w.ThumbnailAssetID = thumbnailAsset.ID
+		w.CreatedAt = base.Add(time.Duration(minutes) * time.Minute)
+		w.UpdatedAt = w.CreatedAt
+		created, err := repo.Create(ctx, w)
+		require.NoError(t, err)
+		return created
+	}
+
+	publicWork := createWork(followee.ID, "public", 0)
+	privateWork := createWork(followee.ID, "private", 1)
+	createWork(followee.ID, "draft", 2)
+	createWork(stranger.ID, "public", 3)
+
+	firstPage, err := repo.GetFollowingFeed(ctx, viewer.ID, nil, 1)
+	require.NoError(t, err)
+	require.Len(t, firstPage, 1)
+	require.Equal(t, privateWork.ID, firstPage[0].ID)
+
+	secondPage, err := repo.GetFollowingFeed(ctx, viewer.ID, entity.NewFeedCursor(firstPage[0]), 10)
+	require.NoError(t, err)
+	require.Len(t, secondPage, 1)
+	require.Equal(t, publicWork.ID, secondPage[0].ID)
+}
+
 func insertTestUser(t *testing.T, db *bun.DB) *entity.User {
 	t.Helper()
 
@@ -679,3 +788,15 @@ func insertTestAsset(t *testing.T, db *bun.DB, userID uuid.UUID) *entity.Asset {
 
 	return asset
 }
+
+func insertTestFavorite(t *testing.T, db *bun.DB, workID, userID uuid.UUID, createdAt time.Time) {
+	t.Helper()
+
+	favorite := &dto.Favorite{
+		WorkID:    workID,
+		UserID:    userID,
+		CreatedAt: createdAt,
+	}
+	_, err := db.NewInsert().Model(favorite).Exec(context.Background())
+	require.NoError(t, err)
+}
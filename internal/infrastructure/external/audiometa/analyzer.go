@@ -0,0 +1,139 @@
+package audiometa
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+)
+
+// Analyzer は純粋な Go だけで音声を読み取る AudioAnalyzer の実装です。
+// WAV は PCM の振幅から波形を求めます。MP3 と M4A は音声を復号せず、
+// フレームごとの量子化の大きさ (global_gain) から音量の概形を求めます。
+type Analyzer struct {
+	// peakCount は波形を分ける区間の数です
+	peakCount int
+}
+
+func NewAnalyzer(peakCount int) *Analyzer {
+	return &Analyzer{
+		peakCount: peakCount,
+	}
+}
+
+func (a *Analyzer) AnalyzeAudio(ctx context.Context, src io.Reader, extension string) (*entity.AudioMetadata, error) {
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audio: %w", err)
+	}
+
+	switch extension {
+	case "wav":
+		return a.analyzeWAV(ctx, data)
+	case "mp3":
+		return a.analyzeMP3(ctx, data)
+	case "m4a":
+		return a.analyzeM4A(ctx, data)
+	default:
+		return nil, fmt.Errorf("%w: unsupported extension %q", domainerrors.ErrInvalidAudio, extension)
+	}
+}
+
+// peaks は units 個の単位 (PCM のフレームや MP3 のフレーム) を区間に分け、区間ごとの最大値を集めます
+type peaks struct {
+	units  int
+	values []float64
+}
+
+func newPeaks(units int, count int) *peaks {
+	return &peaks{
+		units:  units,
+		values: make([]float64, count),
+	}
+}
+
+// add は unit 番目の単位の値を記録します。単位が区間より少ない場合は、1 つの単位が複数の区間にまたがります
+func (p *peaks) add(unit int, value float64) {
+	count := int64(len(p.values))
+	start := int(int64(unit) * count / int64(p.units))
+	end := max(int(int64(unit+1)*count/int64(p.units)), start+1)
+	for i := start; i < end && i < len(p.values); i++ {
+		p.values[i] = max(p.values[i], value)
+	}
+}
+
+// result は区間ごとの最大値を小数第 2 位に丸めて返します。normalize の場合は最大値が 1 になるよう揃えます
+func (p *peaks) result(normalize bool) []float64 {
+	scale := 1.0
+	if normalize {
+		var peak float64
+		for _, v := range p.values {
+			peak = max(peak, v)
+		}
+		if peak > 0 {
+			scale = 1 / peak
+		}
+	}
+	values := make([]float64, len(p.values))
+	for i, v := range p.values {
+		values[i] = math.Round(min(v*scale, 1)*100) / 100
+	}
+	return values
+}
+
+// gainAmplitude は global_gain を振幅に直します。どちらの形式も global_gain が 4 増えると量子化の幅が 2 倍になります
+func gainAmplitude(globalGain uint32) float64 {
+	return math.Pow(2, float64(globalGain)/4)
+}
+
+// bitReader は上位ビットから順にビット列を読みます。範囲外を読んだ場合は 0 を返し、ok が false になります
+type bitReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *bitReader) read(n int) uint32 {
+	var v uint32
+	for i := 0; i < n; i++ {
+		v <<= 1
+		if r.pos>>3 < len(r.data) && r.data[r.pos>>3]&(0x80>>(r.pos&7)) != 0 {
+			v |= 1
+		}
+		r.pos++
+	}
+	return v
+}
+
+func (r *bitReader) skip(n int) {
+	r.pos += n
+}
+
+func (r *bitReader) ok() bool {
+	return r.pos <= len(r.data)*8
+}
+
+// latin1 は ISO-8859-1 の文字列を UTF-8 にします。
+// ISO-8859-1 と書きながら UTF-8 を入れるツールもあるため、UTF-8 として正しい場合はそのまま使います
+func latin1(b []byte) string {
+	if utf8.Valid(b) {
+		return string(b)
+	}
+	runes := make([]rune, len(b))
+	for i, c := range b {
+		runes[i] = rune(c)
+	}
+	return string(runes)
+}
+
+// cleanText はタグの値の前後の空白と終端の NUL を取り除きます
+func cleanText(s string) string {
+	if i := strings.IndexByte(s, 0); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimSpace(s)
+}
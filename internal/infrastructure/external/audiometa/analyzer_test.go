@@ -0,0 +1,285 @@
+package audiometa_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/require"
+
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/external/audiometa"
+)
+
+const peakCount = 10
+
+// bitWriter は上位ビットから順にビット列を書きます
+type bitWriter struct {
+	data []byte
+	pos  int
+}
+
+func (w *bitWriter) write(n int, v uint32) {
+	for i := n - 1; i >= 0; i-- {
+		if w.pos>>3 >= len(w.data) {
+			w.data = append(w.data, 0)
+		}
+		if v&(1<<i) != 0 {
+			w.data[w.pos>>3] |= 0x80 >> (w.pos & 7)
+		}
+		w.pos++
+	}
+}
+
+func chunk(id string, body []byte) []byte {
+	b := append([]byte(id), binary.LittleEndian.AppendUint32(nil, uint32(len(body)))...)
+	b = append(b, body...)
+	if len(body)%2 == 1 {
+		b = append(b, 0)
+	}
+	return b
+}
+
+// newWAV は前半が最大音量の矩形波、後半が無音の 16 ビットモノラルの WAV を作ります
+func newWAV(sampleRate int, frames int) []byte {
+	fmtChunk := binary.LittleEndian.AppendUint16(nil, 1)
+	fmtChunk = binary.LittleEndian.AppendUint16(fmtChunk, 1)
+	fmtChunk = binary.LittleEndian.AppendUint32(fmtChunk, uint32(sampleRate))
+	fmtChunk = binary.LittleEndian.AppendUint32(fmtChunk, uint32(sampleRate*2))
+	fmtChunk = binary.LittleEndian.AppendUint16(fmtChunk, 2)
+	fmtChunk = binary.LittleEndian.AppendUint16(fmtChunk, 16)
+
+	var samples []byte
+	for i := 0; i < frames; i++ {
+		var v int16
+		if i < frames/2 {
+			v = math.MaxInt16
+			if i%2 == 1 {
+				v = math.MinInt16
+			}
+		}
+		samples = binary.LittleEndian.AppendUint16(samples, uint16(v))
+	}
+
+	info := append([]byte("INFO"), chunk("INAM", []byte("Title\x00"))...)
+	info = append(info, chunk("IART", []byte("Artist\x00"))...)
+
+	body := append([]byte("WAVE"), chunk("fmt ", fmtChunk)...)
+	body = append(body, chunk("LIST", info)...)
+	body = append(body, chunk("data", samples)...)
+	return append([]byte("RIFF"), append(binary.LittleEndian.AppendUint32(nil, uint32(len(body))), body...)...)
+}
+
+func TestAnalyzer_AnalyzeAudio_WAV(t *testing.T) {
+	analyzer := audiometa.NewAnalyzer(peakCount)
+
+	metadata, err := analyzer.AnalyzeAudio(context.Background(), bytes.NewReader(newWAV(8000, 16000)), "wav")
+	require.NoError(t, err)
+	require.Equal(t, 2*time.Second, metadata.Duration)
+	require.Equal(t, 8000, metadata.SampleRate)
+	require.Equal(t, 1, metadata.Channels)
+	require.Equal(t, 128000, metadata.Bitrate)
+	require.Equal(t, "Title", metadata.Title)
+	require.Equal(t, "Artist", metadata.Artist)
+	require.Equal(t, []float64{1, 1, 1, 1, 1, 0, 0, 0, 0, 0}, metadata.Peaks)
+}
+
+func synchsafe(n int) []byte {
+	return []byte{byte(n >> 21 & 0x7F), byte(n >> 14 & 0x7F), byte(n >> 7 & 0x7F), byte(n & 0x7F)}
+}
+
+func id3Frame(id string, text []byte) []byte {
+	b := append([]byte(id), synchsafe(len(text))...)
+	return append(append(b, 0, 0), text...)
+}
+
+// newMP3 は ID3v2.4 のタグと、先頭の Info フレームに続く 128kbps・44.1kHz のステレオのフレームを作ります。
+// gains が 0 のフレームは符号化されたデータのない無音のフレームにします
+func newMP3(gains []uint32) []byte {
+	title := append([]byte{3}, []byte("テスト")...)
+	artist := []byte{1, 0xFF, 0xFE}
+	for _, u := range utf16.Encode([]rune("Artist")) {
+		artist = binary.LittleEndian.AppendUint16(artist, u)
+	}
+	frames := append(id3Frame("TIT2", title), id3Frame("TPE1", artist)...)
+	// パディング
+	frames = append(frames, make([]byte, 16)...)
+	data := append([]byte("ID3\x04\x00\x00"), synchsafe(len(frames))...)
+	data = append(data, frames...)
+
+	const frameSize = 417
+	newFrame := func(gain uint32) []byte {
+		frame := make([]byte, frameSize)
+		copy(frame, []byte{0xFF, 0xFB, 0x90, 0x00})
+		w := &bitWriter{}
+		w.write(9+3+8, 0)
+		for gr := 0; gr < 2; gr++ {
+			for ch := 0; ch < 2; ch++ {
+				if gain == 0 {
+					w.write(59, 0)
+					continue
+				}
+				w.write(12, 100)
+				w.write(9, 0)
+				w.write(8, gain)
+				w.write(30, 0)
+			}
+		}
+		copy(frame[4:], w.data)
+		return frame
+	}
+
+	info := newFrame(0)
+	copy(info[4+32:], "Info")
+	data = append(data, info...)
+	for _, gain := range gains {
+		data = append(data, newFrame(gain)...)
+	}
+	return data
+}
+
+func TestAnalyzer_AnalyzeAudio_MP3(t *testing.T) {
+	analyzer := audiometa.NewAnalyzer(peakCount)
+
+	// 音量の大きいフレーム、小さいフレーム、無音のフレームの順に並べる
+	gains := []uint32{200, 200, 200, 200, 200, 192, 192, 192, 0, 0}
+	metadata, err := analyzer.AnalyzeAudio(context.Background(), bytes.NewReader(newMP3(gains)), "mp3")
+	require.NoError(t, err)
+	// Info フレームは再生時間に含めない
+	require.Equal(t, time.Duration(10*1152)*time.Second/44100, metadata.Duration)
+	require.Equal(t, 44100, metadata.SampleRate)
+	require.Equal(t, 2, metadata.Channels)
+	require.InDelta(t, 128000, metadata.Bitrate, 500)
+	require.Equal(t, "テスト", metadata.Title)
+	require.Equal(t, "Artist", metadata.Artist)
+	// global_gain が 8 小さいフレームは振幅が 1/4 になる
+	require.Equal(t, []float64{1, 1, 1, 1, 1, 0.25, 0.25, 0.25, 0, 0}, metadata.Peaks)
+}
+
+func box(boxType string, children ...[]byte) []byte {
+	var body []byte
+	for _, child := range children {
+		body = append(body, child...)
+	}
+	return append(binary.BigEndian.AppendUint32(nil, uint32(8+len(body))), append([]byte(boxType), body...)...)
+}
+
+func u32s(values ...uint32) []byte {
+	var b []byte
+	for _, v := range values {
+		b = binary.BigEndian.AppendUint32(b, v)
+	}
+	return b
+}
+
+// newM4A は 1 チャンネルの AAC のフレームを 1 つのチャンクに並べた M4A を作ります
+func newM4A(gains []uint32) []byte {
+	return newM4AWithChunkOffsets(gains, func(mdatOffset uint32) []byte {
+		return box("stco", u32s(0, 1, mdatOffset))
+	})
+}
+
+// newM4AWithChunkOffsets は chunkOffsets が返すボックスをチャンクの位置として使う M4A を作ります
+func newM4AWithChunkOffsets(gains []uint32, chunkOffsets func(mdatOffset uint32) []byte) []byte {
+	var samples [][]byte
+	for _, gain := range gains {
+		w := &bitWriter{}
+		// SCE・element_instance_tag・global_gain
+		w.write(3, 0)
+		w.write(4, 0)
+		w.write(8, gain)
+		w.write(16, 0)
+		samples = append(samples, w.data)
+	}
+
+	ftyp := box("ftyp", []byte("M4A \x00\x00\x00\x00"))
+	build := func(mdatOffset uint32) []byte {
+		mp4a := make([]byte, 28)
+		binary.BigEndian.PutUint16(mp4a[16:18], 1)
+		binary.BigEndian.PutUint16(mp4a[24:26], 44100)
+		stsz := u32s(0, 0, uint32(len(samples)))
+		for _, sample := range samples {
+			stsz = append(stsz, u32s(uint32(len(sample)))...)
+		}
+		stbl := box("stbl",
+			box("stsd", u32s(0, 1), box("mp4a", mp4a)),
+			box("stsz", stsz),
+			box("stsc", u32s(0, 1, 1, uint32(len(samples)), 1)),
+			chunkOffsets(mdatOffset),
+		)
+		mdia := box("mdia",
+			box("mdhd", u32s(0, 0, 0, 44100, uint32(len(samples)*1024)), make([]byte, 4)),
+			box("hdlr", u32s(0, 0), []byte("soun"), make([]byte, 12)),
+			box("minf", stbl),
+		)
+		ilst := box("ilst",
+			box("\xa9nam", box("data", u32s(1, 0), []byte("曲名"))),
+			box("\xa9ART", box("data", u32s(1, 0), []byte("アーティスト"))),
+		)
+		meta := box("meta", u32s(0), box("hdlr", make([]byte, 25)), ilst)
+		return box("moov", box("trak", mdia), box("udta", meta))
+	}
+	moov := build(0)
+	// mdat の本体の位置が決まってからチャンクの位置を書き込む
+	moov = build(uint32(len(ftyp) + len(moov) + 8))
+
+	var mdat []byte
+	for _, sample := range samples {
+		mdat = append(mdat, sample...)
+	}
+	return append(append(ftyp, moov...), box("mdat", mdat)...)
+}
+
+func TestAnalyzer_AnalyzeAudio_M4A(t *testing.T) {
+	analyzer := audiometa.NewAnalyzer(peakCount)
+
+	gains := []uint32{180, 180, 180, 180, 180, 176, 176, 176, 176, 0}
+	data := newM4A(gains)
+	metadata, err := analyzer.AnalyzeAudio(context.Background(), bytes.NewReader(data), "m4a")
+	require.NoError(t, err)
+	require.Equal(t, time.Duration(10*1024)*time.Second/44100, metadata.Duration)
+	require.Equal(t, 44100, metadata.SampleRate)
+	require.Equal(t, 1, metadata.Channels)
+	require.Equal(t, "曲名", metadata.Title)
+	require.Equal(t, "アーティスト", metadata.Artist)
+	require.Equal(t, []float64{1, 1, 1, 1, 1, 0.5, 0.5, 0.5, 0.5, 0}, metadata.Peaks)
+}
+
+func TestAnalyzer_AnalyzeAudio_M4AChunkOutOfRange(t *testing.T) {
+	analyzer := audiometa.NewAnalyzer(peakCount)
+
+	for _, offset := range []uint64{math.MaxInt64 - 1, math.MaxUint64, 1 << 20} {
+		data := newM4AWithChunkOffsets([]uint32{180, 0}, func(uint32) []byte {
+			return box("co64", binary.BigEndian.AppendUint64(u32s(0, 1), offset))
+		})
+		// ファイルの外を指すチャンクは読まず、波形だけを諦める
+		metadata, err := analyzer.AnalyzeAudio(context.Background(), bytes.NewReader(data), "m4a")
+		require.NoError(t, err)
+		require.Equal(t, time.Duration(2*1024)*time.Second/44100, metadata.Duration)
+		require.Nil(t, metadata.Peaks)
+	}
+}
+
+func TestAnalyzer_AnalyzeAudio_ShortAudio(t *testing.T) {
+	analyzer := audiometa.NewAnalyzer(peakCount)
+
+	// フレームが区間より少ない場合は、1 つのフレームを複数の区間に広げる
+	metadata, err := analyzer.AnalyzeAudio(context.Background(), bytes.NewReader(newMP3([]uint32{200, 0})), "mp3")
+	require.NoError(t, err)
+	require.Equal(t, []float64{1, 1, 1, 1, 1, 0, 0, 0, 0, 0}, metadata.Peaks)
+}
+
+func TestAnalyzer_AnalyzeAudio_Invalid(t *testing.T) {
+	analyzer := audiometa.NewAnalyzer(peakCount)
+
+	for _, extension := range []string{"wav", "mp3", "m4a", "ogg"} {
+		t.Run(extension, func(t *testing.T) {
+			_, err := analyzer.AnalyzeAudio(context.Background(), bytes.NewReader([]byte("not an audio file at all")), extension)
+			require.ErrorIs(t, err, domainerrors.ErrInvalidAudio)
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package audiometa
+
+import (
+	"bytes"
+	"encoding/binary"
+	"unicode/utf16"
+
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+)
+
+const id3v1Size = 128
+
+// id3v2Size は先頭の ID3v2 タグのバイト数を返します。タグがない場合は 0 です
+func id3v2Size(data []byte) int {
+	if len(data) < 10 || string(data[0:3]) != "ID3" {
+		return 0
+	}
+	size := 10 + synchsafe(data[6:10])
+	if data[5]&0x10 != 0 {
+		// フッター
+		size += 10
+	}
+	return min(size, len(data))
+}
+
+// synchsafe は各バイトの下位 7 ビットだけを使う整数を読みます
+func synchsafe(b []byte) int {
+	var v int
+	for _, c := range b {
+		v = v<<7 | int(c&0x7F)
+	}
+	return v
+}
+
+// removeUnsync は同期信号と間違えないよう 0xFF の後に挟まれた 0x00 を取り除きます
+func removeUnsync(b []byte) []byte {
+	return bytes.ReplaceAll(b, []byte{0xFF, 0x00}, []byte{0xFF})
+}
+
+// parseID3v2 は ID3v2.2〜2.4 のタグから曲名とアーティスト名を読みます
+func parseID3v2(data []byte, metadata *entity.AudioMetadata) {
+	size := id3v2Size(data)
+	if size == 0 {
+		return
+	}
+	version := data[3]
+	flags := data[5]
+	tag := data[10:min(10+synchsafe(data[6:10]), len(data))]
+	if flags&0x80 != 0 && version < 4 {
+		tag = removeUnsync(tag)
+	}
+	if flags&0x40 != 0 && version >= 3 && len(tag) >= 4 {
+		// 拡張ヘッダーを読み飛ばす
+		extended := int(binary.BigEndian.Uint32(tag[0:4])) + 4
+		if version == 4 {
+			extended = synchsafe(tag[0:4])
+		}
+		if extended > len(tag) {
+			return
+		}
+		tag = tag[extended:]
+	}
+
+	titleID, artistID, headerSize := "TIT2", "TPE1", 10
+	if version == 2 {
+		titleID, artistID, headerSize = "TT2", "TP1", 6
+	}
+	for pos := 0; pos+headerSize <= len(tag); {
+		var id string
+		var frameSize int
+		var frameFlags uint16
+		switch version {
+		case 2:
+			id = string(tag[pos : pos+3])
+			frameSize = int(tag[pos+3])<<16 | int(tag[pos+4])<<8 | int(tag[pos+5])
+		case 3:
+			id = string(tag[pos : pos+4])
+			frameSize = int(binary.BigEndian.Uint32(tag[pos+4 : pos+8]))
+			frameFlags = binary.BigEndian.Uint16(tag[pos+8 : pos+10])
+		default:
+			id = string(tag[pos : pos+4])
+			frameSize = synchsafe(tag[pos+4 : pos+8])
+			frameFlags = binary.BigEndian.Uint16(tag[pos+8 : pos+10])
+		}
+		// パディングに入った
+		if id[0] == 0 || frameSize <= 0 || frameSize > len(tag)-pos-headerSize {
+			return
+		}
+		body := tag[pos+headerSize : pos+headerSize+frameSize]
+		pos += headerSize + frameSize
+
+		if id != titleID && id != artistID {
+			continue
+		}
+		body, ok := frameBody(version, frameFlags, body)
+		if !ok {
+			continue
+		}
+		if id == titleID {
+			metadata.Title = decodeID3Text(body)
+		} else {
+			metadata.Artist = decodeID3Text(body)
+		}
+	}
+}
+
+// frameBody はフレームのフラグに応じて本体を取り出します。圧縮・暗号化されたフレームは読まないため false を返します
+func frameBody(version byte, flags uint16, body []byte) ([]byte, bool) {
+	switch version {
+	case 3:
+		if flags&0x00C0 != 0 {
+			return nil, false
+		}
+	case 4:
+		if flags&0x000C != 0 {
+			return nil, false
+		}
+		if flags&0x0002 != 0 {
+			body = removeUnsync(body)
+		}
+		if flags&0x0001 != 0 {
+			// 展開後のサイズ
+			if len(body) < 4 {
+				return nil, false
+			}
+			body = body[4:]
+		}
+	}
+	return body, true
+}
+
+// ID3v2 のテキストフレームの文字コード
+const (
+	id3EncodingLatin1  = 0
+	id3EncodingUTF16   = 1
+	id3EncodingUTF16BE = 2
+	id3EncodingUTF8    = 3
+)
+
+// decodeID3Text はテキストフレームを UTF-8 にします。複数の値がある場合は最初の値を返します
+func decodeID3Text(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	text := body[1:]
+	switch body[0] {
+	case id3EncodingUTF16, id3EncodingUTF16BE:
+		bigEndian := body[0] == id3EncodingUTF16BE
+		if len(text) >= 2 && text[0] == 0xFF && text[1] == 0xFE {
+			bigEndian, text = false, text[2:]
+		} else if len(text) >= 2 && text[0] == 0xFE && text[1] == 0xFF {
+			bigEndian, text = true, text[2:]
+		}
+		units := make([]uint16, 0, len(text)/2)
+		for i := 0; i+1 < len(text); i += 2 {
+			if bigEndian {
+				units = append(units, binary.BigEndian.Uint16(text[i:i+2]))
+			} else {
+				units = append(units, binary.LittleEndian.Uint16(text[i:i+2]))
+			}
+		}
+		return cleanText(string(utf16.Decode(units)))
+	case id3EncodingUTF8:
+		return cleanText(string(text))
+	default:
+		return cleanText(latin1(text))
+	}
+}
+
+// parseID3v1 は末尾の ID3v1 タグから曲名とアーティスト名を読みます。ID3v2 で読めた値は上書きしません
+func parseID3v1(data []byte, metadata *entity.AudioMetadata) {
+	if !hasID3v1(data) {
+		return
+	}
+	tag := data[len(data)-id3v1Size:]
+	if metadata.Title == "" {
+		metadata.Title = cleanText(latin1(bytes.TrimRight(tag[3:33], "\x00 ")))
+	}
+	if metadata.Artist == "" {
+		metadata.Artist = cleanText(latin1(bytes.TrimRight(tag[33:63], "\x00 ")))
+	}
+}
+
+func hasID3v1(data []byte) bool {
+	return len(data) >= id3v1Size && string(data[len(data)-id3v1Size:len(data)-id3v1Size+3]) == "TAG"
+}
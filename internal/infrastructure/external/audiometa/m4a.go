@@ -0,0 +1,344 @@
+package audiometa
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+)
+
+// eachBox は MP4 のボックスを順に fn へ渡します。壊れたボックスがあればそこで止めます
+func eachBox(data []byte, fn func(boxType string, body []byte) bool) {
+	for pos := 0; pos+8 <= len(data); {
+		size := int64(binary.BigEndian.Uint32(data[pos : pos+4]))
+		boxType := string(data[pos+4 : pos+8])
+		header := int64(8)
+		switch size {
+		case 0:
+			// ファイルの最後まで続く
+			size = int64(len(data) - pos)
+		case 1:
+			if pos+16 > len(data) {
+				return
+			}
+			size = int64(binary.BigEndian.Uint64(data[pos+8 : pos+16]))
+			header = 16
+		}
+		if size < header || size > int64(len(data)-pos) {
+			return
+		}
+		if !fn(boxType, data[pos+int(header):pos+int(size)]) {
+			return
+		}
+		pos += int(size)
+	}
+}
+
+// findBox は path の順にボックスを辿り、最初に見つかったボックスの本体を返します
+func findBox(data []byte, path ...string) []byte {
+	if len(path) == 0 {
+		return data
+	}
+	var found []byte
+	eachBox(data, func(boxType string, body []byte) bool {
+		if boxType != path[0] {
+			return true
+		}
+		found = findBox(body, path[1:]...)
+		return found == nil
+	})
+	return found
+}
+
+func (a *Analyzer) analyzeM4A(ctx context.Context, data []byte) (*entity.AudioMetadata, error) {
+	moov := findBox(data, "moov")
+	if moov == nil {
+		return nil, fmt.Errorf("%w: missing moov box", domainerrors.ErrInvalidAudio)
+	}
+	var mdia []byte
+	eachBox(moov, func(boxType string, body []byte) bool {
+		if boxType != "trak" {
+			return true
+		}
+		hdlr := findBox(body, "mdia", "hdlr")
+		if len(hdlr) >= 12 && string(hdlr[8:12]) == "soun" {
+			mdia = findBox(body, "mdia")
+			return false
+		}
+		return true
+	})
+	if mdia == nil {
+		return nil, fmt.Errorf("%w: missing sound track", domainerrors.ErrInvalidAudio)
+	}
+
+	metadata := &entity.AudioMetadata{}
+	parseITunesTags(findBox(moov, "udta", "meta"), metadata)
+	timescale, duration, ok := parseMediaHeader(findBox(mdia, "mdhd"))
+	if !ok {
+		return nil, fmt.Errorf("%w: invalid mdhd box", domainerrors.ErrInvalidAudio)
+	}
+	metadata.Duration = time.Duration(float64(duration) / float64(timescale) * float64(time.Second))
+	metadata.SampleRate = int(timescale)
+
+	stbl := findBox(mdia, "minf", "stbl")
+	codec, entry := sampleEntry(findBox(stbl, "stsd"))
+	if len(entry) >= 28 {
+		metadata.Channels = int(binary.BigEndian.Uint16(entry[16:18]))
+		if sampleRate := int(binary.BigEndian.Uint16(entry[24:26])); sampleRate > 0 {
+			metadata.SampleRate = sampleRate
+		}
+	}
+
+	sizes := sampleSizes(findBox(stbl, "stsz"))
+	var total int64
+	for _, size := range sizes {
+		total += int64(size)
+	}
+	if seconds := metadata.Duration.Seconds(); seconds > 0 {
+		metadata.Bitrate = int(float64(total*8) / seconds)
+	}
+
+	if codec != "mp4a" || len(sizes) == 0 {
+		// ALAC などの AAC 以外の形式は波形を求めない
+		return metadata, nil
+	}
+	offsets := sampleOffsets(stbl, sizes, int64(len(data)))
+	if offsets == nil {
+		return metadata, nil
+	}
+	p := newPeaks(len(sizes), a.peakCount)
+	for i, size := range sizes {
+		if i&0x3FFF == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		start := offsets[i]
+		if int64(size) > int64(len(data))-start {
+			break
+		}
+		p.add(i, aacFrameAmplitude(data[start:start+int64(size)]))
+	}
+	metadata.Peaks = p.result(true)
+	return metadata, nil
+}
+
+// parseMediaHeader は mdhd ボックスからタイムスケールと長さを読みます
+func parseMediaHeader(mdhd []byte) (timescale uint32, duration uint64, ok bool) {
+	switch {
+	case len(mdhd) >= 24 && mdhd[0] == 0:
+		timescale = binary.BigEndian.Uint32(mdhd[12:16])
+		duration = uint64(binary.BigEndian.Uint32(mdhd[16:20]))
+	case len(mdhd) >= 32 && mdhd[0] == 1:
+		timescale = binary.BigEndian.Uint32(mdhd[20:24])
+		duration = binary.BigEndian.Uint64(mdhd[24:32])
+	default:
+		return 0, 0, false
+	}
+	return timescale, duration, timescale > 0
+}
+
+// sampleEntry は stsd ボックスの最初のサンプルエントリの形式と本体を返します
+func sampleEntry(stsd []byte) (codec string, entry []byte) {
+	if len(stsd) < 8 {
+		return "", nil
+	}
+	eachBox(stsd[8:], func(boxType string, body []byte) bool {
+		codec, entry = boxType, body
+		return false
+	})
+	return codec, entry
+}
+
+// maxSamples は読むサンプルの数の上限です。48kHz の AAC で 1 日分を超える数は壊れたファイルとみなします
+const maxSamples = 1 << 22
+
+// sampleSizes は stsz ボックスからサンプルごとのバイト数を読みます
+func sampleSizes(stsz []byte) []uint32 {
+	if len(stsz) < 12 {
+		return nil
+	}
+	size := binary.BigEndian.Uint32(stsz[4:8])
+	count := int(binary.BigEndian.Uint32(stsz[8:12]))
+	if count > maxSamples || (size == 0 && count > (len(stsz)-12)/4) {
+		return nil
+	}
+	sizes := make([]uint32, count)
+	for i := range sizes {
+		if size != 0 {
+			sizes[i] = size
+		} else {
+			sizes[i] = binary.BigEndian.Uint32(stsz[12+i*4 : 16+i*4])
+		}
+	}
+	return sizes
+}
+
+// sampleOffsets はチャンクの位置 (stco・co64) とチャンクごとのサンプル数 (stsc) から、サンプルごとのファイル上の位置を求めます。
+// ファイルの外を指すチャンクがある場合は壊れたファイルとみなして nil を返します
+func sampleOffsets(stbl []byte, sizes []uint32, dataLen int64) []int64 {
+	var chunks []int64
+	if stco := findBox(stbl, "stco"); len(stco) >= 8 {
+		n := int(binary.BigEndian.Uint32(stco[4:8]))
+		for i := 0; i < n && 12+i*4 <= len(stco); i++ {
+			chunks = append(chunks, int64(binary.BigEndian.Uint32(stco[8+i*4:12+i*4])))
+		}
+	} else if co64 := findBox(stbl, "co64"); len(co64) >= 8 {
+		n := int(binary.BigEndian.Uint32(co64[4:8]))
+		for i := 0; i < n && 16+i*8 <= len(co64); i++ {
+			chunks = append(chunks, int64(binary.BigEndian.Uint64(co64[8+i*8:16+i*8])))
+		}
+	}
+	stsc := findBox(stbl, "stsc")
+	if len(chunks) == 0 || len(stsc) < 8 {
+		return nil
+	}
+	type run struct {
+		firstChunk      int
+		samplesPerChunk int
+	}
+	var runs []run
+	n := int(binary.BigEndian.Uint32(stsc[4:8]))
+	for i := 0; i < n && 20+i*12 <= len(stsc); i++ {
+		entry := stsc[8+i*12:]
+		runs = append(runs, run{
+			firstChunk:      int(binary.BigEndian.Uint32(entry[0:4])),
+			samplesPerChunk: int(binary.BigEndian.Uint32(entry[4:8])),
+		})
+	}
+	if len(runs) == 0 {
+		return nil
+	}
+
+	count := len(sizes)
+	offsets := make([]int64, 0, count)
+	r := 0
+	for chunk := range chunks {
+		// stsc のチャンク番号は 1 から始まる
+		for r+1 < len(runs) && runs[r+1].firstChunk <= chunk+1 {
+			r++
+		}
+		offset := chunks[chunk]
+		if offset < 0 || offset > dataLen {
+			return nil
+		}
+		for j := 0; j < runs[r].samplesPerChunk && len(offsets) < count; j++ {
+			offsets = append(offsets, offset)
+			offset += int64(sizes[len(offsets)-1])
+		}
+	}
+	if len(offsets) < count {
+		return nil
+	}
+	return offsets
+}
+
+// AAC の raw_data_block の要素
+const (
+	aacSCE = 0
+	aacCPE = 1
+	aacLFE = 3
+	aacDSE = 4
+	aacFIL = 6
+)
+
+// aacFrameAmplitude は AAC のフレームの最初のチャンネルの global_gain から、フレームの振幅の目安を求めます
+func aacFrameAmplitude(frame []byte) float64 {
+	r := &bitReader{data: frame}
+	for {
+		switch r.read(3) {
+		case aacSCE, aacLFE:
+			r.skip(4)
+		case aacCPE:
+			r.skip(4)
+			if r.read(1) == 1 {
+				// common_window の場合は ics_info と M/S の情報が global_gain の前にある
+				maxSfb, groups, ok := readICSInfo(r)
+				if !ok {
+					return 0
+				}
+				if r.read(2) == 1 {
+					r.skip(maxSfb * groups)
+				}
+			}
+		case aacDSE:
+			r.skip(4)
+			align := r.read(1) == 1
+			count := int(r.read(8))
+			if count == 255 {
+				count += int(r.read(8))
+			}
+			if align {
+				r.pos = (r.pos + 7) &^ 7
+			}
+			r.skip(count * 8)
+			continue
+		case aacFIL:
+			count := int(r.read(4))
+			if count == 15 {
+				count += int(r.read(8)) - 1
+			}
+			r.skip(count * 8)
+			continue
+		default:
+			return 0
+		}
+		globalGain := r.read(8)
+		if !r.ok() || globalGain == 0 {
+			return 0
+		}
+		return gainAmplitude(globalGain)
+	}
+}
+
+// readICSInfo は AAC-LC の ics_info を読み、スケールファクターバンドの数とウィンドウのグループの数を返します
+func readICSInfo(r *bitReader) (maxSfb int, groups int, ok bool) {
+	r.skip(1)
+	windowSequence := r.read(2)
+	r.skip(1)
+	if windowSequence == 2 {
+		// EIGHT_SHORT_SEQUENCE はグループにまとめられなかったウィンドウの数だけグループが増える
+		maxSfb = int(r.read(4))
+		grouping := r.read(7)
+		groups = 1
+		for i := 6; i >= 0; i-- {
+			if grouping&(1<<i) == 0 {
+				groups++
+			}
+		}
+		return maxSfb, groups, true
+	}
+	maxSfb = int(r.read(6))
+	// 予測は AAC Main だけが使い、読み飛ばせないため扱わない
+	if r.read(1) == 1 {
+		return 0, 0, false
+	}
+	return maxSfb, 1, true
+}
+
+// parseITunesTags は meta ボックスの ilst から曲名とアーティスト名を読みます
+func parseITunesTags(meta []byte, metadata *entity.AudioMetadata) {
+	if len(meta) < 8 {
+		return
+	}
+	// meta ボックスはバージョンとフラグを持つが、持たないものを書き出すツールもある
+	if string(meta[4:8]) != "hdlr" {
+		meta = meta[4:]
+	}
+	eachBox(findBox(meta, "ilst"), func(boxType string, body []byte) bool {
+		data := findBox(body, "data")
+		if len(data) < 8 {
+			return true
+		}
+		switch boxType {
+		case "\xa9nam":
+			metadata.Title = cleanText(string(data[8:]))
+		case "\xa9ART":
+			metadata.Artist = cleanText(string(data[8:]))
+		}
+		return true
+	})
+}
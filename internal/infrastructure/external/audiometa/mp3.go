@@ -0,0 +1,213 @@
+package audiometa
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+)
+
+// mp3Bitrates は Layer III のビットレート (kbps) です。[0] が MPEG-1、[1] が MPEG-2・2.5 の値です
+var mp3Bitrates = [2][16]int{
+	{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 0},
+	{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0},
+}
+
+// mp3SampleRates はヘッダーのバージョンのビットごとのサンプルレートです。1 は予約済みです
+var mp3SampleRates = [4][3]int{
+	{11025, 12000, 8000},
+	{},
+	{22050, 24000, 16000},
+	{44100, 48000, 32000},
+}
+
+type mp3Frame struct {
+	mpeg1      bool
+	crc        bool
+	mono       bool
+	sampleRate int
+	size       int
+	samples    int
+}
+
+// sideInfoSize はヘッダーの後にあるサイド情報のバイト数を返します
+func (f *mp3Frame) sideInfoSize() int {
+	switch {
+	case f.mpeg1 && f.mono:
+		return 17
+	case f.mpeg1:
+		return 32
+	case f.mono:
+		return 9
+	default:
+		return 17
+	}
+}
+
+// parseMP3Header は Layer III のフレームヘッダーを読みます
+func parseMP3Header(b []byte) (*mp3Frame, bool) {
+	if len(b) < 4 || b[0] != 0xFF || b[1]&0xE0 != 0xE0 {
+		return nil, false
+	}
+	version := (b[1] >> 3) & 0x03
+	layer := (b[1] >> 1) & 0x03
+	bitrateIndex := b[2] >> 4
+	sampleRateIndex := (b[2] >> 2) & 0x03
+	if version == 1 || layer != 1 || bitrateIndex == 0 || bitrateIndex == 15 || sampleRateIndex == 3 {
+		return nil, false
+	}
+
+	frame := &mp3Frame{
+		mpeg1:      version == 3,
+		crc:        b[1]&0x01 == 0,
+		mono:       b[3]>>6 == 3,
+		sampleRate: mp3SampleRates[version][sampleRateIndex],
+	}
+	padding := int((b[2] >> 1) & 0x01)
+	if frame.mpeg1 {
+		frame.samples = 1152
+		frame.size = 144*mp3Bitrates[0][bitrateIndex]*1000/frame.sampleRate + padding
+	} else {
+		frame.samples = 576
+		frame.size = 72*mp3Bitrates[1][bitrateIndex]*1000/frame.sampleRate + padding
+	}
+	return frame, true
+}
+
+func (a *Analyzer) analyzeMP3(ctx context.Context, data []byte) (*entity.AudioMetadata, error) {
+	metadata := &entity.AudioMetadata{}
+	parseID3v2(data, metadata)
+	parseID3v1(data, metadata)
+
+	end := len(data)
+	if hasID3v1(data) {
+		end -= id3v1Size
+	}
+	var gains []float64
+	var first *mp3Frame
+	var samples, frameBytes int
+	for pos := id3v2Size(data); pos+4 <= end; {
+		frame, ok := parseMP3Header(data[pos:end])
+		if !ok || pos+frame.size > end || (first != nil && frame.sampleRate != first.sampleRate) || !nextIsFrame(data[pos+frame.size:end], frame) {
+			// 同期が外れたため 1 バイトずつ次のフレームを探す
+			pos++
+			continue
+		}
+		body := data[pos : pos+frame.size]
+		pos += frame.size
+		if first == nil {
+			first = frame
+			metadata.SampleRate = frame.sampleRate
+			metadata.Channels = 2
+			if frame.mono {
+				metadata.Channels = 1
+			}
+			// エンコーダーが先頭に置く VBR の情報のフレームは音声を含まない
+			if isVBRInfoFrame(body, frame) {
+				continue
+			}
+		}
+		if len(gains)&0x3FFF == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		gains = append(gains, mp3FrameAmplitude(body, frame))
+		samples += frame.samples
+		frameBytes += frame.size
+	}
+	if first == nil || samples == 0 {
+		return nil, fmt.Errorf("%w: no mpeg audio frames", domainerrors.ErrInvalidAudio)
+	}
+
+	metadata.Duration = time.Duration(int64(samples) * int64(time.Second) / int64(first.sampleRate))
+	metadata.Bitrate = int(int64(frameBytes) * 8 * int64(first.sampleRate) / int64(samples))
+	p := newPeaks(len(gains), a.peakCount)
+	for i, amplitude := range gains {
+		p.add(i, amplitude)
+	}
+	metadata.Peaks = p.result(true)
+	return metadata, nil
+}
+
+// nextIsFrame は誤って同期したものでないか、次のフレームが続くかで確かめます
+func nextIsFrame(rest []byte, frame *mp3Frame) bool {
+	if len(rest) < 4 {
+		return true
+	}
+	// 末尾の APE タグや Lyrics3 タグ
+	if string(rest[0:3]) == "APE" || string(rest[0:3]) == "LYR" || string(rest[0:3]) == "TAG" {
+		return true
+	}
+	next, ok := parseMP3Header(rest)
+	return ok && next.sampleRate == frame.sampleRate
+}
+
+// isVBRInfoFrame は Xing・Info・VBRI のヘッダーを持つフレームかを返します
+func isVBRInfoFrame(body []byte, frame *mp3Frame) bool {
+	offset := 4 + frame.sideInfoSize()
+	if frame.crc {
+		offset += 2
+	}
+	if len(body) >= offset+4 {
+		tag := string(body[offset : offset+4])
+		if tag == "Xing" || tag == "Info" {
+			return true
+		}
+	}
+	return len(body) >= 40 && string(body[36:40]) == "VBRI"
+}
+
+// mp3FrameAmplitude はサイド情報のグラニュールごとの global_gain から、フレームの振幅の目安を求めます。
+// 符号化されたデータがない (part2_3_length が 0 の) グラニュールは無音として扱います
+func mp3FrameAmplitude(body []byte, frame *mp3Frame) float64 {
+	offset := 4
+	if frame.crc {
+		offset += 2
+	}
+	if len(body) < offset+frame.sideInfoSize() {
+		return 0
+	}
+	channels := 2
+	if frame.mono {
+		channels = 1
+	}
+
+	r := &bitReader{data: body[offset : offset+frame.sideInfoSize()]}
+	granules, granuleBits := 1, 63
+	if frame.mpeg1 {
+		granules, granuleBits = 2, 59
+		// main_data_begin・private_bits・scfsi
+		if frame.mono {
+			r.skip(9 + 5 + 4)
+		} else {
+			r.skip(9 + 3 + 8)
+		}
+	} else {
+		// main_data_begin・private_bits
+		if frame.mono {
+			r.skip(8 + 1)
+		} else {
+			r.skip(8 + 2)
+		}
+	}
+
+	var amplitude float64
+	for gr := 0; gr < granules; gr++ {
+		for ch := 0; ch < channels; ch++ {
+			part23Length := r.read(12)
+			r.skip(9)
+			globalGain := r.read(8)
+			r.skip(granuleBits - 29)
+			if part23Length > 0 {
+				amplitude = max(amplitude, gainAmplitude(globalGain))
+			}
+		}
+	}
+	if !r.ok() {
+		return 0
+	}
+	return amplitude
+}
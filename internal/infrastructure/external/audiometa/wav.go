@@ -0,0 +1,168 @@
+package audiometa
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+)
+
+// WAV の fmt チャンクのフォーマット
+const (
+	wavFormatPCM        = 1
+	wavFormatFloat      = 3
+	wavFormatExtensible = 0xFFFE
+)
+
+type wavFormat struct {
+	format        uint16
+	channels      int
+	sampleRate    int
+	blockAlign    int
+	bitsPerSample int
+}
+
+func (a *Analyzer) analyzeWAV(ctx context.Context, data []byte) (*entity.AudioMetadata, error) {
+	if len(data) < 12 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WAVE" {
+		return nil, fmt.Errorf("%w: not a wave file", domainerrors.ErrInvalidAudio)
+	}
+
+	var format *wavFormat
+	var samples []byte
+	metadata := &entity.AudioMetadata{}
+	for pos := 12; pos+8 <= len(data); {
+		id := string(data[pos : pos+4])
+		size := int(binary.LittleEndian.Uint32(data[pos+4 : pos+8]))
+		body := data[pos+8:]
+		// 書き込み中に途切れたファイルはチャンクのサイズが実際より大きいことがある
+		if size > len(body) {
+			size = len(body)
+		}
+		body = body[:size]
+
+		switch id {
+		case "fmt ":
+			format = parseWAVFormat(body)
+		case "data":
+			samples = body
+		case "LIST":
+			if len(body) >= 4 && string(body[0:4]) == "INFO" {
+				parseWAVInfo(body[4:], metadata)
+			}
+		}
+		// チャンクは 2 バイト単位に揃えられている
+		pos += 8 + size + size&1
+	}
+	if format == nil || samples == nil || format.channels <= 0 || format.sampleRate <= 0 || format.blockAlign <= 0 {
+		return nil, fmt.Errorf("%w: missing fmt or data chunk", domainerrors.ErrInvalidAudio)
+	}
+
+	frames := len(samples) / format.blockAlign
+	metadata.SampleRate = format.sampleRate
+	metadata.Channels = format.channels
+	metadata.Bitrate = format.sampleRate * format.blockAlign * 8
+	metadata.Duration = time.Duration(int64(frames) * int64(time.Second) / int64(format.sampleRate))
+
+	decode := sampleDecoder(format)
+	if decode == nil || frames == 0 {
+		// 圧縮された WAV は波形を求めない
+		return metadata, nil
+	}
+	width := format.blockAlign / format.channels
+	p := newPeaks(frames, a.peakCount)
+	for frame := 0; frame < frames; frame++ {
+		if frame&0xFFFF == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		var peak float64
+		offset := frame * format.blockAlign
+		for ch := 0; ch < format.channels; ch++ {
+			start := offset + ch*width
+			peak = max(peak, math.Abs(decode(samples[start:start+width])))
+		}
+		p.add(frame, peak)
+	}
+	metadata.Peaks = p.result(false)
+	return metadata, nil
+}
+
+func parseWAVFormat(body []byte) *wavFormat {
+	if len(body) < 16 {
+		return nil
+	}
+	format := &wavFormat{
+		format:        binary.LittleEndian.Uint16(body[0:2]),
+		channels:      int(binary.LittleEndian.Uint16(body[2:4])),
+		sampleRate:    int(binary.LittleEndian.Uint32(body[4:8])),
+		blockAlign:    int(binary.LittleEndian.Uint16(body[12:14])),
+		bitsPerSample: int(binary.LittleEndian.Uint16(body[14:16])),
+	}
+	if format.format == wavFormatExtensible && len(body) >= 26 {
+		// WAVE_FORMAT_EXTENSIBLE は SubFormat の GUID の先頭にフォーマットがある
+		format.format = binary.LittleEndian.Uint16(body[24:26])
+	}
+	return format
+}
+
+// sampleDecoder は 1 チャンネル分のサンプルを -1〜1 の値にする関数を返します。対応していない形式の場合は nil です
+func sampleDecoder(format *wavFormat) func(b []byte) float64 {
+	width := format.blockAlign / format.channels
+	switch {
+	case format.format == wavFormatPCM && width == 1:
+		// 8 ビットの PCM だけは符号なし
+		return func(b []byte) float64 { return (float64(b[0]) - 128) / 128 }
+	case format.format == wavFormatPCM && width >= 2 && width <= 4:
+		// 有効なビットは上位に寄せられているため、サンプルの幅で割る
+		scale := math.Ldexp(1, width*8-1)
+		return func(b []byte) float64 {
+			var v int32
+			for i := width - 1; i >= 0; i-- {
+				v = v<<8 | int32(b[i])
+			}
+			v = v << (32 - width*8) >> (32 - width*8)
+			return float64(v) / scale
+		}
+	case format.format == wavFormatFloat && width == 4:
+		return func(b []byte) float64 {
+			return finite(float64(math.Float32frombits(binary.LittleEndian.Uint32(b))))
+		}
+	case format.format == wavFormatFloat && width == 8:
+		return func(b []byte) float64 {
+			return finite(math.Float64frombits(binary.LittleEndian.Uint64(b)))
+		}
+	}
+	return nil
+}
+
+// finite は浮動小数点のサンプルのうち、NaN と無限大を 0 にします
+func finite(v float64) float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0
+	}
+	return v
+}
+
+// parseWAVInfo は LIST チャンクの INFO から曲名とアーティスト名を読みます
+func parseWAVInfo(body []byte, metadata *entity.AudioMetadata) {
+	for pos := 0; pos+8 <= len(body); {
+		id := string(body[pos : pos+4])
+		size := int(binary.LittleEndian.Uint32(body[pos+4 : pos+8]))
+		if size > len(body)-pos-8 {
+			return
+		}
+		value := cleanText(latin1(body[pos+8 : pos+8+size]))
+		switch id {
+		case "INAM":
+			metadata.Title = value
+		case "IART":
+			metadata.Artist = value
+		}
+		pos += 8 + size + size&1
+	}
+}
@@ -0,0 +1,31 @@
+package blobstore
+
+import (
+	"mime"
+	"path"
+	"strings"
+)
+
+// contentEncodings は圧縮済みのファイルの拡張子と Content-Encoding です。
+// Unity の WebGL ビルドは .br や .gz で圧縮したファイルをブラウザに展開させます
+var contentEncodings = map[string]string{
+	".br": "br",
+	".gz": "gzip",
+}
+
+// ContentHeaders はキーの拡張子から Content-Type と Content-Encoding を決めます。
+// 圧縮済みのファイルは圧縮前の拡張子から Content-Type を決め、圧縮されていない場合の Content-Encoding は空です
+func ContentHeaders(key string) (contentType string, contentEncoding string) {
+	name := path.Base(key)
+	ext := strings.ToLower(path.Ext(name))
+	if encoding, ok := contentEncodings[ext]; ok {
+		contentEncoding = encoding
+		name = strings.TrimSuffix(name, path.Ext(name))
+		ext = strings.ToLower(path.Ext(name))
+	}
+	contentType = mime.TypeByExtension(ext)
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return contentType, contentEncoding
+}
@@ -0,0 +1,32 @@
+package blobstore_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/simesaba80/toybox-back/internal/infrastructure/external/blobstore"
+)
+
+func TestContentHeaders(t *testing.T) {
+	tests := []struct {
+		key          string
+		wantType     string
+		wantEncoding string
+	}{
+		{key: "play/abc/index.html", wantType: "text/html; charset=utf-8"},
+		{key: "play/abc/Build/game.wasm", wantType: "application/wasm"},
+		{key: "play/abc/Build/game.wasm.br", wantType: "application/wasm", wantEncoding: "br"},
+		{key: "play/abc/Build/game.framework.js.gz", wantType: "text/javascript; charset=utf-8", wantEncoding: "gzip"},
+		{key: "play/abc/Build/game.data.br", wantType: "application/octet-stream", wantEncoding: "br"},
+		{key: "play/abc/Build/game.data.unityweb", wantType: "application/octet-stream"},
+		{key: "play/abc/Build/GAME.WASM.BR", wantType: "application/wasm", wantEncoding: "br"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			contentType, contentEncoding := blobstore.ContentHeaders(tt.key)
+			require.Equal(t, tt.wantType, contentType)
+			require.Equal(t, tt.wantEncoding, contentEncoding)
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+)
+
+// listPageSize は List で一度に fn へ渡すファイルの数
+const listPageSize = 1000
+
+// tempFilePrefix は書き込み中のファイル名の接頭辞。List では返さない
+const tempFilePrefix = ".tmp-"
+
+// LocalStore はローカルディスクのディレクトリにファイルを保存します。
+// 開発やテストで S3 を使わずに済ませるためのもので、ファイルは Echo の静的ファイル配信で公開します。
+// Content-Type は保存せず、静的ファイル配信と同じくキーの拡張子から判断します。
+type LocalStore struct {
+	root    string
+	baseURL string
+}
+
+// NewLocalStore は root 以下にファイルを保存する LocalStore を作成します。
+// 公開 URL は baseURL/key の形になります
+func NewLocalStore(root string, baseURL string) *LocalStore {
+	return &LocalStore{
+		root:    root,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// pathOf はキーに対応するファイルのパスを返す。root の外を指すキーはエラーにする
+func (s *LocalStore) pathOf(key string) (string, error) {
+	name := filepath.FromSlash(key)
+	if !filepath.IsLocal(name) {
+		return "", fmt.Errorf("invalid blob key: %q", key)
+	}
+	return filepath.Join(s.root, name), nil
+}
+
+func (s *LocalStore) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
+	name, err := s.pathOf(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	// 書き込み途中のファイルが配信されないよう、別名で書いてから置き換える
+	tmp, err := os.CreateTemp(filepath.Dir(name), tempFilePrefix+"*")
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), name); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+// PutEncoded は Content-Encoding を保存できないため Put と同じです。
+// 配信時に ContentHeaders で拡張子から Content-Encoding を決めます
+func (s *LocalStore) PutEncoded(ctx context.Context, key string, body io.Reader, contentType string, contentEncoding string) error {
+	return s.Put(ctx, key, body, contentType)
+}
+
+func (s *LocalStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	name, err := s.pathOf(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, domainerrors.ErrBlobNotFound
+		}
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	return f, nil
+}
+
+func (s *LocalStore) Delete(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		name, err := s.pathOf(key)
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to delete file: %w", err)
+		}
+		s.removeEmptyDirs(filepath.Dir(name))
+	}
+	return nil
+}
+
+// removeEmptyDirs は dir から root の手前までの空になったディレクトリを削除する
+func (s *LocalStore) removeEmptyDirs(dir string) {
+	root := filepath.Clean(s.root)
+	for dir != root && strings.HasPrefix(dir, root+string(filepath.Separator)) {
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+func (s *LocalStore) Head(ctx context.Context, key string) (*entity.Blob, error) {
+	name, err := s.pathOf(key)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, domainerrors.ErrBlobNotFound
+		}
+		return nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+	if info.IsDir() {
+		return nil, domainerrors.ErrBlobNotFound
+	}
+	return s.blobOf(key, info), nil
+}
+
+func (s *LocalStore) blobOf(key string, info fs.FileInfo) *entity.Blob {
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return &entity.Blob{
+		Key:          key,
+		Size:         info.Size(),
+		ContentType:  contentType,
+		LastModified: info.ModTime(),
+	}
+}
+
+func (s *LocalStore) List(ctx context.Context, prefix string, fn func(blobs []*entity.Blob) error) error {
+	// prefix を含むディレクトリから辿り、キーが prefix で始まるファイルだけを返す
+	start := s.root
+	if dir := path.Dir(prefix); dir != "." && dir != "/" {
+		var err error
+		if start, err = s.pathOf(dir); err != nil {
+			return err
+		}
+	}
+	blobs := make([]*entity.Blob, 0, listPageSize)
+	var fnErr error
+	err := filepath.WalkDir(start, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), tempFilePrefix) {
+			return nil
+		}
+		rel, err := filepath.Rel(s.root, name)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		blobs = append(blobs, s.blobOf(key, info))
+		if len(blobs) == listPageSize {
+			if fnErr = fn(blobs); fnErr != nil {
+				return fnErr
+			}
+			blobs = make([]*entity.Blob, 0, listPageSize)
+		}
+		return nil
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to list files: %w", err)
+	}
+	if len(blobs) > 0 {
+		return fn(blobs)
+	}
+	return nil
+}
+
+// PresignGet はローカルのファイルに期限を付けられないため、公開 URL をそのまま返します
+func (s *LocalStore) PresignGet(ctx context.Context, key string, expires time.Duration) (string, error) {
+	if _, err := s.pathOf(key); err != nil {
+		return "", err
+	}
+	return s.URL(key), nil
+}
+
+func (s *LocalStore) URL(key string) string {
+	return s.baseURL + "/" + key
+}
@@ -0,0 +1,100 @@
+package blobstore_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/external/blobstore"
+)
+
+func TestLocalStore_PutGetHeadDelete(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	store := blobstore.NewLocalStore(root, "http://localhost:8080/storage/")
+
+	key := "dir/image/abc/origin.png"
+	require.NoError(t, store.Put(ctx, key, strings.NewReader("png data"), "image/png"))
+	require.FileExists(t, filepath.Join(root, "dir", "image", "abc", "origin.png"))
+	require.Equal(t, "http://localhost:8080/storage/dir/image/abc/origin.png", store.URL(key))
+
+	blob, err := store.Head(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, key, blob.Key)
+	require.Equal(t, int64(8), blob.Size)
+	require.Equal(t, "image/png", blob.ContentType)
+
+	body, err := store.Get(ctx, key)
+	require.NoError(t, err)
+	data, err := io.ReadAll(body)
+	require.NoError(t, body.Close())
+	require.NoError(t, err)
+	require.Equal(t, "png data", string(data))
+
+	presignedURL, err := store.PresignGet(ctx, key, 0)
+	require.NoError(t, err)
+	require.Equal(t, store.URL(key), presignedURL)
+
+	// 存在しないキーの削除はエラーにしない
+	require.NoError(t, store.Delete(ctx, key, "dir/image/missing/origin.png"))
+	_, err = store.Head(ctx, key)
+	require.ErrorIs(t, err, domainerrors.ErrBlobNotFound)
+	_, err = store.Get(ctx, key)
+	require.ErrorIs(t, err, domainerrors.ErrBlobNotFound)
+	// 空になったディレクトリも片付ける
+	require.NoDirExists(t, filepath.Join(root, "dir"))
+	require.DirExists(t, root)
+}
+
+func TestLocalStore_RejectsKeysOutsideRoot(t *testing.T) {
+	ctx := context.Background()
+	root := filepath.Join(t.TempDir(), "root")
+	store := blobstore.NewLocalStore(root, "http://localhost:8080/storage")
+
+	for _, key := range []string{"../escape.txt", "/etc/passwd", "dir/../../escape.txt"} {
+		require.Error(t, store.Put(ctx, key, strings.NewReader("x"), "text/plain"), key)
+		_, err := store.Get(ctx, key)
+		require.Error(t, err, key)
+	}
+	require.NoFileExists(t, filepath.Join(filepath.Dir(root), "escape.txt"))
+}
+
+func TestLocalStore_List(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	store := blobstore.NewLocalStore(root, "http://localhost:8080/storage")
+
+	keys := []string{"dir/image/a/origin.png", "dir/zip/b/origin.zip", "dir2/image/c/origin.png", "other.txt"}
+	for _, key := range keys {
+		require.NoError(t, store.Put(ctx, key, strings.NewReader(key), "application/octet-stream"))
+	}
+	// 書き込み途中のファイルは一覧に含めない
+	require.NoError(t, os.WriteFile(filepath.Join(root, "dir", "image", "a", ".tmp-123"), []byte("x"), 0o644))
+
+	var listed []*entity.Blob
+	require.NoError(t, store.List(ctx, "dir/", func(blobs []*entity.Blob) error {
+		listed = append(listed, blobs...)
+		return nil
+	}))
+	var listedKeys []string
+	for _, blob := range listed {
+		listedKeys = append(listedKeys, blob.Key)
+		require.Equal(t, int64(len(blob.Key)), blob.Size)
+	}
+	sort.Strings(listedKeys)
+	require.Equal(t, []string{"dir/image/a/origin.png", "dir/zip/b/origin.zip"}, listedKeys)
+
+	// 存在しないディレクトリは空の一覧になる
+	require.NoError(t, store.List(ctx, "missing/", func(blobs []*entity.Blob) error {
+		t.Fatalf("unexpected blobs: %v", blobs)
+		return nil
+	}))
+}
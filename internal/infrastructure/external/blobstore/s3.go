@@ -0,0 +1,283 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+)
+
+// deleteObjectsBatchSize は DeleteObjects で一度に削除できるオブジェクトの上限
+const deleteObjectsBatchSize = 1000
+
+// S3Store は S3 のバケットにファイルを保存します。
+// 直接アップロードとマルチパートアップロードにも対応しています。
+type S3Store struct {
+	client  *s3.Client
+	bucket  string
+	baseURL string
+}
+
+// NewS3Store は bucket にファイルを保存する S3Store を作成します。
+// 公開 URL は baseURL/bucket/key の形になります
+func NewS3Store(client *s3.Client, bucket string, baseURL string) *S3Store {
+	return &S3Store{
+		client:  client,
+		bucket:  bucket,
+		baseURL: baseURL,
+	}
+}
+
+func (s *S3Store) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		Body:        body,
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put object: %w", err)
+	}
+	return nil
+}
+
+func (s *S3Store) PutEncoded(ctx context.Context, key string, body io.Reader, contentType string, contentEncoding string) error {
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:          aws.String(s.bucket),
+		Key:             aws.String(key),
+		Body:            body,
+		ContentType:     aws.String(contentType),
+		ContentEncoding: aws.String(contentEncoding),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put object: %w", err)
+	}
+	return nil
+}
+
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var noSuchKey *s3types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, domainerrors.ErrBlobNotFound
+		}
+		return nil, fmt.Errorf("failed to get object: %w", err)
+	}
+	return out.Body, nil
+}
+
+func (s *S3Store) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 1 {
+		_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+			Bucket: aws.String(s.bucket),
+			Key:    aws.String(keys[0]),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete object: %w", err)
+		}
+		return nil
+	}
+	for start := 0; start < len(keys); start += deleteObjectsBatchSize {
+		end := min(start+deleteObjectsBatchSize, len(keys))
+		objects := make([]s3types.ObjectIdentifier, 0, end-start)
+		for _, key := range keys[start:end] {
+			objects = append(objects, s3types.ObjectIdentifier{Key: aws.String(key)})
+		}
+		out, err := s.client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(s.bucket),
+			Delete: &s3types.Delete{Objects: objects, Quiet: aws.Bool(true)},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete objects: %w", err)
+		}
+		if len(out.Errors) > 0 {
+			return fmt.Errorf("failed to delete %d objects: %s: %s", len(out.Errors), aws.ToString(out.Errors[0].Key), aws.ToString(out.Errors[0].Message))
+		}
+	}
+	return nil
+}
+
+func (s *S3Store) Head(ctx context.Context, key string) (*entity.Blob, error) {
+	out, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var notFound *s3types.NotFound
+		if errors.As(err, &notFound) {
+			return nil, domainerrors.ErrBlobNotFound
+		}
+		return nil, fmt.Errorf("failed to head object: %w", err)
+	}
+	return &entity.Blob{
+		Key:          key,
+		Size:         aws.ToInt64(out.ContentLength),
+		ContentType:  aws.ToString(out.ContentType),
+		LastModified: aws.ToTime(out.LastModified),
+	}, nil
+}
+
+func (s *S3Store) List(ctx context.Context, prefix string, fn func(blobs []*entity.Blob) error) error {
+	paginator := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(prefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list objects: %w", err)
+		}
+		blobs := make([]*entity.Blob, len(page.Contents))
+		for i, object := range page.Contents {
+			blobs[i] = &entity.Blob{
+				Key:          aws.ToString(object.Key),
+				Size:         aws.ToInt64(object.Size),
+				LastModified: aws.ToTime(object.LastModified),
+			}
+		}
+		if err := fn(blobs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *S3Store) PresignGet(ctx context.Context, key string, expires time.Duration) (string, error) {
+	req, err := s.presignClient().PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(expires))
+	if err != nil {
+		return "", fmt.Errorf("failed to presign get object: %w", err)
+	}
+	return req.URL, nil
+}
+
+func (s *S3Store) URL(key string) string {
+	return s.baseURL + "/" + s.bucket + "/" + key
+}
+
+func (s *S3Store) presignClient() *s3.PresignClient {
+	return s3.NewPresignClient(s.client, func(o *s3.PresignOptions) {
+		// 既定ではチェックサムのヘッダーも署名に含まれ、ブラウザからの PUT が通らなくなるため必要な場合だけにする
+		o.ClientOptions = append(o.ClientOptions, func(o *s3.Options) {
+			o.RequestChecksumCalculation = aws.RequestChecksumCalculationWhenRequired
+		})
+	})
+}
+
+func (s *S3Store) PresignPut(ctx context.Context, key string, contentType string, size int64, expires time.Duration) (string, error) {
+	// Content-Type と Content-Length を署名に含め、申告と異なるファイルを置けないようにする
+	req, err := s.presignClient().PresignPutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(s.bucket),
+		Key:           aws.String(key),
+		ContentType:   aws.String(contentType),
+		ContentLength: aws.Int64(size),
+	}, s3.WithPresignExpires(expires))
+	if err != nil {
+		return "", fmt.Errorf("failed to presign put object: %w", err)
+	}
+	return req.URL, nil
+}
+
+func (s *S3Store) CreateMultipart(ctx context.Context, key string, contentType string) (string, error) {
+	out, err := s.client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create multipart upload: %w", err)
+	}
+	return aws.ToString(out.UploadId), nil
+}
+
+func (s *S3Store) PresignPart(ctx context.Context, key string, multipartUploadID string, partNumber int32, size int64, expires time.Duration) (string, error) {
+	// パートの大きさを署名に含め、最後以外のパートが小さすぎて完了できなくなるのを防ぐ
+	req, err := s.presignClient().PresignUploadPart(ctx, &s3.UploadPartInput{
+		Bucket:        aws.String(s.bucket),
+		Key:           aws.String(key),
+		UploadId:      aws.String(multipartUploadID),
+		PartNumber:    aws.Int32(partNumber),
+		ContentLength: aws.Int64(size),
+	}, s3.WithPresignExpires(expires))
+	if err != nil {
+		return "", fmt.Errorf("failed to presign upload part: %w", err)
+	}
+	return req.URL, nil
+}
+
+func (s *S3Store) ListParts(ctx context.Context, key string, multipartUploadID string) ([]*entity.UploadedPart, error) {
+	paginator := s3.NewListPartsPaginator(s.client, &s3.ListPartsInput{
+		Bucket:   aws.String(s.bucket),
+		Key:      aws.String(key),
+		UploadId: aws.String(multipartUploadID),
+	})
+	var parts []*entity.UploadedPart
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			// 完了・中止したアップロードのパートは一覧できない
+			var noSuchUpload *s3types.NoSuchUpload
+			if errors.As(err, &noSuchUpload) {
+				return nil, domainerrors.ErrBlobNotFound
+			}
+			return nil, fmt.Errorf("failed to list parts: %w", err)
+		}
+		for _, part := range page.Parts {
+			parts = append(parts, &entity.UploadedPart{
+				PartNumber: aws.ToInt32(part.PartNumber),
+				ETag:       aws.ToString(part.ETag),
+				Size:       aws.ToInt64(part.Size),
+			})
+		}
+	}
+	return parts, nil
+}
+
+func (s *S3Store) CompleteMultipart(ctx context.Context, key string, multipartUploadID string, parts []*entity.UploadedPart) error {
+	completedParts := make([]s3types.CompletedPart, len(parts))
+	for i, part := range parts {
+		completedParts[i] = s3types.CompletedPart{
+			PartNumber: aws.Int32(part.PartNumber),
+			ETag:       aws.String(part.ETag),
+		}
+	}
+	_, err := s.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(s.bucket),
+		Key:             aws.String(key),
+		UploadId:        aws.String(multipartUploadID),
+		MultipartUpload: &s3types.CompletedMultipartUpload{Parts: completedParts},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to complete multipart upload: %w", err)
+	}
+	return nil
+}
+
+func (s *S3Store) AbortMultipart(ctx context.Context, key string, multipartUploadID string) error {
+	_, err := s.client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(s.bucket),
+		Key:      aws.String(key),
+		UploadId: aws.String(multipartUploadID),
+	})
+	if err != nil {
+		var noSuchUpload *s3types.NoSuchUpload
+		if errors.As(err, &noSuchUpload) {
+			return nil
+		}
+		return fmt.Errorf("failed to abort multipart upload: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package eventbroker
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+)
+
+// subscriberBufferSize は購読者ごとに溜めておけるイベントの数です。
+// これを超えて受信が遅れた購読者は切断し、Last-Event-IDでの再接続に任せます。
+const subscriberBufferSize = 32
+
+type subscriber struct {
+	ch chan *entity.Event
+}
+
+// MemoryBroker はプロセス内でイベントを配信するEventBrokerの実装です。
+// 接続の再開に備えて、直近historySize件のイベントを保持します。
+type MemoryBroker struct {
+	mu          sync.Mutex
+	seq         uint64
+	history     []*entity.Event
+	historySize int
+	subscribers map[uuid.UUID]map[*subscriber]struct{}
+	closed      bool
+}
+
+func NewMemoryBroker(historySize int) *MemoryBroker {
+	return &MemoryBroker{
+		history:     make([]*entity.Event, 0, historySize),
+		historySize: historySize,
+		subscribers: make(map[uuid.UUID]map[*subscriber]struct{}),
+	}
+}
+
+func (b *MemoryBroker) Publish(ctx context.Context, event *entity.Event) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return domainerrors.ErrEventBrokerClosed
+	}
+
+	b.seq++
+	published := *event
+	published.ID = strconv.FormatUint(b.seq, 10)
+
+	if b.historySize > 0 {
+		if len(b.history) == b.historySize {
+			copy(b.history, b.history[1:])
+			b.history = b.history[:len(b.history)-1]
+		}
+		b.history = append(b.history, &published)
+	}
+
+	for sub := range b.subscribers[published.UserID] {
+		select {
+		case sub.ch <- &published:
+		default:
+			b.removeLocked(published.UserID, sub)
+		}
+	}
+	return nil
+}
+
+func (b *MemoryBroker) Subscribe(ctx context.Context, userID uuid.UUID, lastEventID string) (<-chan *entity.Event, func(), error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil, nil, domainerrors.ErrEventBrokerClosed
+	}
+
+	replay := b.replayLocked(userID, lastEventID)
+	sub := &subscriber{ch: make(chan *entity.Event, len(replay)+subscriberBufferSize)}
+	for _, event := range replay {
+		sub.ch <- event
+	}
+
+	if b.subscribers[userID] == nil {
+		b.subscribers[userID] = make(map[*subscriber]struct{})
+	}
+	b.subscribers[userID][sub] = struct{}{}
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.removeLocked(userID, sub)
+	}
+	return sub.ch, unsubscribe, nil
+}
+
+// Close は全ての購読者のチャネルを閉じ、以降の配信と購読を受け付けないようにします。
+func (b *MemoryBroker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+	b.closed = true
+	for userID, subs := range b.subscribers {
+		for sub := range subs {
+			close(sub.ch)
+		}
+		delete(b.subscribers, userID)
+	}
+}
+
+// replayLocked はlastEventIDより後に配信されたuserID宛てのイベントを返します。
+// IDを解釈できない場合や、保持している範囲より古い場合は再送できる分だけを返します。
+func (b *MemoryBroker) replayLocked(userID uuid.UUID, lastEventID string) []*entity.Event {
+	if lastEventID == "" {
+		return nil
+	}
+	last, err := strconv.ParseUint(lastEventID, 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	var replay []*entity.Event
+	for _, event := range b.history {
+		seq, _ := strconv.ParseUint(event.ID, 10, 64)
+		if seq > last && event.UserID == userID {
+			replay = append(replay, event)
+		}
+	}
+	return replay
+}
+
+func (b *MemoryBroker) removeLocked(userID uuid.UUID, sub *subscriber) {
+	subs, ok := b.subscribers[userID]
+	if !ok {
+		return
+	}
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+	delete(subs, sub)
+	close(sub.ch)
+	if len(subs) == 0 {
+		delete(b.subscribers, userID)
+	}
+}
@@ -0,0 +1,119 @@
+package eventbroker_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/external/eventbroker"
+)
+
+func newFavoriteEvent(userID uuid.UUID) *entity.Event {
+	return entity.NewEvent(userID, uuid.New(), entity.EventTypeFavoriteCreated, uuid.New(), nil)
+}
+
+func TestMemoryBroker_PublishAndSubscribe(t *testing.T) {
+	broker := eventbroker.NewMemoryBroker(10)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	otherUserID := uuid.New()
+
+	events, unsubscribe, err := broker.Subscribe(ctx, userID, "")
+	require.NoError(t, err)
+	defer unsubscribe()
+
+	require.NoError(t, broker.Publish(ctx, newFavoriteEvent(otherUserID)))
+	require.NoError(t, broker.Publish(ctx, newFavoriteEvent(userID)))
+
+	// 他のユーザー宛てのイベントは届かない
+	event := <-events
+	require.Equal(t, "2", event.ID)
+	require.Equal(t, userID, event.UserID)
+	require.Empty(t, events)
+}
+
+func TestMemoryBroker_ResumeFromLastEventID(t *testing.T) {
+	broker := eventbroker.NewMemoryBroker(2)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, broker.Publish(ctx, newFavoriteEvent(userID)))
+	}
+
+	events, unsubscribe, err := broker.Subscribe(ctx, userID, "1")
+	require.NoError(t, err)
+	defer unsubscribe()
+
+	require.Equal(t, "2", (<-events).ID)
+	require.Equal(t, "3", (<-events).ID)
+	require.Empty(t, events)
+
+	// 保持している件数より古いIDからは残っている分だけを再送する
+	events, unsubscribe2, err := broker.Subscribe(ctx, userID, "0")
+	require.NoError(t, err)
+	defer unsubscribe2()
+	require.Len(t, events, 2)
+}
+
+func TestMemoryBroker_Unsubscribe(t *testing.T) {
+	broker := eventbroker.NewMemoryBroker(10)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	events, unsubscribe, err := broker.Subscribe(ctx, userID, "")
+	require.NoError(t, err)
+
+	unsubscribe()
+	// 二回呼んでも問題ない
+	unsubscribe()
+
+	_, ok := <-events
+	require.False(t, ok)
+	require.NoError(t, broker.Publish(ctx, newFavoriteEvent(userID)))
+}
+
+func TestMemoryBroker_DropSlowSubscriber(t *testing.T) {
+	broker := eventbroker.NewMemoryBroker(0)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	events, unsubscribe, err := broker.Subscribe(ctx, userID, "")
+	require.NoError(t, err)
+	defer unsubscribe()
+
+	for i := 0; i < cap(events)+1; i++ {
+		require.NoError(t, broker.Publish(ctx, newFavoriteEvent(userID)))
+	}
+
+	// 溜まっていたイベントを読み切るとチャネルが閉じている
+	received := 0
+	for range events {
+		received++
+	}
+	require.Equal(t, cap(events), received)
+}
+
+func TestMemoryBroker_Close(t *testing.T) {
+	broker := eventbroker.NewMemoryBroker(10)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	events, unsubscribe, err := broker.Subscribe(ctx, userID, "")
+	require.NoError(t, err)
+
+	broker.Close()
+	unsubscribe()
+
+	_, ok := <-events
+	require.False(t, ok)
+
+	_, _, err = broker.Subscribe(ctx, userID, "")
+	require.ErrorIs(t, err, domainerrors.ErrEventBrokerClosed)
+	require.ErrorIs(t, broker.Publish(ctx, newFavoriteEvent(userID)), domainerrors.ErrEventBrokerClosed)
+}
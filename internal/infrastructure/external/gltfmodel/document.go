@@ -0,0 +1,94 @@
+package gltfmodel
+
+// document は glTF 2.0 の JSON のうち、検査と集計に使う部分です
+type document struct {
+	Asset struct {
+		Version string `json:"version"`
+	} `json:"asset"`
+	Scene       *int         `json:"scene"`
+	Scenes      []scene      `json:"scenes"`
+	Nodes       []node       `json:"nodes"`
+	Meshes      []mesh       `json:"meshes"`
+	Materials   []material   `json:"materials"`
+	Accessors   []accessor   `json:"accessors"`
+	BufferViews []bufferView `json:"bufferViews"`
+	Buffers     []buffer     `json:"buffers"`
+}
+
+type scene struct {
+	Nodes []int `json:"nodes"`
+}
+
+type node struct {
+	Mesh        *int      `json:"mesh"`
+	Children    []int     `json:"children"`
+	Matrix      []float64 `json:"matrix"`
+	Translation []float64 `json:"translation"`
+	Rotation    []float64 `json:"rotation"`
+	Scale       []float64 `json:"scale"`
+}
+
+type mesh struct {
+	Primitives []primitive `json:"primitives"`
+}
+
+type primitive struct {
+	Attributes map[string]int `json:"attributes"`
+	Indices    *int           `json:"indices"`
+	Material   *int           `json:"material"`
+	Mode       *int           `json:"mode"`
+}
+
+type material struct {
+	Name string `json:"name"`
+}
+
+type accessor struct {
+	BufferView    *int      `json:"bufferView"`
+	ByteOffset    int64     `json:"byteOffset"`
+	ComponentType int       `json:"componentType"`
+	Count         int64     `json:"count"`
+	Type          string    `json:"type"`
+	Min           []float64 `json:"min"`
+	Max           []float64 `json:"max"`
+}
+
+type bufferView struct {
+	Buffer     int   `json:"buffer"`
+	ByteOffset int64 `json:"byteOffset"`
+	ByteLength int64 `json:"byteLength"`
+	ByteStride int64 `json:"byteStride"`
+}
+
+type buffer struct {
+	URI        string `json:"uri"`
+	ByteLength int64  `json:"byteLength"`
+}
+
+// componentSizes は accessor の componentType ごとのバイト数です
+var componentSizes = map[int]int64{
+	5120: 1, // BYTE
+	5121: 1, // UNSIGNED_BYTE
+	5122: 2, // SHORT
+	5123: 2, // UNSIGNED_SHORT
+	5125: 4, // UNSIGNED_INT
+	5126: 4, // FLOAT
+}
+
+// componentCounts は accessor の type ごとの要素数です
+var componentCounts = map[string]int64{
+	"SCALAR": 1,
+	"VEC2":   2,
+	"VEC3":   3,
+	"VEC4":   4,
+	"MAT2":   4,
+	"MAT3":   9,
+	"MAT4":   16,
+}
+
+// primitive の mode
+const (
+	modeTriangles     = 4
+	modeTriangleStrip = 5
+	modeTriangleFan   = 6
+)
@@ -0,0 +1,234 @@
+package gltfmodel
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+)
+
+// GLB のチャンクの種類
+const (
+	chunkJSON = 0x4E4F534A
+	chunkBIN  = 0x004E4942
+)
+
+// Inspector は標準ライブラリだけで glTF 2.0 を検査する ModelInspector の実装です。
+// JSON の参照とバイト範囲を確かめ、頂点のデータそのものは読みません。
+type Inspector struct {
+	// maxNodeVisits はノードを辿る回数の上限です。同じノードを何度も参照して集計を重くするモデルを断ります
+	maxNodeVisits int
+}
+
+func NewInspector(maxNodeVisits int) *Inspector {
+	return &Inspector{
+		maxNodeVisits: maxNodeVisits,
+	}
+}
+
+func (i *Inspector) InspectModel(ctx context.Context, src io.Reader, extension string) (*entity.ModelStats, error) {
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read model: %w", err)
+	}
+
+	var jsonChunk, binChunk []byte
+	switch extension {
+	case "glb":
+		jsonChunk, binChunk, err = splitGLB(data)
+		if err != nil {
+			return nil, err
+		}
+	case "gltf":
+		jsonChunk = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	default:
+		return nil, fmt.Errorf("%w: unsupported extension %q", domainerrors.ErrInvalidModel, extension)
+	}
+
+	var doc document
+	if err := json.Unmarshal(jsonChunk, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainerrors.ErrInvalidModel, err)
+	}
+	if !strings.HasPrefix(doc.Asset.Version, "2.") {
+		return nil, fmt.Errorf("%w: unsupported version %q", domainerrors.ErrInvalidModel, doc.Asset.Version)
+	}
+	if err := validateBuffers(&doc, binChunk); err != nil {
+		return nil, err
+	}
+	if err := validateAccessors(&doc); err != nil {
+		return nil, err
+	}
+	if err := validateMeshes(&doc); err != nil {
+		return nil, err
+	}
+	return i.collectStats(ctx, &doc)
+}
+
+// splitGLB は GLB のヘッダーを検査し、JSON と BIN のチャンクを取り出します
+func splitGLB(data []byte) (jsonChunk []byte, binChunk []byte, err error) {
+	if len(data) < 20 || string(data[0:4]) != "glTF" {
+		return nil, nil, fmt.Errorf("%w: missing glb header", domainerrors.ErrInvalidModel)
+	}
+	if version := binary.LittleEndian.Uint32(data[4:8]); version != 2 {
+		return nil, nil, fmt.Errorf("%w: unsupported glb version %d", domainerrors.ErrInvalidModel, version)
+	}
+	length := int64(binary.LittleEndian.Uint32(data[8:12]))
+	if length > int64(len(data)) {
+		return nil, nil, fmt.Errorf("%w: glb is truncated", domainerrors.ErrInvalidModel)
+	}
+
+	for pos := int64(12); pos+8 <= length; {
+		chunkLength := int64(binary.LittleEndian.Uint32(data[pos : pos+4]))
+		chunkType := binary.LittleEndian.Uint32(data[pos+4 : pos+8])
+		if pos+8+chunkLength > length {
+			return nil, nil, fmt.Errorf("%w: glb chunk is truncated", domainerrors.ErrInvalidModel)
+		}
+		chunk := data[pos+8 : pos+8+chunkLength]
+		switch {
+		case pos == 12 && chunkType != chunkJSON:
+			return nil, nil, fmt.Errorf("%w: first glb chunk must be json", domainerrors.ErrInvalidModel)
+		case pos == 12:
+			jsonChunk = chunk
+		case chunkType == chunkBIN && binChunk == nil:
+			binChunk = chunk
+		}
+		pos += 8 + chunkLength
+	}
+	if jsonChunk == nil {
+		return nil, nil, fmt.Errorf("%w: missing json chunk", domainerrors.ErrInvalidModel)
+	}
+	return jsonChunk, binChunk, nil
+}
+
+// validateBuffers はバッファの中身がファイルに含まれているかを確かめます。
+// アップロードされるのは 1 ファイルだけのため、data URI と GLB の BIN チャンク以外のバッファは参照できません
+func validateBuffers(doc *document, binChunk []byte) error {
+	for idx, buf := range doc.Buffers {
+		var size int64
+		switch {
+		case buf.URI == "" && idx == 0 && binChunk != nil:
+			size = int64(len(binChunk))
+		case buf.URI == "":
+			return fmt.Errorf("%w: buffer %d has no data", domainerrors.ErrInvalidModel, idx)
+		case strings.HasPrefix(buf.URI, "data:"):
+			decoded, err := decodeDataURI(buf.URI)
+			if err != nil {
+				return fmt.Errorf("%w: buffer %d: %w", domainerrors.ErrInvalidModel, idx, err)
+			}
+			size = int64(len(decoded))
+		default:
+			return fmt.Errorf("%w: %q", domainerrors.ErrExternalModelBuffer, buf.URI)
+		}
+		if buf.ByteLength <= 0 || buf.ByteLength > size {
+			return fmt.Errorf("%w: buffer %d is shorter than byteLength", domainerrors.ErrInvalidModel, idx)
+		}
+	}
+
+	for idx, view := range doc.BufferViews {
+		if view.Buffer < 0 || view.Buffer >= len(doc.Buffers) {
+			return fmt.Errorf("%w: bufferView %d references missing buffer", domainerrors.ErrInvalidModel, idx)
+		}
+		if view.ByteOffset < 0 || view.ByteLength <= 0 || view.ByteOffset+view.ByteLength > doc.Buffers[view.Buffer].ByteLength {
+			return fmt.Errorf("%w: bufferView %d is out of range", domainerrors.ErrInvalidModel, idx)
+		}
+		if view.ByteStride != 0 && (view.ByteStride < 4 || view.ByteStride > 252) {
+			return fmt.Errorf("%w: bufferView %d has invalid byteStride", domainerrors.ErrInvalidModel, idx)
+		}
+	}
+	return nil
+}
+
+// decodeDataURI は base64 の data URI を読みます
+func decodeDataURI(uri string) ([]byte, error) {
+	header, payload, ok := strings.Cut(uri, ",")
+	if !ok || !strings.HasSuffix(header, ";base64") {
+		return nil, errors.New("data uri must be base64")
+	}
+	return base64.StdEncoding.DecodeString(payload)
+}
+
+// validateAccessors は accessor が bufferView の範囲に収まるかを確かめます
+func validateAccessors(doc *document) error {
+	for idx, acc := range doc.Accessors {
+		componentSize, ok := componentSizes[acc.ComponentType]
+		components, typeOK := componentCounts[acc.Type]
+		if !ok || !typeOK || acc.Count <= 0 || acc.ByteOffset < 0 {
+			return fmt.Errorf("%w: accessor %d is invalid", domainerrors.ErrInvalidModel, idx)
+		}
+		// bufferView のない accessor は 0 で埋められたもの (sparse で一部を置き換える) として扱う
+		if acc.BufferView == nil {
+			continue
+		}
+		if *acc.BufferView < 0 || *acc.BufferView >= len(doc.BufferViews) {
+			return fmt.Errorf("%w: accessor %d references missing bufferView", domainerrors.ErrInvalidModel, idx)
+		}
+		view := doc.BufferViews[*acc.BufferView]
+		elementSize := componentSize * components
+		stride := view.ByteStride
+		if stride == 0 {
+			stride = elementSize
+		}
+		if acc.ByteOffset+stride*(acc.Count-1)+elementSize > view.ByteLength {
+			return fmt.Errorf("%w: accessor %d is out of range", domainerrors.ErrInvalidModel, idx)
+		}
+	}
+	return nil
+}
+
+// validateMeshes はメッシュとノードとシーンの参照先があるかを確かめます
+func validateMeshes(doc *document) error {
+	for meshIdx, m := range doc.Meshes {
+		if len(m.Primitives) == 0 {
+			return fmt.Errorf("%w: mesh %d has no primitives", domainerrors.ErrInvalidModel, meshIdx)
+		}
+		for _, p := range m.Primitives {
+			for name, idx := range p.Attributes {
+				if idx < 0 || idx >= len(doc.Accessors) {
+					return fmt.Errorf("%w: mesh %d attribute %s references missing accessor", domainerrors.ErrInvalidModel, meshIdx, name)
+				}
+			}
+			if idx, ok := p.Attributes["POSITION"]; ok && doc.Accessors[idx].Type != "VEC3" {
+				return fmt.Errorf("%w: mesh %d position must be vec3", domainerrors.ErrInvalidModel, meshIdx)
+			}
+			if p.Indices != nil && (*p.Indices < 0 || *p.Indices >= len(doc.Accessors)) {
+				return fmt.Errorf("%w: mesh %d indices references missing accessor", domainerrors.ErrInvalidModel, meshIdx)
+			}
+			if p.Material != nil && (*p.Material < 0 || *p.Material >= len(doc.Materials)) {
+				return fmt.Errorf("%w: mesh %d references missing material", domainerrors.ErrInvalidModel, meshIdx)
+			}
+		}
+	}
+	for nodeIdx, n := range doc.Nodes {
+		if n.Mesh != nil && (*n.Mesh < 0 || *n.Mesh >= len(doc.Meshes)) {
+			return fmt.Errorf("%w: node %d references missing mesh", domainerrors.ErrInvalidModel, nodeIdx)
+		}
+		for _, child := range n.Children {
+			if child < 0 || child >= len(doc.Nodes) {
+				return fmt.Errorf("%w: node %d references missing child", domainerrors.ErrInvalidModel, nodeIdx)
+			}
+		}
+		if (n.Matrix != nil && len(n.Matrix) != 16) || (n.Translation != nil && len(n.Translation) != 3) ||
+			(n.Rotation != nil && len(n.Rotation) != 4) || (n.Scale != nil && len(n.Scale) != 3) {
+			return fmt.Errorf("%w: node %d has invalid transform", domainerrors.ErrInvalidModel, nodeIdx)
+		}
+	}
+	for sceneIdx, s := range doc.Scenes {
+		for _, idx := range s.Nodes {
+			if idx < 0 || idx >= len(doc.Nodes) {
+				return fmt.Errorf("%w: scene %d references missing node", domainerrors.ErrInvalidModel, sceneIdx)
+			}
+		}
+	}
+	if doc.Scene != nil && (*doc.Scene < 0 || *doc.Scene >= len(doc.Scenes)) {
+		return fmt.Errorf("%w: default scene is missing", domainerrors.ErrInvalidModel)
+	}
+	return nil
+}
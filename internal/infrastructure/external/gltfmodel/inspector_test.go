@@ -0,0 +1,175 @@
+package gltfmodel_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/external/gltfmodel"
+)
+
+// triangleJSON は 1 辺 1 の三角形を、x 方向に 10 ずらしたノードと 2 倍に拡大したノードに置いたモデルです。
+// バッファは BUFFER の部分を置き換えて指定します
+const triangleJSON = `{
+	"asset": {"version": "2.0"},
+	"scene": 0,
+	"scenes": [{"nodes": [0, 1]}],
+	"nodes": [
+		{"mesh": 0, "translation": [10, 0, 0]},
+		{"children": [2], "scale": [2, 2, 2]},
+		{"mesh": 0}
+	],
+	"meshes": [{"primitives": [{"attributes": {"POSITION": 0}, "indices": 1, "material": 0}]}],
+	"materials": [{"name": "Body"}, {}],
+	"accessors": [
+		{"bufferView": 0, "componentType": 5126, "count": 3, "type": "VEC3", "min": [0, 0, 0], "max": [1, 1, 0]},
+		{"bufferView": 1, "componentType": 5123, "count": 3, "type": "SCALAR"}
+	],
+	"bufferViews": [
+		{"buffer": 0, "byteOffset": 0, "byteLength": 36},
+		{"buffer": 0, "byteOffset": 36, "byteLength": 6}
+	],
+	"buffers": [BUFFER]
+}`
+
+func triangleBuffer() []byte {
+	var b []byte
+	for _, v := range []float32{0, 0, 0, 1, 0, 0, 0, 1, 0} {
+		b = binary.LittleEndian.AppendUint32(b, math.Float32bits(v))
+	}
+	for _, i := range []uint16{0, 1, 2} {
+		b = binary.LittleEndian.AppendUint16(b, i)
+	}
+	// GLB のチャンクは 4 バイト単位に揃える
+	return append(b, 0, 0)
+}
+
+func newGLTF(buffer string) []byte {
+	return []byte(strings.Replace(triangleJSON, "BUFFER", buffer, 1))
+}
+
+func newGLB(t *testing.T) []byte {
+	t.Helper()
+
+	jsonChunk := newGLTF(`{"byteLength": 42}`)
+	for len(jsonChunk)%4 != 0 {
+		jsonChunk = append(jsonChunk, ' ')
+	}
+	bin := triangleBuffer()
+
+	var b bytes.Buffer
+	b.WriteString("glTF")
+	b.Write(binary.LittleEndian.AppendUint32(nil, 2))
+	b.Write(binary.LittleEndian.AppendUint32(nil, uint32(12+8+len(jsonChunk)+8+len(bin))))
+	b.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(jsonChunk))))
+	b.WriteString("JSON")
+	b.Write(jsonChunk)
+	b.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(bin))))
+	b.WriteString("BIN\x00")
+	b.Write(bin)
+	return b.Bytes()
+}
+
+func TestInspector_InspectModel(t *testing.T) {
+	inspector := gltfmodel.NewInspector(1000)
+	dataURI := `{"uri": "data:application/octet-stream;base64,` + base64.StdEncoding.EncodeToString(triangleBuffer()) + `", "byteLength": 42}`
+
+	// 同じメッシュを 2 つのノードに置いたため、頂点と三角形は 2 つ分になる
+	want := &entity.ModelStats{
+		VertexCount:   6,
+		TriangleCount: 2,
+		Materials:     []string{"Body", ""},
+		BoundingBox: &entity.BoundingBox{
+			Min: [3]float64{0, 0, 0},
+			Max: [3]float64{11, 2, 0},
+		},
+	}
+
+	t.Run("gltf", func(t *testing.T) {
+		stats, err := inspector.InspectModel(context.Background(), bytes.NewReader(newGLTF(dataURI)), "gltf")
+		require.NoError(t, err)
+		require.Equal(t, want, stats)
+	})
+
+	t.Run("glb", func(t *testing.T) {
+		stats, err := inspector.InspectModel(context.Background(), bytes.NewReader(newGLB(t)), "glb")
+		require.NoError(t, err)
+		require.Equal(t, want, stats)
+	})
+}
+
+func TestInspector_InspectModel_Invalid(t *testing.T) {
+	inspector := gltfmodel.NewInspector(1000)
+	dataURI := `{"uri": "data:application/octet-stream;base64,` + base64.StdEncoding.EncodeToString(triangleBuffer()) + `", "byteLength": 42}`
+
+	tests := []struct {
+		name      string
+		data      []byte
+		extension string
+		wantErr   error
+	}{
+		{
+			name:      "アップロードされていない外部のバッファ",
+			data:      newGLTF(`{"uri": "scene.bin", "byteLength": 42}`),
+			extension: "gltf",
+			wantErr:   domainerrors.ErrExternalModelBuffer,
+		},
+		{
+			name:      "バッファが byteLength より短い",
+			data:      newGLTF(`{"uri": "data:application/octet-stream;base64,AAAA", "byteLength": 42}`),
+			extension: "gltf",
+			wantErr:   domainerrors.ErrInvalidModel,
+		},
+		{
+			name:      "accessor が bufferView の範囲を超える",
+			data:      []byte(strings.Replace(string(newGLTF(dataURI)), `"count": 3, "type": "VEC3"`, `"count": 4, "type": "VEC3"`, 1)),
+			extension: "gltf",
+			wantErr:   domainerrors.ErrInvalidModel,
+		},
+		{
+			name:      "ノードが循環している",
+			data:      []byte(strings.Replace(string(newGLTF(dataURI)), `{"mesh": 0}`, `{"mesh": 0, "children": [1]}`, 1)),
+			extension: "gltf",
+			wantErr:   domainerrors.ErrInvalidModel,
+		},
+		{
+			name:      "存在しないマテリアル",
+			data:      []byte(strings.Replace(string(newGLTF(dataURI)), `"material": 0`, `"material": 5`, 1)),
+			extension: "gltf",
+			wantErr:   domainerrors.ErrInvalidModel,
+		},
+		{
+			name:      "glTF 1.0",
+			data:      []byte(strings.Replace(string(newGLTF(dataURI)), `"version": "2.0"`, `"version": "1.0"`, 1)),
+			extension: "gltf",
+			wantErr:   domainerrors.ErrInvalidModel,
+		},
+		{
+			name:      "JSON として読めない",
+			data:      []byte(`{"asset": `),
+			extension: "gltf",
+			wantErr:   domainerrors.ErrInvalidModel,
+		},
+		{
+			name:      "GLB が途中で切れている",
+			data:      newGLB(t)[:12+8+len(newGLTF(`{"byteLength": 42}`))],
+			extension: "glb",
+			wantErr:   domainerrors.ErrInvalidModel,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := inspector.InspectModel(context.Background(), bytes.NewReader(tt.data), tt.extension)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
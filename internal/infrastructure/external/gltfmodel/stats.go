@@ -0,0 +1,216 @@
+package gltfmodel
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+)
+
+// mat4 は列優先の 4x4 行列です
+type mat4 [16]float64
+
+var identity = mat4{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1}
+
+func (a mat4) mul(b mat4) mat4 {
+	var m mat4
+	for col := 0; col < 4; col++ {
+		for row := 0; row < 4; row++ {
+			var sum float64
+			for k := 0; k < 4; k++ {
+				sum += a[k*4+row] * b[col*4+k]
+			}
+			m[col*4+row] = sum
+		}
+	}
+	return m
+}
+
+func (a mat4) apply(p [3]float64) [3]float64 {
+	return [3]float64{
+		a[0]*p[0] + a[4]*p[1] + a[8]*p[2] + a[12],
+		a[1]*p[0] + a[5]*p[1] + a[9]*p[2] + a[13],
+		a[2]*p[0] + a[6]*p[1] + a[10]*p[2] + a[14],
+	}
+}
+
+// localMatrix はノードの matrix、または translation・rotation・scale を合わせた行列を返します
+func localMatrix(n *node) mat4 {
+	if n.Matrix != nil {
+		var m mat4
+		copy(m[:], n.Matrix)
+		return m
+	}
+	t := [3]float64{0, 0, 0}
+	copy(t[:], n.Translation)
+	s := [3]float64{1, 1, 1}
+	copy(s[:], n.Scale)
+	q := [4]float64{0, 0, 0, 1}
+	copy(q[:], n.Rotation)
+
+	x, y, z, w := q[0], q[1], q[2], q[3]
+	r := [3][3]float64{
+		{1 - 2*(y*y+z*z), 2 * (x*y - z*w), 2 * (x*z + y*w)},
+		{2 * (x*y + z*w), 1 - 2*(x*x+z*z), 2 * (y*z - x*w)},
+		{2 * (x*z - y*w), 2 * (y*z + x*w), 1 - 2*(x*x+y*y)},
+	}
+	var m mat4
+	for col := 0; col < 3; col++ {
+		for row := 0; row < 3; row++ {
+			m[col*4+row] = r[row][col] * s[col]
+		}
+	}
+	m[12], m[13], m[14], m[15] = t[0], t[1], t[2], 1
+	return m
+}
+
+// collector はシーンのノードを辿り、配置されたメッシュの頂点数と大きさを集計します
+type collector struct {
+	ctx       context.Context
+	doc       *document
+	stats     *entity.ModelStats
+	visits    int
+	maxVisits int
+	// path は辿っている途中のノードです。循環した参照を見つけるのに使います
+	path map[int]bool
+}
+
+func (i *Inspector) collectStats(ctx context.Context, doc *document) (*entity.ModelStats, error) {
+	c := &collector{
+		ctx:       ctx,
+		doc:       doc,
+		stats:     &entity.ModelStats{Materials: make([]string, len(doc.Materials))},
+		maxVisits: i.maxNodeVisits,
+		path:      make(map[int]bool),
+	}
+	for idx, m := range doc.Materials {
+		c.stats.Materials[idx] = m.Name
+	}
+
+	roots, ok := rootNodes(doc)
+	if !ok {
+		// シーンもノードもないモデルは、すべてのメッシュを 1 つずつ置いたものとして扱う
+		for idx := range doc.Meshes {
+			c.addMesh(idx, identity)
+		}
+		return c.stats, nil
+	}
+	for _, idx := range roots {
+		if err := c.visit(idx, identity); err != nil {
+			return nil, err
+		}
+	}
+	return c.stats, nil
+}
+
+// rootNodes は既定のシーンのノードを返します。シーンがない場合は、どのノードの子でもないノードを返します
+func rootNodes(doc *document) ([]int, bool) {
+	if len(doc.Scenes) > 0 {
+		idx := 0
+		if doc.Scene != nil {
+			idx = *doc.Scene
+		}
+		return doc.Scenes[idx].Nodes, true
+	}
+	if len(doc.Nodes) == 0 {
+		return nil, false
+	}
+	isChild := make([]bool, len(doc.Nodes))
+	for _, n := range doc.Nodes {
+		for _, child := range n.Children {
+			isChild[child] = true
+		}
+	}
+	var roots []int
+	for idx := range doc.Nodes {
+		if !isChild[idx] {
+			roots = append(roots, idx)
+		}
+	}
+	return roots, true
+}
+
+func (c *collector) visit(idx int, parent mat4) error {
+	c.visits++
+	if c.visits > c.maxVisits {
+		return fmt.Errorf("%w: too many node references", domainerrors.ErrInvalidModel)
+	}
+	if c.path[idx] {
+		return fmt.Errorf("%w: node %d is its own ancestor", domainerrors.ErrInvalidModel, idx)
+	}
+	if err := c.ctx.Err(); err != nil {
+		return err
+	}
+	c.path[idx] = true
+	defer delete(c.path, idx)
+
+	n := &c.doc.Nodes[idx]
+	world := parent.mul(localMatrix(n))
+	if n.Mesh != nil {
+		c.addMesh(*n.Mesh, world)
+	}
+	for _, child := range n.Children {
+		if err := c.visit(child, world); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addMesh はメッシュの頂点数と三角形の数を加え、POSITION の min・max を world で移した範囲を大きさに含めます
+func (c *collector) addMesh(idx int, world mat4) {
+	for _, p := range c.doc.Meshes[idx].Primitives {
+		position, ok := p.Attributes["POSITION"]
+		if !ok {
+			continue
+		}
+		acc := c.doc.Accessors[position]
+		c.stats.VertexCount += acc.Count
+
+		count := acc.Count
+		if p.Indices != nil {
+			count = c.doc.Accessors[*p.Indices].Count
+		}
+		mode := modeTriangles
+		if p.Mode != nil {
+			mode = *p.Mode
+		}
+		switch mode {
+		case modeTriangles:
+			c.stats.TriangleCount += count / 3
+		case modeTriangleStrip, modeTriangleFan:
+			c.stats.TriangleCount += max(count-2, 0)
+		}
+
+		if len(acc.Min) == 3 && len(acc.Max) == 3 {
+			for corner := 0; corner < 8; corner++ {
+				var point [3]float64
+				for axis := 0; axis < 3; axis++ {
+					point[axis] = acc.Min[axis]
+					if corner&(1<<axis) != 0 {
+						point[axis] = acc.Max[axis]
+					}
+				}
+				c.expand(world.apply(point))
+			}
+		}
+	}
+}
+
+func (c *collector) expand(point [3]float64) {
+	for axis := 0; axis < 3; axis++ {
+		if math.IsNaN(point[axis]) || math.IsInf(point[axis], 0) {
+			return
+		}
+	}
+	if c.stats.BoundingBox == nil {
+		c.stats.BoundingBox = &entity.BoundingBox{Min: point, Max: point}
+		return
+	}
+	for axis := 0; axis < 3; axis++ {
+		c.stats.BoundingBox.Min[axis] = min(c.stats.BoundingBox.Min[axis], point[axis])
+		c.stats.BoundingBox.Max[axis] = max(c.stats.BoundingBox.Max[axis], point[axis])
+	}
+}
@@ -0,0 +1,437 @@
+package imageproc
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/webp"
+
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+)
+
+// 向きを画素に反映して再エンコードするときの JPEG の品質です。元画像に近い画質を保つため高めにしています。
+const orientedJPEGQuality = 92
+
+// exifOrientationTag は EXIF の Orientation タグの ID です。
+const exifOrientationTag = 0x0112
+
+// 向きを画素に反映した WebP は純粋な Go ではエンコードできないため、劣化しない PNG で保存します。
+const orientedWebPExtension = "png"
+
+var (
+	errMalformedJPEG = errors.New("malformed jpeg")
+	errMalformedPNG  = errors.New("malformed png")
+	errMalformedWebP = errors.New("malformed webp")
+)
+
+// StripMetadata は EXIF・XMP・GPS などのメタデータを取り除いた画像を返します。
+// EXIF の向きは画素に反映してから取り除くため、見た目の向きは変わりません。
+// 画素数の上限は向きを画素に反映するために画像全体をデコードする場合だけ確かめます。
+func (p *Processor) StripMetadata(ctx context.Context, data []byte, contentType string) (*entity.StrippedImage, error) {
+	var stripped []byte
+	var err error
+	switch contentType {
+	case "image/jpeg":
+		stripped, err = stripJPEG(data, p.maxPixels)
+	case "image/png":
+		stripped, err = stripPNG(data, p.maxPixels)
+	case "image/webp":
+		var converted bool
+		stripped, converted, err = stripWebP(data, p.maxPixels)
+		if err == nil && converted {
+			return &entity.StrippedImage{Data: stripped, ConvertedExtension: orientedWebPExtension}, nil
+		}
+	default:
+		return nil, fmt.Errorf("unsupported content type: %s", contentType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entity.StrippedImage{Data: stripped}, nil
+}
+
+// stripJPEG は APP0(JFIF)・ICC プロファイル・APP14(Adobe) 以外のアプリケーションセグメントとコメントを取り除きます。
+func stripJPEG(data []byte, maxPixels int) ([]byte, error) {
+	if len(data) < 4 || data[0] != 0xff || data[1] != 0xd8 {
+		return nil, errMalformedJPEG
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(data[:2])
+	orientation := 1
+	// 再エンコードすると ICC プロファイルが失われるため、書き戻せるよう取っておく
+	var iccSegments [][]byte
+	pos := 2
+	for {
+		if pos+2 > len(data) || data[pos] != 0xff {
+			return nil, errMalformedJPEG
+		}
+		marker := data[pos+1]
+		// マーカーの前の埋め草の 0xff は読み飛ばす
+		if marker == 0xff {
+			pos++
+			continue
+		}
+		// RST・TEM は長さを持たない
+		if (marker >= 0xd0 && marker <= 0xd7) || marker == 0x01 {
+			out.Write(data[pos : pos+2])
+			pos += 2
+			continue
+		}
+		if marker == 0xd9 {
+			out.Write(data[pos : pos+2])
+			break
+		}
+		// SOS 以降は圧縮データなので、そのまま書き出す
+		if marker == 0xda {
+			out.Write(data[pos:])
+			break
+		}
+		if pos+4 > len(data) {
+			return nil, errMalformedJPEG
+		}
+		end := pos + 2 + int(binary.BigEndian.Uint16(data[pos+2:pos+4]))
+		if end > len(data) {
+			return nil, errMalformedJPEG
+		}
+		segment := data[pos:end]
+		payload := data[pos+4 : end]
+
+		switch {
+		case marker == 0xe1:
+			if bytes.HasPrefix(payload, []byte("Exif\x00\x00")) {
+				orientation = exifOrientation(payload[6:])
+			}
+		case marker == 0xe2:
+			if bytes.HasPrefix(payload, []byte("ICC_PROFILE\x00")) {
+				out.Write(segment)
+				iccSegments = append(iccSegments, segment)
+			}
+		case marker == 0xe0 || marker == 0xee:
+			out.Write(segment)
+		case marker >= 0xe3 && marker <= 0xef, marker == 0xfe:
+			// その他のアプリケーションセグメント(IPTC など)とコメントは捨てる
+		default:
+			out.Write(segment)
+		}
+		pos = end
+	}
+
+	if orientation == 1 {
+		return out.Bytes(), nil
+	}
+	if err := checkPixels(out.Bytes(), maxPixels); err != nil {
+		return nil, err
+	}
+	img, err := jpeg.Decode(bytes.NewReader(out.Bytes()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode jpeg: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, applyOrientation(img, orientation), &jpeg.Options{Quality: orientedJPEGQuality}); err != nil {
+		return nil, fmt.Errorf("failed to encode jpeg: %w", err)
+	}
+	encoded := buf.Bytes()
+	if len(iccSegments) == 0 {
+		return encoded, nil
+	}
+	// 色域の広い画像の色が変わらないよう、ICC プロファイルを SOI の直後に書き戻す
+	var withICC bytes.Buffer
+	withICC.Write(encoded[:2])
+	for _, segment := range iccSegments {
+		withICC.Write(segment)
+	}
+	withICC.Write(encoded[2:])
+	return withICC.Bytes(), nil
+}
+
+// checkPixels は向きを反映するために画像全体をデコードする前に、画素数が上限以内か確かめます。
+func checkPixels(data []byte, maxPixels int) error {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to decode image config: %w", err)
+	}
+	if cfg.Width*cfg.Height > maxPixels {
+		return domainerrors.ErrImageTooLarge
+	}
+	return nil
+}
+
+// PNG のうちメタデータを持つ補助チャンクです。
+var pngMetadataChunks = map[string]bool{
+	"eXIf": true,
+	"tEXt": true,
+	"zTXt": true,
+	"iTXt": true,
+	"tIME": true,
+}
+
+func stripPNG(data []byte, maxPixels int) ([]byte, error) {
+	const signature = "\x89PNG\r\n\x1a\n"
+	if !bytes.HasPrefix(data, []byte(signature)) {
+		return nil, errMalformedPNG
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.WriteString(signature)
+	orientation := 1
+	pos := len(signature)
+	for pos < len(data) {
+		if pos+12 > len(data) {
+			return nil, errMalformedPNG
+		}
+		length := int(binary.BigEndian.Uint32(data[pos : pos+4]))
+		chunkType := string(data[pos+4 : pos+8])
+		end := pos + 12 + length
+		if length < 0 || end > len(data) {
+			return nil, errMalformedPNG
+		}
+		if chunkType == "eXIf" {
+			orientation = exifOrientation(data[pos+8 : pos+8+length])
+		}
+		if !pngMetadataChunks[chunkType] {
+			out.Write(data[pos:end])
+		}
+		pos = end
+		if chunkType == "IEND" {
+			break
+		}
+	}
+
+	if orientation == 1 {
+		return out.Bytes(), nil
+	}
+	if err := checkPixels(out.Bytes(), maxPixels); err != nil {
+		return nil, err
+	}
+	img, err := png.Decode(bytes.NewReader(out.Bytes()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode png: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, applyOrientation(img, orientation)); err != nil {
+		return nil, fmt.Errorf("failed to encode png: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// VP8X チャンクのフラグのうち、アニメーション・EXIF・XMP の有無を表すビットです。
+const (
+	webpFlagAnimation = 0x02
+	webpFlagEXIF      = 0x08
+	webpFlagXMP       = 0x04
+)
+
+// stripWebP は EXIF・XMP チャンクを取り除きます。
+// 向きが指定されている場合は画素に反映し、WebP では書き出せないため PNG に変換して converted を true で返します。
+// golang.org/x/image/webp はアニメーションをデコードできないため、アニメーション WebP は向きを反映せずに取り除きます。
+func stripWebP(data []byte, maxPixels int) (stripped []byte, converted bool, err error) {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, false, errMalformedWebP
+	}
+
+	var chunks [][]byte
+	animated := false
+	orientation := 1
+	pos := 12
+	for pos < len(data) {
+		if pos+8 > len(data) {
+			return nil, false, errMalformedWebP
+		}
+		fourCC := string(data[pos : pos+4])
+		size := int(binary.LittleEndian.Uint32(data[pos+4 : pos+8]))
+		end := pos + 8 + size + size%2
+		if size < 0 || pos+8+size > len(data) {
+			return nil, false, errMalformedWebP
+		}
+		end = min(end, len(data))
+
+		switch fourCC {
+		case "EXIF":
+			orientation = exifOrientation(data[pos+8 : pos+8+size])
+		case "XMP ":
+		default:
+			chunk := append([]byte(nil), data[pos:end]...)
+			if fourCC == "VP8X" {
+				if size < 1 {
+					return nil, false, errMalformedWebP
+				}
+				chunk[8] &^= webpFlagEXIF | webpFlagXMP
+				animated = chunk[8]&webpFlagAnimation != 0
+			}
+			chunks = append(chunks, chunk)
+		}
+		pos = end
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.WriteString("RIFF")
+	size := 4
+	for _, chunk := range chunks {
+		size += len(chunk)
+	}
+	_ = binary.Write(out, binary.LittleEndian, uint32(size))
+	out.WriteString("WEBP")
+	for _, chunk := range chunks {
+		out.Write(chunk)
+	}
+
+	if orientation == 1 || animated {
+		return out.Bytes(), false, nil
+	}
+	if err := checkPixels(out.Bytes(), maxPixels); err != nil {
+		return nil, false, err
+	}
+	img, err := webp.Decode(bytes.NewReader(out.Bytes()))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to decode webp: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, applyOrientation(img, orientation)); err != nil {
+		return nil, false, fmt.Errorf("failed to encode png: %w", err)
+	}
+	return buf.Bytes(), true, nil
+}
+
+// webpOrientation は WebP の EXIF チャンクから向きを読み取ります。
+// StripMetadata を通していない WebP (以前は向きだけを残していたアセットなど) も、表示される向きで扱うために使います。
+func webpOrientation(data []byte) int {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return 1
+	}
+	pos := 12
+	for pos+8 <= len(data) {
+		size := int(binary.LittleEndian.Uint32(data[pos+4 : pos+8]))
+		if pos+8+size > len(data) {
+			return 1
+		}
+		if string(data[pos:pos+4]) == "EXIF" {
+			return exifOrientation(data[pos+8 : pos+8+size])
+		}
+		pos += 8 + size + size%2
+	}
+	return 1
+}
+
+// jpegOrientation は JPEG の EXIF から向きを読み取ります。
+// StripMetadata を通していない JPEG (移行前からあるアセットなど) も、表示される向きで扱うために使います。
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xff || data[1] != 0xd8 {
+		return 1
+	}
+	pos := 2
+	for pos+4 <= len(data) && data[pos] == 0xff {
+		marker := data[pos+1]
+		if marker == 0xff {
+			pos++
+			continue
+		}
+		if (marker >= 0xd0 && marker <= 0xd7) || marker == 0x01 {
+			pos += 2
+			continue
+		}
+		// EXIF は圧縮データより前にしか置かれない
+		if marker == 0xda || marker == 0xd9 {
+			return 1
+		}
+		end := pos + 2 + int(binary.BigEndian.Uint16(data[pos+2:pos+4]))
+		if end > len(data) {
+			return 1
+		}
+		payload := data[pos+4 : end]
+		if marker == 0xe1 && bytes.HasPrefix(payload, []byte("Exif\x00\x00")) {
+			return exifOrientation(payload[6:])
+		}
+		pos = end
+	}
+	return 1
+}
+
+// exifOrientation は TIFF 形式の EXIF から向きを読み取ります。読み取れない場合は 1(そのまま) を返します。
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:8]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+	count := int(order.Uint16(tiff[ifd : ifd+2]))
+	for i := 0; i < count; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:entry+2]) != exifOrientationTag {
+			continue
+		}
+		orientation := int(order.Uint16(tiff[entry+8 : entry+10]))
+		if orientation < 1 || orientation > 8 {
+			return 1
+		}
+		return orientation
+	}
+	return 1
+}
+
+// applyOrientation は EXIF の向き(2〜8)に従って画像を反転・回転します。
+func applyOrientation(img image.Image, orientation int) image.Image {
+	src := toNRGBA(img)
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2:
+				sx, sy = w-1-x, y
+			case 3:
+				sx, sy = w-1-x, h-1-y
+			case 4:
+				sx, sy = x, h-1-y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, h-1-x
+			case 7:
+				sx, sy = w-1-y, h-1-x
+			case 8:
+				sx, sy = w-1-y, x
+			default:
+				sx, sy = x, y
+			}
+			copy(dst.Pix[dst.PixOffset(x, y):dst.PixOffset(x, y)+4], src.Pix[src.PixOffset(sx, sy):src.PixOffset(sx, sy)+4])
+		}
+	}
+	return dst
+}
+
+func toNRGBA(img image.Image) *image.NRGBA {
+	bounds := img.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), img, bounds.Min, draw.Src)
+	return dst
+}
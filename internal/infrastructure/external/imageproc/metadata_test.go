@@ -0,0 +1,278 @@
+package imageproc_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/external/imageproc"
+)
+
+// newEXIF は Orientation と GPS の IFD へのポインタを持つリトルエンディアンの EXIF を作る
+func newEXIF(orientation uint16) []byte {
+	exif := make([]byte, 38)
+	copy(exif, "II")
+	binary.LittleEndian.PutUint16(exif[2:], 42)
+	binary.LittleEndian.PutUint32(exif[4:], 8)
+	binary.LittleEndian.PutUint16(exif[8:], 2)
+	binary.LittleEndian.PutUint16(exif[10:], 0x0112)
+	binary.LittleEndian.PutUint16(exif[12:], 3)
+	binary.LittleEndian.PutUint32(exif[14:], 1)
+	binary.LittleEndian.PutUint16(exif[18:], orientation)
+	binary.LittleEndian.PutUint16(exif[22:], 0x8825) // GPSInfo
+	binary.LittleEndian.PutUint16(exif[24:], 4)
+	binary.LittleEndian.PutUint32(exif[26:], 1)
+	binary.LittleEndian.PutUint32(exif[30:], 38)
+	return exif
+}
+
+// newJPEGWithEXIF は APP1 に EXIF と XMP を持つ幅 width、高さ height の JPEG を作る
+func newJPEGWithEXIF(t *testing.T, width int, height int, orientation uint16) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: 200, G: 100, B: 50, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90}))
+	encoded := buf.Bytes()
+
+	segment := func(marker byte, payload []byte) []byte {
+		seg := []byte{0xff, marker, 0, 0}
+		binary.BigEndian.PutUint16(seg[2:], uint16(len(payload)+2))
+		return append(seg, payload...)
+	}
+
+	out := append([]byte{}, encoded[:2]...)
+	out = append(out, segment(0xe1, append([]byte("Exif\x00\x00"), newEXIF(orientation)...))...)
+	out = append(out, segment(0xe1, []byte("http://ns.adobe.com/xap/1.0/\x00<x:xmpmeta>secret</x:xmpmeta>"))...)
+	out = append(out, segment(0xfe, []byte("comment"))...)
+	return append(out, encoded[2:]...)
+}
+
+func TestProcessor_StripMetadata_JPEG(t *testing.T) {
+	processor := imageproc.NewProcessor(10_000_000, 80)
+
+	t.Run("向きがそのままの場合はメタデータだけを取り除く", func(t *testing.T) {
+		src := newJPEGWithEXIF(t, 40, 20, 1)
+
+		result, err := processor.StripMetadata(context.Background(), src, "image/jpeg")
+		require.NoError(t, err)
+		stripped := result.Data
+		require.NotContains(t, string(stripped), "Exif")
+		require.NotContains(t, string(stripped), "xmpmeta")
+		require.NotContains(t, string(stripped), "comment")
+
+		cfg, err := jpeg.DecodeConfig(bytes.NewReader(stripped))
+		require.NoError(t, err)
+		require.Equal(t, 40, cfg.Width)
+		require.Equal(t, 20, cfg.Height)
+	})
+
+	t.Run("向きが指定されている場合は画素を回転させる", func(t *testing.T) {
+		src := newJPEGWithEXIF(t, 40, 20, 6)
+
+		result, err := processor.StripMetadata(context.Background(), src, "image/jpeg")
+		require.NoError(t, err)
+		stripped := result.Data
+		require.NotContains(t, string(stripped), "Exif")
+
+		cfg, err := jpeg.DecodeConfig(bytes.NewReader(stripped))
+		require.NoError(t, err)
+		require.Equal(t, 20, cfg.Width)
+		require.Equal(t, 40, cfg.Height)
+	})
+
+	t.Run("画素を回転させてもICCプロファイルは残す", func(t *testing.T) {
+		src := newJPEGWithEXIF(t, 40, 20, 6)
+		icc := []byte("ICC_PROFILE\x00\x01\x01display-p3")
+		segment := binary.BigEndian.AppendUint16([]byte{0xff, 0xe2}, uint16(len(icc)+2))
+		src = append(append(append([]byte{}, src[:2]...), append(segment, icc...)...), src[2:]...)
+
+		result, err := processor.StripMetadata(context.Background(), src, "image/jpeg")
+		require.NoError(t, err)
+		stripped := result.Data
+		require.NotContains(t, string(stripped), "Exif")
+		require.Contains(t, string(stripped), string(icc))
+
+		cfg, err := jpeg.DecodeConfig(bytes.NewReader(stripped))
+		require.NoError(t, err)
+		require.Equal(t, 20, cfg.Width)
+		require.Equal(t, 40, cfg.Height)
+	})
+}
+
+func TestProcessor_StripMetadata_TooLarge(t *testing.T) {
+	small := imageproc.NewProcessor(100, 80)
+
+	t.Run("デコードしない場合は画素数の上限を超えていても取り除く", func(t *testing.T) {
+		result, err := small.StripMetadata(context.Background(), newJPEGWithEXIF(t, 40, 20, 1), "image/jpeg")
+		require.NoError(t, err)
+		stripped := result.Data
+		require.NotContains(t, string(stripped), "Exif")
+	})
+
+	t.Run("向きを反映する場合は画素数の上限を超える画像を扱わない", func(t *testing.T) {
+		_, err := small.StripMetadata(context.Background(), newJPEGWithEXIF(t, 40, 20, 6), "image/jpeg")
+		require.ErrorIs(t, err, domainerrors.ErrImageTooLarge)
+	})
+}
+
+func pngChunk(chunkType string, data []byte) []byte {
+	chunk := make([]byte, 8, 12+len(data))
+	binary.BigEndian.PutUint32(chunk, uint32(len(data)))
+	copy(chunk[4:], chunkType)
+	chunk = append(chunk, data...)
+	return binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+}
+
+func TestProcessor_StripMetadata_PNG(t *testing.T) {
+	processor := imageproc.NewProcessor(10_000_000, 80)
+
+	img := image.NewNRGBA(image.Rect(0, 0, 30, 10))
+	// 左上だけ赤くして回転後の位置を確かめる
+	img.Set(0, 0, color.NRGBA{R: 255, A: 255})
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	encoded := buf.Bytes()
+
+	// IHDR の直後にメタデータのチャンクを差し込む
+	ihdrEnd := 8 + 12 + 13
+	src := append([]byte{}, encoded[:ihdrEnd]...)
+	src = append(src, pngChunk("tEXt", []byte("GPS\x0035.6,139.7"))...)
+	src = append(src, pngChunk("eXIf", newEXIF(8))...)
+	src = append(src, encoded[ihdrEnd:]...)
+
+	result, err := processor.StripMetadata(context.Background(), src, "image/png")
+	require.NoError(t, err)
+	stripped := result.Data
+	require.NotContains(t, string(stripped), "tEXt")
+	require.NotContains(t, string(stripped), "eXIf")
+
+	decoded, err := png.Decode(bytes.NewReader(stripped))
+	require.NoError(t, err)
+	require.Equal(t, 10, decoded.Bounds().Dx())
+	require.Equal(t, 30, decoded.Bounds().Dy())
+	// 向き 8 (反時計回りに 90 度) では左上の画素が左下に移る
+	r, _, _, _ := decoded.At(0, 29).RGBA()
+	require.Equal(t, uint32(0xffff), r)
+}
+
+func webpChunk(fourCC string, data []byte) []byte {
+	chunk := make([]byte, 8, 9+len(data))
+	copy(chunk, fourCC)
+	binary.LittleEndian.PutUint32(chunk[4:], uint32(len(data)))
+	chunk = append(chunk, data...)
+	if len(data)%2 == 1 {
+		chunk = append(chunk, 0)
+	}
+	return chunk
+}
+
+// bitWriter は WebP のロスレス形式のビット列を下位ビットから書き込む
+type bitWriter struct {
+	buf   []byte
+	nbits uint
+}
+
+func (w *bitWriter) write(value uint32, n uint) {
+	for i := uint(0); i < n; i++ {
+		if w.nbits%8 == 0 {
+			w.buf = append(w.buf, 0)
+		}
+		w.buf[len(w.buf)-1] |= byte((value>>i)&1) << (w.nbits % 8)
+		w.nbits++
+	}
+}
+
+// newVP8L は幅 width、高さ height の単色のロスレス WebP のビットストリームを作る
+func newVP8L(width int, height int) []byte {
+	w := &bitWriter{}
+	w.write(0x2f, 8)
+	w.write(uint32(width-1), 14)
+	w.write(uint32(height-1), 14)
+	w.write(0, 1) // アルファなし
+	w.write(0, 3) // バージョン
+	w.write(0, 1) // 変換なし
+	w.write(0, 1) // カラーキャッシュなし
+	w.write(0, 1) // メタプレフィックス符号なし
+	// 緑・赤・青・アルファ・距離の 5 つの符号をそれぞれ 1 つの記号だけの単純な符号にすると、画素は 0 ビットで表せる
+	for _, symbol := range []uint32{100, 200, 50, 255, 0} {
+		w.write(1, 1) // 単純な符号
+		w.write(0, 1) // 記号は 1 つ
+		w.write(1, 1) // 記号は 8 ビット
+		w.write(symbol, 8)
+	}
+	return w.buf
+}
+
+func TestProcessor_StripMetadata_WebP(t *testing.T) {
+	processor := imageproc.NewProcessor(10_000_000, 80)
+
+	newWebP := func(orientation uint16, flags byte) []byte {
+		// VP8X のフラグに EXIF と XMP を立て、キャンバスを 16x8 とする
+		vp8x := []byte{0x0c | flags, 0, 0, 0, 15, 0, 0, 7, 0, 0}
+		body := []byte("WEBP")
+		body = append(body, webpChunk("VP8X", vp8x)...)
+		body = append(body, webpChunk("VP8L", newVP8L(16, 8))...)
+		body = append(body, webpChunk("EXIF", newEXIF(orientation))...)
+		body = append(body, webpChunk("XMP ", []byte("<x:xmpmeta>secret</x:xmpmeta>"))...)
+		out := []byte("RIFF")
+		out = binary.LittleEndian.AppendUint32(out, uint32(len(body)))
+		return append(out, body...)
+	}
+
+	t.Run("EXIFとXMPのチャンクを取り除く", func(t *testing.T) {
+		result, err := processor.StripMetadata(context.Background(), newWebP(1, 0), "image/webp")
+		require.NoError(t, err)
+		require.Empty(t, result.ConvertedExtension)
+		stripped := result.Data
+		require.NotContains(t, string(stripped), "EXIF")
+		require.NotContains(t, string(stripped), "xmpmeta")
+		require.Contains(t, string(stripped), "VP8L")
+		require.Equal(t, uint32(len(stripped)-8), binary.LittleEndian.Uint32(stripped[4:8]))
+		// VP8X のフラグも落とす
+		require.Equal(t, byte(0), stripped[20]&0x0c)
+	})
+
+	t.Run("向きが指定されている場合は画素を回転させてPNGに変換する", func(t *testing.T) {
+		result, err := processor.StripMetadata(context.Background(), newWebP(6, 0), "image/webp")
+		require.NoError(t, err)
+		require.Equal(t, "png", result.ConvertedExtension)
+		require.NotContains(t, string(result.Data), "eXIf")
+
+		decoded, err := png.Decode(bytes.NewReader(result.Data))
+		require.NoError(t, err)
+		require.Equal(t, 8, decoded.Bounds().Dx())
+		require.Equal(t, 16, decoded.Bounds().Dy())
+		r, g, b, _ := decoded.At(0, 0).RGBA()
+		require.Equal(t, []uint32{200, 100, 50}, []uint32{r >> 8, g >> 8, b >> 8})
+	})
+
+	t.Run("デコードできないアニメーションは向きを反映せずに取り除く", func(t *testing.T) {
+		result, err := processor.StripMetadata(context.Background(), newWebP(6, 0x02), "image/webp")
+		require.NoError(t, err)
+		require.Empty(t, result.ConvertedExtension)
+		require.NotContains(t, string(result.Data), "EXIF")
+		require.Equal(t, byte(0), result.Data[20]&0x0c)
+	})
+}
+
+func TestProcessor_StripMetadata_Malformed(t *testing.T) {
+	processor := imageproc.NewProcessor(10_000_000, 80)
+
+	_, err := processor.StripMetadata(context.Background(), []byte("\xff\xd8\xff\xe1\xff\xff"), "image/jpeg")
+	require.Error(t, err)
+}
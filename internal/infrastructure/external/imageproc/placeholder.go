@@ -0,0 +1,86 @@
+package imageproc
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"io"
+
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+)
+
+// placeholderSize はプレースホルダーを求める前に縮小する長辺の画素数です。
+// BlurHash も主要な色も細部は使わないため、縮小してから計算します。
+const placeholderSize = 64
+
+// BlurHash の成分の数です。長辺の方向に 4、短辺の方向に 3 とします。
+const (
+	blurHashLongComponents  = 4
+	blurHashShortComponents = 3
+)
+
+func (p *Processor) GeneratePlaceholder(ctx context.Context, src io.Reader) (*entity.ImagePlaceholder, error) {
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+
+	img, err := p.decode(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	smallWidth, smallHeight := width, height
+	if longSide := max(width, height); longSide > placeholderSize {
+		smallWidth = max(width*placeholderSize/longSide, 1)
+		smallHeight = max(height*placeholderSize/longSide, 1)
+	}
+	small := resize(img, smallWidth, smallHeight).(*image.RGBA)
+
+	xComponents, yComponents := blurHashLongComponents, blurHashShortComponents
+	if height > width {
+		xComponents, yComponents = blurHashShortComponents, blurHashLongComponents
+	}
+	return &entity.ImagePlaceholder{
+		Width:         width,
+		Height:        height,
+		BlurHash:      encodeBlurHash(small, xComponents, yComponents),
+		DominantColor: dominantColor(small),
+	}, nil
+}
+
+// dominantColor は色を 4096 色に減色したときに最も多い色を求め、その色に含まれる画素の平均を返します。
+func dominantColor(img *image.RGBA) string {
+	type bucket struct {
+		count   int
+		r, g, b int
+	}
+	var buckets [1 << 12]bucket
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := img.RGBAAt(x, y)
+			b := &buckets[int(c.R>>4)<<8|int(c.G>>4)<<4|int(c.B>>4)]
+			b.count++
+			b.r += int(c.R)
+			b.g += int(c.G)
+			b.b += int(c.B)
+		}
+	}
+
+	best := &buckets[0]
+	for i := range buckets {
+		if buckets[i].count > best.count {
+			best = &buckets[i]
+		}
+	}
+	if best.count == 0 {
+		return "#ffffff"
+	}
+	return fmt.Sprintf("#%02x%02x%02x", best.r/best.count, best.g/best.count, best.b/best.count)
+}
@@ -0,0 +1,82 @@
+package imageproc_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/external/imageproc"
+)
+
+func newSolidPNG(t *testing.T, width int, height int, c color.Color) []byte {
+	t.Helper()
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestProcessor_GeneratePlaceholder(t *testing.T) {
+	processor := imageproc.NewProcessor(10_000_000, 80)
+
+	t.Run("大きさと BlurHash と主要な色を求める", func(t *testing.T) {
+		placeholder, err := processor.GeneratePlaceholder(context.Background(), bytes.NewReader(newSolidPNG(t, 200, 100, color.NRGBA{R: 200, G: 100, B: 50, A: 255})))
+		require.NoError(t, err)
+
+		require.Equal(t, 200, placeholder.Width)
+		require.Equal(t, 100, placeholder.Height)
+		// 64x32 に縮小してから横 4・縦 3 成分で求める。平均の色は #c86432 のまま
+		require.Equal(t, "L5M|T9-9fQ-9}Xj@fQj@fQfQfQfQ", placeholder.BlurHash)
+		require.Equal(t, "#c86432", placeholder.DominantColor)
+	})
+
+	t.Run("最も多く使われている色を主要な色とする", func(t *testing.T) {
+		img := image.NewNRGBA(image.Rect(0, 0, 40, 40))
+		for y := 0; y < 40; y++ {
+			for x := 0; x < 40; x++ {
+				if x < 30 {
+					img.Set(x, y, color.NRGBA{R: 20, G: 120, B: 220, A: 255})
+				} else {
+					img.Set(x, y, color.NRGBA{R: 250, G: 250, B: 10, A: 255})
+				}
+			}
+		}
+		var buf bytes.Buffer
+		require.NoError(t, png.Encode(&buf, img))
+
+		placeholder, err := processor.GeneratePlaceholder(context.Background(), &buf)
+		require.NoError(t, err)
+		require.Equal(t, "#1478dc", placeholder.DominantColor)
+		require.Len(t, placeholder.BlurHash, 28)
+	})
+
+	t.Run("EXIF の向きを反映した大きさを返す", func(t *testing.T) {
+		// 向き 6 は時計回りに 90 度回して表示する
+		placeholder, err := processor.GeneratePlaceholder(context.Background(), bytes.NewReader(newJPEGWithEXIF(t, 40, 20, 6)))
+		require.NoError(t, err)
+
+		require.Equal(t, 20, placeholder.Width)
+		require.Equal(t, 40, placeholder.Height)
+		// 縦長なので横 3・縦 4 成分になる
+		require.Equal(t, byte('T'), placeholder.BlurHash[0])
+	})
+
+	t.Run("画素数が上限を超える画像は扱わない", func(t *testing.T) {
+		small := imageproc.NewProcessor(100*100, 80)
+
+		_, err := small.GeneratePlaceholder(context.Background(), bytes.NewReader(newSolidPNG(t, 200, 100, color.White)))
+		require.ErrorIs(t, err, domainerrors.ErrImageTooLarge)
+	})
+}
@@ -0,0 +1,121 @@
+package imageproc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+
+	"golang.org/x/image/draw"
+
+	_ "golang.org/x/image/bmp"
+	_ "golang.org/x/image/webp"
+
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+)
+
+// 派生画像は外部ライブラリに頼らず標準ライブラリで書き出せる JPEG にします。
+const (
+	variantContentType = "image/jpeg"
+	variantExtension   = "jpg"
+)
+
+// Processor は純粋な Go だけで画像を加工する ImageProcessor の実装です。
+type Processor struct {
+	// maxPixels を超える画像はデコード時のメモリ消費が大きいため加工しません。
+	maxPixels int
+	quality   int
+}
+
+func NewProcessor(maxPixels int, quality int) *Processor {
+	return &Processor{
+		maxPixels: maxPixels,
+		quality:   quality,
+	}
+}
+
+func (p *Processor) GenerateVariants(ctx context.Context, src io.Reader, widths []int) ([]*entity.ImageVariant, error) {
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+
+	img, err := p.decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := img.Bounds()
+	variants := make([]*entity.ImageVariant, 0, len(widths))
+	for _, width := range widths {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if width <= 0 || width >= bounds.Dx() {
+			continue
+		}
+		height := max(bounds.Dy()*width/bounds.Dx(), 1)
+
+		encoded, err := p.encode(resize(img, width, height))
+		if err != nil {
+			return nil, err
+		}
+		variants = append(variants, &entity.ImageVariant{
+			Width:       width,
+			Height:      height,
+			ContentType: variantContentType,
+			Extension:   variantExtension,
+			Data:        encoded,
+		})
+	}
+	return variants, nil
+}
+
+// decode は画素数が上限を超えていないことを確かめてから画像をデコードし、EXIF の向きを画素に反映します。
+func (p *Processor) decode(data []byte) (image.Image, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image config: %w", err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > p.maxPixels {
+		return nil, domainerrors.ErrImageTooLarge
+	}
+
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+	orientation := 1
+	switch format {
+	case "webp":
+		orientation = webpOrientation(data)
+	case "jpeg":
+		orientation = jpegOrientation(data)
+	}
+	if orientation != 1 {
+		img = applyOrientation(img, orientation)
+	}
+	return img, nil
+}
+
+// resize は透過部分を白で塗りつぶしたうえで画像を縮小します。JPEG はアルファチャンネルを持てないためです。
+func resize(img image.Image, width int, height int) image.Image {
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, img.Bounds(), draw.Over, nil)
+	return dst
+}
+
+func (p *Processor) encode(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: p.quality}); err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,66 @@
+package imageproc_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/external/imageproc"
+)
+
+func newPNG(t *testing.T, width int, height int) []byte {
+	t.Helper()
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestProcessor_GenerateVariants(t *testing.T) {
+	processor := imageproc.NewProcessor(10_000_000, 80)
+
+	variants, err := processor.GenerateVariants(context.Background(), bytes.NewReader(newPNG(t, 800, 400)), []int{320, 640, 1280})
+	require.NoError(t, err)
+
+	// 元画像より大きい幅は生成しない
+	require.Len(t, variants, 2)
+	for i, want := range []struct{ width, height int }{{320, 160}, {640, 320}} {
+		variant := variants[i]
+		require.Equal(t, want.width, variant.Width)
+		require.Equal(t, want.height, variant.Height)
+		require.Equal(t, "image/jpeg", variant.ContentType)
+		require.Equal(t, "jpg", variant.Extension)
+
+		decoded, err := jpeg.Decode(bytes.NewReader(variant.Data))
+		require.NoError(t, err)
+		require.Equal(t, want.width, decoded.Bounds().Dx())
+		require.Equal(t, want.height, decoded.Bounds().Dy())
+	}
+}
+
+func TestProcessor_GenerateVariants_TooManyPixels(t *testing.T) {
+	processor := imageproc.NewProcessor(100*100, 80)
+
+	_, err := processor.GenerateVariants(context.Background(), bytes.NewReader(newPNG(t, 200, 100)), []int{32})
+	require.ErrorIs(t, err, domainerrors.ErrImageTooLarge)
+}
+
+func TestProcessor_GenerateVariants_InvalidImage(t *testing.T) {
+	processor := imageproc.NewProcessor(10_000_000, 80)
+
+	_, err := processor.GenerateVariants(context.Background(), bytes.NewReader([]byte("\x89PNG\r\n\x1a\nbroken")), []int{32})
+	require.Error(t, err)
+}
@@ -0,0 +1,286 @@
+package ziparchive
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+)
+
+// Inspector は標準ライブラリの archive/zip で ZIP を検査する ArchiveInspector の実装です。
+// 中央ディレクトリだけを読み、ファイルの中身は展開しません。
+// archive/zip は申告された展開後のサイズを超えて読み出せないため、申告の合計で展開後のサイズを制限できます。
+type Inspector struct {
+	maxEntries int
+	// maxTotalSize は展開後の合計バイト数の上限です
+	maxTotalSize int64
+	// maxRatio は ZIP のサイズに対する展開後のサイズの比率の上限です
+	maxRatio int64
+}
+
+func NewInspector(maxEntries int, maxTotalSize int64, maxRatio int64) *Inspector {
+	return &Inspector{
+		maxEntries:   maxEntries,
+		maxTotalSize: maxTotalSize,
+		maxRatio:     maxRatio,
+	}
+}
+
+// readerAtSeeker は中央ディレクトリを読むために、末尾からの位置指定と読み込みができるファイルです
+type readerAtSeeker interface {
+	io.ReaderAt
+	io.Seeker
+}
+
+func (i *Inspector) InspectZip(ctx context.Context, src io.Reader) (*entity.ZipManifest, error) {
+	reader, size, release, err := openZip(src)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	if len(reader.File) > i.maxEntries {
+		return nil, fmt.Errorf("%w: %d entries", domainerrors.ErrZipBomb, len(reader.File))
+	}
+
+	manifest := &entity.ZipManifest{Entries: make([]*entity.ZipEntry, 0, len(reader.File))}
+	var totalSize uint64
+	for _, f := range reader.File {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		name, ok := entryPath(f.Name)
+		if !ok || f.Mode()&fs.ModeSymlink != 0 {
+			return nil, fmt.Errorf("%w: %q", domainerrors.ErrUnsafeZipPath, f.Name)
+		}
+		// 中身を共有するエントリを重ねた ZIP でも申告の合計は増えるため、合計で判定する
+		totalSize += f.UncompressedSize64
+		if totalSize > uint64(i.maxTotalSize) {
+			return nil, fmt.Errorf("%w: more than %d bytes", domainerrors.ErrZipBomb, i.maxTotalSize)
+		}
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		manifest.Entries = append(manifest.Entries, &entity.ZipEntry{
+			Path:           name,
+			Size:           int64(f.UncompressedSize64),
+			CompressedSize: int64(f.CompressedSize64),
+		})
+	}
+	if size > 0 && totalSize/uint64(size) > uint64(i.maxRatio) {
+		return nil, fmt.Errorf("%w: compression ratio is over %d", domainerrors.ErrZipBomb, i.maxRatio)
+	}
+	manifest.TotalSize = int64(totalSize)
+
+	paths := make([]string, len(manifest.Entries))
+	for j, e := range manifest.Entries {
+		paths[j] = e.Path
+	}
+	manifest.Contents = detectContents(paths)
+	return manifest, nil
+}
+
+// ExtractZip は ZIP のうち dir の下にあるファイルを、dir からの相対パスとともに順に fn へ渡します。
+// 展開後のサイズは InspectZip で確かめてから呼び出します
+func (i *Inspector) ExtractZip(ctx context.Context, src io.Reader, dir string, fn func(filePath string, body io.Reader) error) error {
+	reader, _, release, err := openZip(src)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	prefix := ""
+	if dir != "" {
+		prefix = dir + "/"
+	}
+	for _, f := range reader.File {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		name, ok := entryPath(f.Name)
+		if !ok || f.Mode()&fs.ModeSymlink != 0 {
+			return fmt.Errorf("%w: %q", domainerrors.ErrUnsafeZipPath, f.Name)
+		}
+		if f.FileInfo().IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if err := extractFile(f, strings.TrimPrefix(name, prefix), fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractFile(f *zip.File, filePath string, fn func(filePath string, body io.Reader) error) error {
+	body, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("%w: %w", domainerrors.ErrInvalidZip, err)
+	}
+	defer body.Close()
+	return fn(filePath, body)
+}
+
+// openZip は中央ディレクトリを読み、ZIP のサイズとともに返します。読み終えたら release を呼び出します
+func openZip(src io.Reader) (reader *zip.Reader, size int64, release func(), err error) {
+	release = func() {}
+	file, ok := src.(readerAtSeeker)
+	if !ok {
+		// ストレージから読み込んだファイルは位置を指定して読めないため、一時ファイルに書き出す
+		tmp, err := os.CreateTemp("", "zip-inspect-*")
+		if err != nil {
+			return nil, 0, nil, fmt.Errorf("failed to create temp file: %w", err)
+		}
+		release = func() {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+		if _, err := io.Copy(tmp, src); err != nil {
+			release()
+			return nil, 0, nil, fmt.Errorf("failed to copy zip: %w", err)
+		}
+		file = tmp
+	}
+
+	size, err = file.Seek(0, io.SeekEnd)
+	if err == nil {
+		reader, err = zip.NewReader(file, size)
+		if err != nil && !errors.Is(err, zip.ErrInsecurePath) {
+			err = fmt.Errorf("%w: %w", domainerrors.ErrInvalidZip, err)
+		} else {
+			err = nil
+		}
+	} else {
+		err = fmt.Errorf("failed to seek zip: %w", err)
+	}
+	if err != nil {
+		release()
+		return nil, 0, nil, err
+	}
+	return reader, size, release, nil
+}
+
+// entryPath はエントリ名を "/" 区切りの相対パスにします。展開先の外を指すパスの場合は false を返します
+func entryPath(name string) (string, bool) {
+	// Windows で作られた ZIP は区切り文字に "\" を使うことがある
+	name = strings.ReplaceAll(name, `\`, "/")
+	if name == "" || strings.ContainsRune(name, 0) || strings.HasPrefix(name, "/") {
+		return "", false
+	}
+	// "C:" のようなドライブの指定
+	if len(name) >= 2 && name[1] == ':' {
+		return "", false
+	}
+	cleaned := path.Clean(name)
+	if cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", false
+	}
+	return cleaned, true
+}
+
+// executableExtensions は実行ファイルとして扱う拡張子です
+var executableExtensions = map[string]bool{
+	".exe":      true,
+	".msi":      true,
+	".bat":      true,
+	".cmd":      true,
+	".com":      true,
+	".scr":      true,
+	".ps1":      true,
+	".apk":      true,
+	".dmg":      true,
+	".appimage": true,
+	".jar":      true,
+}
+
+// projectFiles はソースコードのプロジェクトであることを示すファイル名です
+var projectFiles = map[string]bool{
+	"package.json":     true,
+	"go.mod":           true,
+	"cargo.toml":       true,
+	"cmakelists.txt":   true,
+	"makefile":         true,
+	"pom.xml":          true,
+	"build.gradle":     true,
+	"build.gradle.kts": true,
+	"pyproject.toml":   true,
+	"requirements.txt": true,
+	"project.godot":    true,
+}
+
+// projectExtensions はソースコードのプロジェクトであることを示す拡張子です
+var projectExtensions = map[string]bool{
+	".sln":      true,
+	".csproj":   true,
+	".uproject": true,
+}
+
+// detectContents はファイルのパスから ZIP の内容の種類を推定します
+func detectContents(paths []string) []string {
+	var hasIndexHTML, hasUnityLoader, hasUnityAssets, hasUnitySettings bool
+	var hasExecutable, hasProject bool
+	for _, p := range paths {
+		lower := strings.ToLower(p)
+		base := path.Base(lower)
+		ext := path.Ext(base)
+		dirs := strings.Split(path.Dir(lower), "/")
+
+		switch {
+		case base == "index.html":
+			hasIndexHTML = true
+		case containsDir(dirs, "build") && (strings.HasSuffix(base, ".loader.js") || base == "unityloader.js"):
+			hasUnityLoader = true
+		}
+		if executableExtensions[ext] || containsSuffixDir(dirs, ".app") {
+			hasExecutable = true
+		}
+		if projectFiles[base] || projectExtensions[ext] || containsSuffixDir(dirs, ".xcodeproj") {
+			hasProject = true
+		}
+		// Unity のプロジェクトは Assets と ProjectSettings のディレクトリを持つ
+		if containsDir(dirs, "assets") {
+			hasUnityAssets = true
+		}
+		if containsDir(dirs, "projectsettings") {
+			hasUnitySettings = true
+		}
+	}
+
+	var contents []string
+	if hasIndexHTML && hasUnityLoader {
+		contents = append(contents, entity.ZipContentUnityWebGL)
+	}
+	if hasExecutable {
+		contents = append(contents, entity.ZipContentExecutable)
+	}
+	if hasProject || (hasUnityAssets && hasUnitySettings) {
+		contents = append(contents, entity.ZipContentSourceProject)
+	}
+	sort.Strings(contents)
+	return contents
+}
+
+func containsDir(dirs []string, name string) bool {
+	for _, dir := range dirs {
+		if dir == name {
+			return true
+		}
+	}
+	return false
+}
+
+func containsSuffixDir(dirs []string, suffix string) bool {
+	for _, dir := range dirs {
+		if len(dir) > len(suffix) && strings.HasSuffix(dir, suffix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,189 @@
+package ziparchive_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/external/ziparchive"
+)
+
+func newZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestInspector_InspectZip(t *testing.T) {
+	inspector := ziparchive.NewInspector(100, 1<<20, 100)
+
+	data := newZip(t, map[string]string{
+		"game/index.html":               "<html></html>",
+		"game/Build/game.loader.js":     "loader",
+		"game/Build/game.wasm":          "wasm",
+		"game/TemplateData/style.css":   "body {}",
+		`windows\Game.exe`:              "MZ",
+		"src/Assets/Scripts/Player.cs":  "class Player {}",
+		"src/ProjectSettings/Tag.asset": "tags",
+		"src/Packages/manifest.json":    "{}",
+		"empty/":                        "",
+	})
+
+	manifest, err := inspector.InspectZip(context.Background(), bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, []string{entity.ZipContentExecutable, entity.ZipContentSourceProject, entity.ZipContentUnityWebGL}, manifest.Contents)
+
+	// ディレクトリは一覧に含めず、"\" 区切りのパスは "/" 区切りにする
+	paths := make(map[string]int64)
+	for _, e := range manifest.Entries {
+		paths[e.Path] = e.Size
+	}
+	require.Len(t, paths, 8)
+	require.Equal(t, int64(len("MZ")), paths["windows/Game.exe"])
+	require.Equal(t, int64(len("<html></html>")), paths["game/index.html"])
+	require.NotContains(t, paths, "empty")
+	require.Equal(t, int64(len("<html></html>loaderwasmbody {}MZclass Player {}tags{}")), manifest.TotalSize)
+}
+
+func TestInspector_InspectZip_FromStream(t *testing.T) {
+	inspector := ziparchive.NewInspector(100, 1<<20, 100)
+
+	// ストレージから読み込んだファイルのように位置を指定して読めない場合
+	data := newZip(t, map[string]string{"README.md": "readme"})
+	manifest, err := inspector.InspectZip(context.Background(), io.NopCloser(bytes.NewReader(data)))
+	require.NoError(t, err)
+	require.Len(t, manifest.Entries, 1)
+	require.Equal(t, "README.md", manifest.Entries[0].Path)
+	require.Empty(t, manifest.Contents)
+}
+
+func TestInspector_InspectZip_UnsafePath(t *testing.T) {
+	inspector := ziparchive.NewInspector(100, 1<<20, 100)
+
+	for _, name := range []string{"../evil.sh", "a/../../evil.sh", `..\evil.exe`, "/etc/passwd", "C:/Windows/evil.dll"} {
+		t.Run(name, func(t *testing.T) {
+			data := newZip(t, map[string]string{name: "evil"})
+			_, err := inspector.InspectZip(context.Background(), bytes.NewReader(data))
+			require.ErrorIs(t, err, domainerrors.ErrUnsafeZipPath)
+		})
+	}
+}
+
+func TestInspector_InspectZip_Symlink(t *testing.T) {
+	inspector := ziparchive.NewInspector(100, 1<<20, 100)
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	header := &zip.FileHeader{Name: "link"}
+	header.SetMode(fs.ModeSymlink | 0o777)
+	f, err := w.CreateHeader(header)
+	require.NoError(t, err)
+	_, err = f.Write([]byte("/etc/passwd"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	_, err = inspector.InspectZip(context.Background(), bytes.NewReader(buf.Bytes()))
+	require.ErrorIs(t, err, domainerrors.ErrUnsafeZipPath)
+}
+
+func TestInspector_InspectZip_Bomb(t *testing.T) {
+	zeros := string(make([]byte, 1<<20))
+
+	t.Run("展開後の合計サイズが上限を超える", func(t *testing.T) {
+		inspector := ziparchive.NewInspector(100, 1<<20, 100_000)
+		data := newZip(t, map[string]string{"a.bin": zeros, "b.bin": zeros})
+		_, err := inspector.InspectZip(context.Background(), bytes.NewReader(data))
+		require.ErrorIs(t, err, domainerrors.ErrZipBomb)
+	})
+
+	t.Run("圧縮率が上限を超える", func(t *testing.T) {
+		inspector := ziparchive.NewInspector(100, 1<<30, 100)
+		data := newZip(t, map[string]string{"a.bin": zeros})
+		_, err := inspector.InspectZip(context.Background(), bytes.NewReader(data))
+		require.ErrorIs(t, err, domainerrors.ErrZipBomb)
+	})
+
+	t.Run("ファイル数が上限を超える", func(t *testing.T) {
+		inspector := ziparchive.NewInspector(2, 1<<30, 100)
+		data := newZip(t, map[string]string{"a": "a", "b": "b", "c": "c"})
+		_, err := inspector.InspectZip(context.Background(), bytes.NewReader(data))
+		require.ErrorIs(t, err, domainerrors.ErrZipBomb)
+	})
+}
+
+func TestInspector_InspectZip_Invalid(t *testing.T) {
+	inspector := ziparchive.NewInspector(100, 1<<20, 100)
+
+	_, err := inspector.InspectZip(context.Background(), bytes.NewReader([]byte("PK\x03\x04 broken")))
+	require.ErrorIs(t, err, domainerrors.ErrInvalidZip)
+}
+
+func TestInspector_ExtractZip(t *testing.T) {
+	inspector := ziparchive.NewInspector(100, 1<<20, 100)
+
+	data := newZip(t, map[string]string{
+		"game/index.html":              "<html></html>",
+		"game/Build/game.wasm.br":      "wasm",
+		"game/TemplateData/":           "",
+		"README.md":                    "readme",
+		"src/Assets/Scripts/Player.cs": "class Player {}",
+	})
+
+	t.Run("dir の下のファイルだけを dir からの相対パスで渡す", func(t *testing.T) {
+		files := make(map[string]string)
+		err := inspector.ExtractZip(context.Background(), io.NopCloser(bytes.NewReader(data)), "game", func(filePath string, body io.Reader) error {
+			content, err := io.ReadAll(body)
+			require.NoError(t, err)
+			files[filePath] = string(content)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{
+			"index.html":         "<html></html>",
+			"Build/game.wasm.br": "wasm",
+		}, files)
+	})
+
+	t.Run("dir が空の場合はすべてのファイルを渡す", func(t *testing.T) {
+		var count int
+		err := inspector.ExtractZip(context.Background(), bytes.NewReader(data), "", func(string, io.Reader) error {
+			count++
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 4, count)
+	})
+
+	t.Run("fn のエラーで中断する", func(t *testing.T) {
+		errStop := errors.New("stop")
+		err := inspector.ExtractZip(context.Background(), bytes.NewReader(data), "", func(string, io.Reader) error {
+			return errStop
+		})
+		require.ErrorIs(t, err, errStop)
+	})
+
+	t.Run("展開先の外を指すパス", func(t *testing.T) {
+		evil := newZip(t, map[string]string{"../index.html": "<html></html>"})
+		err := inspector.ExtractZip(context.Background(), bytes.NewReader(evil), "", func(string, io.Reader) error {
+			return nil
+		})
+		require.ErrorIs(t, err, domainerrors.ErrUnsafeZipPath)
+	})
+}
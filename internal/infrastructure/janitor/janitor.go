@@ -0,0 +1,69 @@
+package janitor
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Janitor は一定間隔でバックグラウンドの後片付けを実行します。
+// 起動直後にも一度実行し、Stop を呼ぶと実行中の処理の終了を待ってから止まります。
+type Janitor struct {
+	name     string
+	interval time.Duration
+	task     func(ctx context.Context) error
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func NewJanitor(name string, interval time.Duration, task func(ctx context.Context) error) *Janitor {
+	return &Janitor{
+		name:     name,
+		interval: interval,
+		task:     task,
+	}
+}
+
+func (j *Janitor) Start() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.cancel != nil || j.interval <= 0 {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	j.cancel = cancel
+	j.done = make(chan struct{})
+
+	go func() {
+		defer close(j.done)
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+
+		for {
+			if err := j.task(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("%s の実行に失敗しました: %v", j.name, err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (j *Janitor) Stop() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.cancel == nil {
+		return
+	}
+	j.cancel()
+	<-j.done
+	j.cancel = nil
+}
@@ -0,0 +1,44 @@
+package janitor_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/simesaba80/toybox-back/internal/infrastructure/janitor"
+)
+
+func TestJanitor_RunsPeriodicallyUntilStopped(t *testing.T) {
+	var runs atomic.Int32
+	j := janitor.NewJanitor("test", 10*time.Millisecond, func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	})
+
+	j.Start()
+	require.Eventually(t, func() bool { return runs.Load() >= 3 }, time.Second, 5*time.Millisecond)
+
+	j.Stop()
+	stopped := runs.Load()
+	time.Sleep(30 * time.Millisecond)
+	require.Equal(t, stopped, runs.Load())
+
+	// 二度止めても問題ない
+	j.Stop()
+}
+
+func TestJanitor_StopCancelsRunningTask(t *testing.T) {
+	started := make(chan struct{})
+	j := janitor.NewJanitor("test", time.Hour, func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	j.Start()
+	<-started
+	j.Stop()
+}
@@ -1,13 +1,17 @@
 package router
 
 import (
+	"net"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/golang-jwt/jwt/v5"
 	echojwt "github.com/labstack/echo-jwt/v4"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/simesaba80/toybox-back/internal/infrastructure/config"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/external/blobstore"
 	"github.com/simesaba80/toybox-back/internal/interface/controller"
 	"github.com/simesaba80/toybox-back/internal/interface/schema"
 	"github.com/simesaba80/toybox-back/pkg/echovalidator"
@@ -15,31 +19,44 @@ import (
 )
 
 type Router struct {
-	echo               *echo.Echo
-	UserController     *controller.UserController
-	WorkController     *controller.WorkController
-	CommentController  *controller.CommentController
-	AuthController     *controller.AuthController
-	AssetController    *controller.AssetController
-	FavoriteController *controller.FavoriteController
-	TagController      *controller.TagController
+	echo                   *echo.Echo
+	UserController         *controller.UserController
+	WorkController         *controller.WorkController
+	CommentController      *controller.CommentController
+	AuthController         *controller.AuthController
+	AssetController        *controller.AssetController
+	FavoriteController     *controller.FavoriteController
+	TagController          *controller.TagController
+	FollowController       *controller.FollowController
+	TagFollowController    *controller.TagFollowController
+	NotificationController *controller.NotificationController
+	EventController        *controller.EventController
+	MentionController      *controller.MentionController
+	ReactionController     *controller.ReactionController
 }
 
-func NewRouter(e *echo.Echo, uc *controller.UserController, wc *controller.WorkController, cc *controller.CommentController, authc *controller.AuthController, assetc *controller.AssetController, fc *controller.FavoriteController, tagc *controller.TagController) *Router {
+func NewRouter(e *echo.Echo, uc *controller.UserController, wc *controller.WorkController, cc *controller.CommentController, authc *controller.AuthController, assetc *controller.AssetController, fc *controller.FavoriteController, tagc *controller.TagController, followc *controller.FollowController, tagfollowc *controller.TagFollowController, nc *controller.NotificationController, ec *controller.EventController, mc *controller.MentionController, rc *controller.ReactionController) *Router {
 	return &Router{
-		echo:               e,
-		UserController:     uc,
-		WorkController:     wc,
-		CommentController:  cc,
-		AuthController:     authc,
-		AssetController:    assetc,
-		FavoriteController: fc,
-		TagController:      tagc,
+		echo:                   e,
+		UserController:         uc,
+		WorkController:         wc,
+		CommentController:      cc,
+		AuthController:         authc,
+		AssetController:        assetc,
+		FavoriteController:     fc,
+		TagController:          tagc,
+		FollowController:       followc,
+		TagFollowController:    tagfollowc,
+		NotificationController: nc,
+		EventController:        ec,
+		MentionController:      mc,
+		ReactionController:     rc,
 	}
 }
 
 func (r *Router) Setup() *echo.Echo {
 	r.echo.Validator = echovalidator.NewValidator()
+	r.echo.IPExtractor = ipExtractor(config.TRUSTED_PROXIES)
 	r.echo.Use(middleware.Logger())
 	r.echo.Use(middleware.Recover())
 	r.echo.Use(middleware.CORSWithConfig(middleware.CORSConfig{
@@ -51,6 +68,17 @@ func (r *Router) Setup() *echo.Echo {
 
 	r.echo.GET("/swagger/*", echoSwagger.WrapHandler)
 
+	if config.STORAGE_BACKEND == config.StorageBackendLocal {
+		// ローカルに保存したファイルを LOCAL_STORAGE_BASE_URL のパスで配信する
+		r.echo.Add(
+			http.MethodGet,
+			localStoragePath()+"*",
+			echo.StaticDirectoryHandler(echo.MustSubFS(r.echo.Filesystem, config.LOCAL_STORAGE_DIR), false),
+			sandboxedContent,
+			encodedContentHeaders,
+		)
+	}
+
 	r.echo.GET("/health", func(c echo.Context) error {
 		return c.JSON(200, map[string]string{"status": "ok"})
 	})
@@ -64,6 +92,8 @@ func (r *Router) Setup() *echo.Echo {
 	// User
 	r.echo.GET("/users", r.UserController.GetAllUsers)
 	r.echo.GET("/users/:id", r.UserController.GetUserByID)
+	r.echo.GET("/users/:id/followers", r.FollowController.GetFollowers)
+	r.echo.GET("/users/:id/following", r.FollowController.GetFollowing)
 
 	// Work
 	optionalConfig := echojwt.Config{
@@ -80,16 +110,19 @@ func (r *Router) Setup() *echo.Echo {
 
 	o.GET("", r.WorkController.GetAllWorks)
 	o.GET("/users/:user_id", r.WorkController.GetWorksByUserID)
-
-	r.echo.GET("/works/:work_id", r.WorkController.GetWorkByID)
-
-	// Comment
-	r.echo.GET("/works/:work_id/comments", r.CommentController.GetCommentsByWorkID)
-	r.echo.POST("/works/:work_id/comments", r.CommentController.CreateComment)
+	// コメントの投稿者はトークンから決める。トークンがない場合は匿名コメントになる
+	o.POST("/:work_id/comments", r.CommentController.CreateComment)
+	// トークンがある場合は自分が付けたリアクションも返す
+	o.GET("/:work_id", r.WorkController.GetWorkByID)
+	o.GET("/:work_id/comments", r.CommentController.GetCommentsByWorkID)
+	o.GET("/:work_id/comments/:id/replies", r.CommentController.GetReplies)
 
 	// Favorite
 	r.echo.GET("/works/:work_id/favorite", r.FavoriteController.CountFavoritesByWorkID)
 
+	// Asset (認証不要 - ダウンロード前に ZIP の中身を確認する)
+	r.echo.GET("/assets/:id/manifest", r.AssetController.GetZipManifest)
+
 	// Tag (認証不要 - 一覧取得)
 	r.echo.GET("/tags", r.TagController.GetAllTags)
 
@@ -105,20 +138,112 @@ func (r *Router) Setup() *echo.Echo {
 	// User
 	e.PUT("/users", r.UserController.UpdateUser)
 	e.GET("/users/me", r.UserController.GetIconAndURLByUserID)
+	e.GET("/users/me/storage", r.AssetController.GetStorageUsage)
 
 	// Work
 	e.POST("/works", r.WorkController.CreateWork)
+	e.GET("/feed", r.WorkController.GetFeed)
+	e.GET("/feed/following", r.WorkController.GetFollowingFeed)
+
+	// Comment
+	e.PUT("/works/:work_id/comments/:comment_id", r.CommentController.UpdateComment)
+	e.DELETE("/works/:work_id/comments/:comment_id", r.CommentController.DeleteComment)
 
 	// Asset
 	e.POST("/works/asset", r.AssetController.UploadAsset)
+	// 同じ内容のファイルをアップロード済みなら、アップロードし直さずにそのアセットを使える
+	e.GET("/assets/by-hash/:sha256", r.AssetController.GetAssetByHash)
+	// 大きなファイルはサーバーを経由せず、署名付きURLでS3へ直接アップロードする
+	e.POST("/assets/uploads", r.AssetController.CreateUpload)
+	e.POST("/assets/uploads/:id/complete", r.AssetController.CompleteUpload)
+	e.POST("/assets/multipart", r.AssetController.CreateMultipartUpload)
+	e.GET("/assets/multipart/:id/parts", r.AssetController.ListUploadedParts)
+	e.POST("/assets/multipart/:id/parts", r.AssetController.PresignUploadParts)
+	e.POST("/assets/multipart/:id/complete", r.AssetController.CompleteMultipartUpload)
+	e.DELETE("/assets/multipart/:id", r.AssetController.AbortMultipartUpload)
 
 	// Favorite
 	e.GET("/works/:work_id/favorite/is-favorite", r.FavoriteController.IsFavorite)
 	e.POST("/works/:work_id/favorite", r.FavoriteController.CreateFavorite)
 	e.DELETE("/works/:work_id/favorite", r.FavoriteController.DeleteFavorite)
 
+	// Follow
+	e.POST("/users/:id/follow", r.FollowController.FollowUser)
+	e.DELETE("/users/:id/follow", r.FollowController.UnfollowUser)
+
 	// Tag (認証必要 - 新規作成)
 	e.POST("/tags", r.TagController.CreateTag)
 
+	// TagFollow
+	e.POST("/tags/:id/follow", r.TagFollowController.FollowTag)
+	e.DELETE("/tags/:id/follow", r.TagFollowController.UnfollowTag)
+	e.GET("/tags/following/new-works", r.TagFollowController.GetNewWorks)
+	e.POST("/tags/following/new-works/seen", r.TagFollowController.MarkNewWorksAsSeen)
+
+	// Notification
+	e.GET("/notifications", r.NotificationController.GetNotifications)
+	e.POST("/notifications/read", r.NotificationController.MarkAsRead)
+
+	// Event
+	e.GET("/events/stream", r.EventController.Stream)
+
+	// Mention
+	e.GET("/mentions", r.MentionController.GetMentions)
+
+	// Reaction
+	e.PUT("/reactions", r.ReactionController.AddReaction)
+	e.DELETE("/reactions", r.ReactionController.RemoveReaction)
+
 	return r.echo
 }
+
+// localStoragePath は LOCAL_STORAGE_BASE_URL のパスを返します
+func localStoragePath() string {
+	baseURL, err := url.Parse(config.LOCAL_STORAGE_BASE_URL)
+	if err != nil {
+		return "/"
+	}
+	path := strings.TrimSuffix(baseURL.Path, "/")
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+// encodedContentHeaders は圧縮済みのファイルに Content-Encoding を付け、圧縮前の Content-Type で配信します。
+// ローカルディスクには Content-Encoding を保存できないため、S3 と同じヘッダーを拡張子から決めます
+func encodedContentHeaders(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		contentType, contentEncoding := blobstore.ContentHeaders(c.Request().URL.Path)
+		if contentEncoding != "" {
+			c.Response().Header().Set(echo.HeaderContentEncoding, contentEncoding)
+			c.Response().Header().Set(echo.HeaderContentType, contentType)
+		}
+		return next(c)
+	}
+}
+
+// sandboxedContent はローカルに保存したファイルを、API と同じオリジンの権限を持たないページとして配信します。
+// ZIP から展開したビルドはユーザーがアップロードした HTML と JS のため、同じオリジンのままだと
+// 開いたユーザーの Cookie 付きで /auth/refresh などを呼び出せてしまいます。sandbox で別のオリジンとして扱わせます
+func sandboxedContent(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		c.Response().Header().Set(echo.HeaderContentSecurityPolicy, "sandbox allow-scripts")
+		c.Response().Header().Set(echo.HeaderXContentTypeOptions, "nosniff")
+		return next(c)
+	}
+}
+
+// ipExtractor は投稿元のIPアドレスの求め方を返します。
+// X-Forwarded-Forはクライアントが自由に付けられるため、信頼するプロキシが設定されている場合だけ使います。
+func ipExtractor(trustedProxies []*net.IPNet) echo.IPExtractor {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+	// 既定で信頼されるループバックやプライベートネットワークも、設定された範囲に含まれない限り信頼しない
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, ipNet := range trustedProxies {
+		options = append(options, echo.TrustIPRange(ipNet))
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
@@ -0,0 +1,26 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSandboxedContent(t *testing.T) {
+	e := echo.New()
+	e.GET("/storage/*", func(c echo.Context) error {
+		return c.HTML(http.StatusOK, "<script>fetch('/auth/refresh', {method: 'POST'})</script>")
+	}, sandboxedContent)
+
+	req := httptest.NewRequest(http.MethodGet, "/storage/toybox/play/id/index.html", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	// allow-same-origin を付けず、展開したビルドが API のオリジンとして Cookie 付きのリクエストを送れないようにする
+	assert.Equal(t, "sandbox allow-scripts", rec.Header().Get(echo.HeaderContentSecurityPolicy))
+	assert.Equal(t, "nosniff", rec.Header().Get(echo.HeaderXContentTypeOptions))
+}
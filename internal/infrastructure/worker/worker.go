@@ -0,0 +1,44 @@
+package worker
+
+import (
+	"context"
+	"sync"
+)
+
+// Worker はリクエストに応答した後も続ける処理をバックグラウンドで実行します。
+// Stop を呼ぶと実行中の処理の終了を待ち、その後に渡された処理は呼び出し元でそのまま実行します。
+type Worker struct {
+	mu      sync.Mutex
+	stopped bool
+	running sync.WaitGroup
+}
+
+func NewWorker() *Worker {
+	return &Worker{}
+}
+
+func (w *Worker) Go(ctx context.Context, task func(ctx context.Context)) {
+	ctx = context.WithoutCancel(ctx)
+
+	w.mu.Lock()
+	if w.stopped {
+		w.mu.Unlock()
+		// 停止した後でも処理を落とさないよう、呼び出し元で実行する
+		task(ctx)
+		return
+	}
+	w.running.Add(1)
+	w.mu.Unlock()
+
+	go func() {
+		defer w.running.Done()
+		task(ctx)
+	}()
+}
+
+func (w *Worker) Stop() {
+	w.mu.Lock()
+	w.stopped = true
+	w.mu.Unlock()
+	w.running.Wait()
+}
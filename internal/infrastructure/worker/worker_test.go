@@ -0,0 +1,67 @@
+package worker_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/simesaba80/toybox-back/internal/infrastructure/worker"
+)
+
+func TestWorker_StopWaitsForRunningTasks(t *testing.T) {
+	w := worker.NewWorker()
+
+	var finished atomic.Int32
+	release := make(chan struct{})
+	for i := 0; i < 3; i++ {
+		w.Go(context.Background(), func(ctx context.Context) {
+			<-release
+			finished.Add(1)
+		})
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		w.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		t.Fatal("実行中の処理を待たずに止まりました")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+	<-stopped
+	require.Equal(t, int32(3), finished.Load())
+}
+
+func TestWorker_TaskOutlivesRequestContext(t *testing.T) {
+	w := worker.NewWorker()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var taskErr error
+	w.Go(ctx, func(ctx context.Context) {
+		taskErr = ctx.Err()
+	})
+	w.Stop()
+	require.NoError(t, taskErr)
+}
+
+func TestWorker_RunsInlineAfterStop(t *testing.T) {
+	w := worker.NewWorker()
+	w.Stop()
+
+	ran := false
+	w.Go(context.Background(), func(ctx context.Context) {
+		ran = true
+	})
+	require.True(t, ran)
+
+	// 二度止めても問題ない
+	w.Stop()
+}
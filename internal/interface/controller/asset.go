@@ -31,6 +31,7 @@ func NewAssetController(assetUsecase usecase.IAssetUseCase) *AssetController {
 // @Param file formData file true "File to upload"
 // @Success 200 {object} schema.UploadAssetResponse
 // @Failure 400 {object} echo.HTTPError
+// @Failure 413 {object} echo.HTTPError
 // @Failure 500 {object} echo.HTTPError
 // @Security BearerAuth
 // @Router /auth/works/asset [post]
@@ -53,16 +54,354 @@ func (ac *AssetController) UploadAsset(c echo.Context) error {
 	return c.JSON(http.StatusOK, schema.ToUploadAssetResponse(asset))
 }
 
+// CreateUpload godoc
+// @Summary Start a direct upload
+// @Description Issue a presigned URL to PUT an asset file directly to S3. Call complete after the upload finishes.
+// @Tags assets
+// @Accept json
+// @Produce json
+// @Param input body schema.CreateAssetUploadRequest true "File to upload"
+// @Success 201 {object} schema.AssetUploadResponse
+// @Failure 400 {object} echo.HTTPError
+// @Failure 413 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Failure 501 {object} echo.HTTPError
+// @Security BearerAuth
+// @Router /auth/assets/uploads [post]
+func (ac *AssetController) CreateUpload(c echo.Context) error {
+	userID, err := userIDFromToken(c)
+	if err != nil {
+		return handleAssetError(c, domainerrors.ErrInvalidRequestBody)
+	}
+	var input schema.CreateAssetUploadRequest
+	if err := c.Bind(&input); err != nil {
+		return handleAssetError(c, domainerrors.ErrInvalidRequestBody)
+	}
+	if err := c.Validate(&input); err != nil {
+		return handleAssetError(c, domainerrors.ErrInvalidRequestBody)
+	}
+
+	upload, uploadURL, err := ac.assetUsecase.CreateUpload(c.Request().Context(), userID, input.FileName, input.Size)
+	if err != nil {
+		return handleAssetError(c, err)
+	}
+	return c.JSON(http.StatusCreated, schema.ToAssetUploadResponse(upload, uploadURL))
+}
+
+// CompleteUpload godoc
+// @Summary Complete a direct upload
+// @Description Verify the file uploaded with the presigned URL and register it as an asset
+// @Tags assets
+// @Produce json
+// @Param id path string true "Upload ID"
+// @Success 200 {object} schema.UploadAssetResponse
+// @Failure 400 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 410 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Security BearerAuth
+// @Router /auth/assets/uploads/{id}/complete [post]
+func (ac *AssetController) CompleteUpload(c echo.Context) error {
+	userID, err := userIDFromToken(c)
+	if err != nil {
+		return handleAssetError(c, domainerrors.ErrInvalidRequestBody)
+	}
+	uploadID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return handleAssetError(c, domainerrors.ErrInvalidRequestBody)
+	}
+
+	asset, err := ac.assetUsecase.CompleteUpload(c.Request().Context(), userID, uploadID)
+	if err != nil {
+		return handleAssetError(c, err)
+	}
+	return c.JSON(http.StatusOK, schema.ToUploadAssetResponse(asset))
+}
+
+// CreateMultipartUpload godoc
+// @Summary Start a multipart upload
+// @Description Start uploading a large asset file directly to S3 in parts. Split the file by part_size and request presigned URLs for each part.
+// @Tags assets
+// @Accept json
+// @Produce json
+// @Param input body schema.CreateAssetUploadRequest true "File to upload"
+// @Success 201 {object} schema.MultipartUploadResponse
+// @Failure 400 {object} echo.HTTPError
+// @Failure 413 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Failure 501 {object} echo.HTTPError
+// @Security BearerAuth
+// @Router /auth/assets/multipart [post]
+func (ac *AssetController) CreateMultipartUpload(c echo.Context) error {
+	userID, err := userIDFromToken(c)
+	if err != nil {
+		return handleAssetError(c, domainerrors.ErrInvalidRequestBody)
+	}
+	var input schema.CreateAssetUploadRequest
+	if err := c.Bind(&input); err != nil {
+		return handleAssetError(c, domainerrors.ErrInvalidRequestBody)
+	}
+	if err := c.Validate(&input); err != nil {
+		return handleAssetError(c, domainerrors.ErrInvalidRequestBody)
+	}
+
+	upload, err := ac.assetUsecase.CreateMultipartUpload(c.Request().Context(), userID, input.FileName, input.Size)
+	if err != nil {
+		return handleAssetError(c, err)
+	}
+	return c.JSON(http.StatusCreated, schema.ToMultipartUploadResponse(upload))
+}
+
+// PresignUploadParts godoc
+// @Summary Presign upload parts
+// @Description Issue presigned URLs to PUT the given parts of a multipart upload
+// @Tags assets
+// @Accept json
+// @Produce json
+// @Param id path string true "Upload ID"
+// @Param input body schema.PresignUploadPartsRequest true "Part numbers"
+// @Success 200 {object} schema.PresignUploadPartsResponse
+// @Failure 400 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 410 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Security BearerAuth
+// @Router /auth/assets/multipart/{id}/parts [post]
+func (ac *AssetController) PresignUploadParts(c echo.Context) error {
+	userID, err := userIDFromToken(c)
+	if err != nil {
+		return handleAssetError(c, domainerrors.ErrInvalidRequestBody)
+	}
+	uploadID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return handleAssetError(c, domainerrors.ErrInvalidRequestBody)
+	}
+	var input schema.PresignUploadPartsRequest
+	if err := c.Bind(&input); err != nil {
+		return handleAssetError(c, domainerrors.ErrInvalidRequestBody)
+	}
+	if err := c.Validate(&input); err != nil {
+		return handleAssetError(c, domainerrors.ErrInvalidRequestBody)
+	}
+
+	parts, expiresAt, err := ac.assetUsecase.PresignUploadParts(c.Request().Context(), userID, uploadID, input.PartNumbers)
+	if err != nil {
+		return handleAssetError(c, err)
+	}
+	return c.JSON(http.StatusOK, schema.ToPresignUploadPartsResponse(parts, expiresAt))
+}
+
+// ListUploadedParts godoc
+// @Summary List uploaded parts
+// @Description List the parts already uploaded to resume an interrupted multipart upload
+// @Tags assets
+// @Produce json
+// @Param id path string true "Upload ID"
+// @Success 200 {object} schema.ListUploadedPartsResponse
+// @Failure 400 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Security BearerAuth
+// @Router /auth/assets/multipart/{id}/parts [get]
+func (ac *AssetController) ListUploadedParts(c echo.Context) error {
+	userID, err := userIDFromToken(c)
+	if err != nil {
+		return handleAssetError(c, domainerrors.ErrInvalidRequestBody)
+	}
+	uploadID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return handleAssetError(c, domainerrors.ErrInvalidRequestBody)
+	}
+
+	upload, parts, err := ac.assetUsecase.ListUploadedParts(c.Request().Context(), userID, uploadID)
+	if err != nil {
+		return handleAssetError(c, err)
+	}
+	return c.JSON(http.StatusOK, schema.ToListUploadedPartsResponse(upload, parts))
+}
+
+// CompleteMultipartUpload godoc
+// @Summary Complete a multipart upload
+// @Description Combine the uploaded parts, verify the file and register it as an asset
+// @Tags assets
+// @Produce json
+// @Param id path string true "Upload ID"
+// @Success 200 {object} schema.UploadAssetResponse
+// @Failure 400 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 410 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Security BearerAuth
+// @Router /auth/assets/multipart/{id}/complete [post]
+func (ac *AssetController) CompleteMultipartUpload(c echo.Context) error {
+	userID, err := userIDFromToken(c)
+	if err != nil {
+		return handleAssetError(c, domainerrors.ErrInvalidRequestBody)
+	}
+	uploadID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return handleAssetError(c, domainerrors.ErrInvalidRequestBody)
+	}
+
+	asset, err := ac.assetUsecase.CompleteMultipartUpload(c.Request().Context(), userID, uploadID)
+	if err != nil {
+		return handleAssetError(c, err)
+	}
+	return c.JSON(http.StatusOK, schema.ToUploadAssetResponse(asset))
+}
+
+// AbortMultipartUpload godoc
+// @Summary Abort a multipart upload
+// @Description Abort a multipart upload and discard the uploaded parts
+// @Tags assets
+// @Param id path string true "Upload ID"
+// @Success 204
+// @Failure 400 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Security BearerAuth
+// @Router /auth/assets/multipart/{id} [delete]
+func (ac *AssetController) AbortMultipartUpload(c echo.Context) error {
+	userID, err := userIDFromToken(c)
+	if err != nil {
+		return handleAssetError(c, domainerrors.ErrInvalidRequestBody)
+	}
+	uploadID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return handleAssetError(c, domainerrors.ErrInvalidRequestBody)
+	}
+
+	if err := ac.assetUsecase.AbortMultipartUpload(c.Request().Context(), userID, uploadID); err != nil {
+		return handleAssetError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetStorageUsage godoc
+// @Summary Get my storage usage
+// @Description Get the total size of the assets uploaded by the logged-in user, broken down by asset type
+// @Tags assets
+// @Produce json
+// @Success 200 {object} schema.StorageUsageResponse
+// @Failure 400 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Security BearerAuth
+// @Router /auth/users/me/storage [get]
+func (ac *AssetController) GetStorageUsage(c echo.Context) error {
+	userID, err := userIDFromToken(c)
+	if err != nil {
+		return handleAssetError(c, domainerrors.ErrInvalidRequestBody)
+	}
+	usage, err := ac.assetUsecase.GetStorageUsage(c.Request().Context(), userID)
+	if err != nil {
+		return handleAssetError(c, err)
+	}
+	return c.JSON(http.StatusOK, schema.ToStorageUsageResponse(usage))
+}
+
+// GetZipManifest godoc
+// @Summary Get the file list of a zip asset
+// @Description Get the files in a zip asset and what it seems to contain, so visitors know what they are downloading
+// @Tags assets
+// @Produce json
+// @Param id path string true "Asset ID"
+// @Success 200 {object} schema.ZipManifestResponse
+// @Failure 400 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Router /assets/{id}/manifest [get]
+func (ac *AssetController) GetZipManifest(c echo.Context) error {
+	assetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return handleAssetError(c, domainerrors.ErrInvalidRequestBody)
+	}
+	manifest, err := ac.assetUsecase.GetZipManifest(c.Request().Context(), assetID)
+	if err != nil {
+		return handleAssetError(c, err)
+	}
+	return c.JSON(http.StatusOK, schema.ToZipManifestResponse(assetID, manifest))
+}
+
+// GetAssetByHash godoc
+// @Summary Find my asset by content hash
+// @Description Find an asset the logged-in user has already uploaded whose original file has the given SHA-256, so the client can reuse it instead of uploading the same file again
+// @Tags assets
+// @Produce json
+// @Param sha256 path string true "SHA-256 of the file in hex"
+// @Success 200 {object} schema.UploadAssetResponse
+// @Failure 400 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Security BearerAuth
+// @Router /auth/assets/by-hash/{sha256} [get]
+func (ac *AssetController) GetAssetByHash(c echo.Context) error {
+	userID, err := userIDFromToken(c)
+	if err != nil {
+		return handleAssetError(c, domainerrors.ErrInvalidRequestBody)
+	}
+	asset, err := ac.assetUsecase.FindAssetByHash(c.Request().Context(), userID, c.Param("sha256"))
+	if err != nil {
+		return handleAssetError(c, err)
+	}
+	return c.JSON(http.StatusOK, schema.ToUploadAssetResponse(asset))
+}
+
 func handleAssetError(c echo.Context, err error) error {
 	switch {
 	case errors.Is(err, domainerrors.ErrInvalidRequestBody):
 		return echo.NewHTTPError(http.StatusBadRequest, "無効なリクエストです")
+	case errors.Is(err, domainerrors.ErrUnsupportedFileType):
+		return echo.NewHTTPError(http.StatusBadRequest, "対応していないファイル形式です")
+	case errors.Is(err, domainerrors.ErrFileTypeMismatch):
+		return echo.NewHTTPError(http.StatusBadRequest, "ファイルの内容が拡張子と一致しません")
+	case errors.Is(err, domainerrors.ErrFileTooLarge):
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "ファイルサイズが上限を超えています")
+	case errors.Is(err, domainerrors.ErrStorageQuotaExceeded):
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "ストレージの使用量が上限を超えています")
+	case errors.Is(err, domainerrors.ErrImageTooLarge):
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "画像の解像度が上限を超えています")
+	case errors.Is(err, domainerrors.ErrFailedToStripMetadata):
+		return echo.NewHTTPError(http.StatusBadRequest, "画像を読み込めませんでした")
+	case errors.Is(err, domainerrors.ErrInvalidZip):
+		return echo.NewHTTPError(http.StatusBadRequest, "ZIPファイルを読み込めませんでした")
+	case errors.Is(err, domainerrors.ErrZipBomb):
+		return echo.NewHTTPError(http.StatusBadRequest, "展開後のサイズが大きすぎるZIPファイルはアップロードできません")
+	case errors.Is(err, domainerrors.ErrUnsafeZipPath):
+		return echo.NewHTTPError(http.StatusBadRequest, "ZIPファイルに展開先の外を指すパスが含まれています")
+	case errors.Is(err, domainerrors.ErrInvalidModel):
+		return echo.NewHTTPError(http.StatusBadRequest, "3Dモデルを読み込めませんでした")
+	case errors.Is(err, domainerrors.ErrExternalModelBuffer):
+		return echo.NewHTTPError(http.StatusBadRequest, "別のファイルを参照する3Dモデルはアップロードできません。GLB形式か、データを埋め込んだglTFを使ってください")
+	case errors.Is(err, domainerrors.ErrAssetNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "アセットが見つかりません")
+	case errors.Is(err, domainerrors.ErrZipManifestNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "ZIPファイルの中身の一覧がありません")
+	case errors.Is(err, domainerrors.ErrAssetUploadNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "アップロードが見つかりません")
+	case errors.Is(err, domainerrors.ErrAssetUploadExpired):
+		return echo.NewHTTPError(http.StatusGone, "アップロードの有効期限が切れています")
+	case errors.Is(err, domainerrors.ErrUploadedFileNotFound):
+		return echo.NewHTTPError(http.StatusBadRequest, "ファイルがアップロードされていません")
+	case errors.Is(err, domainerrors.ErrUploadedFileMismatch):
+		return echo.NewHTTPError(http.StatusBadRequest, "アップロードされたファイルが申告と一致しません")
+	case errors.Is(err, domainerrors.ErrInvalidPartNumber):
+		return echo.NewHTTPError(http.StatusBadRequest, "パート番号が範囲外です")
+	case errors.Is(err, domainerrors.ErrIncompleteMultipartUpload):
+		return echo.NewHTTPError(http.StatusBadRequest, "アップロードされていないパートがあります")
+	case errors.Is(err, domainerrors.ErrDirectUploadNotSupported):
+		return echo.NewHTTPError(http.StatusNotImplemented, "このストレージではクライアントからの直接アップロードに対応していません")
 	case errors.Is(err, domainerrors.ErrFailedToOpenFile):
 		return echo.NewHTTPError(http.StatusInternalServerError, "ファイルの読み込みに失敗しました")
 	case errors.Is(err, domainerrors.ErrFailedToUploadFile):
 		return echo.NewHTTPError(http.StatusInternalServerError, "ファイルのアップロードに失敗しました")
 	case errors.Is(err, domainerrors.ErrFailedToCreateAsset):
 		return echo.NewHTTPError(http.StatusInternalServerError, "アセットの作成に失敗しました")
+	case errors.Is(err, domainerrors.ErrFailedToPresignUpload), errors.Is(err, domainerrors.ErrFailedToCreateAssetUpload), errors.Is(err, domainerrors.ErrFailedToCreateMultipart):
+		return echo.NewHTTPError(http.StatusInternalServerError, "アップロードの準備に失敗しました")
+	case errors.Is(err, domainerrors.ErrFailedToCompleteMultipart):
+		return echo.NewHTTPError(http.StatusInternalServerError, "パートの結合に失敗しました")
+	case errors.Is(err, domainerrors.ErrFailedToRegisterBlob):
+		return echo.NewHTTPError(http.StatusInternalServerError, "ファイルの登録に失敗しました")
 	}
 	c.Logger().Error("Failed to upload asset: %w", err)
 	return echo.NewHTTPError(http.StatusInternalServerError, "サーバーエラーが発生しました")
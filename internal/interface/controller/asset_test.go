@@ -5,10 +5,13 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
@@ -18,6 +21,7 @@ import (
 	"github.com/simesaba80/toybox-back/internal/interface/controller"
 	"github.com/simesaba80/toybox-back/internal/interface/controller/mock"
 	"github.com/simesaba80/toybox-back/internal/interface/schema"
+	"github.com/simesaba80/toybox-back/pkg/echovalidator"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -58,6 +62,12 @@ func TestAssetController_UploadAsset(t *testing.T) {
 	invalidRequestResponseBytes, _ := json.Marshal(map[string]string{"message": "無効なリクエストです"})
 	failedUploadResponseBytes, _ := json.Marshal(map[string]string{"message": "ファイルのアップロードに失敗しました"})
 	internalErrorResponseBytes, _ := json.Marshal(map[string]string{"message": "サーバーエラーが発生しました"})
+	unsupportedResponseBytes, _ := json.Marshal(map[string]string{"message": "対応していないファイル形式です"})
+	mismatchResponseBytes, _ := json.Marshal(map[string]string{"message": "ファイルの内容が拡張子と一致しません"})
+	stripFailedResponseBytes, _ := json.Marshal(map[string]string{"message": "画像を読み込めませんでした"})
+	tooLargeResponseBytes, _ := json.Marshal(map[string]string{"message": "ファイルサイズが上限を超えています"})
+	zipBombResponseBytes, _ := json.Marshal(map[string]string{"message": "展開後のサイズが大きすぎるZIPファイルはアップロードできません"})
+	externalModelBufferResponseBytes, _ := json.Marshal(map[string]string{"message": "別のファイルを参照する3Dモデルはアップロードできません。GLB形式か、データを埋め込んだglTFを使ってください"})
 
 	tests := []struct {
 		name          string
@@ -117,6 +127,90 @@ func TestAssetController_UploadAsset(t *testing.T) {
 			wantStatus: http.StatusBadRequest,
 			wantBody:   invalidRequestResponseBytes,
 		},
+		{
+			name:   "異常系: 対応していないファイル形式",
+			userID: uuid.New(),
+			setupMock: func(mockAssetUsecase *mock.MockIAssetUseCase, userID uuid.UUID) {
+				mockAssetUsecase.EXPECT().
+					UploadFile(gomock.Any(), gomock.Any(), userID).
+					Return(nil, domainerrors.ErrUnsupportedFileType)
+			},
+			request: func(t *testing.T) *http.Request {
+				return newAssetUploadRequest(t, "/works/asset", true)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   unsupportedResponseBytes,
+		},
+		{
+			name:   "異常系: 内容が拡張子と一致しない",
+			userID: uuid.New(),
+			setupMock: func(mockAssetUsecase *mock.MockIAssetUseCase, userID uuid.UUID) {
+				mockAssetUsecase.EXPECT().
+					UploadFile(gomock.Any(), gomock.Any(), userID).
+					Return(nil, domainerrors.ErrFileTypeMismatch)
+			},
+			request: func(t *testing.T) *http.Request {
+				return newAssetUploadRequest(t, "/works/asset", true)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   mismatchResponseBytes,
+		},
+		{
+			name:   "異常系: ファイルサイズ超過",
+			userID: uuid.New(),
+			setupMock: func(mockAssetUsecase *mock.MockIAssetUseCase, userID uuid.UUID) {
+				mockAssetUsecase.EXPECT().
+					UploadFile(gomock.Any(), gomock.Any(), userID).
+					Return(nil, domainerrors.ErrFileTooLarge)
+			},
+			request: func(t *testing.T) *http.Request {
+				return newAssetUploadRequest(t, "/works/asset", true)
+			},
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantBody:   tooLargeResponseBytes,
+		},
+		{
+			name:   "異常系: ZIP爆弾",
+			userID: uuid.New(),
+			setupMock: func(mockAssetUsecase *mock.MockIAssetUseCase, userID uuid.UUID) {
+				mockAssetUsecase.EXPECT().
+					UploadFile(gomock.Any(), gomock.Any(), userID).
+					Return(nil, fmt.Errorf("failed to inspect zip: %w", domainerrors.ErrZipBomb))
+			},
+			request: func(t *testing.T) *http.Request {
+				return newAssetUploadRequest(t, "/works/asset", true)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   zipBombResponseBytes,
+		},
+		{
+			name:   "異常系: 外部のバッファを参照する3Dモデル",
+			userID: uuid.New(),
+			setupMock: func(mockAssetUsecase *mock.MockIAssetUseCase, userID uuid.UUID) {
+				mockAssetUsecase.EXPECT().
+					UploadFile(gomock.Any(), gomock.Any(), userID).
+					Return(nil, fmt.Errorf("failed to inspect model: %w", domainerrors.ErrExternalModelBuffer))
+			},
+			request: func(t *testing.T) *http.Request {
+				return newAssetUploadRequest(t, "/works/asset", true)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   externalModelBufferResponseBytes,
+		},
+		{
+			name:   "異常系: 画像のメタデータを取り除けない",
+			userID: uuid.New(),
+			setupMock: func(mockAssetUsecase *mock.MockIAssetUseCase, userID uuid.UUID) {
+				mockAssetUsecase.EXPECT().
+					UploadFile(gomock.Any(), gomock.Any(), userID).
+					Return(nil, fmt.Errorf("%w: %w", domainerrors.ErrFailedToStripMetadata, errors.New("malformed jpeg")))
+			},
+			request: func(t *testing.T) *http.Request {
+				return newAssetUploadRequest(t, "/works/asset", true)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   stripFailedResponseBytes,
+		},
 		{
 			name:   "異常系: アップロード失敗",
 			userID: uuid.New(),
@@ -178,3 +272,640 @@ func TestAssetController_UploadAsset(t *testing.T) {
 		})
 	}
 }
+
+func TestAssetController_CreateUpload(t *testing.T) {
+	userID := uuid.New()
+	upload := &entity.AssetUpload{
+		ID:          uuid.New(),
+		UserID:      userID,
+		Extension:   "mp4",
+		ContentType: "video/mp4",
+		Size:        1024,
+		ExpiresAt:   time.Date(2025, 1, 1, 0, 15, 0, 0, time.UTC),
+	}
+	successResponseBytes, _ := json.Marshal(schema.ToAssetUploadResponse(upload, "https://s3.example.com/presigned"))
+	invalidRequestResponseBytes, _ := json.Marshal(map[string]string{"message": "無効なリクエストです"})
+	tooLargeResponseBytes, _ := json.Marshal(map[string]string{"message": "ファイルサイズが上限を超えています"})
+	presignFailedResponseBytes, _ := json.Marshal(map[string]string{"message": "アップロードの準備に失敗しました"})
+	notSupportedResponseBytes, _ := json.Marshal(map[string]string{"message": "このストレージではクライアントからの直接アップロードに対応していません"})
+	quotaExceededResponseBytes, _ := json.Marshal(map[string]string{"message": "ストレージの使用量が上限を超えています"})
+
+	tests := []struct {
+		name       string
+		body       string
+		setupMock  func(mockAssetUsecase *mock.MockIAssetUseCase)
+		wantStatus int
+		wantBody   []byte
+	}{
+		{
+			name: "正常系: 署名付きURLを発行する",
+			body: `{"file_name":"movie.mp4","size":1024}`,
+			setupMock: func(mockAssetUsecase *mock.MockIAssetUseCase) {
+				mockAssetUsecase.EXPECT().
+					CreateUpload(gomock.Any(), userID, "movie.mp4", int64(1024)).
+					Return(upload, "https://s3.example.com/presigned", nil)
+			},
+			wantStatus: http.StatusCreated,
+			wantBody:   successResponseBytes,
+		},
+		{
+			name: "異常系: サイズ未指定",
+			body: `{"file_name":"movie.mp4"}`,
+			setupMock: func(mockAssetUsecase *mock.MockIAssetUseCase) {
+				mockAssetUsecase.EXPECT().CreateUpload(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   invalidRequestResponseBytes,
+		},
+		{
+			name: "異常系: ファイルサイズ超過",
+			body: `{"file_name":"movie.mp4","size":1073741824}`,
+			setupMock: func(mockAssetUsecase *mock.MockIAssetUseCase) {
+				mockAssetUsecase.EXPECT().
+					CreateUpload(gomock.Any(), userID, "movie.mp4", int64(1073741824)).
+					Return(nil, "", domainerrors.ErrFileTooLarge)
+			},
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantBody:   tooLargeResponseBytes,
+		},
+		{
+			name: "異常系: 署名付きURLの発行に失敗",
+			body: `{"file_name":"movie.mp4","size":1024}`,
+			setupMock: func(mockAssetUsecase *mock.MockIAssetUseCase) {
+				mockAssetUsecase.EXPECT().
+					CreateUpload(gomock.Any(), userID, "movie.mp4", int64(1024)).
+					Return(nil, "", domainerrors.ErrFailedToPresignUpload)
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   presignFailedResponseBytes,
+		},
+		{
+			name: "異常系: ストレージの使用量の上限を超える",
+			body: `{"file_name":"movie.mp4","size":1024}`,
+			setupMock: func(mockAssetUsecase *mock.MockIAssetUseCase) {
+				mockAssetUsecase.EXPECT().
+					CreateUpload(gomock.Any(), userID, "movie.mp4", int64(1024)).
+					Return(nil, "", domainerrors.ErrStorageQuotaExceeded)
+			},
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantBody:   quotaExceededResponseBytes,
+		},
+		{
+			name: "異常系: ストレージが直接アップロードに対応していない",
+			body: `{"file_name":"movie.mp4","size":1024}`,
+			setupMock: func(mockAssetUsecase *mock.MockIAssetUseCase) {
+				mockAssetUsecase.EXPECT().
+					CreateUpload(gomock.Any(), userID, "movie.mp4", int64(1024)).
+					Return(nil, "", domainerrors.ErrDirectUploadNotSupported)
+			},
+			wantStatus: http.StatusNotImplemented,
+			wantBody:   notSupportedResponseBytes,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Validator = echovalidator.NewValidator()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAssetUsecase := mock.NewMockIAssetUseCase(ctrl)
+			tt.setupMock(mockAssetUsecase)
+
+			assetController := controller.NewAssetController(mockAssetUsecase)
+			token := jwt.NewWithClaims(jwt.SigningMethodHS256, &schema.JWTCustomClaims{
+				UserID: userID.String(),
+			})
+
+			e.POST("/assets/uploads", func(c echo.Context) error {
+				c.Set("user", token)
+				return assetController.CreateUpload(c)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/assets/uploads", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.JSONEq(t, string(tt.wantBody), rec.Body.String())
+		})
+	}
+}
+
+func TestAssetController_CompleteUpload(t *testing.T) {
+	userID := uuid.New()
+	uploadID := uuid.New()
+	successResponseBytes, _ := json.Marshal(schema.ToUploadAssetResponse(&entity.Asset{
+		ID:  uploadID,
+		URL: "https://example.com/video/origin.mp4",
+	}))
+	invalidRequestResponseBytes, _ := json.Marshal(map[string]string{"message": "無効なリクエストです"})
+	notFoundResponseBytes, _ := json.Marshal(map[string]string{"message": "アップロードが見つかりません"})
+	expiredResponseBytes, _ := json.Marshal(map[string]string{"message": "アップロードの有効期限が切れています"})
+	notUploadedResponseBytes, _ := json.Marshal(map[string]string{"message": "ファイルがアップロードされていません"})
+	mismatchResponseBytes, _ := json.Marshal(map[string]string{"message": "アップロードされたファイルが申告と一致しません"})
+
+	tests := []struct {
+		name       string
+		uploadID   string
+		setupMock  func(mockAssetUsecase *mock.MockIAssetUseCase)
+		wantStatus int
+		wantBody   []byte
+	}{
+		{
+			name:     "正常系: アセットとして登録する",
+			uploadID: uploadID.String(),
+			setupMock: func(mockAssetUsecase *mock.MockIAssetUseCase) {
+				mockAssetUsecase.EXPECT().
+					CompleteUpload(gomock.Any(), userID, uploadID).
+					Return(&entity.Asset{ID: uploadID, URL: "https://example.com/video/origin.mp4"}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   successResponseBytes,
+		},
+		{
+			name:     "異常系: 無効なID",
+			uploadID: "invalid",
+			setupMock: func(mockAssetUsecase *mock.MockIAssetUseCase) {
+				mockAssetUsecase.EXPECT().CompleteUpload(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   invalidRequestResponseBytes,
+		},
+		{
+			name:     "異常系: アップロードが存在しない",
+			uploadID: uploadID.String(),
+			setupMock: func(mockAssetUsecase *mock.MockIAssetUseCase) {
+				mockAssetUsecase.EXPECT().
+					CompleteUpload(gomock.Any(), userID, uploadID).
+					Return(nil, domainerrors.ErrAssetUploadNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   notFoundResponseBytes,
+		},
+		{
+			name:     "異常系: 有効期限切れ",
+			uploadID: uploadID.String(),
+			setupMock: func(mockAssetUsecase *mock.MockIAssetUseCase) {
+				mockAssetUsecase.EXPECT().
+					CompleteUpload(gomock.Any(), userID, uploadID).
+					Return(nil, domainerrors.ErrAssetUploadExpired)
+			},
+			wantStatus: http.StatusGone,
+			wantBody:   expiredResponseBytes,
+		},
+		{
+			name:     "異常系: ファイルがアップロードされていない",
+			uploadID: uploadID.String(),
+			setupMock: func(mockAssetUsecase *mock.MockIAssetUseCase) {
+				mockAssetUsecase.EXPECT().
+					CompleteUpload(gomock.Any(), userID, uploadID).
+					Return(nil, domainerrors.ErrUploadedFileNotFound)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   notUploadedResponseBytes,
+		},
+		{
+			name:     "異常系: ファイルが申告と一致しない",
+			uploadID: uploadID.String(),
+			setupMock: func(mockAssetUsecase *mock.MockIAssetUseCase) {
+				mockAssetUsecase.EXPECT().
+					CompleteUpload(gomock.Any(), userID, uploadID).
+					Return(nil, domainerrors.ErrUploadedFileMismatch)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   mismatchResponseBytes,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAssetUsecase := mock.NewMockIAssetUseCase(ctrl)
+			tt.setupMock(mockAssetUsecase)
+
+			assetController := controller.NewAssetController(mockAssetUsecase)
+			token := jwt.NewWithClaims(jwt.SigningMethodHS256, &schema.JWTCustomClaims{
+				UserID: userID.String(),
+			})
+
+			e.POST("/assets/uploads/:id/complete", func(c echo.Context) error {
+				c.Set("user", token)
+				return assetController.CompleteUpload(c)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/assets/uploads/"+tt.uploadID+"/complete", nil)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.JSONEq(t, string(tt.wantBody), rec.Body.String())
+		})
+	}
+}
+
+func TestAssetController_MultipartUpload(t *testing.T) {
+	userID := uuid.New()
+	uploadID := uuid.New()
+	expiresAt := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	upload := &entity.AssetUpload{
+		ID:                uploadID,
+		UserID:            userID,
+		Extension:         "zip",
+		ContentType:       "application/zip",
+		Size:              40 << 20,
+		MultipartUploadID: "multipart-upload-id",
+		PartSize:          16 << 20,
+		ExpiresAt:         expiresAt,
+	}
+	presignedParts := []*entity.PresignedPart{
+		{PartNumber: 3, Size: 8 << 20, URL: "https://s3.example.com/part3"},
+	}
+	uploadedParts := []*entity.UploadedPart{
+		{PartNumber: 1, ETag: `"etag1"`, Size: 16 << 20},
+	}
+
+	createResponseBytes, _ := json.Marshal(schema.ToMultipartUploadResponse(upload))
+	presignResponseBytes, _ := json.Marshal(schema.ToPresignUploadPartsResponse(presignedParts, expiresAt))
+	listResponseBytes, _ := json.Marshal(schema.ToListUploadedPartsResponse(upload, uploadedParts))
+	completeResponseBytes, _ := json.Marshal(schema.ToUploadAssetResponse(&entity.Asset{ID: uploadID, URL: "https://example.com/zip/origin.zip"}))
+	invalidRequestResponseBytes, _ := json.Marshal(map[string]string{"message": "無効なリクエストです"})
+	invalidPartResponseBytes, _ := json.Marshal(map[string]string{"message": "パート番号が範囲外です"})
+	incompleteResponseBytes, _ := json.Marshal(map[string]string{"message": "アップロードされていないパートがあります"})
+	notFoundResponseBytes, _ := json.Marshal(map[string]string{"message": "アップロードが見つかりません"})
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		setupMock  func(mockAssetUsecase *mock.MockIAssetUseCase)
+		wantStatus int
+		wantBody   []byte
+	}{
+		{
+			name:   "正常系: マルチパートアップロードを開始する",
+			method: http.MethodPost,
+			path:   "/assets/multipart",
+			body:   `{"file_name":"game.zip","size":41943040}`,
+			setupMock: func(mockAssetUsecase *mock.MockIAssetUseCase) {
+				mockAssetUsecase.EXPECT().
+					CreateMultipartUpload(gomock.Any(), userID, "game.zip", int64(40<<20)).
+					Return(upload, nil)
+			},
+			wantStatus: http.StatusCreated,
+			wantBody:   createResponseBytes,
+		},
+		{
+			name:   "正常系: パートの署名付きURLを発行する",
+			method: http.MethodPost,
+			path:   "/assets/multipart/" + uploadID.String() + "/parts",
+			body:   `{"part_numbers":[3]}`,
+			setupMock: func(mockAssetUsecase *mock.MockIAssetUseCase) {
+				mockAssetUsecase.EXPECT().
+					PresignUploadParts(gomock.Any(), userID, uploadID, []int32{3}).
+					Return(presignedParts, expiresAt, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   presignResponseBytes,
+		},
+		{
+			name:   "異常系: パート番号の指定がない",
+			method: http.MethodPost,
+			path:   "/assets/multipart/" + uploadID.String() + "/parts",
+			body:   `{"part_numbers":[]}`,
+			setupMock: func(mockAssetUsecase *mock.MockIAssetUseCase) {
+				mockAssetUsecase.EXPECT().PresignUploadParts(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   invalidRequestResponseBytes,
+		},
+		{
+			name:   "異常系: パート番号が範囲外",
+			method: http.MethodPost,
+			path:   "/assets/multipart/" + uploadID.String() + "/parts",
+			body:   `{"part_numbers":[4]}`,
+			setupMock: func(mockAssetUsecase *mock.MockIAssetUseCase) {
+				mockAssetUsecase.EXPECT().
+					PresignUploadParts(gomock.Any(), userID, uploadID, []int32{4}).
+					Return(nil, time.Time{}, domainerrors.ErrInvalidPartNumber)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   invalidPartResponseBytes,
+		},
+		{
+			name:   "正常系: アップロード済みのパートを一覧する",
+			method: http.MethodGet,
+			path:   "/assets/multipart/" + uploadID.String() + "/parts",
+			setupMock: func(mockAssetUsecase *mock.MockIAssetUseCase) {
+				mockAssetUsecase.EXPECT().
+					ListUploadedParts(gomock.Any(), userID, uploadID).
+					Return(upload, uploadedParts, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   listResponseBytes,
+		},
+		{
+			name:   "正常系: パートを結合してアセットを登録する",
+			method: http.MethodPost,
+			path:   "/assets/multipart/" + uploadID.String() + "/complete",
+			setupMock: func(mockAssetUsecase *mock.MockIAssetUseCase) {
+				mockAssetUsecase.EXPECT().
+					CompleteMultipartUpload(gomock.Any(), userID, uploadID).
+					Return(&entity.Asset{ID: uploadID, URL: "https://example.com/zip/origin.zip"}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   completeResponseBytes,
+		},
+		{
+			name:   "異常系: アップロードされていないパートがある",
+			method: http.MethodPost,
+			path:   "/assets/multipart/" + uploadID.String() + "/complete",
+			setupMock: func(mockAssetUsecase *mock.MockIAssetUseCase) {
+				mockAssetUsecase.EXPECT().
+					CompleteMultipartUpload(gomock.Any(), userID, uploadID).
+					Return(nil, domainerrors.ErrIncompleteMultipartUpload)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   incompleteResponseBytes,
+		},
+		{
+			name:   "正常系: マルチパートアップロードを中止する",
+			method: http.MethodDelete,
+			path:   "/assets/multipart/" + uploadID.String(),
+			setupMock: func(mockAssetUsecase *mock.MockIAssetUseCase) {
+				mockAssetUsecase.EXPECT().AbortMultipartUpload(gomock.Any(), userID, uploadID).Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:   "異常系: 中止するアップロードが存在しない",
+			method: http.MethodDelete,
+			path:   "/assets/multipart/" + uploadID.String(),
+			setupMock: func(mockAssetUsecase *mock.MockIAssetUseCase) {
+				mockAssetUsecase.EXPECT().
+					AbortMultipartUpload(gomock.Any(), userID, uploadID).
+					Return(domainerrors.ErrAssetUploadNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   notFoundResponseBytes,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Validator = echovalidator.NewValidator()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAssetUsecase := mock.NewMockIAssetUseCase(ctrl)
+			tt.setupMock(mockAssetUsecase)
+
+			assetController := controller.NewAssetController(mockAssetUsecase)
+			token := jwt.NewWithClaims(jwt.SigningMethodHS256, &schema.JWTCustomClaims{
+				UserID: userID.String(),
+			})
+			withToken := func(handler echo.HandlerFunc) echo.HandlerFunc {
+				return func(c echo.Context) error {
+					c.Set("user", token)
+					return handler(c)
+				}
+			}
+
+			e.POST("/assets/multipart", withToken(assetController.CreateMultipartUpload))
+			e.GET("/assets/multipart/:id/parts", withToken(assetController.ListUploadedParts))
+			e.POST("/assets/multipart/:id/parts", withToken(assetController.PresignUploadParts))
+			e.POST("/assets/multipart/:id/complete", withToken(assetController.CompleteMultipartUpload))
+			e.DELETE("/assets/multipart/:id", withToken(assetController.AbortMultipartUpload))
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantBody == nil {
+				assert.Empty(t, rec.Body.String())
+				return
+			}
+			assert.JSONEq(t, string(tt.wantBody), rec.Body.String())
+		})
+	}
+}
+
+func TestAssetController_GetStorageUsage(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name       string
+		usage      *entity.StorageUsage
+		err        error
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "正常系: 種類ごとの使用量を返す",
+			usage: &entity.StorageUsage{
+				Quota:        2000,
+				PendingBytes: 50,
+				ByType: []*entity.AssetTypeUsage{
+					{AssetType: "image", Count: 3, Bytes: 300},
+					{AssetType: "zip", Count: 1, Bytes: 1000},
+				},
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"used_bytes":1300,"pending_bytes":50,"quota_bytes":2000,"by_type":[{"asset_type":"image","count":3,"bytes":300},{"asset_type":"zip","count":1,"bytes":1000}]}`,
+		},
+		{
+			name:       "正常系: 上限がない場合はnullを返す",
+			usage:      &entity.StorageUsage{ByType: []*entity.AssetTypeUsage{}},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"used_bytes":0,"pending_bytes":0,"quota_bytes":null,"by_type":[]}`,
+		},
+		{
+			name:       "異常系: 使用量の取得に失敗",
+			err:        domainerrors.ErrFailedToGetStorageUsage,
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   `{"message":"サーバーエラーが発生しました"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAssetUsecase := mock.NewMockIAssetUseCase(ctrl)
+			mockAssetUsecase.EXPECT().GetStorageUsage(gomock.Any(), userID).Return(tt.usage, tt.err)
+
+			assetController := controller.NewAssetController(mockAssetUsecase)
+			token := jwt.NewWithClaims(jwt.SigningMethodHS256, &schema.JWTCustomClaims{
+				UserID: userID.String(),
+			})
+
+			e.GET("/users/me/storage", func(c echo.Context) error {
+				c.Set("user", token)
+				return assetController.GetStorageUsage(c)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/users/me/storage", nil)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestAssetController_GetZipManifest(t *testing.T) {
+	assetID := uuid.New()
+
+	tests := []struct {
+		name       string
+		assetID    string
+		manifest   *entity.ZipManifest
+		err        error
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:    "正常系: ZIPの中身の一覧を返す",
+			assetID: assetID.String(),
+			manifest: &entity.ZipManifest{
+				Entries: []*entity.ZipEntry{
+					{Path: "game/index.html", Size: 100, CompressedSize: 60},
+					{Path: "game/Build/game.loader.js", Size: 200, CompressedSize: 80},
+				},
+				TotalSize: 300,
+				Contents:  []string{entity.ZipContentUnityWebGL},
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"asset_id":"` + assetID.String() + `","file_count":2,"total_size":300,"contents":["unity_webgl"],"entries":[{"path":"game/index.html","size":100},{"path":"game/Build/game.loader.js","size":200}]}`,
+		},
+		{
+			name:       "正常系: 推定できる内容がない場合は空の配列を返す",
+			assetID:    assetID.String(),
+			manifest:   &entity.ZipManifest{Entries: []*entity.ZipEntry{}},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"asset_id":"` + assetID.String() + `","file_count":0,"total_size":0,"contents":[],"entries":[]}`,
+		},
+		{
+			name:       "異常系: ZIP以外のアセット",
+			assetID:    assetID.String(),
+			err:        domainerrors.ErrZipManifestNotFound,
+			wantStatus: http.StatusNotFound,
+			wantBody:   `{"message":"ZIPファイルの中身の一覧がありません"}`,
+		},
+		{
+			name:       "異常系: アセットが存在しない",
+			assetID:    assetID.String(),
+			err:        domainerrors.ErrAssetNotFound,
+			wantStatus: http.StatusNotFound,
+			wantBody:   `{"message":"アセットが見つかりません"}`,
+		},
+		{
+			name:       "異常系: 不正なID",
+			assetID:    "invalid",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"message":"無効なリクエストです"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAssetUsecase := mock.NewMockIAssetUseCase(ctrl)
+			if tt.manifest != nil || tt.err != nil {
+				mockAssetUsecase.EXPECT().GetZipManifest(gomock.Any(), assetID).Return(tt.manifest, tt.err)
+			}
+
+			assetController := controller.NewAssetController(mockAssetUsecase)
+			e.GET("/assets/:id/manifest", assetController.GetZipManifest)
+
+			req := httptest.NewRequest(http.MethodGet, "/assets/"+tt.assetID+"/manifest", nil)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestAssetController_GetAssetByHash(t *testing.T) {
+	userID := uuid.New()
+	assetID := uuid.New()
+	hash := strings.Repeat("ab", 32)
+
+	tests := []struct {
+		name       string
+		asset      *entity.Asset
+		err        error
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "正常系: 同じ内容のアセットを返す",
+			asset:      &entity.Asset{ID: assetID, UserID: userID, URL: "https://example.com/video/origin.mp4", Hash: hash},
+			wantStatus: http.StatusOK,
+			wantBody:   `{"id":"` + assetID.String() + `","url":"https://example.com/video/origin.mp4","hash":"` + hash + `","srcset":[],"play_url":"","audio":null,"model":null,"placeholder":null}`,
+		},
+		{
+			name:       "異常系: アセットが存在しない",
+			err:        domainerrors.ErrAssetNotFound,
+			wantStatus: http.StatusNotFound,
+			wantBody:   `{"message":"アセットが見つかりません"}`,
+		},
+		{
+			name:       "異常系: SHA-256 ではない",
+			err:        domainerrors.ErrInvalidRequestBody,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"message":"無効なリクエストです"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAssetUsecase := mock.NewMockIAssetUseCase(ctrl)
+			mockAssetUsecase.EXPECT().FindAssetByHash(gomock.Any(), userID, hash).Return(tt.asset, tt.err)
+
+			assetController := controller.NewAssetController(mockAssetUsecase)
+			token := jwt.NewWithClaims(jwt.SigningMethodHS256, &schema.JWTCustomClaims{
+				UserID: userID.String(),
+			})
+
+			e.GET("/assets/by-hash/:sha256", func(c echo.Context) error {
+				c.Set("user", token)
+				return assetController.GetAssetByHash(c)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/assets/by-hash/"+hash, nil)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
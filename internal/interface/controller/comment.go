@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
 	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
 	"github.com/simesaba80/toybox-back/internal/interface/schema"
 	"github.com/simesaba80/toybox-back/internal/usecase"
@@ -23,14 +24,17 @@ func NewCommentController(commentUsecase usecase.ICommentUsecase) *CommentContro
 
 // GetCommentsByWorkID godoc
 // @Summary Get comments for a work
-// @Description Get all comments for a specific work
+// @Description Get all comments for a specific work. With format=tree, replies are nested up to the given depth as schema.CommentTreeResponse.
 // @Tags comments
 // @Produce json
 // @Param work_id path string true "Work ID"
+// @Param format query string false "Response format (flat or tree, default: flat)"
+// @Param depth query int false "Maximum depth of nested replies when format=tree (default: 3, max: 10)"
 // @Success 200 {array} schema.CommentResponse
 // @Failure 400 {object} echo.HTTPError
 // @Failure 404 {object} echo.HTTPError
 // @Failure 500 {object} echo.HTTPError
+// @Security BearerAuth
 // @Router /works/{work_id}/comments [get]
 func (cc *CommentController) GetCommentsByWorkID(c echo.Context) error {
 	workIDStr := c.Param("work_id")
@@ -38,8 +42,28 @@ func (cc *CommentController) GetCommentsByWorkID(c echo.Context) error {
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid work ID format")
 	}
+	viewerID, err := viewerIDFromToken(c)
+	if err != nil {
+		return handleCommentError(c, domainerrors.ErrInvalidRequestBody)
+	}
+
+	var query schema.GetCommentsQuery
+	if err := c.Bind(&query); err != nil {
+		return handleCommentError(c, domainerrors.ErrInvalidRequestBody)
+	}
+	if err := c.Validate(&query); err != nil {
+		return err
+	}
+
+	if query.Format == "tree" {
+		tree, err := cc.commentUsecase.GetCommentTree(c.Request().Context(), workID, viewerID, query.Depth)
+		if err != nil {
+			return handleCommentError(c, err)
+		}
+		return c.JSON(http.StatusOK, schema.ToCommentTreeResponse(tree))
+	}
 
-	comments, err := cc.commentUsecase.GetCommentsByWorkID(c.Request().Context(), workID)
+	comments, err := cc.commentUsecase.GetCommentsByWorkID(c.Request().Context(), workID, viewerID)
 	if err != nil {
 		c.Logger().Error("CommentUsecase.GetCommentsByWorkID error:", err)
 		return handleCommentError(c, err)
@@ -48,9 +72,52 @@ func (cc *CommentController) GetCommentsByWorkID(c echo.Context) error {
 	return c.JSON(http.StatusOK, schema.ToCommentListResponse(comments))
 }
 
+// GetReplies godoc
+// @Summary Get replies to a comment
+// @Description Get replies to a comment nested up to the given depth, for loading branches omitted from the comment tree
+// @Tags comments
+// @Produce json
+// @Param work_id path string true "Work ID"
+// @Param id path string true "Comment ID"
+// @Param depth query int false "Maximum depth of nested replies (default: 3, max: 10)"
+// @Success 200 {array} schema.CommentTreeResponse
+// @Failure 400 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Security BearerAuth
+// @Router /works/{work_id}/comments/{id}/replies [get]
+func (cc *CommentController) GetReplies(c echo.Context) error {
+	workID, err := uuid.Parse(c.Param("work_id"))
+	if err != nil {
+		return handleCommentError(c, domainerrors.ErrInvalidRequestBody)
+	}
+	commentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return handleCommentError(c, domainerrors.ErrInvalidRequestBody)
+	}
+	viewerID, err := viewerIDFromToken(c)
+	if err != nil {
+		return handleCommentError(c, domainerrors.ErrInvalidRequestBody)
+	}
+
+	var query schema.GetRepliesQuery
+	if err := c.Bind(&query); err != nil {
+		return handleCommentError(c, domainerrors.ErrInvalidRequestBody)
+	}
+	if err := c.Validate(&query); err != nil {
+		return err
+	}
+
+	replies, err := cc.commentUsecase.GetReplies(c.Request().Context(), workID, commentID, viewerID, query.Depth)
+	if err != nil {
+		return handleCommentError(c, err)
+	}
+	return c.JSON(http.StatusOK, schema.ToCommentTreeResponse(replies))
+}
+
 // CreateComment godoc
 // @Summary Create a comment for a work
-// @Description Create a new comment for a specific work. Can be anonymous or by a logged-in user.
+// @Description Create a new comment for a specific work as the logged-in user. Without a token the comment is posted anonymously, only when anonymous comments are enabled.
 // @Tags comments
 // @Accept json
 // @Produce json
@@ -58,8 +125,12 @@ func (cc *CommentController) GetCommentsByWorkID(c echo.Context) error {
 // @Param comment body schema.CreateCommentRequest true "Comment to create"
 // @Success 201 {object} schema.CreateCommentResponse
 // @Failure 400 {object} echo.HTTPError
+// @Failure 401 {object} echo.HTTPError
 // @Failure 404 {object} echo.HTTPError
+// @Failure 422 {object} echo.HTTPError
+// @Failure 429 {object} echo.HTTPError
 // @Failure 500 {object} echo.HTTPError
+// @Security BearerAuth
 // @Router /works/{work_id}/comments [post]
 func (cc *CommentController) CreateComment(c echo.Context) error {
 	workIDStr := c.Param("work_id")
@@ -77,22 +148,31 @@ func (cc *CommentController) CreateComment(c echo.Context) error {
 		return err
 	}
 
-	var userID uuid.UUID
-	if input.UserID != "" {
-		userID, err = uuid.Parse(input.UserID)
-		if err != nil {
-			c.Logger().Error("Invalid UserID format:", err)
-			return echo.NewHTTPError(http.StatusBadRequest, "Invalid UserID format")
+	// 投稿者はリクエストボディではなくトークンから決める。トークンがなければ匿名コメントとして扱う
+	var createdComment *entity.Comment
+	if c.Get("user") == nil {
+		createdComment, err = cc.commentUsecase.CreateAnonymousComment(
+			c.Request().Context(),
+			input.Content,
+			workID,
+			input.DisplayName,
+			c.RealIP(),
+			input.ReplyAt,
+		)
+	} else {
+		userID, parseErr := userIDFromToken(c)
+		if parseErr != nil {
+			return handleCommentError(c, domainerrors.ErrInvalidRequestBody)
 		}
+		createdComment, err = cc.commentUsecase.CreateComment(
+			c.Request().Context(),
+			input.Content,
+			workID,
+			userID,
+			c.RealIP(),
+			input.ReplyAt,
+		)
 	}
-
-	createdComment, err := cc.commentUsecase.CreateComment(
-		c.Request().Context(),
-		input.Content,
-		workID,
-		userID,
-		input.ReplyAt,
-	)
 	if err != nil {
 		c.Logger().Error("CommentUsecase.CreateComment error:", err)
 		return handleCommentError(c, err)
@@ -101,12 +181,107 @@ func (cc *CommentController) CreateComment(c echo.Context) error {
 	return c.JSON(http.StatusCreated, schema.ToCreateCommentResponse(createdComment))
 }
 
+// UpdateComment godoc
+// @Summary Update a comment
+// @Description Edit the content of the logged-in user's own comment. The comment is marked with edited_at.
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param work_id path string true "Work ID"
+// @Param comment_id path string true "Comment ID"
+// @Param comment body schema.UpdateCommentRequest true "New content"
+// @Success 200 {object} schema.CommentResponse
+// @Failure 400 {object} echo.HTTPError
+// @Failure 403 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 422 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Security BearerAuth
+// @Router /auth/works/{work_id}/comments/{comment_id} [put]
+func (cc *CommentController) UpdateComment(c echo.Context) error {
+	userID, err := userIDFromToken(c)
+	if err != nil {
+		return handleCommentError(c, domainerrors.ErrInvalidRequestBody)
+	}
+	workID, err := uuid.Parse(c.Param("work_id"))
+	if err != nil {
+		return handleCommentError(c, domainerrors.ErrInvalidRequestBody)
+	}
+	commentID, err := uuid.Parse(c.Param("comment_id"))
+	if err != nil {
+		return handleCommentError(c, domainerrors.ErrInvalidRequestBody)
+	}
+
+	var input schema.UpdateCommentRequest
+	if err := c.Bind(&input); err != nil {
+		return handleCommentError(c, domainerrors.ErrInvalidRequestBody)
+	}
+	if err := c.Validate(&input); err != nil {
+		return err
+	}
+
+	updatedComment, err := cc.commentUsecase.UpdateComment(c.Request().Context(), workID, commentID, userID, input.Content)
+	if err != nil {
+		return handleCommentError(c, err)
+	}
+	return c.JSON(http.StatusOK, schema.ToCommentResponse(updatedComment))
+}
+
+// DeleteComment godoc
+// @Summary Delete a comment
+// @Description Delete a comment. The author of the comment and the owner of the work can delete it. A comment with replies is left as a deleted tombstone.
+// @Tags comments
+// @Param work_id path string true "Work ID"
+// @Param comment_id path string true "Comment ID"
+// @Success 204
+// @Failure 400 {object} echo.HTTPError
+// @Failure 403 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Security BearerAuth
+// @Router /auth/works/{work_id}/comments/{comment_id} [delete]
+func (cc *CommentController) DeleteComment(c echo.Context) error {
+	userID, err := userIDFromToken(c)
+	if err != nil {
+		return handleCommentError(c, domainerrors.ErrInvalidRequestBody)
+	}
+	workID, err := uuid.Parse(c.Param("work_id"))
+	if err != nil {
+		return handleCommentError(c, domainerrors.ErrInvalidRequestBody)
+	}
+	commentID, err := uuid.Parse(c.Param("comment_id"))
+	if err != nil {
+		return handleCommentError(c, domainerrors.ErrInvalidRequestBody)
+	}
+
+	if err := cc.commentUsecase.DeleteComment(c.Request().Context(), workID, commentID, userID); err != nil {
+		return handleCommentError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
 func handleCommentError(c echo.Context, err error) error {
 	var httpErr *echo.HTTPError
 	if errors.As(err, &httpErr) {
 		return httpErr
 	}
 
+	var rejectedErr *domainerrors.CommentRejectedError
+	if errors.As(err, &rejectedErr) {
+		switch rejectedErr.Reason {
+		case domainerrors.CommentRejectNGWord:
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "コメントに使用できない語句が含まれています")
+		case domainerrors.CommentRejectTooManyLinks:
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "コメントに含めるリンクが多すぎます")
+		case domainerrors.CommentRejectDuplicate:
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "同じ内容のコメントが既に投稿されています")
+		case domainerrors.CommentRejectRateLimited:
+			return echo.NewHTTPError(http.StatusTooManyRequests, "コメントの投稿が多すぎます。しばらく待ってから再度お試しください")
+		default:
+			return echo.NewHTTPError(http.StatusUnprocessableEntity, "このコメントは投稿できません")
+		}
+	}
+
 	switch {
 	case errors.Is(err, domainerrors.ErrInvalidRequestBody):
 		return echo.NewHTTPError(http.StatusBadRequest, "無効なリクエストです")
@@ -118,6 +293,18 @@ func handleCommentError(c echo.Context, err error) error {
 		return echo.NewHTTPError(http.StatusNotFound, "コメントが見つかりませんでした")
 	case errors.Is(err, domainerrors.ErrFailedToCreateComment):
 		return echo.NewHTTPError(http.StatusInternalServerError, "コメントの作成に失敗しました")
+	case errors.Is(err, domainerrors.ErrFailedToUpdateComment):
+		return echo.NewHTTPError(http.StatusInternalServerError, "コメントの更新に失敗しました")
+	case errors.Is(err, domainerrors.ErrFailedToDeleteComment):
+		return echo.NewHTTPError(http.StatusInternalServerError, "コメントの削除に失敗しました")
+	case errors.Is(err, domainerrors.ErrCommentForbidden):
+		return echo.NewHTTPError(http.StatusForbidden, "このコメントを操作する権限がありません")
+	case errors.Is(err, domainerrors.ErrWorkNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "作品が見つかりませんでした")
+	case errors.Is(err, domainerrors.ErrInvalidReplyTarget):
+		return echo.NewHTTPError(http.StatusBadRequest, "返信先のコメントが不正です")
+	case errors.Is(err, domainerrors.ErrAnonymousCommentDisabled):
+		return echo.NewHTTPError(http.StatusUnauthorized, "コメントするにはログインが必要です")
 	}
 
 	c.Logger().Error("Comment error:", err)
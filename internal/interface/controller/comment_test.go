@@ -3,17 +3,23 @@ package controller_test
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
 	"github.com/simesaba80/toybox-back/internal/interface/controller"
 	"github.com/simesaba80/toybox-back/internal/interface/controller/mock"
 	"github.com/simesaba80/toybox-back/internal/interface/schema"
+	"github.com/simesaba80/toybox-back/internal/util"
+	"github.com/simesaba80/toybox-back/pkg/echovalidator"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -47,7 +53,7 @@ func TestCommentController_GetCommentsByWorkID(t *testing.T) {
 			workID: workID.String(),
 			setupMock: func(mockCommentUsecase *mock.MockICommentUsecase, mockWorkUsecase *mock.MockIWorkUseCase) {
 				mockCommentUsecase.EXPECT().
-					GetCommentsByWorkID(gomock.Any(), workID).
+					GetCommentsByWorkID(gomock.Any(), workID, uuid.Nil).
 					Return(mockComments, nil)
 			},
 			wantStatus: http.StatusOK,
@@ -58,7 +64,7 @@ func TestCommentController_GetCommentsByWorkID(t *testing.T) {
 			workID: "invalid-uuid",
 			setupMock: func(mockCommentUsecase *mock.MockICommentUsecase, mockWorkUsecase *mock.MockIWorkUseCase) {
 				mockCommentUsecase.EXPECT().
-					GetCommentsByWorkID(gomock.Any(), workID).
+					GetCommentsByWorkID(gomock.Any(), workID, uuid.Nil).
 					Return(nil, errors.New("some db error")).
 					Times(0)
 			},
@@ -71,7 +77,7 @@ func TestCommentController_GetCommentsByWorkID(t *testing.T) {
 			workID: workID.String(),
 			setupMock: func(mockCommentUsecase *mock.MockICommentUsecase, mockWorkUsecase *mock.MockIWorkUseCase) {
 				mockCommentUsecase.EXPECT().
-					GetCommentsByWorkID(gomock.Any(), workID).
+					GetCommentsByWorkID(gomock.Any(), workID, uuid.Nil).
 					Return(nil, errors.New("some error"))
 			},
 			wantStatus: http.StatusInternalServerError,
@@ -82,6 +88,7 @@ func TestCommentController_GetCommentsByWorkID(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			e := echo.New()
+			e.Validator = echovalidator.NewValidator()
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
@@ -102,3 +109,375 @@ func TestCommentController_GetCommentsByWorkID(t *testing.T) {
 		})
 	}
 }
+
+func TestCommentController_CreateComment(t *testing.T) {
+	workID := uuid.New()
+	userID := uuid.New()
+	now := time.Now()
+
+	userComment := &entity.Comment{ID: uuid.New(), Content: "コメント", WorkID: workID, UserID: userID, CreatedAt: now, UpdatedAt: now}
+	anonymousComment := entity.NewAnonymousComment("コメント", workID, "guest", "0123456789abcdef", "")
+	userCommentBytes, _ := json.Marshal(schema.ToCreateCommentResponse(userComment))
+	anonymousCommentBytes, _ := json.Marshal(schema.ToCreateCommentResponse(anonymousComment))
+
+	tests := []struct {
+		name       string
+		loggedIn   bool
+		body       string
+		setupMock  func(*mock.MockICommentUsecase)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:     "正常系: ログインユーザーとしてコメントできる",
+			loggedIn: true,
+			// ボディのuser_idは無視され、トークンのユーザーが投稿者になる
+			body: `{"content":"コメント","user_id":"` + uuid.New().String() + `"}`,
+			setupMock: func(m *mock.MockICommentUsecase) {
+				m.EXPECT().CreateComment(gomock.Any(), "コメント", workID, userID, gomock.Any(), "").Return(userComment, nil)
+			},
+			wantStatus: http.StatusCreated,
+			wantBody:   string(userCommentBytes),
+		},
+		{
+			name:     "正常系: トークンがない場合は匿名コメントになる",
+			loggedIn: false,
+			body:     `{"content":"コメント","display_name":"guest"}`,
+			setupMock: func(m *mock.MockICommentUsecase) {
+				m.EXPECT().CreateAnonymousComment(gomock.Any(), "コメント", workID, "guest", "192.0.2.1", "").Return(anonymousComment, nil)
+			},
+			wantStatus: http.StatusCreated,
+			wantBody:   string(anonymousCommentBytes),
+		},
+		{
+			name:     "異常系: 匿名コメントが無効になっている",
+			loggedIn: false,
+			body:     `{"content":"コメント"}`,
+			setupMock: func(m *mock.MockICommentUsecase) {
+				m.EXPECT().CreateAnonymousComment(gomock.Any(), "コメント", workID, "", "192.0.2.1", "").Return(nil, domainerrors.ErrAnonymousCommentDisabled)
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantBody:   `{"message":"コメントするにはログインが必要です"}`,
+		},
+		{
+			name:     "異常系: NGワードを含むコメントは拒否される",
+			loggedIn: true,
+			body:     `{"content":"コメント"}`,
+			setupMock: func(m *mock.MockICommentUsecase) {
+				m.EXPECT().CreateComment(gomock.Any(), "コメント", workID, userID, gomock.Any(), "").Return(nil, domainerrors.NewCommentRejectedError(domainerrors.CommentRejectNGWord))
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `{"message":"コメントに使用できない語句が含まれています"}`,
+		},
+		{
+			name:     "異常系: 短時間に投稿しすぎると拒否される",
+			loggedIn: false,
+			body:     `{"content":"コメント"}`,
+			setupMock: func(m *mock.MockICommentUsecase) {
+				m.EXPECT().CreateAnonymousComment(gomock.Any(), "コメント", workID, "", "192.0.2.1", "").Return(nil, fmt.Errorf("wrapped: %w", domainerrors.NewCommentRejectedError(domainerrors.CommentRejectRateLimited)))
+			},
+			wantStatus: http.StatusTooManyRequests,
+			wantBody:   `{"message":"コメントの投稿が多すぎます。しばらく待ってから再度お試しください"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Validator = echovalidator.NewValidator()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCommentUsecase := mock.NewMockICommentUsecase(ctrl)
+			tt.setupMock(mockCommentUsecase)
+
+			commentController := controller.NewCommentController(mockCommentUsecase)
+			e.POST("/works/:work_id/comments", func(c echo.Context) error {
+				if tt.loggedIn {
+					c.Set("user", jwt.NewWithClaims(jwt.SigningMethodHS256, &schema.JWTCustomClaims{UserID: userID.String()}))
+				}
+				return commentController.CreateComment(c)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/works/"+workID.String()+"/comments", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.RemoteAddr = "192.0.2.1:12345"
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestCommentController_UpdateComment(t *testing.T) {
+	workID := uuid.New()
+	commentID := uuid.New()
+	userID := uuid.New()
+	now := time.Now()
+
+	updatedComment := &entity.Comment{ID: commentID, Content: "after", WorkID: workID, UserID: userID, EditedAt: &now, CreatedAt: now, UpdatedAt: now}
+	successResponseBytes, _ := json.Marshal(schema.ToCommentResponse(updatedComment))
+
+	tests := []struct {
+		name       string
+		commentID  string
+		body       string
+		setupMock  func(*mock.MockICommentUsecase)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:      "正常系: コメントを編集できる",
+			commentID: commentID.String(),
+			body:      `{"content":"after"}`,
+			setupMock: func(m *mock.MockICommentUsecase) {
+				m.EXPECT().UpdateComment(gomock.Any(), workID, commentID, userID, "after").Return(updatedComment, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   string(successResponseBytes),
+		},
+		{
+			name:      "異常系: comment_idがUUID形式でない",
+			commentID: "invalid-uuid",
+			body:      `{"content":"after"}`,
+			setupMock: func(m *mock.MockICommentUsecase) {
+				m.EXPECT().UpdateComment(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"message":"無効なリクエストです"}`,
+		},
+		{
+			name:      "異常系: 投稿者以外が編集しようとした",
+			commentID: commentID.String(),
+			body:      `{"content":"after"}`,
+			setupMock: func(m *mock.MockICommentUsecase) {
+				m.EXPECT().UpdateComment(gomock.Any(), workID, commentID, userID, "after").Return(nil, domainerrors.ErrCommentForbidden)
+			},
+			wantStatus: http.StatusForbidden,
+			wantBody:   `{"message":"このコメントを操作する権限がありません"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Validator = echovalidator.NewValidator()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCommentUsecase := mock.NewMockICommentUsecase(ctrl)
+			tt.setupMock(mockCommentUsecase)
+
+			commentController := controller.NewCommentController(mockCommentUsecase)
+			e.PUT("/auth/works/:work_id/comments/:comment_id", func(c echo.Context) error {
+				c.Set("user", jwt.NewWithClaims(jwt.SigningMethodHS256, &schema.JWTCustomClaims{UserID: userID.String()}))
+				return commentController.UpdateComment(c)
+			})
+
+			req := httptest.NewRequest(http.MethodPut, "/auth/works/"+workID.String()+"/comments/"+tt.commentID, strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestCommentController_DeleteComment(t *testing.T) {
+	workID := uuid.New()
+	commentID := uuid.New()
+	userID := uuid.New()
+
+	tests := []struct {
+		name       string
+		setupMock  func(*mock.MockICommentUsecase)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "正常系: コメントを削除できる",
+			setupMock: func(m *mock.MockICommentUsecase) {
+				m.EXPECT().DeleteComment(gomock.Any(), workID, commentID, userID).Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "異常系: 削除する権限がない",
+			setupMock: func(m *mock.MockICommentUsecase) {
+				m.EXPECT().DeleteComment(gomock.Any(), workID, commentID, userID).Return(domainerrors.ErrCommentForbidden)
+			},
+			wantStatus: http.StatusForbidden,
+			wantBody:   `{"message":"このコメントを操作する権限がありません"}`,
+		},
+		{
+			name: "異常系: コメントが存在しない",
+			setupMock: func(m *mock.MockICommentUsecase) {
+				m.EXPECT().DeleteComment(gomock.Any(), workID, commentID, userID).Return(domainerrors.ErrCommentNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   `{"message":"コメントが見つかりませんでした"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCommentUsecase := mock.NewMockICommentUsecase(ctrl)
+			tt.setupMock(mockCommentUsecase)
+
+			commentController := controller.NewCommentController(mockCommentUsecase)
+			e.DELETE("/auth/works/:work_id/comments/:comment_id", func(c echo.Context) error {
+				c.Set("user", jwt.NewWithClaims(jwt.SigningMethodHS256, &schema.JWTCustomClaims{UserID: userID.String()}))
+				return commentController.DeleteComment(c)
+			})
+
+			req := httptest.NewRequest(http.MethodDelete, "/auth/works/"+workID.String()+"/comments/"+commentID.String(), nil)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantBody == "" {
+				assert.Empty(t, rec.Body.String())
+			} else {
+				assert.JSONEq(t, tt.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestCommentController_GetCommentsByWorkID_Tree(t *testing.T) {
+	workID := uuid.New()
+	now := time.Now()
+	parent := &entity.Comment{ID: uuid.New(), WorkID: workID, Content: "parent", CreatedAt: now, UpdatedAt: now}
+	reply := &entity.Comment{ID: uuid.New(), WorkID: workID, Content: "reply", ReplyAt: parent.ID.String(), CreatedAt: now, UpdatedAt: now}
+	tree := []*entity.CommentNode{
+		{Comment: parent, ReplyCount: 1, Replies: []*entity.CommentNode{{Comment: reply, Replies: []*entity.CommentNode{}}}},
+	}
+	successResponseBytes, _ := json.Marshal(schema.ToCommentTreeResponse(tree))
+
+	tests := []struct {
+		name       string
+		query      string
+		setupMock  func(*mock.MockICommentUsecase)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:  "正常系: 返信を入れ子にして取得できる",
+			query: "?format=tree&depth=2",
+			setupMock: func(m *mock.MockICommentUsecase) {
+				m.EXPECT().GetCommentTree(gomock.Any(), workID, uuid.Nil, util.IntPtr(2)).Return(tree, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   string(successResponseBytes),
+		},
+		{
+			name:  "異常系: depthが上限を超えている",
+			query: "?format=tree&depth=11",
+			setupMock: func(m *mock.MockICommentUsecase) {
+				m.EXPECT().GetCommentTree(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "異常系: formatが不正",
+			query: "?format=nested",
+			setupMock: func(m *mock.MockICommentUsecase) {
+				m.EXPECT().GetCommentsByWorkID(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Validator = echovalidator.NewValidator()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCommentUsecase := mock.NewMockICommentUsecase(ctrl)
+			tt.setupMock(mockCommentUsecase)
+
+			commentController := controller.NewCommentController(mockCommentUsecase)
+			e.GET("/works/:work_id/comments", commentController.GetCommentsByWorkID)
+
+			req := httptest.NewRequest(http.MethodGet, "/works/"+workID.String()+"/comments"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestCommentController_GetReplies(t *testing.T) {
+	workID := uuid.New()
+	commentID := uuid.New()
+	now := time.Now()
+	replies := []*entity.CommentNode{
+		{Comment: &entity.Comment{ID: uuid.New(), WorkID: workID, Content: "reply", ReplyAt: commentID.String(), CreatedAt: now, UpdatedAt: now}, Replies: []*entity.CommentNode{}},
+	}
+	successResponseBytes, _ := json.Marshal(schema.ToCommentTreeResponse(replies))
+
+	tests := []struct {
+		name       string
+		setupMock  func(*mock.MockICommentUsecase)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "正常系: 返信を取得できる",
+			setupMock: func(m *mock.MockICommentUsecase) {
+				m.EXPECT().GetReplies(gomock.Any(), workID, commentID, uuid.Nil, gomock.Nil()).Return(replies, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   string(successResponseBytes),
+		},
+		{
+			name: "異常系: コメントが存在しない",
+			setupMock: func(m *mock.MockICommentUsecase) {
+				m.EXPECT().GetReplies(gomock.Any(), workID, commentID, uuid.Nil, gomock.Nil()).Return(nil, domainerrors.ErrCommentNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   `{"message":"コメントが見つかりませんでした"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Validator = echovalidator.NewValidator()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockCommentUsecase := mock.NewMockICommentUsecase(ctrl)
+			tt.setupMock(mockCommentUsecase)
+
+			commentController := controller.NewCommentController(mockCommentUsecase)
+			e.GET("/works/:work_id/comments/:id/replies", commentController.GetReplies)
+
+			req := httptest.NewRequest(http.MethodGet, "/works/"+workID.String()+"/comments/"+commentID.String()+"/replies", nil)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
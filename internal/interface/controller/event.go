@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/interface/schema"
+	"github.com/simesaba80/toybox-back/internal/usecase"
+)
+
+// eventStreamHeartbeatInterval はプロキシに接続を切られないようにコメント行を送る間隔です。
+const eventStreamHeartbeatInterval = 15 * time.Second
+
+type EventController struct {
+	eventUsecase usecase.IEventUsecase
+}
+
+func NewEventController(eventUsecase usecase.IEventUsecase) *EventController {
+	return &EventController{eventUsecase: eventUsecase}
+}
+
+// Stream godoc
+// @Summary Stream activity events
+// @Description Stream favorites and comments on the logged-in user's works as Server-Sent Events. Send the Last-Event-ID header to resume after reconnecting.
+// @Tags events
+// @Produce text/event-stream
+// @Param Last-Event-ID header string false "ID of the last received event"
+// @Success 200 {object} schema.EventResponse
+// @Failure 400 {object} echo.HTTPError
+// @Failure 503 {object} echo.HTTPError
+// @Security BearerAuth
+// @Router /auth/events/stream [get]
+func (ec *EventController) Stream(c echo.Context) error {
+	userID, err := userIDFromToken(c)
+	if err != nil {
+		return handleEventError(c, domainerrors.ErrInvalidRequestBody)
+	}
+
+	ctx := c.Request().Context()
+	events, unsubscribe, err := ec.eventUsecase.Subscribe(ctx, userID, c.Request().Header.Get("Last-Event-ID"))
+	if err != nil {
+		return handleEventError(c, err)
+	}
+	defer unsubscribe()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+	res.Flush()
+
+	heartbeat := time.NewTicker(eventStreamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	// ヘッダー送信後はエラーレスポンスを返せないため、書き込みに失敗したら接続を終える
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-heartbeat.C:
+			if _, err := io.WriteString(res, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		case event, ok := <-events:
+			// チャネルが閉じられたらサーバーの停止か受信の遅れなので、クライアントの再接続に任せる
+			if !ok {
+				return nil
+			}
+			if err := writeEvent(res, event); err != nil {
+				return nil
+			}
+			res.Flush()
+		}
+	}
+}
+
+func writeEvent(w io.Writer, event *entity.Event) error {
+	data, err := json.Marshal(schema.ToEventResponse(event))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return err
+}
+
+func handleEventError(c echo.Context, err error) error {
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr
+	}
+
+	switch {
+	case errors.Is(err, domainerrors.ErrInvalidRequestBody):
+		return echo.NewHTTPError(http.StatusBadRequest, "無効なリクエストです")
+	case errors.Is(err, domainerrors.ErrEventBrokerClosed):
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "サーバーが停止中です")
+	default:
+		c.Logger().Error("Event error:", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "サーバーエラーが発生しました")
+	}
+}
@@ -0,0 +1,93 @@
+package controller_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/interface/controller"
+	"github.com/simesaba80/toybox-back/internal/interface/controller/mock"
+	"github.com/simesaba80/toybox-back/internal/interface/schema"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestEventController_Stream(t *testing.T) {
+	userID := uuid.New()
+	event := &entity.Event{
+		ID:        "42",
+		UserID:    userID,
+		Type:      entity.EventTypeFavoriteCreated,
+		ActorID:   uuid.New(),
+		WorkID:    uuid.New(),
+		CreatedAt: time.Now(),
+	}
+	eventData, _ := json.Marshal(schema.ToEventResponse(event))
+
+	tests := []struct {
+		name        string
+		lastEventID string
+		setupMock   func(*mock.MockIEventUsecase)
+		wantStatus  int
+		wantBody    string
+	}{
+		{
+			name:        "正常系: イベントをSSE形式で送信し、チャネルが閉じたら終了する",
+			lastEventID: "41",
+			setupMock: func(m *mock.MockIEventUsecase) {
+				events := make(chan *entity.Event, 1)
+				events <- event
+				close(events)
+				m.EXPECT().Subscribe(gomock.Any(), userID, "41").Return((<-chan *entity.Event)(events), func() {}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   "id: 42\nevent: favorite.created\ndata: " + string(eventData) + "\n\n",
+		},
+		{
+			name: "異常系: ブローカーが停止している",
+			setupMock: func(m *mock.MockIEventUsecase) {
+				m.EXPECT().Subscribe(gomock.Any(), userID, "").Return(nil, nil, domainerrors.ErrEventBrokerClosed)
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody:   `{"message":"サーバーが停止中です"}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockIEventUsecase(ctrl)
+			tt.setupMock(mockUsecase)
+
+			eventController := controller.NewEventController(mockUsecase)
+			e.GET("/auth/events/stream", func(c echo.Context) error {
+				c.Set("user", jwt.NewWithClaims(jwt.SigningMethodHS256, &schema.JWTCustomClaims{UserID: userID.String()}))
+				return eventController.Stream(c)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/auth/events/stream", nil)
+			if tt.lastEventID != "" {
+				req.Header.Set("Last-Event-ID", tt.lastEventID)
+			}
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantBody, rec.Body.String())
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, "text/event-stream", rec.Header().Get(echo.HeaderContentType))
+			}
+		})
+	}
+}
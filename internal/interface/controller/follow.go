@@ -0,0 +1,157 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/interface/schema"
+	"github.com/simesaba80/toybox-back/internal/usecase"
+)
+
+type FollowController struct {
+	followUsecase usecase.IFollowUsecase
+}
+
+func NewFollowController(followUsecase usecase.IFollowUsecase) *FollowController {
+	return &FollowController{followUsecase: followUsecase}
+}
+
+// FollowUser godoc
+// @Summary Follow a user
+// @Description Follow a user
+// @Tags follows
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 201
+// @Failure 400 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Security BearerAuth
+// @Router /auth/users/{id}/follow [post]
+func (fc *FollowController) FollowUser(c echo.Context) error {
+	user := c.Get("user").(*jwt.Token)
+	claims := user.Claims.(*schema.JWTCustomClaims)
+	followerID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "無効なリクエストです")
+	}
+	followeeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "無効なリクエストです")
+	}
+
+	err = fc.followUsecase.FollowUser(c.Request().Context(), followerID, followeeID)
+	if err != nil {
+		c.Logger().Error("Failed to follow user:", err)
+		return handleFollowError(err)
+	}
+	return c.NoContent(http.StatusCreated)
+}
+
+// UnfollowUser godoc
+// @Summary Unfollow a user
+// @Description Unfollow a user
+// @Tags follows
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 204
+// @Failure 400 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Security BearerAuth
+// @Router /auth/users/{id}/follow [delete]
+func (fc *FollowController) UnfollowUser(c echo.Context) error {
+	user := c.Get("user").(*jwt.Token)
+	claims := user.Claims.(*schema.JWTCustomClaims)
+	followerID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "無効なリクエストです")
+	}
+	followeeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "無効なリクエストです")
+	}
+
+	err = fc.followUsecase.UnfollowUser(c.Request().Context(), followerID, followeeID)
+	if err != nil {
+		c.Logger().Error("Failed to unfollow user:", err)
+		return handleFollowError(err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetFollowers godoc
+// @Summary Get followers of a user
+// @Description Get users who follow the user, most recently followed first
+// @Tags follows
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} schema.UserListResponse
+// @Failure 400 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Router /users/{id}/followers [get]
+func (fc *FollowController) GetFollowers(c echo.Context) error {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "無効なリクエストです")
+	}
+
+	users, err := fc.followUsecase.GetFollowers(c.Request().Context(), userID)
+	if err != nil {
+		c.Logger().Error("Failed to get followers:", err)
+		return handleFollowError(err)
+	}
+	return c.JSON(http.StatusOK, schema.ToUserListResponse(users))
+}
+
+// GetFollowing godoc
+// @Summary Get users followed by a user
+// @Description Get users the user follows, most recently followed first
+// @Tags follows
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} schema.UserListResponse
+// @Failure 400 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Router /users/{id}/following [get]
+func (fc *FollowController) GetFollowing(c echo.Context) error {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "無効なリクエストです")
+	}
+
+	users, err := fc.followUsecase.GetFollowing(c.Request().Context(), userID)
+	if err != nil {
+		c.Logger().Error("Failed to get following:", err)
+		return handleFollowError(err)
+	}
+	return c.JSON(http.StatusOK, schema.ToUserListResponse(users))
+}
+
+func handleFollowError(err error) error {
+	switch {
+	case errors.Is(err, domainerrors.ErrUserNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "ユーザーが見つかりませんでした")
+	case errors.Is(err, domainerrors.ErrCannotFollowYourself):
+		return echo.NewHTTPError(http.StatusBadRequest, "自分自身はフォローできません")
+	case errors.Is(err, domainerrors.ErrFollowAlreadyExists):
+		return echo.NewHTTPError(http.StatusBadRequest, "既にフォローしています")
+	case errors.Is(err, domainerrors.ErrFollowNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "フォローしていません")
+	case errors.Is(err, domainerrors.ErrFailedToCreateFollow):
+		return echo.NewHTTPError(http.StatusInternalServerError, "フォローに失敗しました")
+	case errors.Is(err, domainerrors.ErrFailedToDeleteFollow):
+		return echo.NewHTTPError(http.StatusInternalServerError, "フォローの解除に失敗しました")
+	case errors.Is(err, domainerrors.ErrFailedToGetFollowers):
+		return echo.NewHTTPError(http.StatusInternalServerError, "フォロワーの取得に失敗しました")
+	case errors.Is(err, domainerrors.ErrFailedToGetFollowing):
+		return echo.NewHTTPError(http.StatusInternalServerError, "フォロー中のユーザーの取得に失敗しました")
+	}
+	return echo.NewHTTPError(http.StatusInternalServerError, "サーバーエラーが発生しました")
+}
@@ -0,0 +1,237 @@
+package controller_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/interface/controller"
+	"github.com/simesaba80/toybox-back/internal/interface/controller/mock"
+	"github.com/simesaba80/toybox-back/internal/interface/schema"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFollowController_FollowUser(t *testing.T) {
+	followerID := uuid.New()
+	followeeID := uuid.New()
+
+	tests := []struct {
+		name       string
+		followeeID string
+		setupMock  func(*mock.MockIFollowUsecase)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "正常系: フォローが成功する",
+			followeeID: followeeID.String(),
+			setupMock: func(m *mock.MockIFollowUsecase) {
+				m.EXPECT().FollowUser(gomock.Any(), followerID, followeeID).Return(nil)
+			},
+			wantStatus: http.StatusCreated,
+			wantBody:   "",
+		},
+		{
+			name:       "異常系: idがUUID形式でない",
+			followeeID: "invalid-uuid",
+			setupMock: func(m *mock.MockIFollowUsecase) {
+				m.EXPECT().FollowUser(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"message":"無効なリクエストです"}`,
+		},
+		{
+			name:       "異常系: 自分自身をフォローしようとした",
+			followeeID: followeeID.String(),
+			setupMock: func(m *mock.MockIFollowUsecase) {
+				m.EXPECT().FollowUser(gomock.Any(), followerID, followeeID).Return(domainerrors.ErrCannotFollowYourself)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"message":"自分自身はフォローできません"}`,
+		},
+		{
+			name:       "異常系: ユーザーが存在しない",
+			followeeID: followeeID.String(),
+			setupMock: func(m *mock.MockIFollowUsecase) {
+				m.EXPECT().FollowUser(gomock.Any(), followerID, followeeID).Return(domainerrors.ErrUserNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   `{"message":"ユーザーが見つかりませんでした"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockIFollowUsecase(ctrl)
+			tt.setupMock(mockUsecase)
+
+			followController := controller.NewFollowController(mockUsecase)
+			e.POST("/auth/users/:id/follow", func(c echo.Context) error {
+				token := jwt.NewWithClaims(jwt.SigningMethodHS256, &schema.JWTCustomClaims{
+					UserID: followerID.String(),
+				})
+				c.Set("user", token)
+				return followController.FollowUser(c)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/auth/users/"+tt.followeeID+"/follow", nil)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantBody == "" {
+				assert.Empty(t, rec.Body.String())
+			} else {
+				assert.JSONEq(t, tt.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestFollowController_UnfollowUser(t *testing.T) {
+	followerID := uuid.New()
+	followeeID := uuid.New()
+
+	tests := []struct {
+		name       string
+		setupMock  func(*mock.MockIFollowUsecase)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "正常系: フォロー解除が成功する",
+			setupMock: func(m *mock.MockIFollowUsecase) {
+				m.EXPECT().UnfollowUser(gomock.Any(), followerID, followeeID).Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+			wantBody:   "",
+		},
+		{
+			name: "異常系: フォローしていない",
+			setupMock: func(m *mock.MockIFollowUsecase) {
+				m.EXPECT().UnfollowUser(gomock.Any(), followerID, followeeID).Return(domainerrors.ErrFollowNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   `{"message":"フォローしていません"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockIFollowUsecase(ctrl)
+			tt.setupMock(mockUsecase)
+
+			followController := controller.NewFollowController(mockUsecase)
+			e.DELETE("/auth/users/:id/follow", func(c echo.Context) error {
+				token := jwt.NewWithClaims(jwt.SigningMethodHS256, &schema.JWTCustomClaims{
+					UserID: followerID.String(),
+				})
+				c.Set("user", token)
+				return followController.UnfollowUser(c)
+			})
+
+			req := httptest.NewRequest(http.MethodDelete, "/auth/users/"+followeeID.String()+"/follow", nil)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantBody == "" {
+				assert.Empty(t, rec.Body.String())
+			} else {
+				assert.JSONEq(t, tt.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestFollowController_GetFollowers(t *testing.T) {
+	userID := uuid.New()
+	followers := []*entity.User{
+		{
+			ID:             uuid.New(),
+			Name:           "follower",
+			DisplayName:    "Follower",
+			FollowerCount:  1,
+			FollowingCount: 2,
+			CreatedAt:      time.Now(),
+			UpdatedAt:      time.Now(),
+		},
+	}
+	successResponseBytes, _ := json.Marshal(schema.ToUserListResponse(followers))
+
+	tests := []struct {
+		name       string
+		userID     string
+		setupMock  func(*mock.MockIFollowUsecase)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:   "正常系: フォロワー一覧を取得できる",
+			userID: userID.String(),
+			setupMock: func(m *mock.MockIFollowUsecase) {
+				m.EXPECT().GetFollowers(gomock.Any(), userID).Return(followers, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   string(successResponseBytes),
+		},
+		{
+			name:   "異常系: idがUUID形式でない",
+			userID: "invalid-uuid",
+			setupMock: func(m *mock.MockIFollowUsecase) {
+				m.EXPECT().GetFollowers(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"message":"無効なリクエストです"}`,
+		},
+		{
+			name:   "異常系: Usecaseエラー",
+			userID: userID.String(),
+			setupMock: func(m *mock.MockIFollowUsecase) {
+				m.EXPECT().GetFollowers(gomock.Any(), userID).Return(nil, domainerrors.ErrFailedToGetFollowers)
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   `{"message":"フォロワーの取得に失敗しました"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockIFollowUsecase(ctrl)
+			tt.setupMock(mockUsecase)
+
+			followController := controller.NewFollowController(mockUsecase)
+			e.GET("/users/:id/followers", followController.GetFollowers)
+
+			req := httptest.NewRequest(http.MethodGet, "/users/"+tt.userID+"/followers", nil)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/interface/schema"
+	"github.com/simesaba80/toybox-back/internal/usecase"
+)
+
+type MentionController struct {
+	mentionUsecase usecase.IMentionUsecase
+}
+
+func NewMentionController(mentionUsecase usecase.IMentionUsecase) *MentionController {
+	return &MentionController{mentionUsecase: mentionUsecase}
+}
+
+// GetMentions godoc
+// @Summary Get mentions
+// @Description Get mentions of the logged-in user in comments and work descriptions, newest first
+// @Tags mentions
+// @Produce json
+// @Param limit query int false "Limit per page (default: 20, max: 100)"
+// @Param page query int false "Page number (default: 1)"
+// @Success 200 {object} schema.MentionListResponse
+// @Failure 400 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Security BearerAuth
+// @Router /auth/mentions [get]
+func (mc *MentionController) GetMentions(c echo.Context) error {
+	userID, err := userIDFromToken(c)
+	if err != nil {
+		return handleMentionError(c, domainerrors.ErrInvalidRequestBody)
+	}
+
+	var query schema.GetMentionsQuery
+	if err := c.Bind(&query); err != nil {
+		return handleMentionError(c, domainerrors.ErrInvalidRequestBody)
+	}
+	if err := c.Validate(&query); err != nil {
+		return err
+	}
+
+	mentions, total, limit, page, err := mc.mentionUsecase.GetMentions(c.Request().Context(), userID, query.Limit, query.Page)
+	if err != nil {
+		return handleMentionError(c, err)
+	}
+	return c.JSON(http.StatusOK, schema.ToMentionListResponse(mentions, total, page, limit))
+}
+
+func handleMentionError(c echo.Context, err error) error {
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr
+	}
+
+	switch {
+	case errors.Is(err, domainerrors.ErrInvalidRequestBody):
+		return echo.NewHTTPError(http.StatusBadRequest, "無効なリクエストです")
+	case errors.Is(err, domainerrors.ErrFailedToGetMentions):
+		return echo.NewHTTPError(http.StatusInternalServerError, "メンションの取得に失敗しました")
+	default:
+		c.Logger().Error("Mention error:", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "サーバーエラーが発生しました")
+	}
+}
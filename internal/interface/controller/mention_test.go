@@ -0,0 +1,102 @@
+package controller_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/interface/controller"
+	"github.com/simesaba80/toybox-back/internal/interface/controller/mock"
+	"github.com/simesaba80/toybox-back/internal/interface/schema"
+	"github.com/simesaba80/toybox-back/pkg/echovalidator"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMentionController_GetMentions(t *testing.T) {
+	userID := uuid.New()
+	actorID := uuid.New()
+	commentID := uuid.New()
+	mentions := []*entity.Mention{
+		{
+			ID:        uuid.New(),
+			UserID:    userID,
+			ActorID:   actorID,
+			Actor:     &entity.User{ID: actorID, Name: "actor", DisplayName: "Actor"},
+			WorkID:    uuid.New(),
+			CommentID: &commentID,
+			CreatedAt: time.Now(),
+		},
+	}
+	successResponseBytes, _ := json.Marshal(schema.ToMentionListResponse(mentions, 1, 2, 10))
+
+	tests := []struct {
+		name       string
+		query      string
+		setupMock  func(*mock.MockIMentionUsecase)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:  "正常系: メンションを取得できる",
+			query: "?limit=10&page=2",
+			setupMock: func(m *mock.MockIMentionUsecase) {
+				m.EXPECT().GetMentions(gomock.Any(), userID, gomock.Any(), gomock.Any()).Return(mentions, 1, 10, 2, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   string(successResponseBytes),
+		},
+		{
+			name:  "異常系: pageが0",
+			query: "?page=0",
+			setupMock: func(m *mock.MockIMentionUsecase) {
+				m.EXPECT().GetMentions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "異常系: Usecaseエラー",
+			query: "",
+			setupMock: func(m *mock.MockIMentionUsecase) {
+				m.EXPECT().GetMentions(gomock.Any(), userID, gomock.Nil(), gomock.Nil()).Return(nil, 0, 0, 0, domainerrors.ErrFailedToGetMentions)
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   `{"message":"メンションの取得に失敗しました"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Validator = echovalidator.NewValidator()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockIMentionUsecase(ctrl)
+			tt.setupMock(mockUsecase)
+
+			mentionController := controller.NewMentionController(mockUsecase)
+			e.GET("/auth/mentions", func(c echo.Context) error {
+				c.Set("user", jwt.NewWithClaims(jwt.SigningMethodHS256, &schema.JWTCustomClaims{UserID: userID.String()}))
+				return mentionController.GetMentions(c)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/auth/mentions"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
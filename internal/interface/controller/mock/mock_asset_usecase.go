@@ -13,6 +13,7 @@ import (
 	context "context"
 	multipart "mime/multipart"
 	reflect "reflect"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	entity "github.com/simesaba80/toybox-back/internal/domain/entity"
@@ -43,6 +44,173 @@ func (m *MockIAssetUseCase) EXPECT() *MockIAssetUseCaseMockRecorder {
 	return m.recorder
 }
 
+// AbortExpiredUploads mocks base method.
+func (m *MockIAssetUseCase) AbortExpiredUploads(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AbortExpiredUploads", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AbortExpiredUploads indicates an expected call of AbortExpiredUploads.
+func (mr *MockIAssetUseCaseMockRecorder) AbortExpiredUploads(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbortExpiredUploads", reflect.TypeOf((*MockIAssetUseCase)(nil).AbortExpiredUploads), ctx)
+}
+
+// AbortMultipartUpload mocks base method.
+func (m *MockIAssetUseCase) AbortMultipartUpload(ctx context.Context, userID, uploadID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AbortMultipartUpload", ctx, userID, uploadID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AbortMultipartUpload indicates an expected call of AbortMultipartUpload.
+func (mr *MockIAssetUseCaseMockRecorder) AbortMultipartUpload(ctx, userID, uploadID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbortMultipartUpload", reflect.TypeOf((*MockIAssetUseCase)(nil).AbortMultipartUpload), ctx, userID, uploadID)
+}
+
+// CompleteMultipartUpload mocks base method.
+func (m *MockIAssetUseCase) CompleteMultipartUpload(ctx context.Context, userID, uploadID uuid.UUID) (*entity.Asset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteMultipartUpload", ctx, userID, uploadID)
+	ret0, _ := ret[0].(*entity.Asset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteMultipartUpload indicates an expected call of CompleteMultipartUpload.
+func (mr *MockIAssetUseCaseMockRecorder) CompleteMultipartUpload(ctx, userID, uploadID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteMultipartUpload", reflect.TypeOf((*MockIAssetUseCase)(nil).CompleteMultipartUpload), ctx, userID, uploadID)
+}
+
+// CompleteUpload mocks base method.
+func (m *MockIAssetUseCase) CompleteUpload(ctx context.Context, userID, uploadID uuid.UUID) (*entity.Asset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteUpload", ctx, userID, uploadID)
+	ret0, _ := ret[0].(*entity.Asset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteUpload indicates an expected call of CompleteUpload.
+func (mr *MockIAssetUseCaseMockRecorder) CompleteUpload(ctx, userID, uploadID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteUpload", reflect.TypeOf((*MockIAssetUseCase)(nil).CompleteUpload), ctx, userID, uploadID)
+}
+
+// CreateMultipartUpload mocks base method.
+func (m *MockIAssetUseCase) CreateMultipartUpload(ctx context.Context, userID uuid.UUID, fileName string, size int64) (*entity.AssetUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMultipartUpload", ctx, userID, fileName, size)
+	ret0, _ := ret[0].(*entity.AssetUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMultipartUpload indicates an expected call of CreateMultipartUpload.
+func (mr *MockIAssetUseCaseMockRecorder) CreateMultipartUpload(ctx, userID, fileName, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMultipartUpload", reflect.TypeOf((*MockIAssetUseCase)(nil).CreateMultipartUpload), ctx, userID, fileName, size)
+}
+
+// CreateUpload mocks base method.
+func (m *MockIAssetUseCase) CreateUpload(ctx context.Context, userID uuid.UUID, fileName string, size int64) (*entity.AssetUpload, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUpload", ctx, userID, fileName, size)
+	ret0, _ := ret[0].(*entity.AssetUpload)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateUpload indicates an expected call of CreateUpload.
+func (mr *MockIAssetUseCaseMockRecorder) CreateUpload(ctx, userID, fileName, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUpload", reflect.TypeOf((*MockIAssetUseCase)(nil).CreateUpload), ctx, userID, fileName, size)
+}
+
+// FindAssetByHash mocks base method.
+func (m *MockIAssetUseCase) FindAssetByHash(ctx context.Context, userID uuid.UUID, hash string) (*entity.Asset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAssetByHash", ctx, userID, hash)
+	ret0, _ := ret[0].(*entity.Asset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAssetByHash indicates an expected call of FindAssetByHash.
+func (mr *MockIAssetUseCaseMockRecorder) FindAssetByHash(ctx, userID, hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAssetByHash", reflect.TypeOf((*MockIAssetUseCase)(nil).FindAssetByHash), ctx, userID, hash)
+}
+
+// GetStorageUsage mocks base method.
+func (m *MockIAssetUseCase) GetStorageUsage(ctx context.Context, userID uuid.UUID) (*entity.StorageUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStorageUsage", ctx, userID)
+	ret0, _ := ret[0].(*entity.StorageUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStorageUsage indicates an expected call of GetStorageUsage.
+func (mr *MockIAssetUseCaseMockRecorder) GetStorageUsage(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStorageUsage", reflect.TypeOf((*MockIAssetUseCase)(nil).GetStorageUsage), ctx, userID)
+}
+
+// GetZipManifest mocks base method.
+func (m *MockIAssetUseCase) GetZipManifest(ctx context.Context, assetID uuid.UUID) (*entity.ZipManifest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetZipManifest", ctx, assetID)
+	ret0, _ := ret[0].(*entity.ZipManifest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetZipManifest indicates an expected call of GetZipManifest.
+func (mr *MockIAssetUseCaseMockRecorder) GetZipManifest(ctx, assetID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetZipManifest", reflect.TypeOf((*MockIAssetUseCase)(nil).GetZipManifest), ctx, assetID)
+}
+
+// ListUploadedParts mocks base method.
+func (m *MockIAssetUseCase) ListUploadedParts(ctx context.Context, userID, uploadID uuid.UUID) (*entity.AssetUpload, []*entity.UploadedPart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUploadedParts", ctx, userID, uploadID)
+	ret0, _ := ret[0].(*entity.AssetUpload)
+	ret1, _ := ret[1].([]*entity.UploadedPart)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListUploadedParts indicates an expected call of ListUploadedParts.
+func (mr *MockIAssetUseCaseMockRecorder) ListUploadedParts(ctx, userID, uploadID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUploadedParts", reflect.TypeOf((*MockIAssetUseCase)(nil).ListUploadedParts), ctx, userID, uploadID)
+}
+
+// PresignUploadParts mocks base method.
+func (m *MockIAssetUseCase) PresignUploadParts(ctx context.Context, userID, uploadID uuid.UUID, partNumbers []int32) ([]*entity.PresignedPart, time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PresignUploadParts", ctx, userID, uploadID, partNumbers)
+	ret0, _ := ret[0].([]*entity.PresignedPart)
+	ret1, _ := ret[1].(time.Time)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PresignUploadParts indicates an expected call of PresignUploadParts.
+func (mr *MockIAssetUseCaseMockRecorder) PresignUploadParts(ctx, userID, uploadID, partNumbers any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresignUploadParts", reflect.TypeOf((*MockIAssetUseCase)(nil).PresignUploadParts), ctx, userID, uploadID, partNumbers)
+}
+
 // UploadFile mocks base method.
 func (m *MockIAssetUseCase) UploadFile(ctx context.Context, file *multipart.FileHeader, userID uuid.UUID) (*entity.Asset, error) {
 	m.ctrl.T.Helper()
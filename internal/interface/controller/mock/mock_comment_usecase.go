@@ -42,32 +42,106 @@ func (m *MockICommentUsecase) EXPECT() *MockICommentUsecaseMockRecorder {
 	return m.recorder
 }
 
+// CreateAnonymousComment mocks base method.
+func (m *MockICommentUsecase) CreateAnonymousComment(ctx context.Context, content string, workID uuid.UUID, anonymousName, clientIP, replyAt string) (*entity.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAnonymousComment", ctx, content, workID, anonymousName, clientIP, replyAt)
+	ret0, _ := ret[0].(*entity.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAnonymousComment indicates an expected call of CreateAnonymousComment.
+func (mr *MockICommentUsecaseMockRecorder) CreateAnonymousComment(ctx, content, workID, anonymousName, clientIP, replyAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAnonymousComment", reflect.TypeOf((*MockICommentUsecase)(nil).CreateAnonymousComment), ctx, content, workID, anonymousName, clientIP, replyAt)
+}
+
 // CreateComment mocks base method.
-func (m *MockICommentUsecase) CreateComment(ctx context.Context, content string, workID, userID uuid.UUID, replyAt string) (*entity.Comment, error) {
+func (m *MockICommentUsecase) CreateComment(ctx context.Context, content string, workID, userID uuid.UUID, clientIP, replyAt string) (*entity.Comment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateComment", ctx, content, workID, userID, replyAt)
+	ret := m.ctrl.Call(m, "CreateComment", ctx, content, workID, userID, clientIP, replyAt)
 	ret0, _ := ret[0].(*entity.Comment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateComment indicates an expected call of CreateComment.
-func (mr *MockICommentUsecaseMockRecorder) CreateComment(ctx, content, workID, userID, replyAt any) *gomock.Call {
+func (mr *MockICommentUsecaseMockRecorder) CreateComment(ctx, content, workID, userID, clientIP, replyAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockICommentUsecase)(nil).CreateComment), ctx, content, workID, userID, clientIP, replyAt)
+}
+
+// DeleteComment mocks base method.
+func (m *MockICommentUsecase) DeleteComment(ctx context.Context, workID, commentID, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", ctx, workID, commentID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockICommentUsecaseMockRecorder) DeleteComment(ctx, workID, commentID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockICommentUsecase)(nil).DeleteComment), ctx, workID, commentID, userID)
+}
+
+// GetCommentTree mocks base method.
+func (m *MockICommentUsecase) GetCommentTree(ctx context.Context, workID, viewerID uuid.UUID, depth *int) ([]*entity.CommentNode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentTree", ctx, workID, viewerID, depth)
+	ret0, _ := ret[0].([]*entity.CommentNode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommentTree indicates an expected call of GetCommentTree.
+func (mr *MockICommentUsecaseMockRecorder) GetCommentTree(ctx, workID, viewerID, depth any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockICommentUsecase)(nil).CreateComment), ctx, content, workID, userID, replyAt)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentTree", reflect.TypeOf((*MockICommentUsecase)(nil).GetCommentTree), ctx, workID, viewerID, depth)
 }
 
 // GetCommentsByWorkID mocks base method.
-func (m *MockICommentUsecase) GetCommentsByWorkID(ctx context.Context, workID uuid.UUID) ([]*entity.Comment, error) {
+func (m *MockICommentUsecase) GetCommentsByWorkID(ctx context.Context, workID, viewerID uuid.UUID) ([]*entity.Comment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCommentsByWorkID", ctx, workID)
+	ret := m.ctrl.Call(m, "GetCommentsByWorkID", ctx, workID, viewerID)
 	ret0, _ := ret[0].([]*entity.Comment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetCommentsByWorkID indicates an expected call of GetCommentsByWorkID.
-func (mr *MockICommentUsecaseMockRecorder) GetCommentsByWorkID(ctx, workID any) *gomock.Call {
+func (mr *MockICommentUsecaseMockRecorder) GetCommentsByWorkID(ctx, workID, viewerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentsByWorkID", reflect.TypeOf((*MockICommentUsecase)(nil).GetCommentsByWorkID), ctx, workID, viewerID)
+}
+
+// GetReplies mocks base method.
+func (m *MockICommentUsecase) GetReplies(ctx context.Context, workID, commentID, viewerID uuid.UUID, depth *int) ([]*entity.CommentNode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReplies", ctx, workID, commentID, viewerID, depth)
+	ret0, _ := ret[0].([]*entity.CommentNode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReplies indicates an expected call of GetReplies.
+func (mr *MockICommentUsecaseMockRecorder) GetReplies(ctx, workID, commentID, viewerID, depth any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReplies", reflect.TypeOf((*MockICommentUsecase)(nil).GetReplies), ctx, workID, commentID, viewerID, depth)
+}
+
+// UpdateComment mocks base method.
+func (m *MockICommentUsecase) UpdateComment(ctx context.Context, workID, commentID, userID uuid.UUID, content string) (*entity.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComment", ctx, workID, commentID, userID, content)
+	ret0, _ := ret[0].(*entity.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateComment indicates an expected call of UpdateComment.
+func (mr *MockICommentUsecaseMockRecorder) UpdateComment(ctx, workID, commentID, userID, content any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentsByWorkID", reflect.TypeOf((*MockICommentUsecase)(nil).GetCommentsByWorkID), ctx, workID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockICommentUsecase)(nil).UpdateComment), ctx, workID, commentID, userID, content)
 }
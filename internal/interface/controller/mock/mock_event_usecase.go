@@ -0,0 +1,59 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/event.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/event.go -destination=internal/interface/controller/mock/mock_event_usecase.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	entity "github.com/simesaba80/toybox-back/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIEventUsecase is a mock of IEventUsecase interface.
+type MockIEventUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIEventUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockIEventUsecaseMockRecorder is the mock recorder for MockIEventUsecase.
+type MockIEventUsecaseMockRecorder struct {
+	mock *MockIEventUsecase
+}
+
+// NewMockIEventUsecase creates a new mock instance.
+func NewMockIEventUsecase(ctrl *gomock.Controller) *MockIEventUsecase {
+	mock := &MockIEventUsecase{ctrl: ctrl}
+	mock.recorder = &MockIEventUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIEventUsecase) EXPECT() *MockIEventUsecaseMockRecorder {
+	return m.recorder
+}
+
+// Subscribe mocks base method.
+func (m *MockIEventUsecase) Subscribe(ctx context.Context, userID uuid.UUID, lastEventID string) (<-chan *entity.Event, func(), error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", ctx, userID, lastEventID)
+	ret0, _ := ret[0].(<-chan *entity.Event)
+	ret1, _ := ret[1].(func())
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockIEventUsecaseMockRecorder) Subscribe(ctx, userID, lastEventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockIEventUsecase)(nil).Subscribe), ctx, userID, lastEventID)
+}
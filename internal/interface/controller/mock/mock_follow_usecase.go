@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/follow.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/follow.go -destination=internal/interface/controller/mock/mock_follow_usecase.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	entity "github.com/simesaba80/toybox-back/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIFollowUsecase is a mock of IFollowUsecase interface.
+type MockIFollowUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIFollowUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockIFollowUsecaseMockRecorder is the mock recorder for MockIFollowUsecase.
+type MockIFollowUsecaseMockRecorder struct {
+	mock *MockIFollowUsecase
+}
+
+// NewMockIFollowUsecase creates a new mock instance.
+func NewMockIFollowUsecase(ctrl *gomock.Controller) *MockIFollowUsecase {
+	mock := &MockIFollowUsecase{ctrl: ctrl}
+	mock.recorder = &MockIFollowUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIFollowUsecase) EXPECT() *MockIFollowUsecaseMockRecorder {
+	return m.recorder
+}
+
+// FollowUser mocks base method.
+func (m *MockIFollowUsecase) FollowUser(ctx context.Context, followerID, followeeID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FollowUser", ctx, followerID, followeeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FollowUser indicates an expected call of FollowUser.
+func (mr *MockIFollowUsecaseMockRecorder) FollowUser(ctx, followerID, followeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowUser", reflect.TypeOf((*MockIFollowUsecase)(nil).FollowUser), ctx, followerID, followeeID)
+}
+
+// GetFollowers mocks base method.
+func (m *MockIFollowUsecase) GetFollowers(ctx context.Context, userID uuid.UUID) ([]*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFollowers", ctx, userID)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFollowers indicates an expected call of GetFollowers.
+func (mr *MockIFollowUsecaseMockRecorder) GetFollowers(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowers", reflect.TypeOf((*MockIFollowUsecase)(nil).GetFollowers), ctx, userID)
+}
+
+// GetFollowing mocks base method.
+func (m *MockIFollowUsecase) GetFollowing(ctx context.Context, userID uuid.UUID) ([]*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFollowing", ctx, userID)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFollowing indicates an expected call of GetFollowing.
+func (mr *MockIFollowUsecaseMockRecorder) GetFollowing(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowing", reflect.TypeOf((*MockIFollowUsecase)(nil).GetFollowing), ctx, userID)
+}
+
+// UnfollowUser mocks base method.
+func (m *MockIFollowUsecase) UnfollowUser(ctx context.Context, followerID, followeeID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnfollowUser", ctx, followerID, followeeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnfollowUser indicates an expected call of UnfollowUser.
+func (mr *MockIFollowUsecaseMockRecorder) UnfollowUser(ctx, followerID, followeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnfollowUser", reflect.TypeOf((*MockIFollowUsecase)(nil).UnfollowUser), ctx, followerID, followeeID)
+}
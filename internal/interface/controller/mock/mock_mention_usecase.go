@@ -0,0 +1,61 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/mention.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/mention.go -destination=internal/interface/controller/mock/mock_mention_usecase.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	entity "github.com/simesaba80/toybox-back/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIMentionUsecase is a mock of IMentionUsecase interface.
+type MockIMentionUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIMentionUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockIMentionUsecaseMockRecorder is the mock recorder for MockIMentionUsecase.
+type MockIMentionUsecaseMockRecorder struct {
+	mock *MockIMentionUsecase
+}
+
+// NewMockIMentionUsecase creates a new mock instance.
+func NewMockIMentionUsecase(ctrl *gomock.Controller) *MockIMentionUsecase {
+	mock := &MockIMentionUsecase{ctrl: ctrl}
+	mock.recorder = &MockIMentionUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIMentionUsecase) EXPECT() *MockIMentionUsecaseMockRecorder {
+	return m.recorder
+}
+
+// GetMentions mocks base method.
+func (m *MockIMentionUsecase) GetMentions(ctx context.Context, userID uuid.UUID, limit, page *int) ([]*entity.Mention, int, int, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMentions", ctx, userID, limit, page)
+	ret0, _ := ret[0].([]*entity.Mention)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(int)
+	ret3, _ := ret[3].(int)
+	ret4, _ := ret[4].(error)
+	return ret0, ret1, ret2, ret3, ret4
+}
+
+// GetMentions indicates an expected call of GetMentions.
+func (mr *MockIMentionUsecaseMockRecorder) GetMentions(ctx, userID, limit, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMentions", reflect.TypeOf((*MockIMentionUsecase)(nil).GetMentions), ctx, userID, limit, page)
+}
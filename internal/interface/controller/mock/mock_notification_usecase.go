@@ -0,0 +1,90 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/notification.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/notification.go -destination=internal/interface/controller/mock/mock_notification_usecase.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	entity "github.com/simesaba80/toybox-back/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockINotificationUsecase is a mock of INotificationUsecase interface.
+type MockINotificationUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockINotificationUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockINotificationUsecaseMockRecorder is the mock recorder for MockINotificationUsecase.
+type MockINotificationUsecaseMockRecorder struct {
+	mock *MockINotificationUsecase
+}
+
+// NewMockINotificationUsecase creates a new mock instance.
+func NewMockINotificationUsecase(ctrl *gomock.Controller) *MockINotificationUsecase {
+	mock := &MockINotificationUsecase{ctrl: ctrl}
+	mock.recorder = &MockINotificationUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockINotificationUsecase) EXPECT() *MockINotificationUsecaseMockRecorder {
+	return m.recorder
+}
+
+// CountUnread mocks base method.
+func (m *MockINotificationUsecase) CountUnread(ctx context.Context, userID uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnread", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnread indicates an expected call of CountUnread.
+func (mr *MockINotificationUsecaseMockRecorder) CountUnread(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnread", reflect.TypeOf((*MockINotificationUsecase)(nil).CountUnread), ctx, userID)
+}
+
+// GetNotifications mocks base method.
+func (m *MockINotificationUsecase) GetNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, page *int) ([]*entity.Notification, int, int, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", ctx, userID, unreadOnly, limit, page)
+	ret0, _ := ret[0].([]*entity.Notification)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(int)
+	ret3, _ := ret[3].(int)
+	ret4, _ := ret[4].(error)
+	return ret0, ret1, ret2, ret3, ret4
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MockINotificationUsecaseMockRecorder) GetNotifications(ctx, userID, unreadOnly, limit, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*MockINotificationUsecase)(nil).GetNotifications), ctx, userID, unreadOnly, limit, page)
+}
+
+// MarkAsRead mocks base method.
+func (m *MockINotificationUsecase) MarkAsRead(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAsRead", ctx, userID, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAsRead indicates an expected call of MarkAsRead.
+func (mr *MockINotificationUsecaseMockRecorder) MarkAsRead(ctx, userID, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAsRead", reflect.TypeOf((*MockINotificationUsecase)(nil).MarkAsRead), ctx, userID, ids)
+}
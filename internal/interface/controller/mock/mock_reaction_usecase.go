@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/reaction.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/reaction.go -destination=internal/interface/controller/mock/mock_reaction_usecase.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	entity "github.com/simesaba80/toybox-back/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIReactionUsecase is a mock of IReactionUsecase interface.
+type MockIReactionUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockIReactionUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockIReactionUsecaseMockRecorder is the mock recorder for MockIReactionUsecase.
+type MockIReactionUsecaseMockRecorder struct {
+	mock *MockIReactionUsecase
+}
+
+// NewMockIReactionUsecase creates a new mock instance.
+func NewMockIReactionUsecase(ctrl *gomock.Controller) *MockIReactionUsecase {
+	mock := &MockIReactionUsecase{ctrl: ctrl}
+	mock.recorder = &MockIReactionUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIReactionUsecase) EXPECT() *MockIReactionUsecaseMockRecorder {
+	return m.recorder
+}
+
+// AddReaction mocks base method.
+func (m *MockIReactionUsecase) AddReaction(ctx context.Context, userID uuid.UUID, targetType entity.ReactionTargetType, targetID uuid.UUID, emoji string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReaction", ctx, userID, targetType, targetID, emoji)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddReaction indicates an expected call of AddReaction.
+func (mr *MockIReactionUsecaseMockRecorder) AddReaction(ctx, userID, targetType, targetID, emoji any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReaction", reflect.TypeOf((*MockIReactionUsecase)(nil).AddReaction), ctx, userID, targetType, targetID, emoji)
+}
+
+// RemoveReaction mocks base method.
+func (m *MockIReactionUsecase) RemoveReaction(ctx context.Context, userID uuid.UUID, targetType entity.ReactionTargetType, targetID uuid.UUID, emoji string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveReaction", ctx, userID, targetType, targetID, emoji)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveReaction indicates an expected call of RemoveReaction.
+func (mr *MockIReactionUsecaseMockRecorder) RemoveReaction(ctx, userID, targetType, targetID, emoji any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReaction", reflect.TypeOf((*MockIReactionUsecase)(nil).RemoveReaction), ctx, userID, targetType, targetID, emoji)
+}
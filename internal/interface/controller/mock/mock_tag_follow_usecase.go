@@ -0,0 +1,103 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecase/tag_follow.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/usecase/tag_follow.go -destination=internal/interface/controller/mock/mock_tag_follow_usecase.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	entity "github.com/simesaba80/toybox-back/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockITagFollowUsecase is a mock of ITagFollowUsecase interface.
+type MockITagFollowUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockITagFollowUsecaseMockRecorder
+	isgomock struct{}
+}
+
+// MockITagFollowUsecaseMockRecorder is the mock recorder for MockITagFollowUsecase.
+type MockITagFollowUsecaseMockRecorder struct {
+	mock *MockITagFollowUsecase
+}
+
+// NewMockITagFollowUsecase creates a new mock instance.
+func NewMockITagFollowUsecase(ctrl *gomock.Controller) *MockITagFollowUsecase {
+	mock := &MockITagFollowUsecase{ctrl: ctrl}
+	mock.recorder = &MockITagFollowUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITagFollowUsecase) EXPECT() *MockITagFollowUsecaseMockRecorder {
+	return m.recorder
+}
+
+// FollowTag mocks base method.
+func (m *MockITagFollowUsecase) FollowTag(ctx context.Context, userID, tagID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FollowTag", ctx, userID, tagID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FollowTag indicates an expected call of FollowTag.
+func (mr *MockITagFollowUsecaseMockRecorder) FollowTag(ctx, userID, tagID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowTag", reflect.TypeOf((*MockITagFollowUsecase)(nil).FollowTag), ctx, userID, tagID)
+}
+
+// GetNewWorks mocks base method.
+func (m *MockITagFollowUsecase) GetNewWorks(ctx context.Context, userID uuid.UUID, unseenOnly bool, limit, page *int) ([]*entity.TagNewWork, int, int, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNewWorks", ctx, userID, unseenOnly, limit, page)
+	ret0, _ := ret[0].([]*entity.TagNewWork)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(int)
+	ret3, _ := ret[3].(int)
+	ret4, _ := ret[4].(error)
+	return ret0, ret1, ret2, ret3, ret4
+}
+
+// GetNewWorks indicates an expected call of GetNewWorks.
+func (mr *MockITagFollowUsecaseMockRecorder) GetNewWorks(ctx, userID, unseenOnly, limit, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNewWorks", reflect.TypeOf((*MockITagFollowUsecase)(nil).GetNewWorks), ctx, userID, unseenOnly, limit, page)
+}
+
+// MarkNewWorksAsSeen mocks base method.
+func (m *MockITagFollowUsecase) MarkNewWorksAsSeen(ctx context.Context, userID uuid.UUID, workIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNewWorksAsSeen", ctx, userID, workIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNewWorksAsSeen indicates an expected call of MarkNewWorksAsSeen.
+func (mr *MockITagFollowUsecaseMockRecorder) MarkNewWorksAsSeen(ctx, userID, workIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNewWorksAsSeen", reflect.TypeOf((*MockITagFollowUsecase)(nil).MarkNewWorksAsSeen), ctx, userID, workIDs)
+}
+
+// UnfollowTag mocks base method.
+func (m *MockITagFollowUsecase) UnfollowTag(ctx context.Context, userID, tagID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnfollowTag", ctx, userID, tagID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnfollowTag indicates an expected call of UnfollowTag.
+func (mr *MockITagFollowUsecaseMockRecorder) UnfollowTag(ctx, userID, tagID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnfollowTag", reflect.TypeOf((*MockITagFollowUsecase)(nil).UnfollowTag), ctx, userID, tagID)
+}
@@ -76,18 +76,18 @@ func (mr *MockIWorkUseCaseMockRecorder) GetAll(ctx, limit, page, userID, tagIDs
 }
 
 // GetByID mocks base method.
-func (m *MockIWorkUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.Work, error) {
+func (m *MockIWorkUseCase) GetByID(ctx context.Context, id, viewerID uuid.UUID) (*entity.Work, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret := m.ctrl.Call(m, "GetByID", ctx, id, viewerID)
 	ret0, _ := ret[0].(*entity.Work)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByID indicates an expected call of GetByID.
-func (mr *MockIWorkUseCaseMockRecorder) GetByID(ctx, id any) *gomock.Call {
+func (mr *MockIWorkUseCaseMockRecorder) GetByID(ctx, id, viewerID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockIWorkUseCase)(nil).GetByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockIWorkUseCase)(nil).GetByID), ctx, id, viewerID)
 }
 
 // GetByUserID mocks base method.
@@ -104,3 +104,35 @@ func (mr *MockIWorkUseCaseMockRecorder) GetByUserID(ctx, userID, authenticatedUs
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockIWorkUseCase)(nil).GetByUserID), ctx, userID, authenticatedUserID)
 }
+
+// GetFeed mocks base method.
+func (m *MockIWorkUseCase) GetFeed(ctx context.Context, userID uuid.UUID, limit *int, cursor string) ([]*entity.Work, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeed", ctx, userID, limit, cursor)
+	ret0, _ := ret[0].([]*entity.Work)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetFeed indicates an expected call of GetFeed.
+func (mr *MockIWorkUseCaseMockRecorder) GetFeed(ctx, userID, limit, cursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeed", reflect.TypeOf((*MockIWorkUseCase)(nil).GetFeed), ctx, userID, limit, cursor)
+}
+
+// GetFollowingFeed mocks base method.
+func (m *MockIWorkUseCase) GetFollowingFeed(ctx context.Context, userID uuid.UUID, limit *int, cursor string) ([]*entity.Work, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFollowingFeed", ctx, userID, limit, cursor)
+	ret0, _ := ret[0].([]*entity.Work)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetFollowingFeed indicates an expected call of GetFollowingFeed.
+func (mr *MockIWorkUseCaseMockRecorder) GetFollowingFeed(ctx, userID, limit, cursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowingFeed", reflect.TypeOf((*MockIWorkUseCase)(nil).GetFollowingFeed), ctx, userID, limit, cursor)
+}
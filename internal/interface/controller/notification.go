@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/interface/schema"
+	"github.com/simesaba80/toybox-back/internal/usecase"
+)
+
+type NotificationController struct {
+	notificationUsecase usecase.INotificationUsecase
+}
+
+func NewNotificationController(notificationUsecase usecase.INotificationUsecase) *NotificationController {
+	return &NotificationController{notificationUsecase: notificationUsecase}
+}
+
+// GetNotifications godoc
+// @Summary Get notifications
+// @Description Get notifications of the logged-in user, newest first
+// @Tags notifications
+// @Produce json
+// @Param limit query int false "Limit per page (default: 20, max: 100)"
+// @Param page query int false "Page number (default: 1)"
+// @Param unread_only query bool false "Return only unread notifications"
+// @Success 200 {object} schema.NotificationListResponse
+// @Failure 400 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Security BearerAuth
+// @Router /auth/notifications [get]
+func (nc *NotificationController) GetNotifications(c echo.Context) error {
+	userID, err := userIDFromToken(c)
+	if err != nil {
+		return handleNotificationError(c, domainerrors.ErrInvalidRequestBody)
+	}
+
+	var query schema.GetNotificationsQuery
+	if err := c.Bind(&query); err != nil {
+		return handleNotificationError(c, domainerrors.ErrInvalidRequestBody)
+	}
+	if err := c.Validate(&query); err != nil {
+		return err
+	}
+
+	notifications, total, limit, page, err := nc.notificationUsecase.GetNotifications(c.Request().Context(), userID, query.UnreadOnly, query.Limit, query.Page)
+	if err != nil {
+		return handleNotificationError(c, err)
+	}
+	return c.JSON(http.StatusOK, schema.ToNotificationListResponse(notifications, total, page, limit))
+}
+
+// MarkAsRead godoc
+// @Summary Mark notifications as read
+// @Description Mark the given notifications as read. All notifications are marked when ids is empty.
+// @Tags notifications
+// @Accept json
+// @Param input body schema.MarkNotificationsAsReadInput false "Notification IDs to mark as read"
+// @Success 204
+// @Failure 400 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Security BearerAuth
+// @Router /auth/notifications/read [post]
+func (nc *NotificationController) MarkAsRead(c echo.Context) error {
+	userID, err := userIDFromToken(c)
+	if err != nil {
+		return handleNotificationError(c, domainerrors.ErrInvalidRequestBody)
+	}
+
+	var input schema.MarkNotificationsAsReadInput
+	if err := c.Bind(&input); err != nil {
+		return handleNotificationError(c, domainerrors.ErrInvalidRequestBody)
+	}
+	if err := c.Validate(&input); err != nil {
+		return handleNotificationError(c, domainerrors.ErrInvalidRequestBody)
+	}
+
+	if err := nc.notificationUsecase.MarkAsRead(c.Request().Context(), userID, input.IDs); err != nil {
+		return handleNotificationError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func handleNotificationError(c echo.Context, err error) error {
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr
+	}
+
+	switch {
+	case errors.Is(err, domainerrors.ErrInvalidRequestBody):
+		return echo.NewHTTPError(http.StatusBadRequest, "無効なリクエストです")
+	case errors.Is(err, domainerrors.ErrFailedToGetNotifications):
+		return echo.NewHTTPError(http.StatusInternalServerError, "通知の取得に失敗しました")
+	case errors.Is(err, domainerrors.ErrFailedToCountUnreadNotifications):
+		return echo.NewHTTPError(http.StatusInternalServerError, "未読通知数の取得に失敗しました")
+	case errors.Is(err, domainerrors.ErrFailedToMarkNotificationsAsRead):
+		return echo.NewHTTPError(http.StatusInternalServerError, "通知の既読化に失敗しました")
+	default:
+		c.Logger().Error("Notification error:", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "サーバーエラーが発生しました")
+	}
+}
@@ -0,0 +1,181 @@
+package controller_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/interface/controller"
+	"github.com/simesaba80/toybox-back/internal/interface/controller/mock"
+	"github.com/simesaba80/toybox-back/internal/interface/schema"
+	"github.com/simesaba80/toybox-back/pkg/echovalidator"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNotificationController_GetNotifications(t *testing.T) {
+	userID := uuid.New()
+	actorID := uuid.New()
+	notifications := []*entity.Notification{
+		{
+			ID:        uuid.New(),
+			UserID:    userID,
+			ActorID:   actorID,
+			Actor:     &entity.User{ID: actorID, Name: "actor", DisplayName: "Actor"},
+			Type:      entity.NotificationTypeFavorite,
+			WorkID:    uuid.New(),
+			CreatedAt: time.Now(),
+		},
+	}
+	successResponseBytes, _ := json.Marshal(schema.ToNotificationListResponse(notifications, 1, 1, 20))
+
+	tests := []struct {
+		name       string
+		query      string
+		setupMock  func(*mock.MockINotificationUsecase)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:  "正常系: 未読の通知を取得できる",
+			query: "?unread_only=true",
+			setupMock: func(m *mock.MockINotificationUsecase) {
+				m.EXPECT().GetNotifications(gomock.Any(), userID, true, gomock.Nil(), gomock.Nil()).Return(notifications, 1, 20, 1, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   string(successResponseBytes),
+		},
+		{
+			name:  "異常系: limitが上限を超えている",
+			query: "?limit=101",
+			setupMock: func(m *mock.MockINotificationUsecase) {
+				m.EXPECT().GetNotifications(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "異常系: Usecaseエラー",
+			query: "",
+			setupMock: func(m *mock.MockINotificationUsecase) {
+				m.EXPECT().GetNotifications(gomock.Any(), userID, false, gomock.Nil(), gomock.Nil()).Return(nil, 0, 0, 0, domainerrors.ErrFailedToGetNotifications)
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   `{"message":"通知の取得に失敗しました"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Validator = echovalidator.NewValidator()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockINotificationUsecase(ctrl)
+			tt.setupMock(mockUsecase)
+
+			notificationController := controller.NewNotificationController(mockUsecase)
+			e.GET("/auth/notifications", func(c echo.Context) error {
+				c.Set("user", jwt.NewWithClaims(jwt.SigningMethodHS256, &schema.JWTCustomClaims{UserID: userID.String()}))
+				return notificationController.GetNotifications(c)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/auth/notifications"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantBody != "" {
+				assert.JSONEq(t, tt.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestNotificationController_MarkAsRead(t *testing.T) {
+	userID := uuid.New()
+	notificationID := uuid.New()
+
+	tests := []struct {
+		name       string
+		body       string
+		setupMock  func(*mock.MockINotificationUsecase)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "正常系: 指定した通知を既読にできる",
+			body: `{"ids":["` + notificationID.String() + `"]}`,
+			setupMock: func(m *mock.MockINotificationUsecase) {
+				m.EXPECT().MarkAsRead(gomock.Any(), userID, []uuid.UUID{notificationID}).Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "正常系: 空の場合は全件を既読にする",
+			body: `{}`,
+			setupMock: func(m *mock.MockINotificationUsecase) {
+				m.EXPECT().MarkAsRead(gomock.Any(), userID, gomock.Nil()).Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "異常系: idsがUUID形式でない",
+			body: `{"ids":["invalid"]}`,
+			setupMock: func(m *mock.MockINotificationUsecase) {
+				m.EXPECT().MarkAsRead(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"message":"無効なリクエストです"}`,
+		},
+		{
+			name: "異常系: Usecaseエラー",
+			body: `{}`,
+			setupMock: func(m *mock.MockINotificationUsecase) {
+				m.EXPECT().MarkAsRead(gomock.Any(), userID, gomock.Nil()).Return(domainerrors.ErrFailedToMarkNotificationsAsRead)
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   `{"message":"通知の既読化に失敗しました"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Validator = echovalidator.NewValidator()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockINotificationUsecase(ctrl)
+			tt.setupMock(mockUsecase)
+
+			notificationController := controller.NewNotificationController(mockUsecase)
+			e.POST("/auth/notifications/read", func(c echo.Context) error {
+				c.Set("user", jwt.NewWithClaims(jwt.SigningMethodHS256, &schema.JWTCustomClaims{UserID: userID.String()}))
+				return notificationController.MarkAsRead(c)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/auth/notifications/read", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantBody == "" {
+				assert.Empty(t, rec.Body.String())
+			} else {
+				assert.JSONEq(t, tt.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
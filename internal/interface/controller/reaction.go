@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/interface/schema"
+	"github.com/simesaba80/toybox-back/internal/usecase"
+)
+
+type ReactionController struct {
+	reactionUsecase usecase.IReactionUsecase
+}
+
+func NewReactionController(reactionUsecase usecase.IReactionUsecase) *ReactionController {
+	return &ReactionController{reactionUsecase: reactionUsecase}
+}
+
+// AddReaction godoc
+// @Summary Add a reaction
+// @Description Add an emoji reaction to a work or a comment. Adding the same reaction again has no effect.
+// @Tags reactions
+// @Accept json
+// @Param input body schema.ReactionRequest true "Reaction to add"
+// @Success 204
+// @Failure 400 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Security BearerAuth
+// @Router /auth/reactions [put]
+func (rc *ReactionController) AddReaction(c echo.Context) error {
+	userID, input, err := bindReactionRequest(c)
+	if err != nil {
+		return handleReactionError(c, err)
+	}
+
+	if err := rc.reactionUsecase.AddReaction(c.Request().Context(), userID, entity.ReactionTargetType(input.TargetType), input.TargetID, input.Emoji); err != nil {
+		return handleReactionError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// RemoveReaction godoc
+// @Summary Remove a reaction
+// @Description Remove the logged-in user's emoji reaction from a work or a comment
+// @Tags reactions
+// @Param target_type query string true "Target type (work or comment)"
+// @Param target_id query string true "Target ID"
+// @Param emoji query string true "Emoji"
+// @Success 204
+// @Failure 400 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Security BearerAuth
+// @Router /auth/reactions [delete]
+func (rc *ReactionController) RemoveReaction(c echo.Context) error {
+	userID, input, err := bindReactionRequest(c)
+	if err != nil {
+		return handleReactionError(c, err)
+	}
+
+	if err := rc.reactionUsecase.RemoveReaction(c.Request().Context(), userID, entity.ReactionTargetType(input.TargetType), input.TargetID, input.Emoji); err != nil {
+		return handleReactionError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func bindReactionRequest(c echo.Context) (userID uuid.UUID, input schema.ReactionRequest, err error) {
+	userID, err = userIDFromToken(c)
+	if err != nil {
+		return uuid.Nil, input, domainerrors.ErrInvalidRequestBody
+	}
+	if err := c.Bind(&input); err != nil {
+		return uuid.Nil, input, domainerrors.ErrInvalidRequestBody
+	}
+	if err := c.Validate(&input); err != nil {
+		return uuid.Nil, input, domainerrors.ErrInvalidRequestBody
+	}
+	return userID, input, nil
+}
+
+func handleReactionError(c echo.Context, err error) error {
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr
+	}
+
+	switch {
+	case errors.Is(err, domainerrors.ErrInvalidRequestBody):
+		return echo.NewHTTPError(http.StatusBadRequest, "無効なリクエストです")
+	case errors.Is(err, domainerrors.ErrReactionEmojiNotAllowed):
+		return echo.NewHTTPError(http.StatusBadRequest, "この絵文字はリアクションに使えません")
+	case errors.Is(err, domainerrors.ErrWorkNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "作品が見つかりませんでした")
+	case errors.Is(err, domainerrors.ErrCommentNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "コメントが見つかりませんでした")
+	case errors.Is(err, domainerrors.ErrFailedToCreateReaction):
+		return echo.NewHTTPError(http.StatusInternalServerError, "リアクションの追加に失敗しました")
+	case errors.Is(err, domainerrors.ErrFailedToDeleteReaction):
+		return echo.NewHTTPError(http.StatusInternalServerError, "リアクションの取り消しに失敗しました")
+	default:
+		c.Logger().Error("Reaction error:", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "サーバーエラーが発生しました")
+	}
+}
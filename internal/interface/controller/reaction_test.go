@@ -0,0 +1,170 @@
+package controller_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/interface/controller"
+	"github.com/simesaba80/toybox-back/internal/interface/controller/mock"
+	"github.com/simesaba80/toybox-back/internal/interface/schema"
+	"github.com/simesaba80/toybox-back/pkg/echovalidator"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReactionController_AddReaction(t *testing.T) {
+	userID := uuid.New()
+	targetID := uuid.New()
+
+	tests := []struct {
+		name       string
+		body       string
+		setupMock  func(*mock.MockIReactionUsecase)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "正常系: リアクションを付けられる",
+			body: `{"target_type":"work","target_id":"` + targetID.String() + `","emoji":"👍"}`,
+			setupMock: func(m *mock.MockIReactionUsecase) {
+				m.EXPECT().AddReaction(gomock.Any(), userID, entity.ReactionTargetWork, targetID, "👍").Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "異常系: target_typeが不正",
+			body: `{"target_type":"user","target_id":"` + targetID.String() + `","emoji":"👍"}`,
+			setupMock: func(m *mock.MockIReactionUsecase) {
+				m.EXPECT().AddReaction(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"message":"無効なリクエストです"}`,
+		},
+		{
+			name: "異常系: 許可されていない絵文字",
+			body: `{"target_type":"comment","target_id":"` + targetID.String() + `","emoji":"💩"}`,
+			setupMock: func(m *mock.MockIReactionUsecase) {
+				m.EXPECT().AddReaction(gomock.Any(), userID, entity.ReactionTargetComment, targetID, "💩").Return(domainerrors.ErrReactionEmojiNotAllowed)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"message":"この絵文字はリアクションに使えません"}`,
+		},
+		{
+			name: "異常系: 作品が存在しない",
+			body: `{"target_type":"work","target_id":"` + targetID.String() + `","emoji":"👍"}`,
+			setupMock: func(m *mock.MockIReactionUsecase) {
+				m.EXPECT().AddReaction(gomock.Any(), userID, entity.ReactionTargetWork, targetID, "👍").Return(domainerrors.ErrWorkNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   `{"message":"作品が見つかりませんでした"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Validator = echovalidator.NewValidator()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockIReactionUsecase(ctrl)
+			tt.setupMock(mockUsecase)
+
+			reactionController := controller.NewReactionController(mockUsecase)
+			e.PUT("/auth/reactions", func(c echo.Context) error {
+				c.Set("user", jwt.NewWithClaims(jwt.SigningMethodHS256, &schema.JWTCustomClaims{UserID: userID.String()}))
+				return reactionController.AddReaction(c)
+			})
+
+			req := httptest.NewRequest(http.MethodPut, "/auth/reactions", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantBody == "" {
+				assert.Empty(t, rec.Body.String())
+			} else {
+				assert.JSONEq(t, tt.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestReactionController_RemoveReaction(t *testing.T) {
+	userID := uuid.New()
+	targetID := uuid.New()
+
+	tests := []struct {
+		name       string
+		query      string
+		setupMock  func(*mock.MockIReactionUsecase)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:  "正常系: リアクションを取り消せる",
+			query: "?target_type=comment&target_id=" + targetID.String() + "&emoji=%F0%9F%91%8D",
+			setupMock: func(m *mock.MockIReactionUsecase) {
+				m.EXPECT().RemoveReaction(gomock.Any(), userID, entity.ReactionTargetComment, targetID, "👍").Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:  "異常系: emojiがない",
+			query: "?target_type=comment&target_id=" + targetID.String(),
+			setupMock: func(m *mock.MockIReactionUsecase) {
+				m.EXPECT().RemoveReaction(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"message":"無効なリクエストです"}`,
+		},
+		{
+			name:  "異常系: Usecaseエラー",
+			query: "?target_type=work&target_id=" + targetID.String() + "&emoji=%F0%9F%91%8D",
+			setupMock: func(m *mock.MockIReactionUsecase) {
+				m.EXPECT().RemoveReaction(gomock.Any(), userID, entity.ReactionTargetWork, targetID, "👍").Return(domainerrors.ErrFailedToDeleteReaction)
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   `{"message":"リアクションの取り消しに失敗しました"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Validator = echovalidator.NewValidator()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockIReactionUsecase(ctrl)
+			tt.setupMock(mockUsecase)
+
+			reactionController := controller.NewReactionController(mockUsecase)
+			e.DELETE("/auth/reactions", func(c echo.Context) error {
+				c.Set("user", jwt.NewWithClaims(jwt.SigningMethodHS256, &schema.JWTCustomClaims{UserID: userID.String()}))
+				return reactionController.RemoveReaction(c)
+			})
+
+			req := httptest.NewRequest(http.MethodDelete, "/auth/reactions"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantBody == "" {
+				assert.Empty(t, rec.Body.String())
+			} else {
+				assert.JSONEq(t, tt.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
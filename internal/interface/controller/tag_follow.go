@@ -0,0 +1,170 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/interface/schema"
+	"github.com/simesaba80/toybox-back/internal/usecase"
+)
+
+type TagFollowController struct {
+	tagFollowUsecase usecase.ITagFollowUsecase
+}
+
+func NewTagFollowController(tagFollowUsecase usecase.ITagFollowUsecase) *TagFollowController {
+	return &TagFollowController{tagFollowUsecase: tagFollowUsecase}
+}
+
+// FollowTag godoc
+// @Summary Follow a tag
+// @Description Follow a tag to be notified of new works with it
+// @Tags tags
+// @Produce json
+// @Param id path string true "Tag ID"
+// @Success 201
+// @Failure 400 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Security BearerAuth
+// @Router /auth/tags/{id}/follow [post]
+func (tc *TagFollowController) FollowTag(c echo.Context) error {
+	userID, err := userIDFromToken(c)
+	if err != nil {
+		return handleTagFollowError(c, domainerrors.ErrInvalidRequestBody)
+	}
+	tagID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return handleTagFollowError(c, domainerrors.ErrInvalidRequestBody)
+	}
+
+	if err := tc.tagFollowUsecase.FollowTag(c.Request().Context(), userID, tagID); err != nil {
+		return handleTagFollowError(c, err)
+	}
+	return c.NoContent(http.StatusCreated)
+}
+
+// UnfollowTag godoc
+// @Summary Unfollow a tag
+// @Description Unfollow a tag
+// @Tags tags
+// @Produce json
+// @Param id path string true "Tag ID"
+// @Success 204
+// @Failure 400 {object} echo.HTTPError
+// @Failure 404 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Security BearerAuth
+// @Router /auth/tags/{id}/follow [delete]
+func (tc *TagFollowController) UnfollowTag(c echo.Context) error {
+	userID, err := userIDFromToken(c)
+	if err != nil {
+		return handleTagFollowError(c, domainerrors.ErrInvalidRequestBody)
+	}
+	tagID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return handleTagFollowError(c, domainerrors.ErrInvalidRequestBody)
+	}
+
+	if err := tc.tagFollowUsecase.UnfollowTag(c.Request().Context(), userID, tagID); err != nil {
+		return handleTagFollowError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetNewWorks godoc
+// @Summary Get new works with followed tags
+// @Description Get new works posted with the tags the logged-in user follows, newest first
+// @Tags tags
+// @Produce json
+// @Param limit query int false "Limit per page (default: 20, max: 100)"
+// @Param page query int false "Page number (default: 1)"
+// @Param unseen_only query bool false "Return only unseen works"
+// @Success 200 {object} schema.TagNewWorkListResponse
+// @Failure 400 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Security BearerAuth
+// @Router /auth/tags/following/new-works [get]
+func (tc *TagFollowController) GetNewWorks(c echo.Context) error {
+	userID, err := userIDFromToken(c)
+	if err != nil {
+		return handleTagFollowError(c, domainerrors.ErrInvalidRequestBody)
+	}
+
+	var query schema.GetTagNewWorksQuery
+	if err := c.Bind(&query); err != nil {
+		return handleTagFollowError(c, domainerrors.ErrInvalidRequestBody)
+	}
+	if err := c.Validate(&query); err != nil {
+		return err
+	}
+
+	newWorks, total, limit, page, err := tc.tagFollowUsecase.GetNewWorks(c.Request().Context(), userID, query.UnseenOnly, query.Limit, query.Page)
+	if err != nil {
+		return handleTagFollowError(c, err)
+	}
+	return c.JSON(http.StatusOK, schema.ToTagNewWorkListResponse(newWorks, total, page, limit))
+}
+
+// MarkNewWorksAsSeen godoc
+// @Summary Mark new works with followed tags as seen
+// @Description Mark the given new works as seen. All new works are marked when work_ids is empty.
+// @Tags tags
+// @Accept json
+// @Param input body schema.MarkTagNewWorksAsSeenInput false "Work IDs to mark as seen"
+// @Success 204
+// @Failure 400 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Security BearerAuth
+// @Router /auth/tags/following/new-works/seen [post]
+func (tc *TagFollowController) MarkNewWorksAsSeen(c echo.Context) error {
+	userID, err := userIDFromToken(c)
+	if err != nil {
+		return handleTagFollowError(c, domainerrors.ErrInvalidRequestBody)
+	}
+
+	var input schema.MarkTagNewWorksAsSeenInput
+	if err := c.Bind(&input); err != nil {
+		return handleTagFollowError(c, domainerrors.ErrInvalidRequestBody)
+	}
+	if err := c.Validate(&input); err != nil {
+		return handleTagFollowError(c, domainerrors.ErrInvalidRequestBody)
+	}
+
+	if err := tc.tagFollowUsecase.MarkNewWorksAsSeen(c.Request().Context(), userID, input.WorkIDs); err != nil {
+		return handleTagFollowError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func handleTagFollowError(c echo.Context, err error) error {
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr
+	}
+
+	switch {
+	case errors.Is(err, domainerrors.ErrInvalidRequestBody):
+		return echo.NewHTTPError(http.StatusBadRequest, "無効なリクエストです")
+	case errors.Is(err, domainerrors.ErrTagNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "タグが見つかりませんでした")
+	case errors.Is(err, domainerrors.ErrTagFollowAlreadyExists):
+		return echo.NewHTTPError(http.StatusBadRequest, "既にタグをフォローしています")
+	case errors.Is(err, domainerrors.ErrTagFollowNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "タグをフォローしていません")
+	case errors.Is(err, domainerrors.ErrFailedToCreateTagFollow):
+		return echo.NewHTTPError(http.StatusInternalServerError, "タグのフォローに失敗しました")
+	case errors.Is(err, domainerrors.ErrFailedToDeleteTagFollow):
+		return echo.NewHTTPError(http.StatusInternalServerError, "タグのフォロー解除に失敗しました")
+	case errors.Is(err, domainerrors.ErrFailedToGetTagNewWorks):
+		return echo.NewHTTPError(http.StatusInternalServerError, "新着作品の取得に失敗しました")
+	case errors.Is(err, domainerrors.ErrFailedToMarkTagNewWorksAsSeen):
+		return echo.NewHTTPError(http.StatusInternalServerError, "新着作品の既読化に失敗しました")
+	default:
+		c.Logger().Error("Tag follow error:", err)
+		return echo.NewHTTPError(http.StatusInternalServerError, "サーバーエラーが発生しました")
+	}
+}
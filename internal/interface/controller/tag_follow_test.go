@@ -0,0 +1,242 @@
+package controller_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/interface/controller"
+	"github.com/simesaba80/toybox-back/internal/interface/controller/mock"
+	"github.com/simesaba80/toybox-back/internal/interface/schema"
+	"github.com/simesaba80/toybox-back/pkg/echovalidator"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTagFollowController_FollowTag(t *testing.T) {
+	userID := uuid.New()
+	tagID := uuid.New()
+
+	tests := []struct {
+		name       string
+		tagID      string
+		setupMock  func(*mock.MockITagFollowUsecase)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:  "正常系: タグのフォローが成功する",
+			tagID: tagID.String(),
+			setupMock: func(m *mock.MockITagFollowUsecase) {
+				m.EXPECT().FollowTag(gomock.Any(), userID, tagID).Return(nil)
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:  "異常系: idがUUID形式でない",
+			tagID: "invalid-uuid",
+			setupMock: func(m *mock.MockITagFollowUsecase) {
+				m.EXPECT().FollowTag(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"message":"無効なリクエストです"}`,
+		},
+		{
+			name:  "異常系: タグが存在しない",
+			tagID: tagID.String(),
+			setupMock: func(m *mock.MockITagFollowUsecase) {
+				m.EXPECT().FollowTag(gomock.Any(), userID, tagID).Return(domainerrors.ErrTagNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+			wantBody:   `{"message":"タグが見つかりませんでした"}`,
+		},
+		{
+			name:  "異常系: 既にフォローしている",
+			tagID: tagID.String(),
+			setupMock: func(m *mock.MockITagFollowUsecase) {
+				m.EXPECT().FollowTag(gomock.Any(), userID, tagID).Return(domainerrors.ErrTagFollowAlreadyExists)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"message":"既にタグをフォローしています"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockITagFollowUsecase(ctrl)
+			tt.setupMock(mockUsecase)
+
+			tagFollowController := controller.NewTagFollowController(mockUsecase)
+			e.POST("/auth/tags/:id/follow", func(c echo.Context) error {
+				c.Set("user", jwt.NewWithClaims(jwt.SigningMethodHS256, &schema.JWTCustomClaims{UserID: userID.String()}))
+				return tagFollowController.FollowTag(c)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/auth/tags/"+tt.tagID+"/follow", nil)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantBody == "" {
+				assert.Empty(t, rec.Body.String())
+			} else {
+				assert.JSONEq(t, tt.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestTagFollowController_GetNewWorks(t *testing.T) {
+	userID := uuid.New()
+	now := time.Now()
+	newWorks := []*entity.TagNewWork{
+		{
+			UserID: userID,
+			WorkID: uuid.New(),
+			Work: &entity.Work{
+				ID:        uuid.New(),
+				Title:     "New Work",
+				CreatedAt: now,
+				UpdatedAt: now,
+			},
+			CreatedAt: now,
+		},
+	}
+	successResponseBytes, _ := json.Marshal(schema.ToTagNewWorkListResponse(newWorks, 1, 1, 20))
+
+	tests := []struct {
+		name       string
+		query      string
+		setupMock  func(*mock.MockITagFollowUsecase)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:  "正常系: 未読の新着作品を取得できる",
+			query: "?unseen_only=true",
+			setupMock: func(m *mock.MockITagFollowUsecase) {
+				m.EXPECT().GetNewWorks(gomock.Any(), userID, true, gomock.Nil(), gomock.Nil()).Return(newWorks, 1, 20, 1, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   string(successResponseBytes),
+		},
+		{
+			name:  "異常系: Usecaseエラー",
+			query: "",
+			setupMock: func(m *mock.MockITagFollowUsecase) {
+				m.EXPECT().GetNewWorks(gomock.Any(), userID, false, gomock.Nil(), gomock.Nil()).Return(nil, 0, 0, 0, domainerrors.ErrFailedToGetTagNewWorks)
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   `{"message":"新着作品の取得に失敗しました"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Validator = echovalidator.NewValidator()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockITagFollowUsecase(ctrl)
+			tt.setupMock(mockUsecase)
+
+			tagFollowController := controller.NewTagFollowController(mockUsecase)
+			e.GET("/auth/tags/following/new-works", func(c echo.Context) error {
+				c.Set("user", jwt.NewWithClaims(jwt.SigningMethodHS256, &schema.JWTCustomClaims{UserID: userID.String()}))
+				return tagFollowController.GetNewWorks(c)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/auth/tags/following/new-works"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestTagFollowController_MarkNewWorksAsSeen(t *testing.T) {
+	userID := uuid.New()
+	workID := uuid.New()
+
+	tests := []struct {
+		name       string
+		body       string
+		setupMock  func(*mock.MockITagFollowUsecase)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "正常系: 指定した新着作品を既読にできる",
+			body: `{"work_ids":["` + workID.String() + `"]}`,
+			setupMock: func(m *mock.MockITagFollowUsecase) {
+				m.EXPECT().MarkNewWorksAsSeen(gomock.Any(), userID, []uuid.UUID{workID}).Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "正常系: 空の場合は全件を既読にする",
+			body: `{}`,
+			setupMock: func(m *mock.MockITagFollowUsecase) {
+				m.EXPECT().MarkNewWorksAsSeen(gomock.Any(), userID, gomock.Nil()).Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "異常系: work_idsがUUID形式でない",
+			body: `{"work_ids":["invalid"]}`,
+			setupMock: func(m *mock.MockITagFollowUsecase) {
+				m.EXPECT().MarkNewWorksAsSeen(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"message":"無効なリクエストです"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Validator = echovalidator.NewValidator()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUsecase := mock.NewMockITagFollowUsecase(ctrl)
+			tt.setupMock(mockUsecase)
+
+			tagFollowController := controller.NewTagFollowController(mockUsecase)
+			e.POST("/auth/tags/following/new-works/seen", func(c echo.Context) error {
+				c.Set("user", jwt.NewWithClaims(jwt.SigningMethodHS256, &schema.JWTCustomClaims{UserID: userID.String()}))
+				return tagFollowController.MarkNewWorksAsSeen(c)
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/auth/tags/following/new-works/seen", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantBody == "" {
+				assert.Empty(t, rec.Body.String())
+			} else {
+				assert.JSONEq(t, tt.wantBody, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package controller
+
+import (
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/simesaba80/toybox-back/internal/interface/schema"
+)
+
+// userIDFromToken はJWTのクレームからユーザーIDを取り出します。
+func userIDFromToken(c echo.Context) (uuid.UUID, error) {
+	user := c.Get("user").(*jwt.Token)
+	claims := user.Claims.(*schema.JWTCustomClaims)
+	return uuid.Parse(claims.UserID)
+}
+
+// viewerIDFromToken はトークンが任意のエンドポイントで閲覧しているユーザーのIDを返します。トークンがない場合はuuid.Nilです。
+func viewerIDFromToken(c echo.Context) (uuid.UUID, error) {
+	if c.Get("user") == nil {
+		return uuid.Nil, nil
+	}
+	return userIDFromToken(c)
+}
@@ -14,12 +14,14 @@ import (
 )
 
 type UserController struct {
-	userusecase usecase.IUserUseCase
+	userusecase         usecase.IUserUseCase
+	notificationUsecase usecase.INotificationUsecase
 }
 
-func NewUserController(userusecase usecase.IUserUseCase) *UserController {
+func NewUserController(userusecase usecase.IUserUseCase, notificationUsecase usecase.INotificationUsecase) *UserController {
 	return &UserController{
-		userusecase: userusecase,
+		userusecase:         userusecase,
+		notificationUsecase: notificationUsecase,
 	}
 }
 
@@ -37,12 +39,7 @@ func (uc *UserController) GetAllUsers(c echo.Context) error {
 		return handleUserError(err)
 	}
 
-	response := make([]schema.GetUserOutput, len(users))
-	for i, user := range users {
-		response[i] = schema.ToUserResponse(user)
-	}
-
-	return c.JSON(http.StatusOK, schema.UserListResponse{Users: response})
+	return c.JSON(http.StatusOK, schema.ToUserListResponse(users))
 }
 
 // GetUserByID godoc
@@ -92,7 +89,12 @@ func (uc *UserController) GetIconAndURLByUserID(c echo.Context) error {
 	if err != nil {
 		return handleUserError(err)
 	}
-	return c.JSON(http.StatusOK, schema.ToIconAndURLResponse(user))
+	unreadCount, err := uc.notificationUsecase.CountUnread(c.Request().Context(), userID)
+	if err != nil {
+		c.Logger().Error("Failed to count unread notifications:", err)
+		return handleUserError(err)
+	}
+	return c.JSON(http.StatusOK, schema.ToIconAndURLResponse(user, unreadCount))
 }
 
 // UpdateUser godoc
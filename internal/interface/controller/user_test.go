@@ -64,9 +64,10 @@ func TestUserController_GetAllUsers(t *testing.T) {
 			defer ctrl.Finish()
 
 			mockUsecase := mock.NewMockIUserUseCase(ctrl)
+			mockNotificationUsecase := mock.NewMockINotificationUsecase(ctrl)
 			tt.setupMock(mockUsecase)
 
-			userController := controller.NewUserController(mockUsecase)
+			userController := controller.NewUserController(mockUsecase, mockNotificationUsecase)
 			e.GET("/users", userController.GetAllUsers)
 
 			req := httptest.NewRequest(http.MethodGet, "/users", nil)
@@ -120,9 +121,10 @@ func TestUserController_GetUserByID(t *testing.T) {
 			defer ctrl.Finish()
 
 			mockUsecase := mock.NewMockIUserUseCase(ctrl)
+			mockNotificationUsecase := mock.NewMockINotificationUsecase(ctrl)
 			tt.setupMock(mockUsecase)
 
-			userController := controller.NewUserController(mockUsecase)
+			userController := controller.NewUserController(mockUsecase, mockNotificationUsecase)
 			e.GET("/users/:id", userController.GetUserByID)
 
 			req := httptest.NewRequest(http.MethodGet, "/users/"+mockUser.ID.String(), nil)
@@ -139,27 +141,30 @@ func TestUserController_GetUserByID(t *testing.T) {
 func TestUserController_GetIconAndURLByUserID(t *testing.T) {
 	userID := uuid.New()
 	mockUser := &entity.User{ID: userID, Name: "testuser"}
-	successResponseBytes, _ := json.Marshal(schema.ToIconAndURLResponse(mockUser))
+	successResponseBytes, _ := json.Marshal(schema.ToIconAndURLResponse(mockUser, 3))
 	internalErrorResponseBytes, _ := json.Marshal(map[string]string{"message": "サーバーエラーが発生しました"})
 	tests := []struct {
 		name       string
-		setupMock  func(mockUserUsecase *mock.MockIUserUseCase)
+		setupMock  func(mockUserUsecase *mock.MockIUserUseCase, mockNotificationUsecase *mock.MockINotificationUsecase)
 		wantStatus int
 		wantBody   []byte
 	}{
 		{
 			name: "正常系",
-			setupMock: func(mockUserUsecase *mock.MockIUserUseCase) {
+			setupMock: func(mockUserUsecase *mock.MockIUserUseCase, mockNotificationUsecase *mock.MockINotificationUsecase) {
 				mockUserUsecase.EXPECT().
 					GetByUserID(gomock.Any(), gomock.Eq(userID)).
 					Return(mockUser, nil)
+				mockNotificationUsecase.EXPECT().
+					CountUnread(gomock.Any(), gomock.Eq(userID)).
+					Return(3, nil)
 			},
 			wantStatus: http.StatusOK,
 			wantBody:   successResponseBytes,
 		},
 		{
 			name: "異常系: Usecaseエラー",
-			setupMock: func(mockUserUsecase *mock.MockIUserUseCase) {
+			setupMock: func(mockUserUsecase *mock.MockIUserUseCase, mockNotificationUsecase *mock.MockINotificationUsecase) {
 				mockUserUsecase.EXPECT().
 					GetByUserID(gomock.Any(), gomock.Eq(userID)).
 					Return(nil, errors.New("some error"))
@@ -167,6 +172,19 @@ func TestUserController_GetIconAndURLByUserID(t *testing.T) {
 			wantStatus: http.StatusInternalServerError,
 			wantBody:   internalErrorResponseBytes,
 		},
+		{
+			name: "異常系: 未読通知数の取得エラー",
+			setupMock: func(mockUserUsecase *mock.MockIUserUseCase, mockNotificationUsecase *mock.MockINotificationUsecase) {
+				mockUserUsecase.EXPECT().
+					GetByUserID(gomock.Any(), gomock.Eq(userID)).
+					Return(mockUser, nil)
+				mockNotificationUsecase.EXPECT().
+					CountUnread(gomock.Any(), gomock.Eq(userID)).
+					Return(0, errors.New("some error"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   internalErrorResponseBytes,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -175,13 +193,14 @@ func TestUserController_GetIconAndURLByUserID(t *testing.T) {
 			defer ctrl.Finish()
 
 			mockUsecase := mock.NewMockIUserUseCase(ctrl)
-			tt.setupMock(mockUsecase)
+			mockNotificationUsecase := mock.NewMockINotificationUsecase(ctrl)
+			tt.setupMock(mockUsecase, mockNotificationUsecase)
 
 			token := jwt.NewWithClaims(jwt.SigningMethodHS256, &schema.JWTCustomClaims{
 				UserID: userID.String(),
 			})
 
-			userController := controller.NewUserController(mockUsecase)
+			userController := controller.NewUserController(mockUsecase, mockNotificationUsecase)
 			e.GET("/auth/users/me", func(c echo.Context) error {
 				c.Set("user", token)
 				return userController.GetIconAndURLByUserID(c)
@@ -295,13 +314,14 @@ func TestUserController_UpdateUser(t *testing.T) {
 			defer ctrl.Finish()
 
 			mockUsecase := mock.NewMockIUserUseCase(ctrl)
+			mockNotificationUsecase := mock.NewMockINotificationUsecase(ctrl)
 			tt.setupMock(mockUsecase)
 
 			token := jwt.NewWithClaims(jwt.SigningMethodHS256, &schema.JWTCustomClaims{
 				UserID: userID.String(),
 			})
 
-			userController := controller.NewUserController(mockUsecase)
+			userController := controller.NewUserController(mockUsecase, mockNotificationUsecase)
 			e.PUT("/auth/user", func(c echo.Context) error {
 				c.Set("user", token)
 				return userController.UpdateUser(c)
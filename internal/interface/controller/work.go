@@ -105,6 +105,7 @@ func (wc *WorkController) GetAllWorks(c echo.Context) error {
 // @Failure 400 {object} echo.HTTPError
 // @Failure 404 {object} echo.HTTPError
 // @Failure 500 {object} echo.HTTPError
+// @Security BearerAuth
 // @Router /works/{work_id} [get]
 func (wc *WorkController) GetWorkByID(c echo.Context) error {
 	idStr := c.Param("work_id")
@@ -112,8 +113,12 @@ func (wc *WorkController) GetWorkByID(c echo.Context) error {
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "無効なリクエストです")
 	}
+	viewerID, err := viewerIDFromToken(c)
+	if err != nil {
+		return handleWorkError(c, domainerrors.ErrInvalidRequestBody)
+	}
 
-	work, err := wc.workUsecase.GetByID(c.Request().Context(), id)
+	work, err := wc.workUsecase.GetByID(c.Request().Context(), id, viewerID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return echo.NewHTTPError(http.StatusNotFound, "Work not found")
@@ -210,6 +215,76 @@ func (wc *WorkController) CreateWork(c echo.Context) error {
 	return c.JSON(http.StatusCreated, schema.ToCreateWorkOutput(createdWork))
 }
 
+// GetFeed godoc
+// @Summary Get personalized feed
+// @Description Get works recommended for the logged-in user. Works from favorited or posted tags, favorited authors and trending works are mixed, excluding own and already favorited works.
+// @Tags works
+// @Produce json
+// @Param limit query int false "Limit per page (default: 20, max: 100)"
+// @Param cursor query string false "Cursor returned as next_cursor in the previous response"
+// @Success 200 {object} schema.FeedResponse
+// @Failure 400 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Security BearerAuth
+// @Router /auth/feed [get]
+func (wc *WorkController) GetFeed(c echo.Context) error {
+	user := c.Get("user").(*jwt.Token)
+	claims := user.Claims.(*schema.JWTCustomClaims)
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return handleWorkError(c, domainerrors.ErrInvalidRequestBody)
+	}
+
+	var query schema.GetFeedQuery
+	if err := c.Bind(&query); err != nil {
+		return handleWorkError(c, domainerrors.ErrInvalidRequestBody)
+	}
+	if err := c.Validate(&query); err != nil {
+		return err
+	}
+
+	works, nextCursor, err := wc.workUsecase.GetFeed(c.Request().Context(), userID, query.Limit, query.Cursor)
+	if err != nil {
+		return handleWorkError(c, err)
+	}
+	return c.JSON(http.StatusOK, schema.ToFeedResponse(works, nextCursor))
+}
+
+// GetFollowingFeed godoc
+// @Summary Get following feed
+// @Description Get public and private works posted by the users the logged-in user follows, newest first
+// @Tags works
+// @Produce json
+// @Param limit query int false "Limit per page (default: 20, max: 100)"
+// @Param cursor query string false "Cursor returned as next_cursor in the previous response"
+// @Success 200 {object} schema.FeedResponse
+// @Failure 400 {object} echo.HTTPError
+// @Failure 500 {object} echo.HTTPError
+// @Security BearerAuth
+// @Router /auth/feed/following [get]
+func (wc *WorkController) GetFollowingFeed(c echo.Context) error {
+	user := c.Get("user").(*jwt.Token)
+	claims := user.Claims.(*schema.JWTCustomClaims)
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return handleWorkError(c, domainerrors.ErrInvalidRequestBody)
+	}
+
+	var query schema.GetFeedQuery
+	if err := c.Bind(&query); err != nil {
+		return handleWorkError(c, domainerrors.ErrInvalidRequestBody)
+	}
+	if err := c.Validate(&query); err != nil {
+		return err
+	}
+
+	works, nextCursor, err := wc.workUsecase.GetFollowingFeed(c.Request().Context(), userID, query.Limit, query.Cursor)
+	if err != nil {
+		return handleWorkError(c, err)
+	}
+	return c.JSON(http.StatusOK, schema.ToFeedResponse(works, nextCursor))
+}
+
 func handleWorkError(c echo.Context, err error) error {
 	var httpErr *echo.HTTPError
 	if errors.As(err, &httpErr) {
@@ -241,6 +316,12 @@ func handleWorkError(c echo.Context, err error) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "存在しないタグIDが含まれています")
 	case errors.Is(err, domainerrors.ErrInvalidTagIDs):
 		return echo.NewHTTPError(http.StatusBadRequest, "タグが指定されていません")
+	case errors.Is(err, domainerrors.ErrInvalidFeedCursor):
+		return echo.NewHTTPError(http.StatusBadRequest, "カーソルが無効です")
+	case errors.Is(err, domainerrors.ErrFailedToGetFeed):
+		return echo.NewHTTPError(http.StatusInternalServerError, "フィードの取得に失敗しました")
+	case errors.Is(err, domainerrors.ErrFailedToGetFollowingFeed):
+		return echo.NewHTTPError(http.StatusInternalServerError, "フォロー中のユーザーの作品の取得に失敗しました")
 	default:
 		c.Logger().Error("Work error:", err)
 		return echo.NewHTTPError(http.StatusInternalServerError, "サーバーエラーが発生しました")
@@ -163,7 +163,7 @@ func TestWorkController_GetWorkByID(t *testing.T) {
 			workID: workID.String(),
 			setupMock: func(mockWorkUsecase *mock.MockIWorkUseCase) {
 				mockWorkUsecase.EXPECT().
-					GetByID(gomock.Any(), workID).
+					GetByID(gomock.Any(), workID, uuid.Nil).
 					Return(mockWork, nil)
 			},
 			wantStatus: http.StatusOK,
@@ -181,7 +181,7 @@ func TestWorkController_GetWorkByID(t *testing.T) {
 			workID: workID.String(),
 			setupMock: func(mockWorkUsecase *mock.MockIWorkUseCase) {
 				mockWorkUsecase.EXPECT().
-					GetByID(gomock.Any(), workID).
+					GetByID(gomock.Any(), workID, uuid.Nil).
 					Return(nil, domainerrors.ErrWorkNotFound)
 			},
 			wantStatus: http.StatusNotFound,
@@ -427,3 +427,166 @@ func TestWorkController_CreateWork(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkController_GetFeed(t *testing.T) {
+	userID := uuid.New()
+	works := []*entity.Work{
+		{
+			ID:        uuid.New(),
+			Title:     "Feed Work",
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
+	}
+	nextCursor := entity.NewFeedCursor(works[0]).Encode()
+
+	successResponseBytes, _ := json.Marshal(schema.ToFeedResponse(works, nextCursor))
+	invalidCursorResponseBytes, _ := json.Marshal(map[string]string{"message": "カーソルが無効です"})
+	internalErrorResponseBytes, _ := json.Marshal(map[string]string{"message": "フィードの取得に失敗しました"})
+
+	tests := []struct {
+		name       string
+		query      string
+		setupMock  func(mockWorkUsecase *mock.MockIWorkUseCase)
+		wantStatus int
+		wantBody   []byte
+	}{
+		{
+			name:  "正常系",
+			query: "?limit=1",
+			setupMock: func(mockWorkUsecase *mock.MockIWorkUseCase) {
+				mockWorkUsecase.EXPECT().
+					GetFeed(gomock.Any(), userID, util.IntPtr(1), "").
+					Return(works, nextCursor, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   successResponseBytes,
+		},
+		{
+			name:  "異常系: 不正なカーソル",
+			query: "?cursor=invalid",
+			setupMock: func(mockWorkUsecase *mock.MockIWorkUseCase) {
+				mockWorkUsecase.EXPECT().
+					GetFeed(gomock.Any(), userID, gomock.Nil(), "invalid").
+					Return(nil, "", domainerrors.ErrInvalidFeedCursor)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantBody:   invalidCursorResponseBytes,
+		},
+		{
+			name:  "異常系: Usecaseエラー",
+			query: "",
+			setupMock: func(mockWorkUsecase *mock.MockIWorkUseCase) {
+				mockWorkUsecase.EXPECT().
+					GetFeed(gomock.Any(), userID, gomock.Nil(), "").
+					Return(nil, "", domainerrors.ErrFailedToGetFeed)
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   internalErrorResponseBytes,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Validator = echovalidator.NewValidator()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockWorkUsecase := mock.NewMockIWorkUseCase(ctrl)
+			tt.setupMock(mockWorkUsecase)
+			token := jwt.NewWithClaims(jwt.SigningMethodHS256, &schema.JWTCustomClaims{
+				UserID: userID.String(),
+			})
+
+			workController := controller.NewWorkController(mockWorkUsecase)
+			e.GET("/feed", func(c echo.Context) error {
+				c.Set("user", token)
+				return workController.GetFeed(c)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/feed"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.JSONEq(t, string(tt.wantBody), rec.Body.String())
+		})
+	}
+}
+
+func TestWorkController_GetFollowingFeed(t *testing.T) {
+	userID := uuid.New()
+	works := []*entity.Work{
+		{
+			ID:        uuid.New(),
+			Title:     "Following Work",
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
+	}
+
+	successResponseBytes, _ := json.Marshal(schema.ToFeedResponse(works, ""))
+	internalErrorResponseBytes, _ := json.Marshal(map[string]string{"message": "フォロー中のユーザーの作品の取得に失敗しました"})
+
+	tests := []struct {
+		name       string
+		query      string
+		setupMock  func(mockWorkUsecase *mock.MockIWorkUseCase)
+		wantStatus int
+		wantBody   []byte
+	}{
+		{
+			name:  "正常系",
+			query: "?limit=10",
+			setupMock: func(mockWorkUsecase *mock.MockIWorkUseCase) {
+				mockWorkUsecase.EXPECT().
+					GetFollowingFeed(gomock.Any(), userID, util.IntPtr(10), "").
+					Return(works, "", nil)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   successResponseBytes,
+		},
+		{
+			name:  "異常系: Usecaseエラー",
+			query: "",
+			setupMock: func(mockWorkUsecase *mock.MockIWorkUseCase) {
+				mockWorkUsecase.EXPECT().
+					GetFollowingFeed(gomock.Any(), userID, gomock.Nil(), "").
+					Return(nil, "", domainerrors.ErrFailedToGetFollowingFeed)
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   internalErrorResponseBytes,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Validator = echovalidator.NewValidator()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockWorkUsecase := mock.NewMockIWorkUseCase(ctrl)
+			tt.setupMock(mockWorkUsecase)
+			token := jwt.NewWithClaims(jwt.SigningMethodHS256, &schema.JWTCustomClaims{
+				UserID: userID.String(),
+			})
+
+			workController := controller.NewWorkController(mockWorkUsecase)
+			e.GET("/feed/following", func(c echo.Context) error {
+				c.Set("user", token)
+				return workController.GetFollowingFeed(c)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/feed/following"+tt.query, nil)
+			rec := httptest.NewRecorder()
+
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.JSONEq(t, string(tt.wantBody), rec.Body.String())
+		})
+	}
+}
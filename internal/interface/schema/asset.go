@@ -1,18 +1,206 @@
 package schema
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/simesaba80/toybox-back/internal/domain/entity"
 )
 
 type UploadAssetResponse struct {
-	ID  uuid.UUID `json:"id"`
-	URL string    `json:"url"`
+	ID     uuid.UUID              `json:"id"`
+	URL    string                 `json:"url"`
+	Hash   string                 `json:"hash"`
+	Srcset []AssetVariantResponse `json:"srcset"`
+	// PlayURL はブラウザで遊べるビルドの index.html の URL です。ビルドがない場合は空文字です
+	PlayURL string `json:"play_url"`
+	// Audio は音声アセットの再生時間や波形です。音声以外と読み取れなかった場合は null です
+	Audio *AudioMetadataResponse `json:"audio"`
+	// Model は 3D モデルアセットの頂点数や大きさです。3D モデル以外の場合は null です
+	Model *ModelStatsResponse `json:"model"`
+	// Placeholder は画像アセットを読み込むまでの間に表示するプレースホルダーです。画像以外と作成していない場合は null です
+	Placeholder *ImagePlaceholderResponse `json:"placeholder"`
 }
 
 func ToUploadAssetResponse(asset *entity.Asset) UploadAssetResponse {
 	return UploadAssetResponse{
-		ID:  asset.ID,
-		URL: asset.URL,
+		ID:          asset.ID,
+		URL:         asset.URL,
+		Hash:        asset.Hash,
+		Srcset:      ToAssetVariantResponses(asset.Variants),
+		PlayURL:     asset.PlayURL,
+		Audio:       ToAudioMetadataResponse(asset.AudioMetadata),
+		Model:       ToModelStatsResponse(asset.ModelStats),
+		Placeholder: ToImagePlaceholderResponse(asset.Placeholder),
+	}
+}
+
+type CreateAssetUploadRequest struct {
+	FileName string `json:"file_name" validate:"required,max=255"`
+	Size     int64  `json:"size" validate:"required,min=1"`
+}
+
+// AssetUploadResponse はクライアントが S3 へ直接アップロードするための情報です。
+// upload_url へ headers を付けて PUT した後、complete を呼び出します。
+type AssetUploadResponse struct {
+	ID        uuid.UUID         `json:"id"`
+	UploadURL string            `json:"upload_url"`
+	Method    string            `json:"method"`
+	Headers   map[string]string `json:"headers"`
+	ExpiresAt string            `json:"expires_at"`
+}
+
+func ToAssetUploadResponse(upload *entity.AssetUpload, uploadURL string) AssetUploadResponse {
+	return AssetUploadResponse{
+		ID:        upload.ID,
+		UploadURL: uploadURL,
+		Method:    "PUT",
+		Headers: map[string]string{
+			"Content-Type": upload.ContentType,
+		},
+		ExpiresAt: upload.ExpiresAt.Format(time.RFC3339),
+	}
+}
+
+// MultipartUploadResponse はパートに分けて S3 へ直接アップロードするための情報です。
+// 各パートを part_size ごとに区切り、パートの署名付き URL を取得して PUT した後、complete を呼び出します。
+type MultipartUploadResponse struct {
+	ID        uuid.UUID `json:"id"`
+	PartSize  int64     `json:"part_size"`
+	PartCount int32     `json:"part_count"`
+	ExpiresAt string    `json:"expires_at"`
+}
+
+func ToMultipartUploadResponse(upload *entity.AssetUpload) MultipartUploadResponse {
+	return MultipartUploadResponse{
+		ID:        upload.ID,
+		PartSize:  upload.PartSize,
+		PartCount: upload.PartCount(),
+		ExpiresAt: upload.ExpiresAt.Format(time.RFC3339),
+	}
+}
+
+type PresignUploadPartsRequest struct {
+	PartNumbers []int32 `json:"part_numbers" validate:"required,min=1,max=100,dive,min=1"`
+}
+
+type PresignedPartResponse struct {
+	PartNumber int32  `json:"part_number"`
+	Size       int64  `json:"size"`
+	UploadURL  string `json:"upload_url"`
+}
+
+type PresignUploadPartsResponse struct {
+	Method    string                  `json:"method"`
+	Parts     []PresignedPartResponse `json:"parts"`
+	ExpiresAt string                  `json:"expires_at"`
+}
+
+func ToPresignUploadPartsResponse(parts []*entity.PresignedPart, expiresAt time.Time) PresignUploadPartsResponse {
+	response := PresignUploadPartsResponse{
+		Method:    "PUT",
+		Parts:     make([]PresignedPartResponse, len(parts)),
+		ExpiresAt: expiresAt.Format(time.RFC3339),
+	}
+	for i, part := range parts {
+		response.Parts[i] = PresignedPartResponse{
+			PartNumber: part.PartNumber,
+			Size:       part.Size,
+			UploadURL:  part.URL,
+		}
+	}
+	return response
+}
+
+type UploadedPartResponse struct {
+	PartNumber int32  `json:"part_number"`
+	Size       int64  `json:"size"`
+	ETag       string `json:"etag"`
+}
+
+type ListUploadedPartsResponse struct {
+	MultipartUploadResponse
+	Parts []UploadedPartResponse `json:"parts"`
+}
+
+func ToListUploadedPartsResponse(upload *entity.AssetUpload, parts []*entity.UploadedPart) ListUploadedPartsResponse {
+	response := ListUploadedPartsResponse{
+		MultipartUploadResponse: ToMultipartUploadResponse(upload),
+		Parts:                   make([]UploadedPartResponse, len(parts)),
+	}
+	for i, part := range parts {
+		response.Parts[i] = UploadedPartResponse{
+			PartNumber: part.PartNumber,
+			Size:       part.Size,
+			ETag:       part.ETag,
+		}
+	}
+	return response
+}
+
+type AssetTypeUsageResponse struct {
+	AssetType string `json:"asset_type"`
+	Count     int    `json:"count"`
+	Bytes     int64  `json:"bytes"`
+}
+
+type StorageUsageResponse struct {
+	UsedBytes int64 `json:"used_bytes"`
+	// PendingBytes はアップロード中のファイルの合計サイズです
+	PendingBytes int64 `json:"pending_bytes"`
+	// QuotaBytes は上限がない場合 null になります
+	QuotaBytes *int64                   `json:"quota_bytes"`
+	ByType     []AssetTypeUsageResponse `json:"by_type"`
+}
+
+func ToStorageUsageResponse(usage *entity.StorageUsage) StorageUsageResponse {
+	response := StorageUsageResponse{
+		UsedBytes:    usage.UsedBytes(),
+		PendingBytes: usage.PendingBytes,
+		ByType:       make([]AssetTypeUsageResponse, len(usage.ByType)),
+	}
+	if usage.Quota > 0 {
+		response.QuotaBytes = &usage.Quota
+	}
+	for i, byType := range usage.ByType {
+		response.ByType[i] = AssetTypeUsageResponse{
+			AssetType: byType.AssetType,
+			Count:     byType.Count,
+			Bytes:     byType.Bytes,
+		}
+	}
+	return response
+}
+
+type ZipEntryResponse struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+type ZipManifestResponse struct {
+	AssetID   uuid.UUID `json:"asset_id"`
+	FileCount int       `json:"file_count"`
+	// TotalSize は展開後の合計バイト数です
+	TotalSize int64 `json:"total_size"`
+	// Contents は中身から推定した種類です (unity_webgl, executable, source_project)
+	Contents []string           `json:"contents"`
+	Entries  []ZipEntryResponse `json:"entries"`
+}
+
+func ToZipManifestResponse(assetID uuid.UUID, manifest *entity.ZipManifest) ZipManifestResponse {
+	response := ZipManifestResponse{
+		AssetID:   assetID,
+		FileCount: len(manifest.Entries),
+		TotalSize: manifest.TotalSize,
+		Contents:  make([]string, 0, len(manifest.Contents)),
+		Entries:   make([]ZipEntryResponse, len(manifest.Entries)),
+	}
+	response.Contents = append(response.Contents, manifest.Contents...)
+	for i, e := range manifest.Entries {
+		response.Entries[i] = ZipEntryResponse{
+			Path: e.Path,
+			Size: e.Size,
+		}
 	}
+	return response
 }
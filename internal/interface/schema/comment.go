@@ -14,24 +14,68 @@ type UserInCommentResponse struct {
 }
 
 type CommentResponse struct {
-	ID        string                 `json:"id"`
-	Content   string                 `json:"content"`
-	ReplyAt   string                 `json:"reply_at"`
-	User      *UserInCommentResponse `json:"user"`
-	CreatedAt string                 `json:"created_at"`
-	UpdatedAt string                 `json:"updated_at"`
+	ID      string                 `json:"id"`
+	Content string                 `json:"content"`
+	ReplyAt string                 `json:"reply_at"`
+	User    *UserInCommentResponse `json:"user"`
+	// 匿名コメントの場合はuserがnullになり、anonymous_nameとfingerprintで投稿者を表示する
+	Anonymous     bool   `json:"anonymous"`
+	AnonymousName string `json:"anonymous_name,omitempty"`
+	Fingerprint   string `json:"fingerprint,omitempty"`
+	// 編集されていない場合はnull
+	EditedAt *string `json:"edited_at"`
+	// 返信が付いたまま削除されたコメントはtrueになり、本文と投稿者は空になる
+	Deleted bool `json:"deleted"`
+	// contentの中で@nameが書かれている範囲。存在するユーザーへのメンションだけを含む
+	Mentions  []MentionSpanResponse `json:"mentions"`
+	Reactions []ReactionResponse    `json:"reactions"`
+	CreatedAt string                `json:"created_at"`
+	UpdatedAt string                `json:"updated_at"`
+}
+
+// MentionSpanResponse はコメント本文中のメンションの位置です。
+// startとendは文字（Unicodeのコードポイント）単位の位置で、endの文字は含まない
+type MentionSpanResponse struct {
+	Start  int       `json:"start"`
+	End    int       `json:"end"`
+	UserID uuid.UUID `json:"user_id"`
+	Name   string    `json:"name"`
+}
+
+type GetCommentsQuery struct {
+	// treeを指定すると返信をrepliesに入れ子にして返す
+	Format string `query:"format" validate:"omitempty,oneof=flat tree"`
+	Depth  *int   `query:"depth" validate:"omitempty,min=1,max=10"`
+}
+
+type GetRepliesQuery struct {
+	Depth *int `query:"depth" validate:"omitempty,min=1,max=10"`
+}
+
+// CommentTreeResponse は返信を入れ子にしたコメントです。
+// 深さの上限で省略された返信はrepliesに含まれませんが、reply_countで件数が分かります。
+type CommentTreeResponse struct {
+	CommentResponse
+	ReplyCount int                    `json:"reply_count"`
+	Replies    []*CommentTreeResponse `json:"replies"`
 }
 
 type CreateCommentRequest struct {
 	Content string `json:"content" validate:"required,max=255"`
 	ReplyAt string `json:"reply_at" validate:"omitempty,uuid"`
-	UserID  string `json:"user_id" validate:"omitempty,uuid"`
+	// ログインしていない場合の表示名。ログインしている場合は無視する
+	DisplayName string `json:"display_name" validate:"omitempty,max=50"`
+}
+
+type UpdateCommentRequest struct {
+	Content string `json:"content" validate:"required,max=255"`
 }
 
 type CreateCommentResponse struct {
 	ID        string `json:"id"`
 	Content   string `json:"content"`
 	ReplyAt   string `json:"reply_at"`
+	Anonymous bool   `json:"anonymous"`
 	CreatedAt string `json:"created_at"`
 }
 
@@ -40,8 +84,14 @@ func ToCommentResponse(comment *entity.Comment) *CommentResponse {
 		return nil
 	}
 
+	var editedAt *string
+	if comment.EditedAt != nil {
+		formatted := comment.EditedAt.Format(time.RFC3339)
+		editedAt = &formatted
+	}
+
 	var user *UserInCommentResponse
-	if comment.User != nil && comment.User.ID != uuid.Nil {
+	if comment.User != nil && comment.User.ID != uuid.Nil && !comment.IsDeleted() {
 		user = &UserInCommentResponse{
 			ID:          comment.User.ID.String(),
 			DisplayName: comment.User.DisplayName,
@@ -50,15 +100,35 @@ func ToCommentResponse(comment *entity.Comment) *CommentResponse {
 	}
 
 	return &CommentResponse{
-		ID:        comment.ID.String(),
-		Content:   comment.Content,
-		ReplyAt:   comment.ReplyAt,
-		User:      user,
-		CreatedAt: comment.CreatedAt.Format(time.RFC3339),
-		UpdatedAt: comment.UpdatedAt.Format(time.RFC3339),
+		ID:            comment.ID.String(),
+		Content:       comment.Content,
+		ReplyAt:       comment.ReplyAt,
+		User:          user,
+		Anonymous:     comment.Anonymous,
+		AnonymousName: comment.AnonymousName,
+		Fingerprint:   comment.Fingerprint,
+		EditedAt:      editedAt,
+		Deleted:       comment.IsDeleted(),
+		Mentions:      ToMentionSpanResponses(comment.Mentions),
+		Reactions:     ToReactionResponses(comment.Reactions),
+		CreatedAt:     comment.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     comment.UpdatedAt.Format(time.RFC3339),
 	}
 }
 
+func ToMentionSpanResponses(spans []*entity.MentionSpan) []MentionSpanResponse {
+	res := make([]MentionSpanResponse, len(spans))
+	for i, span := range spans {
+		res[i] = MentionSpanResponse{
+			Start:  span.Start,
+			End:    span.End,
+			UserID: span.UserID,
+			Name:   span.Name,
+		}
+	}
+	return res
+}
+
 func ToCommentListResponse(comments []*entity.Comment) []*CommentResponse {
 	res := make([]*CommentResponse, len(comments))
 	for i, comment := range comments {
@@ -67,6 +137,18 @@ func ToCommentListResponse(comments []*entity.Comment) []*CommentResponse {
 	return res
 }
 
+func ToCommentTreeResponse(nodes []*entity.CommentNode) []*CommentTreeResponse {
+	res := make([]*CommentTreeResponse, len(nodes))
+	for i, node := range nodes {
+		res[i] = &CommentTreeResponse{
+			CommentResponse: *ToCommentResponse(node.Comment),
+			ReplyCount:      node.ReplyCount,
+			Replies:         ToCommentTreeResponse(node.Replies),
+		}
+	}
+	return res
+}
+
 func ToCreateCommentResponse(comment *entity.Comment) *CreateCommentResponse {
 	if comment == nil {
 		return nil
@@ -75,6 +157,7 @@ func ToCreateCommentResponse(comment *entity.Comment) *CreateCommentResponse {
 		ID:        comment.ID.String(),
 		Content:   comment.Content,
 		ReplyAt:   comment.ReplyAt,
+		Anonymous: comment.Anonymous,
 		CreatedAt: comment.CreatedAt.String(),
 	}
 }
@@ -0,0 +1,29 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+)
+
+// EventResponse はSSEで送るイベントのdataです。種類はSSEのeventフィールドでも送ります。
+type EventResponse struct {
+	ID        string     `json:"id"`
+	Type      string     `json:"type"`
+	ActorID   uuid.UUID  `json:"actor_id"`
+	WorkID    uuid.UUID  `json:"work_id"`
+	CommentID *uuid.UUID `json:"comment_id"`
+	CreatedAt string     `json:"created_at"`
+}
+
+func ToEventResponse(event *entity.Event) EventResponse {
+	return EventResponse{
+		ID:        event.ID,
+		Type:      string(event.Type),
+		ActorID:   event.ActorID,
+		WorkID:    event.WorkID,
+		CommentID: event.CommentID,
+		CreatedAt: event.CreatedAt.Format(time.RFC3339),
+	}
+}
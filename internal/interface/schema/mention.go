@@ -0,0 +1,60 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+)
+
+type GetMentionsQuery struct {
+	Limit *int `query:"limit" validate:"omitempty,min=1,max=100"`
+	Page  *int `query:"page" validate:"omitempty,min=1"`
+}
+
+type MentionResponse struct {
+	ID     uuid.UUID           `json:"id"`
+	Actor  *UserInWorkResponse `json:"actor"`
+	WorkID uuid.UUID           `json:"work_id"`
+	// 作品の説明文でのメンションの場合はnull
+	CommentID *uuid.UUID `json:"comment_id"`
+	CreatedAt string     `json:"created_at"`
+}
+
+type MentionListResponse struct {
+	Mentions   []MentionResponse `json:"mentions"`
+	TotalCount int               `json:"total_count"`
+	Page       int               `json:"page"`
+	Limit      int               `json:"limit"`
+}
+
+func ToMentionResponse(mention *entity.Mention) MentionResponse {
+	var actor *UserInWorkResponse
+	if mention.Actor != nil {
+		actor = &UserInWorkResponse{
+			ID:          mention.Actor.ID,
+			DisplayName: mention.Actor.DisplayName,
+			AvatarURL:   mention.Actor.AvatarURL,
+		}
+	}
+	return MentionResponse{
+		ID:        mention.ID,
+		Actor:     actor,
+		WorkID:    mention.WorkID,
+		CommentID: mention.CommentID,
+		CreatedAt: mention.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func ToMentionListResponse(mentions []*entity.Mention, total, page, limit int) MentionListResponse {
+	response := make([]MentionResponse, len(mentions))
+	for i, mention := range mentions {
+		response[i] = ToMentionResponse(mention)
+	}
+	return MentionListResponse{
+		Mentions:   response,
+		TotalCount: total,
+		Page:       page,
+		Limit:      limit,
+	}
+}
@@ -0,0 +1,69 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+)
+
+type GetNotificationsQuery struct {
+	Limit      *int `query:"limit" validate:"omitempty,min=1,max=100"`
+	Page       *int `query:"page" validate:"omitempty,min=1"`
+	UnreadOnly bool `query:"unread_only"`
+}
+
+type MarkNotificationsAsReadInput struct {
+	// 空の場合は全ての通知を既読にする
+	IDs []uuid.UUID `json:"ids" validate:"omitempty,dive,uuid"`
+}
+
+type NotificationResponse struct {
+	ID        uuid.UUID           `json:"id"`
+	Type      string              `json:"type"`
+	Actor     *UserInWorkResponse `json:"actor"`
+	WorkID    uuid.UUID           `json:"work_id"`
+	CommentID *uuid.UUID          `json:"comment_id"`
+	Read      bool                `json:"read"`
+	CreatedAt string              `json:"created_at"`
+}
+
+type NotificationListResponse struct {
+	Notifications []NotificationResponse `json:"notifications"`
+	TotalCount    int                    `json:"total_count"`
+	Page          int                    `json:"page"`
+	Limit         int                    `json:"limit"`
+}
+
+func ToNotificationResponse(notification *entity.Notification) NotificationResponse {
+	var actor *UserInWorkResponse
+	if notification.Actor != nil {
+		actor = &UserInWorkResponse{
+			ID:          notification.Actor.ID,
+			DisplayName: notification.Actor.DisplayName,
+			AvatarURL:   notification.Actor.AvatarURL,
+		}
+	}
+	return NotificationResponse{
+		ID:        notification.ID,
+		Type:      string(notification.Type),
+		Actor:     actor,
+		WorkID:    notification.WorkID,
+		CommentID: notification.CommentID,
+		Read:      notification.ReadAt != nil,
+		CreatedAt: notification.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func ToNotificationListResponse(notifications []*entity.Notification, total, page, limit int) NotificationListResponse {
+	response := make([]NotificationResponse, len(notifications))
+	for i, notification := range notifications {
+		response[i] = ToNotificationResponse(notification)
+	}
+	return NotificationListResponse{
+		Notifications: response,
+		TotalCount:    total,
+		Page:          page,
+		Limit:         limit,
+	}
+}
@@ -0,0 +1,33 @@
+package schema
+
+import (
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+)
+
+// ReactionRequest はリアクションを付ける・取り消すリクエストです。
+// PUTではリクエストボディ、DELETEではクエリパラメータで指定する
+type ReactionRequest struct {
+	TargetType string    `json:"target_type" query:"target_type" validate:"required,oneof=work comment"`
+	TargetID   uuid.UUID `json:"target_id" query:"target_id" validate:"required"`
+	Emoji      string    `json:"emoji" query:"emoji" validate:"required,max=64"`
+}
+
+type ReactionResponse struct {
+	Emoji string `json:"emoji"`
+	Count int    `json:"count"`
+	// ログインしているユーザー自身がこの絵文字を付けている場合はtrue
+	Reacted bool `json:"reacted"`
+}
+
+func ToReactionResponses(summaries []*entity.ReactionSummary) []ReactionResponse {
+	res := make([]ReactionResponse, len(summaries))
+	for i, summary := range summaries {
+		res[i] = ReactionResponse{
+			Emoji:   summary.Emoji,
+			Count:   summary.Count,
+			Reacted: summary.Reacted,
+		}
+	}
+	return res
+}
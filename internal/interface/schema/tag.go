@@ -42,3 +42,47 @@ func ToTagListResponse(tags []*entity.Tag) TagListResponse {
 	return TagListResponse{Tags: response}
 }
 
+type GetTagNewWorksQuery struct {
+	Limit      *int `query:"limit" validate:"omitempty,min=1,max=100"`
+	Page       *int `query:"page" validate:"omitempty,min=1"`
+	UnseenOnly bool `query:"unseen_only"`
+}
+
+type MarkTagNewWorksAsSeenInput struct {
+	// 空の場合は全ての新着を既読にする
+	WorkIDs []uuid.UUID `json:"work_ids" validate:"omitempty,dive,uuid"`
+}
+
+type TagNewWorkResponse struct {
+	Work      GetWorkOutput `json:"work"`
+	Seen      bool          `json:"seen"`
+	CreatedAt string        `json:"created_at"`
+}
+
+type TagNewWorkListResponse struct {
+	NewWorks   []TagNewWorkResponse `json:"new_works"`
+	TotalCount int                  `json:"total_count"`
+	Page       int                  `json:"page"`
+	Limit      int                  `json:"limit"`
+}
+
+func ToTagNewWorkResponse(newWork *entity.TagNewWork) TagNewWorkResponse {
+	return TagNewWorkResponse{
+		Work:      ToWorkResponse(newWork.Work),
+		Seen:      newWork.SeenAt != nil,
+		CreatedAt: newWork.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func ToTagNewWorkListResponse(newWorks []*entity.TagNewWork, total, page, limit int) TagNewWorkListResponse {
+	response := make([]TagNewWorkResponse, len(newWorks))
+	for i, newWork := range newWorks {
+		response[i] = ToTagNewWorkResponse(newWork)
+	}
+	return TagNewWorkListResponse{
+		NewWorks:   response,
+		TotalCount: total,
+		Page:       page,
+		Limit:      limit,
+	}
+}
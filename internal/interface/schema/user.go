@@ -8,21 +8,24 @@ import (
 )
 
 type GetUserOutput struct {
-	ID          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
-	Email       string    `json:"email"`
-	DisplayName string    `json:"display_name"`
-	Profile     string    `json:"profile"`
-	AvatarURL   string    `json:"avatar_url"`
-	TwitterID   string    `json:"twitter_id"`
-	GithubID    string    `json:"github_id"`
-	CreatedAt   string    `json:"created_at"`
-	UpdatedAt   string    `json:"updated_at"`
+	ID             uuid.UUID `json:"id"`
+	Name           string    `json:"name"`
+	Email          string    `json:"email"`
+	DisplayName    string    `json:"display_name"`
+	Profile        string    `json:"profile"`
+	AvatarURL      string    `json:"avatar_url"`
+	TwitterID      string    `json:"twitter_id"`
+	GithubID       string    `json:"github_id"`
+	FollowerCount  int       `json:"follower_count"`
+	FollowingCount int       `json:"following_count"`
+	CreatedAt      string    `json:"created_at"`
+	UpdatedAt      string    `json:"updated_at"`
 }
 
 type GetIconAndURLResponse struct {
-	DisplayName string `json:"display_name"`
-	IconURL     string `json:"icon_url"`
+	DisplayName             string `json:"display_name"`
+	IconURL                 string `json:"icon_url"`
+	UnreadNotificationCount int    `json:"unread_notification_count"`
 }
 
 type UserListResponse struct {
@@ -42,25 +45,36 @@ func ToUserResponse(user *entity.User) GetUserOutput {
 		return GetUserOutput{}
 	}
 	return GetUserOutput{
-		ID:          user.ID,
-		Name:        user.Name,
-		Email:       user.Email,
-		DisplayName: user.DisplayName,
-		Profile:     user.Profile,
-		AvatarURL:   user.AvatarURL,
-		TwitterID:   user.TwitterID,
-		GithubID:    user.GithubID,
-		CreatedAt:   user.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   user.UpdatedAt.Format(time.RFC3339),
+		ID:             user.ID,
+		Name:           user.Name,
+		Email:          user.Email,
+		DisplayName:    user.DisplayName,
+		Profile:        user.Profile,
+		AvatarURL:      user.AvatarURL,
+		TwitterID:      user.TwitterID,
+		GithubID:       user.GithubID,
+		FollowerCount:  user.FollowerCount,
+		FollowingCount: user.FollowingCount,
+		CreatedAt:      user.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      user.UpdatedAt.Format(time.RFC3339),
 	}
 }
 
-func ToIconAndURLResponse(user *entity.User) GetIconAndURLResponse {
+func ToUserListResponse(users []*entity.User) UserListResponse {
+	response := make([]GetUserOutput, len(users))
+	for i, user := range users {
+		response[i] = ToUserResponse(user)
+	}
+	return UserListResponse{Users: response}
+}
+
+func ToIconAndURLResponse(user *entity.User, unreadNotificationCount int) GetIconAndURLResponse {
 	if user == nil {
 		return GetIconAndURLResponse{}
 	}
 	return GetIconAndURLResponse{
-		DisplayName: user.DisplayName,
-		IconURL:     user.AvatarURL,
+		DisplayName:             user.DisplayName,
+		IconURL:                 user.AvatarURL,
+		UnreadNotificationCount: unreadNotificationCount,
 	}
 }
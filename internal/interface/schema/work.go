@@ -14,16 +14,22 @@ type UserInWorkResponse struct {
 }
 
 type GetWorkOutput struct {
-	ID           uuid.UUID           `json:"id"`
-	Title        string              `json:"title"`
-	Description  string              `json:"description"`
-	User         *UserInWorkResponse `json:"user"`
-	Visibility   string              `json:"visibility"`
-	ThumbnailURL string              `json:"thumbnail_url"`
-	Assets       []AssetResponse     `json:"assets"`
-	Tags         []TagResponse       `json:"tags"`
-	CreatedAt    string              `json:"created_at"`
-	UpdatedAt    string              `json:"updated_at"`
+	ID                uuid.UUID              `json:"id"`
+	Title             string                 `json:"title"`
+	Description       string                 `json:"description"`
+	User              *UserInWorkResponse    `json:"user"`
+	Visibility        string                 `json:"visibility"`
+	ThumbnailURL      string                 `json:"thumbnail_url"`
+	ThumbnailVariants []AssetVariantResponse `json:"thumbnail_variants"`
+	Assets            []AssetResponse        `json:"assets"`
+	Tags              []TagResponse          `json:"tags"`
+	Reactions         []ReactionResponse     `json:"reactions"`
+	CreatedAt         string                 `json:"created_at"`
+	UpdatedAt         string                 `json:"updated_at"`
+	// PlayURL はブラウザで遊べるビルドの index.html の URL です。iframe で埋め込めます。ビルドがない場合は空文字です
+	PlayURL string `json:"play_url"`
+	// ThumbnailPlaceholder はサムネイル画像を読み込むまでの間に表示するプレースホルダーです。作成していない場合は null です
+	ThumbnailPlaceholder *ImagePlaceholderResponse `json:"thumbnail_placeholder"`
 }
 
 type CreateWorkInput struct {
@@ -52,6 +58,16 @@ type GetWorksQuery struct {
 	TagIDs string `query:"tag_ids" validate:"omitempty"`
 }
 
+type GetFeedQuery struct {
+	Limit  *int   `query:"limit" validate:"omitempty,min=1,max=100"`
+	Cursor string `query:"cursor" validate:"omitempty"`
+}
+
+type FeedResponse struct {
+	Works      []GetWorkOutput `json:"works"`
+	NextCursor string          `json:"next_cursor"`
+}
+
 type WorkListResponse struct {
 	Works      []GetWorkOutput `json:"works"`
 	TotalCount int             `json:"total_count"`
@@ -66,8 +82,65 @@ type AssetResponse struct {
 	UserID    uuid.UUID `json:"user_id"`
 	Extension string    `json:"extension"`
 	URL       string    `json:"url"`
-	CreatedAt string    `json:"created_at"`
-	UpdatedAt string    `json:"updated_at"`
+	// Hash は元ファイルの SHA-256 です。同じ内容のファイルのアップロードを省くのに使えます。分からない場合は空文字です
+	Hash      string                 `json:"hash"`
+	Srcset    []AssetVariantResponse `json:"srcset"`
+	CreatedAt string                 `json:"created_at"`
+	UpdatedAt string                 `json:"updated_at"`
+	// PlayURL は ZIP から展開したブラウザで遊べるビルドの index.html の URL です。ビルドがない場合は空文字です
+	PlayURL string `json:"play_url"`
+	// Audio は音声アセットの再生時間や波形です。音声以外と読み取れなかった場合は null です
+	Audio *AudioMetadataResponse `json:"audio"`
+	// Model は 3D モデルアセットの頂点数や大きさです。3D モデル以外の場合は null です
+	Model *ModelStatsResponse `json:"model"`
+	// Placeholder は画像アセットを読み込むまでの間に表示するプレースホルダーです。画像以外と作成していない場合は null です
+	Placeholder *ImagePlaceholderResponse `json:"placeholder"`
+}
+
+// AssetVariantResponse は img 要素の srcset に並べる縮小版の画像です
+type AssetVariantResponse struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// AudioMetadataResponse は音声を読み込む前に表示できる再生時間や波形です
+type AudioMetadataResponse struct {
+	DurationMs int64 `json:"duration_ms"`
+	SampleRate int   `json:"sample_rate"`
+	// Bitrate は平均のビットレート (bps) です
+	Bitrate  int    `json:"bitrate"`
+	Channels int    `json:"channels"`
+	Title    string `json:"title"`
+	Artist   string `json:"artist"`
+	// Peaks は曲を等しい長さの区間に分け、区間ごとの振幅の最大値を 0〜1 で表したものです
+	Peaks []float64 `json:"peaks"`
+}
+
+// ImagePlaceholderResponse は画像を読み込むまでの間に、画像の場所を埋めて表示するためのものです
+type ImagePlaceholderResponse struct {
+	// Width と Height は表示される向きでの画像の大きさです。読み込む前から縦横比の分だけ場所を確保できます
+	Width  int `json:"width"`
+	Height int `json:"height"`
+	// BlurHash は画像をぼかしたものを表す文字列です。https://blurha.sh のデコーダーで画像に戻せます
+	BlurHash string `json:"blurhash"`
+	// DominantColor は画像で最も多く使われている色の "#rrggbb" 形式の表記です
+	DominantColor string `json:"dominant_color"`
+}
+
+// ModelStatsResponse はモデルビューアーが読み込む前に表示できる 3D モデルの頂点数や大きさです
+type ModelStatsResponse struct {
+	VertexCount   int64    `json:"vertex_count"`
+	TriangleCount int64    `json:"triangle_count"`
+	Materials     []string `json:"materials"`
+	// BoundingBox はシーン全体を囲む直方体です。メッシュがない場合は null です
+	BoundingBox *BoundingBoxResponse `json:"bounding_box"`
+}
+
+// BoundingBoxResponse はモデルの座標系での [x, y, z] の最小値と最大値です
+type BoundingBoxResponse struct {
+	Min [3]float64 `json:"min"`
+	Max [3]float64 `json:"max"`
 }
 
 type TagResponse struct {
@@ -90,16 +163,20 @@ func ToWorkResponse(work *entity.Work) GetWorkOutput {
 	}
 
 	return GetWorkOutput{
-		ID:           work.ID,
-		Title:        work.Title,
-		Description:  work.Description,
-		User:         user,
-		Visibility:   work.Visibility,
-		ThumbnailURL: work.ThumbnailURL,
-		Assets:       ToAssetResponses(work.Assets),
-		Tags:         ToTagResponses(work.Tags),
-		CreatedAt:    work.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:    work.UpdatedAt.Format(time.RFC3339),
+		ID:                   work.ID,
+		Title:                work.Title,
+		Description:          work.Description,
+		User:                 user,
+		Visibility:           work.Visibility,
+		ThumbnailURL:         work.ThumbnailURL,
+		ThumbnailVariants:    ToAssetVariantResponses(work.ThumbnailVariants),
+		Assets:               ToAssetResponses(work.Assets),
+		Tags:                 ToTagResponses(work.Tags),
+		Reactions:            ToReactionResponses(work.Reactions),
+		CreatedAt:            work.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:            work.UpdatedAt.Format(time.RFC3339),
+		PlayURL:              work.PlayURL(),
+		ThumbnailPlaceholder: ToImagePlaceholderResponse(work.ThumbnailPlaceholder),
 	}
 }
 
@@ -124,14 +201,20 @@ func ToAssetResponse(asset *entity.Asset) AssetResponse {
 	}
 
 	return AssetResponse{
-		ID:        asset.ID,
-		WorkID:    asset.WorkID,
-		AssetType: asset.AssetType,
-		UserID:    asset.UserID,
-		Extension: asset.Extension,
-		URL:       asset.URL,
-		CreatedAt: asset.CreatedAt.Format(time.RFC3339),
-		UpdatedAt: asset.UpdatedAt.Format(time.RFC3339),
+		ID:          asset.ID,
+		WorkID:      asset.WorkID,
+		AssetType:   asset.AssetType,
+		UserID:      asset.UserID,
+		Extension:   asset.Extension,
+		URL:         asset.URL,
+		Hash:        asset.Hash,
+		Srcset:      ToAssetVariantResponses(asset.Variants),
+		CreatedAt:   asset.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   asset.UpdatedAt.Format(time.RFC3339),
+		PlayURL:     asset.PlayURL,
+		Audio:       ToAudioMetadataResponse(asset.AudioMetadata),
+		Model:       ToModelStatsResponse(asset.ModelStats),
+		Placeholder: ToImagePlaceholderResponse(asset.Placeholder),
 	}
 }
 
@@ -147,6 +230,69 @@ func ToAssetResponses(assets []*entity.Asset) []AssetResponse {
 	return res
 }
 
+func ToAudioMetadataResponse(metadata *entity.AudioMetadata) *AudioMetadataResponse {
+	if metadata == nil {
+		return nil
+	}
+	peaks := metadata.Peaks
+	if peaks == nil {
+		peaks = []float64{}
+	}
+	return &AudioMetadataResponse{
+		DurationMs: metadata.Duration.Milliseconds(),
+		SampleRate: metadata.SampleRate,
+		Bitrate:    metadata.Bitrate,
+		Channels:   metadata.Channels,
+		Title:      metadata.Title,
+		Artist:     metadata.Artist,
+		Peaks:      peaks,
+	}
+}
+
+func ToImagePlaceholderResponse(placeholder *entity.ImagePlaceholder) *ImagePlaceholderResponse {
+	if placeholder == nil {
+		return nil
+	}
+	return &ImagePlaceholderResponse{
+		Width:         placeholder.Width,
+		Height:        placeholder.Height,
+		BlurHash:      placeholder.BlurHash,
+		DominantColor: placeholder.DominantColor,
+	}
+}
+
+func ToModelStatsResponse(stats *entity.ModelStats) *ModelStatsResponse {
+	if stats == nil {
+		return nil
+	}
+	materials := stats.Materials
+	if materials == nil {
+		materials = []string{}
+	}
+	var box *BoundingBoxResponse
+	if stats.BoundingBox != nil {
+		box = &BoundingBoxResponse{Min: stats.BoundingBox.Min, Max: stats.BoundingBox.Max}
+	}
+	return &ModelStatsResponse{
+		VertexCount:   stats.VertexCount,
+		TriangleCount: stats.TriangleCount,
+		Materials:     materials,
+		BoundingBox:   box,
+	}
+}
+
+func ToAssetVariantResponses(variants []*entity.AssetVariant) []AssetVariantResponse {
+	res := make([]AssetVariantResponse, 0, len(variants))
+	for _, variant := range variants {
+		res = append(res, AssetVariantResponse{
+			URL:    variant.URL,
+			Width:  variant.Width,
+			Height: variant.Height,
+		})
+	}
+	return res
+}
+
 func ToTagResponse(tag *entity.Tag) TagResponse {
 	if tag == nil {
 		return TagResponse{}
@@ -184,3 +330,14 @@ func ToWorkListResponse(works []*entity.Work) WorkListResponse {
 		Limit:      20,
 	}
 }
+
+func ToFeedResponse(works []*entity.Work, nextCursor string) FeedResponse {
+	workResponses := make([]GetWorkOutput, 0, len(works))
+	for _, work := range works {
+		workResponses = append(workResponses, ToWorkResponse(work))
+	}
+	return FeedResponse{
+		Works:      workResponses,
+		NextCursor: nextCursor,
+	}
+}
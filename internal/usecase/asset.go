@@ -1,44 +1,295 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
+	"log"
 	"mime/multipart"
-	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
 	"github.com/simesaba80/toybox-back/internal/domain/repository"
 )
 
 type IAssetUseCase interface {
 	UploadFile(ctx context.Context, file *multipart.FileHeader, userID uuid.UUID) (*entity.Asset, error)
+	// CreateUpload は S3 へ直接アップロードするための署名付き URL を発行します
+	CreateUpload(ctx context.Context, userID uuid.UUID, fileName string, size int64) (*entity.AssetUpload, string, error)
+	// CompleteUpload はアップロードされたファイルを検証してアセットを登録します
+	CompleteUpload(ctx context.Context, userID uuid.UUID, uploadID uuid.UUID) (*entity.Asset, error)
+	// CreateMultipartUpload はパートに分けて S3 へ直接アップロードする準備をします
+	CreateMultipartUpload(ctx context.Context, userID uuid.UUID, fileName string, size int64) (*entity.AssetUpload, error)
+	// PresignUploadParts は指定したパートを PUT するための署名付き URL を発行します
+	PresignUploadParts(ctx context.Context, userID uuid.UUID, uploadID uuid.UUID, partNumbers []int32) ([]*entity.PresignedPart, time.Time, error)
+	// ListUploadedParts はアップロード済みのパートを返します。中断したアップロードの再開に使います
+	ListUploadedParts(ctx context.Context, userID uuid.UUID, uploadID uuid.UUID) (*entity.AssetUpload, []*entity.UploadedPart, error)
+	// CompleteMultipartUpload はパートを結合し、検証したうえでアセットを登録します
+	CompleteMultipartUpload(ctx context.Context, userID uuid.UUID, uploadID uuid.UUID) (*entity.Asset, error)
+	AbortMultipartUpload(ctx context.Context, userID uuid.UUID, uploadID uuid.UUID) error
+	// AbortExpiredUploads は有効期限が切れたまま放置されたアップロードを片付け、片付けた数を返します
+	AbortExpiredUploads(ctx context.Context) (int, error)
+	// GetStorageUsage はユーザーのストレージの使用量をアセットの種類ごとに返します
+	GetStorageUsage(ctx context.Context, userID uuid.UUID) (*entity.StorageUsage, error)
+	// GetZipManifest は ZIP アセットに含まれるファイルの一覧を返します
+	GetZipManifest(ctx context.Context, assetID uuid.UUID) (*entity.ZipManifest, error)
+	// FindAssetByHash は元ファイルの SHA-256 が hash のユーザーのアセットを返します。
+	// クライアントは同じ内容のファイルをアップロードし直す代わりに、このアセットを使えます
+	FindAssetByHash(ctx context.Context, userID uuid.UUID, hash string) (*entity.Asset, error)
+}
+
+// AssetProcessors はアップロードされたファイルを保存する前後に検査・加工する処理です。
+// 扱うファイルの種類を増やすときは、ここに処理を加えます。
+type AssetProcessors struct {
+	// ImageProcessor は画像のメタデータの除去や派生画像・プレースホルダーの作成をする
+	ImageProcessor repository.ImageProcessor
+	// ArchiveInspector は ZIP を保存する前に中身を検査する
+	ArchiveInspector repository.ArchiveInspector
+	// AudioAnalyzer は音声アセットの再生時間や波形を読み取る
+	AudioAnalyzer repository.AudioAnalyzer
+	// ModelInspector は 3D モデルを保存する前に構造を検査する
+	ModelInspector repository.ModelInspector
 }
 
 type assetUseCase struct {
-	assetRepo repository.AssetRepository
+	assetRepo        repository.AssetRepository
+	assetUploadRepo  repository.AssetUploadRepository
+	imageProcessor   repository.ImageProcessor
+	archiveInspector repository.ArchiveInspector
+	audioAnalyzer    repository.AudioAnalyzer
+	modelInspector   repository.ModelInspector
+	uploadURLTTL     time.Duration
+	// multipartTTL を過ぎても完了しないマルチパートアップロードは放置されたものとして中止する
+	multipartTTL time.Duration
+	// storageQuota はユーザーごとに保存できるファイルの合計バイト数。0 以下の場合は上限なし
+	storageQuota int64
 }
 
-func NewAssetUseCase(assetRepo repository.AssetRepository) IAssetUseCase {
+func NewAssetUseCase(assetRepo repository.AssetRepository, assetUploadRepo repository.AssetUploadRepository, processors AssetProcessors, uploadURLTTL time.Duration, multipartTTL time.Duration, storageQuota int64) IAssetUseCase {
 	return &assetUseCase{
-		assetRepo: assetRepo,
+		assetRepo:        assetRepo,
+		assetUploadRepo:  assetUploadRepo,
+		imageProcessor:   processors.ImageProcessor,
+		archiveInspector: processors.ArchiveInspector,
+		audioAnalyzer:    processors.AudioAnalyzer,
+		modelInspector:   processors.ModelInspector,
+		uploadURLTTL:     uploadURLTTL,
+		multipartTTL:     multipartTTL,
+		storageQuota:     storageQuota,
 	}
 }
 
+// metadataStrippedContentTypes は位置情報などのメタデータを取り除いてから保存する画像の形式
+var metadataStrippedContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/webp": true,
+}
+
 func (uc *assetUseCase) UploadFile(ctx context.Context, file *multipart.FileHeader, userID uuid.UUID) (*entity.Asset, error) {
-	extension := strings.Split(file.Filename, ".")[1]
-	asset := entity.NewAsset("", userID, extension, "")
+	fileType, err := validateFileType(file.Filename, file.Size)
+	if err != nil {
+		return nil, err
+	}
+	if uc.storageQuota > 0 {
+		// 保存が終わるまでの間、同時に始めたアップロードと合わせて上限を超えないよう容量を確保しておく
+		reservation := entity.NewAssetUpload(userID, fileType.Extension, fileType.ContentType, file.Size, uc.uploadURLTTL)
+		if err := uc.reserveStorage(ctx, reservation); err != nil {
+			return nil, err
+		}
+		defer func() {
+			if err := uc.assetUploadRepo.Delete(ctx, reservation.ID); err != nil {
+				log.Printf("容量の確保の解除に失敗しました (upload_id=%s): %v", reservation.ID.String(), err)
+			}
+		}()
+	}
 
-	assetURL, assetType, err := uc.assetRepo.UploadFile(ctx, file, asset.ID, extension)
+	openFile, err := file.Open()
 	if err != nil {
+		return nil, domainerrors.ErrFailedToOpenFile
+	}
+	defer openFile.Close()
+
+	if err := validateFileContent(openFile, fileType); err != nil {
+		return nil, err
+	}
+	if _, err := openFile.Seek(0, io.SeekStart); err != nil {
+		return nil, domainerrors.ErrFailedToOpenFile
+	}
+
+	asset := entity.NewAsset("", userID, fileType.Extension, "")
+	return uc.storeAsset(ctx, asset, fileType, openFile)
+}
+
+// storeAsset は必要な加工をしたファイルを保存し、アセットとして登録する
+func (uc *assetUseCase) storeAsset(ctx context.Context, asset *entity.Asset, fileType *entity.FileType, src io.ReadSeeker) (*entity.Asset, error) {
+	if fileType.Extension == zipExtension {
+		// 展開すると巨大になるものや展開先の外に書き出すものは、保存する前に断る
+		manifest, err := uc.inspectZip(ctx, src)
+		if err != nil {
+			return nil, err
+		}
+		asset.ZipManifest = manifest
+		asset.PlayURL = uc.extractPlayable(ctx, asset.ID, manifest, src)
+		if _, err := src.Seek(0, io.SeekStart); err != nil {
+			uc.discardPlayFiles(ctx, asset)
+			return nil, domainerrors.ErrFailedToOpenFile
+		}
+	}
+	if modelExtensions[fileType.Extension] {
+		// 読み込めないモデルや、一緒にアップロードされていないファイルを参照するモデルは保存する前に断る
+		stats, err := uc.inspectModel(ctx, fileType.Extension, src)
+		if err != nil {
+			return nil, err
+		}
+		asset.ModelStats = stats
+	}
+
+	// クライアントが手元のファイルから探せるよう、メタデータを取り除く前の受け取ったままの内容で重複を判定する
+	hash, err := contentHash(src)
+	if err != nil {
+		uc.discardPlayFiles(ctx, asset)
+		return nil, err
+	}
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
+		uc.discardPlayFiles(ctx, asset)
+		return nil, domainerrors.ErrFailedToOpenFile
+	}
+
+	var body io.ReadSeeker = src
+	if metadataStrippedContentTypes[fileType.ContentType] {
+		// 公開 URL から撮影場所などが漏れないよう、S3 に置く前にメタデータを取り除く
+		data, err := io.ReadAll(src)
+		if err != nil {
+			return nil, domainerrors.ErrFailedToOpenFile
+		}
+		stripped, err := uc.imageProcessor.StripMetadata(ctx, data, fileType.ContentType)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", domainerrors.ErrFailedToStripMetadata, err)
+		}
+		if stripped.ConvertedExtension != "" {
+			// 向きを画素に反映するために形式が変わった場合は、変換後の形式で保存する
+			converted, ok := entity.LookupFileType(stripped.ConvertedExtension)
+			if !ok {
+				return nil, fmt.Errorf("%w: unsupported converted extension: %s", domainerrors.ErrFailedToStripMetadata, stripped.ConvertedExtension)
+			}
+			fileType = converted
+			asset.Extension = converted.Extension
+		}
+		body = bytes.NewReader(stripped.Data)
+		asset.MetadataStripped = true
+	}
+
+	uploaded, err := uc.assetRepo.UploadFile(ctx, body, asset.ID, fileType.Extension, fileType.ContentType, hash)
+	if err != nil {
+		// 元の ZIP を保存できなかったアセットは登録しないため、展開したビルドも残さない
+		uc.discardPlayFiles(ctx, asset)
 		return nil, fmt.Errorf("failed to upload file: %w", err)
 	}
-	asset.URL = *assetURL
-	asset.AssetType = *assetType
+	asset.URL = uploaded.URL
+	asset.AssetType = uploaded.AssetType
+	asset.Hash = uploaded.Hash
+	asset.Size = uploaded.Size
+	if asset.AssetType == entity.AssetTypeMusic {
+		if _, err := body.Seek(0, io.SeekStart); err != nil {
+			return nil, domainerrors.ErrFailedToOpenFile
+		}
+		asset.AudioMetadata = uc.analyzeAudio(ctx, asset.ID, fileType.Extension, body)
+	}
+	if asset.AssetType == entity.AssetTypeImage {
+		if _, err := body.Seek(0, io.SeekStart); err != nil {
+			return nil, domainerrors.ErrFailedToOpenFile
+		}
+		asset.Placeholder = uc.generatePlaceholder(ctx, asset.ID, body)
+	}
 
 	createdAsset, err := uc.assetRepo.Create(ctx, asset)
 	if err != nil {
+		// 登録できなかったアセットの分の参照を手放す
+		if releaseErr := uc.assetRepo.ReleaseBlob(ctx, uploaded.Hash); releaseErr != nil {
+			log.Printf("ファイルの参照の解放に失敗しました (hash=%s): %v", uploaded.Hash, releaseErr)
+		}
+		uc.discardPlayFiles(ctx, asset)
 		return nil, fmt.Errorf("failed to create asset: %w", err)
 	}
+
+	if createdAsset.AssetType == entity.AssetTypeImage {
+		// 派生画像がなくても元画像は表示できるため、生成に失敗してもアップロードは成功とする
+		variants, err := uc.createImageVariants(ctx, body, createdAsset.ID)
+		if err != nil {
+			log.Printf("派生画像の生成に失敗しました (asset_id=%s): %v", createdAsset.ID.String(), err)
+		}
+		createdAsset.Variants = variants
+	}
 	return createdAsset, nil
 }
+
+// contentHash は r を読み終えるまでの内容の SHA-256 を 16 進数で返す
+func contentHash(r io.Reader) (string, error) {
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, r); err != nil {
+		return "", domainerrors.ErrFailedToOpenFile
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// createImageVariants は画像アセットの縮小版を生成して保存する
+func (uc *assetUseCase) createImageVariants(ctx context.Context, src io.ReadSeeker, assetID uuid.UUID) ([]*entity.AssetVariant, error) {
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
+		return nil, domainerrors.ErrFailedToOpenFile
+	}
+
+	images, err := uc.imageProcessor.GenerateVariants(ctx, src, entity.ImageVariantWidths)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate variants: %w", err)
+	}
+	if len(images) == 0 {
+		return nil, nil
+	}
+
+	variants := make([]*entity.AssetVariant, 0, len(images))
+	for _, img := range images {
+		fileName := fmt.Sprintf("%dw.%s", img.Width, img.Extension)
+		variantURL, err := uc.assetRepo.UploadVariant(ctx, assetID, fileName, img.Data, img.ContentType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to upload variant %s: %w", fileName, err)
+		}
+		variants = append(variants, entity.NewAssetVariant(assetID, img.Width, img.Height, img.ContentType, *variantURL))
+	}
+
+	if err := uc.assetRepo.CreateVariants(ctx, variants); err != nil {
+		return nil, fmt.Errorf("failed to create variants: %w", err)
+	}
+	return variants, nil
+}
+
+// validateFileType は拡張子とサイズから、受け付けられるファイルの形式かを検証する
+func validateFileType(fileName string, size int64) (*entity.FileType, error) {
+	fileType, ok := entity.LookupFileType(entity.FileExtension(fileName))
+	if !ok {
+		return nil, domainerrors.ErrUnsupportedFileType
+	}
+	if size > fileType.MaxSize {
+		return nil, domainerrors.ErrFileTooLarge
+	}
+	return fileType, nil
+}
+
+// validateFileContent はファイルの先頭バイトが拡張子の形式と一致するかを検証する
+func validateFileContent(r io.Reader, fileType *entity.FileType) error {
+	head := make([]byte, entity.FileSignatureLength)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return domainerrors.ErrFailedToOpenFile
+	}
+	if !fileType.MatchContent(head[:n]) {
+		return domainerrors.ErrFileTypeMismatch
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+)
+
+// analyzeAudio は音声アセットの再生時間や波形を読み取る。
+// 読み取れなくても音声は再生できるため、失敗した場合は nil を返す
+func (uc *assetUseCase) analyzeAudio(ctx context.Context, assetID uuid.UUID, extension string, src io.Reader) *entity.AudioMetadata {
+	metadata, err := uc.audioAnalyzer.AnalyzeAudio(ctx, src, extension)
+	if err != nil {
+		log.Printf("音声の読み取りに失敗しました (asset_id=%s): %v", assetID.String(), err)
+		return nil
+	}
+	return metadata
+}
+
+// analyzeUploadedAudio は直接アップロードされた音声の再生時間や波形を読み取る
+func (uc *assetUseCase) analyzeUploadedAudio(ctx context.Context, upload *entity.AssetUpload) *entity.AudioMetadata {
+	body, err := uc.assetRepo.OpenFile(ctx, upload.ID, upload.Extension)
+	if err != nil {
+		log.Printf("音声の読み取りに失敗しました (asset_id=%s): %v", upload.ID.String(), err)
+		return nil
+	}
+	defer body.Close()
+	return uc.analyzeAudio(ctx, upload.ID, upload.Extension, body)
+}
@@ -0,0 +1,139 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/usecase"
+	"github.com/simesaba80/toybox-back/internal/usecase/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAssetUseCase_UploadFile_Audio(t *testing.T) {
+	t.Parallel()
+
+	mp3Content := []byte("ID3\x04\x00\x00 mp3 content")
+	metadata := &entity.AudioMetadata{
+		Duration:   3 * time.Second,
+		SampleRate: 44100,
+		Bitrate:    128000,
+		Channels:   2,
+		Title:      "曲名",
+		Peaks:      []float64{0.5, 1, 0.25},
+	}
+
+	tests := []struct {
+		name         string
+		setup        func(t *testing.T, analyzer *mock.MockAudioAnalyzer)
+		wantMetadata *entity.AudioMetadata
+	}{
+		{
+			name: "正常系: 再生時間や波形と一緒にアセットを登録する",
+			setup: func(t *testing.T, analyzer *mock.MockAudioAnalyzer) {
+				// 保存したファイルも先頭から読み取る
+				analyzer.EXPECT().
+					AnalyzeAudio(gomock.Any(), gomock.Any(), "mp3").
+					DoAndReturn(func(ctx context.Context, src io.Reader, extension string) (*entity.AudioMetadata, error) {
+						data, err := io.ReadAll(src)
+						assert.NoError(t, err)
+						assert.Equal(t, mp3Content, data)
+						return metadata, nil
+					})
+			},
+			wantMetadata: metadata,
+		},
+		{
+			name: "正常系: 読み取れなくてもアセットは登録する",
+			setup: func(t *testing.T, analyzer *mock.MockAudioAnalyzer) {
+				analyzer.EXPECT().
+					AnalyzeAudio(gomock.Any(), gomock.Any(), "mp3").
+					Return(nil, fmt.Errorf("%w: no mpeg audio frames", domainerrors.ErrInvalidAudio))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockAssetRepository(ctrl)
+			mockAnalyzer := mock.NewMockAudioAnalyzer(ctrl)
+			mockRepo.EXPECT().
+				UploadFile(gomock.Any(), gomock.Any(), gomock.Any(), "mp3", "audio/mpeg", gomock.Any()).
+				DoAndReturn(func(ctx context.Context, body io.ReadSeeker, assetUUID uuid.UUID, extension string, contentType string, hash string) (*entity.UploadedFile, error) {
+					data, err := io.ReadAll(body)
+					assert.NoError(t, err)
+					return &entity.UploadedFile{URL: "https://example.com/music/origin.mp3", AssetType: entity.AssetTypeMusic, Size: int64(len(data))}, nil
+				})
+			tt.setup(t, mockAnalyzer)
+			mockRepo.EXPECT().
+				Create(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, asset *entity.Asset) (*entity.Asset, error) {
+					return asset, nil
+				})
+
+			uc := usecase.NewAssetUseCase(mockRepo, mock.NewMockAssetUploadRepository(ctrl), usecase.AssetProcessors{AudioAnalyzer: mockAnalyzer}, 15*time.Minute, 24*time.Hour, 0)
+
+			got, err := uc.UploadFile(context.Background(), newFileHeader(t, "song.mp3", mp3Content), uuid.New())
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantMetadata, got.AudioMetadata)
+		})
+	}
+}
+
+func TestAssetUseCase_CompleteUpload_Audio(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mp3Content := []byte("ID3\x04\x00\x00 mp3 content")
+	userID := uuid.New()
+	upload := entity.NewAssetUpload(userID, "mp3", "audio/mpeg", int64(len(mp3Content)), 15*time.Minute)
+	metadata := &entity.AudioMetadata{Duration: 3 * time.Second, SampleRate: 44100}
+
+	mockRepo := mock.NewMockAssetRepository(ctrl)
+	mockUploadRepo := mock.NewMockAssetUploadRepository(ctrl)
+	mockAnalyzer := mock.NewMockAudioAnalyzer(ctrl)
+	mockUploadRepo.EXPECT().GetByID(gomock.Any(), upload.ID).Return(upload, nil)
+	mockRepo.EXPECT().
+		HeadFile(gomock.Any(), upload.ID, "mp3").
+		Return(&entity.StoredObject{Size: upload.Size, ContentType: "audio/mpeg"}, nil)
+	// 先頭バイトの検証と、音声の読み取りと、ハッシュの計算で 3 回開く
+	mockRepo.EXPECT().
+		OpenFile(gomock.Any(), upload.ID, "mp3").
+		DoAndReturn(func(ctx context.Context, assetUUID uuid.UUID, extension string) (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(mp3Content)), nil
+		}).
+		Times(3)
+	mockRepo.EXPECT().FileURL(upload.ID, "mp3").Return("https://example.com/music/origin.mp3", entity.AssetTypeMusic)
+	mockRepo.EXPECT().
+		RegisterBlob(gomock.Any(), upload.ID, "mp3", gomock.Any(), upload.Size).
+		Return(&entity.UploadedFile{URL: "https://example.com/music/origin.mp3", AssetType: entity.AssetTypeMusic, Size: upload.Size}, nil)
+	mockAnalyzer.EXPECT().AnalyzeAudio(gomock.Any(), gomock.Any(), "mp3").Return(metadata, nil)
+	mockRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, asset *entity.Asset) (*entity.Asset, error) {
+			assert.Equal(t, metadata, asset.AudioMetadata)
+			return asset, nil
+		})
+	mockUploadRepo.EXPECT().Delete(gomock.Any(), upload.ID).Return(nil)
+
+	uc := usecase.NewAssetUseCase(mockRepo, mockUploadRepo, usecase.AssetProcessors{AudioAnalyzer: mockAnalyzer}, 15*time.Minute, 24*time.Hour, 0)
+
+	got, err := uc.CompleteUpload(context.Background(), userID, upload.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, metadata, got.AudioMetadata)
+}
@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	"github.com/simesaba80/toybox-back/internal/domain/repository"
+)
+
+type IAssetGCUsecase interface {
+	// CollectOrphans は olderThan より前から作品に使われていないアセットと、対応するアセットのないファイルを削除します。
+	// dryRun の場合は削除せずに対象だけを報告します
+	CollectOrphans(ctx context.Context, olderThan time.Duration, dryRun bool) (*entity.OrphanReport, error)
+}
+
+type assetGCUsecase struct {
+	assetRepo       repository.AssetRepository
+	assetUploadRepo repository.AssetUploadRepository
+}
+
+func NewAssetGCUsecase(assetRepo repository.AssetRepository, assetUploadRepo repository.AssetUploadRepository) IAssetGCUsecase {
+	return &assetGCUsecase{
+		assetRepo:       assetRepo,
+		assetUploadRepo: assetUploadRepo,
+	}
+}
+
+func (uc *assetGCUsecase) CollectOrphans(ctx context.Context, olderThan time.Duration, dryRun bool) (*entity.OrphanReport, error) {
+	cutoff := time.Now().Add(-olderThan)
+	report := &entity.OrphanReport{DryRun: dryRun}
+
+	orphans, err := uc.assetRepo.ListOrphans(ctx, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orphan assets: %w", err)
+	}
+	// dry-run では行が残るため、削除の対象になったアセットはファイルの確認でも存在しないものとして扱う
+	removedIDs := make(map[uuid.UUID]bool, len(orphans))
+	if dryRun {
+		report.Assets = orphans
+		for _, orphan := range orphans {
+			removedIDs[orphan.ID] = true
+		}
+	} else {
+		ids := make([]uuid.UUID, len(orphans))
+		for i, orphan := range orphans {
+			ids[i] = orphan.ID
+		}
+		deletedIDs, err := uc.assetRepo.DeleteOrphans(ctx, ids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete orphan assets: %w", err)
+		}
+		deleted := make(map[uuid.UUID]bool, len(deletedIDs))
+		for _, id := range deletedIDs {
+			deleted[id] = true
+		}
+		for _, orphan := range orphans {
+			if deleted[orphan.ID] {
+				report.Assets = append(report.Assets, orphan)
+			}
+		}
+	}
+
+	err = uc.assetRepo.WalkFiles(ctx, func(files []*entity.StoredFile) error {
+		orphanFiles, err := uc.findOrphanFiles(ctx, files, cutoff, removedIDs)
+		if err != nil {
+			return err
+		}
+		if len(orphanFiles) == 0 {
+			return nil
+		}
+		if !dryRun {
+			keys := make([]string, len(orphanFiles))
+			for i, file := range orphanFiles {
+				keys[i] = file.Key
+			}
+			if err := uc.assetRepo.DeleteFiles(ctx, keys); err != nil {
+				return fmt.Errorf("failed to delete orphan files: %w", err)
+			}
+		}
+		report.Files = append(report.Files, orphanFiles...)
+		return nil
+	})
+	if err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+// findOrphanFiles はアセットの行もアップロード中の記録もなく、他のアセットからも参照されていないファイルを返す。
+// 保存してから行を作るまでの間のファイルを消さないよう、cutoff より新しいファイルは対象にしない
+func (uc *assetGCUsecase) findOrphanFiles(ctx context.Context, files []*entity.StoredFile, cutoff time.Time, removedIDs map[uuid.UUID]bool) ([]*entity.StoredFile, error) {
+	candidates := make([]*entity.StoredFile, 0, len(files))
+	seen := make(map[uuid.UUID]bool)
+	var ids []uuid.UUID
+	for _, file := range files {
+		if file.AssetID == uuid.Nil || !file.LastModified.Before(cutoff) {
+			continue
+		}
+		candidates = append(candidates, file)
+		if !seen[file.AssetID] {
+			seen[file.AssetID] = true
+			ids = append(ids, file.AssetID)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	existingAssets, err := uc.assetRepo.FindExistingIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find assets: %w", err)
+	}
+	existingUploads, err := uc.assetUploadRepo.FindExistingIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find asset uploads: %w", err)
+	}
+
+	var orphanFiles []*entity.StoredFile
+	var keys []string
+	for _, file := range candidates {
+		if existingUploads[file.AssetID] {
+			continue
+		}
+		if existingAssets[file.AssetID] && !removedIDs[file.AssetID] {
+			continue
+		}
+		orphanFiles = append(orphanFiles, file)
+		keys = append(keys, file.Key)
+	}
+	if len(orphanFiles) == 0 {
+		return nil, nil
+	}
+
+	// 同じ内容のファイルとして他のアセットから参照されているファイルは、保存したアセットがなくても残す
+	referenced, err := uc.assetRepo.FindBlobKeys(ctx, keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find blob keys: %w", err)
+	}
+	unreferenced := orphanFiles[:0]
+	for _, file := range orphanFiles {
+		if !referenced[file.Key] {
+			unreferenced = append(unreferenced, file)
+		}
+	}
+	return unreferenced, nil
+}
@@ -0,0 +1,127 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	"github.com/simesaba80/toybox-back/internal/usecase"
+	"github.com/simesaba80/toybox-back/internal/usecase/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAssetGCUsecase_CollectOrphans(t *testing.T) {
+	t.Parallel()
+
+	old := time.Now().Add(-48 * time.Hour)
+	orphan := &entity.Asset{ID: uuid.New(), Extension: "png", CreatedAt: old}
+	attachedMeanwhile := &entity.Asset{ID: uuid.New(), Extension: "png", CreatedAt: old}
+	liveAssetID := uuid.New()
+	uploadingID := uuid.New()
+	strayID := uuid.New()
+
+	files := []*entity.StoredFile{
+		{Key: "dir/image/" + orphan.ID.String() + "/origin.png", AssetID: orphan.ID, Size: 100, LastModified: old},
+		{Key: "dir/image/" + orphan.ID.String() + "/320w.jpg", AssetID: orphan.ID, Size: 10, LastModified: old},
+		{Key: "dir/image/" + attachedMeanwhile.ID.String() + "/origin.png", AssetID: attachedMeanwhile.ID, Size: 100, LastModified: old},
+		{Key: "dir/video/" + liveAssetID.String() + "/origin.mp4", AssetID: liveAssetID, Size: 100, LastModified: old},
+		{Key: "dir/zip/" + uploadingID.String() + "/origin.zip", AssetID: uploadingID, Size: 100, LastModified: old},
+		{Key: "dir/zip/" + strayID.String() + "/origin.zip", AssetID: strayID, Size: 1000, LastModified: old},
+		// 保存した直後のファイルとアセット以外のファイルは対象にしない
+		{Key: "dir/zip/" + uuid.New().String() + "/origin.zip", AssetID: uuid.New(), Size: 1, LastModified: time.Now()},
+		{Key: "dir/avatar/hash.webp", AssetID: uuid.Nil, Size: 1, LastModified: old},
+	}
+
+	setup := func(repo *mock.MockAssetRepository, uploadRepo *mock.MockAssetUploadRepository) {
+		repo.EXPECT().
+			ListOrphans(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, createdBefore time.Time) ([]*entity.Asset, error) {
+				assert.WithinDuration(t, time.Now().Add(-24*time.Hour), createdBefore, time.Minute)
+				return []*entity.Asset{orphan, attachedMeanwhile}, nil
+			})
+		repo.EXPECT().
+			WalkFiles(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func([]*entity.StoredFile) error) error {
+				return fn(files)
+			})
+		repo.EXPECT().
+			FindExistingIDs(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]bool, error) {
+				assert.Len(t, ids, 5)
+				return map[uuid.UUID]bool{orphan.ID: true, attachedMeanwhile.ID: true, liveAssetID: true}, nil
+			})
+		uploadRepo.EXPECT().
+			FindExistingIDs(gomock.Any(), gomock.Any()).
+			Return(map[uuid.UUID]bool{uploadingID: true}, nil)
+		repo.EXPECT().
+			FindBlobKeys(gomock.Any(), []string{files[0].Key, files[1].Key, files[2].Key, files[5].Key}).
+			Return(map[string]bool{}, nil)
+	}
+
+	t.Run("dry-runでは削除せずに対象を報告する", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mock.NewMockAssetRepository(ctrl)
+		mockUploadRepo := mock.NewMockAssetUploadRepository(ctrl)
+		setup(mockRepo, mockUploadRepo)
+		mockRepo.EXPECT().DeleteOrphans(gomock.Any(), gomock.Any()).Times(0)
+		mockRepo.EXPECT().DeleteFiles(gomock.Any(), gomock.Any()).Times(0)
+
+		uc := usecase.NewAssetGCUsecase(mockRepo, mockUploadRepo)
+
+		report, err := uc.CollectOrphans(context.Background(), 24*time.Hour, true)
+		assert.NoError(t, err)
+		assert.True(t, report.DryRun)
+		assert.Equal(t, []*entity.Asset{orphan, attachedMeanwhile}, report.Assets)
+		assert.Equal(t, []*entity.StoredFile{files[0], files[1], files[2], files[5]}, report.Files)
+		assert.Equal(t, int64(1210), report.FileBytes())
+	})
+
+	t.Run("削除までに作品に使われたアセットと参照されているファイルは残す", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockRepo := mock.NewMockAssetRepository(ctrl)
+		mockUploadRepo := mock.NewMockAssetUploadRepository(ctrl)
+		mockRepo.EXPECT().
+			ListOrphans(gomock.Any(), gomock.Any()).
+			Return([]*entity.Asset{orphan, attachedMeanwhile}, nil)
+		mockRepo.EXPECT().
+			DeleteOrphans(gomock.Any(), []uuid.UUID{orphan.ID, attachedMeanwhile.ID}).
+			Return([]uuid.UUID{orphan.ID}, nil)
+		mockRepo.EXPECT().
+			WalkFiles(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func([]*entity.StoredFile) error) error {
+				return fn(files)
+			})
+		mockRepo.EXPECT().
+			FindExistingIDs(gomock.Any(), gomock.Any()).
+			Return(map[uuid.UUID]bool{attachedMeanwhile.ID: true, liveAssetID: true}, nil)
+		mockUploadRepo.EXPECT().
+			FindExistingIDs(gomock.Any(), gomock.Any()).
+			Return(map[uuid.UUID]bool{uploadingID: true}, nil)
+		// 他のアセットが同じ内容のファイルとして参照しているファイルは消さない
+		mockRepo.EXPECT().
+			FindBlobKeys(gomock.Any(), []string{files[0].Key, files[1].Key, files[5].Key}).
+			Return(map[string]bool{files[5].Key: true}, nil)
+		mockRepo.EXPECT().
+			DeleteFiles(gomock.Any(), []string{files[0].Key, files[1].Key}).
+			Return(nil)
+
+		uc := usecase.NewAssetGCUsecase(mockRepo, mockUploadRepo)
+
+		report, err := uc.CollectOrphans(context.Background(), 24*time.Hour, false)
+		assert.NoError(t, err)
+		assert.False(t, report.DryRun)
+		assert.Equal(t, []*entity.Asset{orphan}, report.Assets)
+		assert.Equal(t, []*entity.StoredFile{files[0], files[1]}, report.Files)
+	})
+}
@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+)
+
+// modelExtensions は構造を検査してから保存する 3D モデルの拡張子
+var modelExtensions = map[string]bool{
+	"gltf": true,
+	"glb":  true,
+}
+
+// inspectModel は 3D モデルの構造を検査して頂点数などを読み取る。読み終えたら src を先頭に戻す
+func (uc *assetUseCase) inspectModel(ctx context.Context, extension string, src io.ReadSeeker) (*entity.ModelStats, error) {
+	stats, err := uc.modelInspector.InspectModel(ctx, src, extension)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect model: %w", err)
+	}
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
+		return nil, domainerrors.ErrFailedToOpenFile
+	}
+	return stats, nil
+}
+
+// inspectUploadedModel は直接アップロードされた 3D モデルの構造を検査して頂点数などを読み取る
+func (uc *assetUseCase) inspectUploadedModel(ctx context.Context, assetID uuid.UUID, extension string) (*entity.ModelStats, error) {
+	body, err := uc.assetRepo.OpenFile(ctx, assetID, extension)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	stats, err := uc.modelInspector.InspectModel(ctx, body, extension)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect model: %w", err)
+	}
+	return stats, nil
+}
+
+// isRejectedModel は 3D モデルとして受け付けられないことを表すエラーかどうかを返す
+func isRejectedModel(err error) bool {
+	return errors.Is(err, domainerrors.ErrInvalidModel) ||
+		errors.Is(err, domainerrors.ErrExternalModelBuffer)
+}
@@ -0,0 +1,138 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/usecase"
+	"github.com/simesaba80/toybox-back/internal/usecase/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAssetUseCase_UploadFile_Model(t *testing.T) {
+	t.Parallel()
+
+	glbContent := []byte("glTF\x02\x00\x00\x00 glb content")
+	stats := &entity.ModelStats{
+		VertexCount:   24,
+		TriangleCount: 12,
+		Materials:     []string{"木"},
+		BoundingBox:   &entity.BoundingBox{Min: [3]float64{-1, -1, -1}, Max: [3]float64{1, 1, 1}},
+	}
+
+	tests := []struct {
+		name      string
+		setup     func(t *testing.T, repo *mock.MockAssetRepository, inspector *mock.MockModelInspector)
+		wantErr   error
+		wantStats *entity.ModelStats
+	}{
+		{
+			name: "正常系: 頂点数やマテリアルと一緒にアセットを登録する",
+			setup: func(t *testing.T, repo *mock.MockAssetRepository, inspector *mock.MockModelInspector) {
+				inspector.EXPECT().
+					InspectModel(gomock.Any(), gomock.Any(), "glb").
+					DoAndReturn(func(ctx context.Context, src io.Reader, extension string) (*entity.ModelStats, error) {
+						_, err := io.ReadAll(src)
+						assert.NoError(t, err)
+						return stats, nil
+					})
+				// 検査で読み終えたファイルも先頭から保存する
+				repo.EXPECT().
+					UploadFile(gomock.Any(), gomock.Any(), gomock.Any(), "glb", "model/gltf-binary", gomock.Any()).
+					DoAndReturn(func(ctx context.Context, body io.ReadSeeker, assetUUID uuid.UUID, extension string, contentType string, hash string) (*entity.UploadedFile, error) {
+						data, err := io.ReadAll(body)
+						assert.NoError(t, err)
+						assert.Equal(t, glbContent, data)
+						return &entity.UploadedFile{URL: "https://example.com/model/origin.glb", AssetType: "model", Size: int64(len(data))}, nil
+					})
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, asset *entity.Asset) (*entity.Asset, error) {
+						return asset, nil
+					})
+			},
+			wantStats: stats,
+		},
+		{
+			name: "異常系: 読み込めないモデルは保存しない",
+			setup: func(t *testing.T, repo *mock.MockAssetRepository, inspector *mock.MockModelInspector) {
+				inspector.EXPECT().
+					InspectModel(gomock.Any(), gomock.Any(), "glb").
+					Return(nil, fmt.Errorf("%w: glb is truncated", domainerrors.ErrInvalidModel))
+				repo.EXPECT().UploadFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domainerrors.ErrInvalidModel,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockAssetRepository(ctrl)
+			mockInspector := mock.NewMockModelInspector(ctrl)
+			tt.setup(t, mockRepo, mockInspector)
+
+			uc := usecase.NewAssetUseCase(mockRepo, mock.NewMockAssetUploadRepository(ctrl), usecase.AssetProcessors{ModelInspector: mockInspector}, 15*time.Minute, 24*time.Hour, 0)
+
+			got, err := uc.UploadFile(context.Background(), newFileHeader(t, "scene.glb", glbContent), uuid.New())
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStats, got.ModelStats)
+		})
+	}
+}
+
+func TestAssetUseCase_CompleteUpload_ExternalModelBuffer(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gltfContent := []byte(`{"asset":{"version":"2.0"},"buffers":[{"uri":"scene.bin","byteLength":12}]}`)
+	userID := uuid.New()
+	upload := entity.NewAssetUpload(userID, "gltf", "model/gltf+json", int64(len(gltfContent)), 15*time.Minute)
+
+	mockRepo := mock.NewMockAssetRepository(ctrl)
+	mockUploadRepo := mock.NewMockAssetUploadRepository(ctrl)
+	mockInspector := mock.NewMockModelInspector(ctrl)
+	mockUploadRepo.EXPECT().GetByID(gomock.Any(), upload.ID).Return(upload, nil)
+	mockRepo.EXPECT().
+		HeadFile(gomock.Any(), upload.ID, "gltf").
+		Return(&entity.StoredObject{Size: upload.Size, ContentType: "model/gltf+json"}, nil)
+	mockRepo.EXPECT().
+		OpenFile(gomock.Any(), upload.ID, "gltf").
+		DoAndReturn(func(ctx context.Context, assetUUID uuid.UUID, extension string) (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(gltfContent)), nil
+		}).
+		Times(2)
+	mockInspector.EXPECT().
+		InspectModel(gomock.Any(), gomock.Any(), "gltf").
+		Return(nil, fmt.Errorf("%w: \"scene.bin\"", domainerrors.ErrExternalModelBuffer))
+	// 参照先のないモデルは表示できず、残しておく理由がないため消す
+	mockRepo.EXPECT().DeleteFile(gomock.Any(), upload.ID, "gltf").Return(nil)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+
+	uc := usecase.NewAssetUseCase(mockRepo, mockUploadRepo, usecase.AssetProcessors{ModelInspector: mockInspector}, 15*time.Minute, 24*time.Hour, 0)
+
+	got, err := uc.CompleteUpload(context.Background(), userID, upload.ID)
+	assert.ErrorIs(t, err, domainerrors.ErrExternalModelBuffer)
+	assert.Nil(t, got)
+}
@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+)
+
+// expiredUploadBatchSize は期限切れのアップロードを一度に片付ける数
+const expiredUploadBatchSize = 100
+
+func (uc *assetUseCase) CreateMultipartUpload(ctx context.Context, userID uuid.UUID, fileName string, size int64) (*entity.AssetUpload, error) {
+	fileType, err := validateFileType(fileName, size)
+	if err != nil {
+		return nil, err
+	}
+
+	upload := entity.NewMultipartAssetUpload(userID, fileType.Extension, fileType.ContentType, size, uc.multipartTTL)
+	multipartUploadID, err := uc.assetRepo.CreateMultipartUpload(ctx, upload.ID, upload.Extension, upload.ContentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create multipart upload: %w", err)
+	}
+	upload.MultipartUploadID = multipartUploadID
+
+	if err := uc.reserveStorage(ctx, upload); err != nil {
+		// 記録できなかったアップロードは誰も完了できないため、その場で中止する
+		if abortErr := uc.assetRepo.AbortMultipartUpload(ctx, upload.ID, upload.Extension, multipartUploadID); abortErr != nil {
+			log.Printf("マルチパートアップロードの中止に失敗しました (upload_id=%s): %v", upload.ID.String(), abortErr)
+		}
+		return nil, fmt.Errorf("failed to create asset upload: %w", err)
+	}
+	return upload, nil
+}
+
+func (uc *assetUseCase) PresignUploadParts(ctx context.Context, userID uuid.UUID, uploadID uuid.UUID, partNumbers []int32) ([]*entity.PresignedPart, time.Time, error) {
+	upload, err := uc.getUpload(ctx, userID, uploadID, true)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	now := time.Now()
+	if upload.IsExpired(now) {
+		return nil, time.Time{}, domainerrors.ErrAssetUploadExpired
+	}
+
+	parts := make([]*entity.PresignedPart, 0, len(partNumbers))
+	for _, partNumber := range partNumbers {
+		size, ok := upload.PartLength(partNumber)
+		if !ok {
+			return nil, time.Time{}, domainerrors.ErrInvalidPartNumber
+		}
+		uploadURL, err := uc.assetRepo.PresignUploadPart(ctx, upload.ID, upload.Extension, upload.MultipartUploadID, partNumber, size, uc.uploadURLTTL)
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("failed to presign upload part: %w", err)
+		}
+		parts = append(parts, &entity.PresignedPart{
+			PartNumber: partNumber,
+			Size:       size,
+			URL:        uploadURL,
+		})
+	}
+	return parts, now.Add(uc.uploadURLTTL), nil
+}
+
+func (uc *assetUseCase) ListUploadedParts(ctx context.Context, userID uuid.UUID, uploadID uuid.UUID) (*entity.AssetUpload, []*entity.UploadedPart, error) {
+	upload, err := uc.getUpload(ctx, userID, uploadID, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	parts, err := uc.assetRepo.ListUploadedParts(ctx, upload.ID, upload.Extension, upload.MultipartUploadID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return upload, parts, nil
+}
+
+func (uc *assetUseCase) CompleteMultipartUpload(ctx context.Context, userID uuid.UUID, uploadID uuid.UUID) (*entity.Asset, error) {
+	upload, err := uc.getUpload(ctx, userID, uploadID, true)
+	if err != nil {
+		return nil, err
+	}
+	if upload.IsExpired(time.Now()) {
+		return nil, domainerrors.ErrAssetUploadExpired
+	}
+
+	parts, err := uc.assetRepo.ListUploadedParts(ctx, upload.ID, upload.Extension, upload.MultipartUploadID)
+	switch {
+	case errors.Is(err, domainerrors.ErrUploadedFileNotFound):
+		// 前回の完了処理でパートの結合までは済んでいる場合があるため、結合後のファイルの検証に進む
+	case err != nil:
+		return nil, err
+	default:
+		if err := verifyUploadedParts(upload, parts); err != nil {
+			return nil, err
+		}
+		if err := uc.assetRepo.CompleteMultipartUpload(ctx, upload.ID, upload.Extension, upload.MultipartUploadID, parts); err != nil {
+			return nil, fmt.Errorf("failed to complete multipart upload: %w", err)
+		}
+	}
+	return uc.registerUploadedAsset(ctx, upload)
+}
+
+// verifyUploadedParts は全てのパートが申告どおりの大きさでアップロードされているかを確かめる
+func verifyUploadedParts(upload *entity.AssetUpload, parts []*entity.UploadedPart) error {
+	if len(parts) != int(upload.PartCount()) {
+		return domainerrors.ErrIncompleteMultipartUpload
+	}
+	for i, part := range parts {
+		if part.PartNumber != int32(i+1) {
+			return domainerrors.ErrIncompleteMultipartUpload
+		}
+		if size, _ := upload.PartLength(part.PartNumber); part.Size != size {
+			return domainerrors.ErrUploadedFileMismatch
+		}
+	}
+	return nil
+}
+
+func (uc *assetUseCase) AbortMultipartUpload(ctx context.Context, userID uuid.UUID, uploadID uuid.UUID) error {
+	upload, err := uc.getUpload(ctx, userID, uploadID, true)
+	if err != nil {
+		return err
+	}
+	if err := uc.assetRepo.AbortMultipartUpload(ctx, upload.ID, upload.Extension, upload.MultipartUploadID); err != nil {
+		return fmt.Errorf("failed to abort multipart upload: %w", err)
+	}
+	return uc.assetUploadRepo.Delete(ctx, upload.ID)
+}
+
+func (uc *assetUseCase) AbortExpiredUploads(ctx context.Context) (int, error) {
+	cleaned := 0
+	for {
+		uploads, err := uc.assetUploadRepo.ListExpired(ctx, time.Now(), expiredUploadBatchSize)
+		if err != nil {
+			return cleaned, err
+		}
+
+		cleanedInBatch := 0
+		for _, upload := range uploads {
+			if err := uc.cleanupExpiredUpload(ctx, upload); err != nil {
+				log.Printf("期限切れのアップロードの片付けに失敗しました (upload_id=%s): %v", upload.ID.String(), err)
+				continue
+			}
+			cleanedInBatch++
+		}
+		cleaned += cleanedInBatch
+
+		// 失敗したものは次回に回し、同じアップロードを繰り返し処理しないようにする
+		if len(uploads) < expiredUploadBatchSize || cleanedInBatch == 0 {
+			return cleaned, nil
+		}
+	}
+}
+
+func (uc *assetUseCase) cleanupExpiredUpload(ctx context.Context, upload *entity.AssetUpload) error {
+	// 署名付き URL で置かれたファイルは、完了済みのアセットの元ファイルである可能性もあるためここでは消さない
+	if upload.IsMultipart() {
+		if err := uc.assetRepo.AbortMultipartUpload(ctx, upload.ID, upload.Extension, upload.MultipartUploadID); err != nil {
+			return err
+		}
+	}
+	return uc.assetUploadRepo.Delete(ctx, upload.ID)
+}
@@ -0,0 +1,369 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/usecase"
+	"github.com/simesaba80/toybox-back/internal/usecase/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func newMultipartUpload(userID uuid.UUID, size int64) *entity.AssetUpload {
+	upload := entity.NewMultipartAssetUpload(userID, "zip", "application/zip", size, 24*time.Hour)
+	upload.MultipartUploadID = "multipart-upload-id"
+	return upload
+}
+
+func TestAssetUseCase_CreateMultipartUpload(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+
+	tests := []struct {
+		name         string
+		fileName     string
+		size         int64
+		setup        func(repo *mock.MockAssetRepository, uploadRepo *mock.MockAssetUploadRepository)
+		wantPartSize int64
+		wantParts    int32
+		wantErr      error
+	}{
+		{
+			name:     "正常系: 既定のパートの大きさで分割する",
+			fileName: "game.zip",
+			size:     300<<20 + 1,
+			setup: func(repo *mock.MockAssetRepository, uploadRepo *mock.MockAssetUploadRepository) {
+				repo.EXPECT().
+					CreateMultipartUpload(gomock.Any(), gomock.Any(), "zip", "application/zip").
+					Return("multipart-upload-id", nil)
+				uploadRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, upload *entity.AssetUpload) error {
+						assert.Equal(t, "multipart-upload-id", upload.MultipartUploadID)
+						return nil
+					})
+			},
+			wantPartSize: 16 << 20,
+			wantParts:    19,
+		},
+		{
+			name:     "異常系: 対応していない拡張子",
+			fileName: "game.exe",
+			size:     10,
+			setup:    func(repo *mock.MockAssetRepository, uploadRepo *mock.MockAssetUploadRepository) {},
+			wantErr:  domainerrors.ErrUnsupportedFileType,
+		},
+		{
+			name:     "異常系: 記録に失敗した場合はマルチパートアップロードを中止する",
+			fileName: "game.zip",
+			size:     10,
+			setup: func(repo *mock.MockAssetRepository, uploadRepo *mock.MockAssetUploadRepository) {
+				repo.EXPECT().
+					CreateMultipartUpload(gomock.Any(), gomock.Any(), "zip", "application/zip").
+					Return("multipart-upload-id", nil)
+				uploadRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(domainerrors.ErrFailedToCreateAssetUpload)
+				repo.EXPECT().AbortMultipartUpload(gomock.Any(), gomock.Any(), "zip", "multipart-upload-id").Return(nil)
+			},
+			wantErr: domainerrors.ErrFailedToCreateAssetUpload,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockAssetRepository(ctrl)
+			mockUploadRepo := mock.NewMockAssetUploadRepository(ctrl)
+			tt.setup(mockRepo, mockUploadRepo)
+
+			uc := usecase.NewAssetUseCase(mockRepo, mockUploadRepo, usecase.AssetProcessors{}, 15*time.Minute, 24*time.Hour, 0)
+
+			upload, err := uc.CreateMultipartUpload(context.Background(), userID, tt.fileName, tt.size)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, upload)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantPartSize, upload.PartSize)
+			assert.Equal(t, tt.wantParts, upload.PartCount())
+			assert.WithinDuration(t, time.Now().Add(24*time.Hour), upload.ExpiresAt, time.Minute)
+		})
+	}
+}
+
+func TestAssetUseCase_PresignUploadParts(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+
+	tests := []struct {
+		name        string
+		upload      *entity.AssetUpload
+		partNumbers []int32
+		setup       func(upload *entity.AssetUpload, repo *mock.MockAssetRepository)
+		wantSizes   []int64
+		wantErr     error
+	}{
+		{
+			name:        "正常系: 最後のパートは残りの大きさで署名する",
+			upload:      newMultipartUpload(userID, 40<<20),
+			partNumbers: []int32{1, 3},
+			setup: func(upload *entity.AssetUpload, repo *mock.MockAssetRepository) {
+				repo.EXPECT().
+					PresignUploadPart(gomock.Any(), upload.ID, "zip", "multipart-upload-id", int32(1), int64(16<<20), 15*time.Minute).
+					Return("https://s3.example.com/part1", nil)
+				repo.EXPECT().
+					PresignUploadPart(gomock.Any(), upload.ID, "zip", "multipart-upload-id", int32(3), int64(8<<20), 15*time.Minute).
+					Return("https://s3.example.com/part3", nil)
+			},
+			wantSizes: []int64{16 << 20, 8 << 20},
+		},
+		{
+			name:        "異常系: パート番号が範囲外",
+			upload:      newMultipartUpload(userID, 40<<20),
+			partNumbers: []int32{4},
+			setup:       func(upload *entity.AssetUpload, repo *mock.MockAssetRepository) {},
+			wantErr:     domainerrors.ErrInvalidPartNumber,
+		},
+		{
+			name:        "異常系: 署名付きURLで一度に送るアップロードは対象外",
+			upload:      entity.NewAssetUpload(userID, "zip", "application/zip", 10, 15*time.Minute),
+			partNumbers: []int32{1},
+			setup:       func(upload *entity.AssetUpload, repo *mock.MockAssetRepository) {},
+			wantErr:     domainerrors.ErrAssetUploadNotFound,
+		},
+		{
+			name: "異常系: 有効期限切れ",
+			upload: func() *entity.AssetUpload {
+				upload := newMultipartUpload(userID, 10)
+				upload.ExpiresAt = time.Now().Add(-time.Minute)
+				return upload
+			}(),
+			partNumbers: []int32{1},
+			setup:       func(upload *entity.AssetUpload, repo *mock.MockAssetRepository) {},
+			wantErr:     domainerrors.ErrAssetUploadExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockAssetRepository(ctrl)
+			mockUploadRepo := mock.NewMockAssetUploadRepository(ctrl)
+			mockUploadRepo.EXPECT().GetByID(gomock.Any(), tt.upload.ID).Return(tt.upload, nil)
+			tt.setup(tt.upload, mockRepo)
+
+			uc := usecase.NewAssetUseCase(mockRepo, mockUploadRepo, usecase.AssetProcessors{}, 15*time.Minute, 24*time.Hour, 0)
+
+			parts, expiresAt, err := uc.PresignUploadParts(context.Background(), userID, tt.upload.ID, tt.partNumbers)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, parts)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, parts, len(tt.wantSizes))
+			for i, part := range parts {
+				assert.Equal(t, tt.partNumbers[i], part.PartNumber)
+				assert.Equal(t, tt.wantSizes[i], part.Size)
+			}
+			assert.WithinDuration(t, time.Now().Add(15*time.Minute), expiresAt, time.Minute)
+		})
+	}
+}
+
+func TestAssetUseCase_CompleteMultipartUpload(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	zipHeader := []byte("PK\x03\x04")
+
+	manifest := &entity.ZipManifest{Entries: []*entity.ZipEntry{{Path: "README.md", Size: 10}}, TotalSize: 10}
+
+	expectRegister := func(t *testing.T, upload *entity.AssetUpload, repo *mock.MockAssetRepository, uploadRepo *mock.MockAssetUploadRepository, inspector *mock.MockArchiveInspector) {
+		repo.EXPECT().
+			HeadFile(gomock.Any(), upload.ID, "zip").
+			Return(&entity.StoredObject{Size: upload.Size, ContentType: "application/zip"}, nil)
+		repo.EXPECT().
+			OpenFile(gomock.Any(), upload.ID, "zip").
+			DoAndReturn(func(ctx context.Context, assetUUID uuid.UUID, extension string) (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(zipHeader)), nil
+			}).
+			Times(3)
+		inspector.EXPECT().InspectZip(gomock.Any(), gomock.Any()).Return(manifest, nil)
+		repo.EXPECT().
+			FileURL(upload.ID, "zip").
+			Return("https://example.com/zip/origin.zip", "zip")
+		repo.EXPECT().
+			RegisterBlob(gomock.Any(), upload.ID, "zip", gomock.Any(), upload.Size).
+			Return(&entity.UploadedFile{URL: "https://example.com/zip/origin.zip", AssetType: "zip", Size: upload.Size}, nil)
+		repo.EXPECT().
+			Create(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, asset *entity.Asset) (*entity.Asset, error) {
+				assert.Equal(t, upload.ID, asset.ID)
+				assert.Equal(t, "zip", asset.AssetType)
+				assert.Equal(t, manifest, asset.ZipManifest)
+				return asset, nil
+			})
+		uploadRepo.EXPECT().Delete(gomock.Any(), upload.ID).Return(nil)
+	}
+
+	tests := []struct {
+		name    string
+		upload  *entity.AssetUpload
+		setup   func(t *testing.T, upload *entity.AssetUpload, repo *mock.MockAssetRepository, uploadRepo *mock.MockAssetUploadRepository, inspector *mock.MockArchiveInspector)
+		wantErr error
+	}{
+		{
+			name:   "正常系: パートを結合してアセットを登録する",
+			upload: newMultipartUpload(userID, 20<<20),
+			setup: func(t *testing.T, upload *entity.AssetUpload, repo *mock.MockAssetRepository, uploadRepo *mock.MockAssetUploadRepository, inspector *mock.MockArchiveInspector) {
+				parts := []*entity.UploadedPart{
+					{PartNumber: 1, ETag: `"etag1"`, Size: 16 << 20},
+					{PartNumber: 2, ETag: `"etag2"`, Size: 4 << 20},
+				}
+				repo.EXPECT().ListUploadedParts(gomock.Any(), upload.ID, "zip", "multipart-upload-id").Return(parts, nil)
+				repo.EXPECT().CompleteMultipartUpload(gomock.Any(), upload.ID, "zip", "multipart-upload-id", parts).Return(nil)
+				expectRegister(t, upload, repo, uploadRepo, inspector)
+			},
+		},
+		{
+			name:   "正常系: 結合済みの場合はそのまま検証して登録する",
+			upload: newMultipartUpload(userID, 20<<20),
+			setup: func(t *testing.T, upload *entity.AssetUpload, repo *mock.MockAssetRepository, uploadRepo *mock.MockAssetUploadRepository, inspector *mock.MockArchiveInspector) {
+				repo.EXPECT().
+					ListUploadedParts(gomock.Any(), upload.ID, "zip", "multipart-upload-id").
+					Return(nil, domainerrors.ErrUploadedFileNotFound)
+				repo.EXPECT().CompleteMultipartUpload(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				expectRegister(t, upload, repo, uploadRepo, inspector)
+			},
+		},
+		{
+			name:   "異常系: アップロードされていないパートがある",
+			upload: newMultipartUpload(userID, 40<<20),
+			setup: func(t *testing.T, upload *entity.AssetUpload, repo *mock.MockAssetRepository, uploadRepo *mock.MockAssetUploadRepository, inspector *mock.MockArchiveInspector) {
+				repo.EXPECT().
+					ListUploadedParts(gomock.Any(), upload.ID, "zip", "multipart-upload-id").
+					Return([]*entity.UploadedPart{
+						{PartNumber: 1, ETag: `"etag1"`, Size: 16 << 20},
+						{PartNumber: 3, ETag: `"etag3"`, Size: 8 << 20},
+					}, nil)
+				repo.EXPECT().CompleteMultipartUpload(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domainerrors.ErrIncompleteMultipartUpload,
+		},
+		{
+			name:   "異常系: パートの大きさが申告と異なる",
+			upload: newMultipartUpload(userID, 20<<20),
+			setup: func(t *testing.T, upload *entity.AssetUpload, repo *mock.MockAssetRepository, uploadRepo *mock.MockAssetUploadRepository, inspector *mock.MockArchiveInspector) {
+				repo.EXPECT().
+					ListUploadedParts(gomock.Any(), upload.ID, "zip", "multipart-upload-id").
+					Return([]*entity.UploadedPart{
+						{PartNumber: 1, ETag: `"etag1"`, Size: 16 << 20},
+						{PartNumber: 2, ETag: `"etag2"`, Size: 5 << 20},
+					}, nil)
+			},
+			wantErr: domainerrors.ErrUploadedFileMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockAssetRepository(ctrl)
+			mockUploadRepo := mock.NewMockAssetUploadRepository(ctrl)
+			mockUploadRepo.EXPECT().GetByID(gomock.Any(), tt.upload.ID).Return(tt.upload, nil)
+			mockInspector := mock.NewMockArchiveInspector(ctrl)
+			tt.setup(t, tt.upload, mockRepo, mockUploadRepo, mockInspector)
+
+			uc := usecase.NewAssetUseCase(mockRepo, mockUploadRepo, usecase.AssetProcessors{ArchiveInspector: mockInspector}, 15*time.Minute, 24*time.Hour, 0)
+
+			got, err := uc.CompleteMultipartUpload(context.Background(), userID, tt.upload.ID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.upload.ID, got.ID)
+		})
+	}
+}
+
+func TestAssetUseCase_AbortMultipartUpload(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	upload := newMultipartUpload(userID, 10)
+
+	mockRepo := mock.NewMockAssetRepository(ctrl)
+	mockUploadRepo := mock.NewMockAssetUploadRepository(ctrl)
+	mockUploadRepo.EXPECT().GetByID(gomock.Any(), upload.ID).Return(upload, nil).Times(2)
+	mockRepo.EXPECT().AbortMultipartUpload(gomock.Any(), upload.ID, "zip", "multipart-upload-id").Return(nil)
+	mockUploadRepo.EXPECT().Delete(gomock.Any(), upload.ID).Return(nil)
+
+	uc := usecase.NewAssetUseCase(mockRepo, mockUploadRepo, usecase.AssetProcessors{}, 15*time.Minute, 24*time.Hour, 0)
+
+	// 他のユーザーは中止できない
+	assert.ErrorIs(t, uc.AbortMultipartUpload(context.Background(), uuid.New(), upload.ID), domainerrors.ErrAssetUploadNotFound)
+	assert.NoError(t, uc.AbortMultipartUpload(context.Background(), userID, upload.ID))
+}
+
+func TestAssetUseCase_AbortExpiredUploads(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	multipartUpload := newMultipartUpload(uuid.New(), 10)
+	singleUpload := entity.NewAssetUpload(uuid.New(), "png", "image/png", 10, 15*time.Minute)
+	failingUpload := newMultipartUpload(uuid.New(), 10)
+
+	mockRepo := mock.NewMockAssetRepository(ctrl)
+	mockUploadRepo := mock.NewMockAssetUploadRepository(ctrl)
+	mockUploadRepo.EXPECT().
+		ListExpired(gomock.Any(), gomock.Any(), 100).
+		Return([]*entity.AssetUpload{multipartUpload, singleUpload, failingUpload}, nil)
+	mockRepo.EXPECT().AbortMultipartUpload(gomock.Any(), multipartUpload.ID, "zip", "multipart-upload-id").Return(nil)
+	mockRepo.EXPECT().AbortMultipartUpload(gomock.Any(), failingUpload.ID, "zip", "multipart-upload-id").Return(errors.New("s3 unavailable"))
+	// 署名付き URL で置かれたファイルは消さない
+	mockRepo.EXPECT().DeleteFile(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	mockUploadRepo.EXPECT().Delete(gomock.Any(), multipartUpload.ID).Return(nil)
+	mockUploadRepo.EXPECT().Delete(gomock.Any(), singleUpload.ID).Return(nil)
+
+	uc := usecase.NewAssetUseCase(mockRepo, mockUploadRepo, usecase.AssetProcessors{}, 15*time.Minute, 24*time.Hour, 0)
+
+	cleaned, err := uc.AbortExpiredUploads(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, cleaned)
+}
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	"github.com/simesaba80/toybox-back/internal/domain/repository"
+)
+
+// generatePlaceholder は画像アセットを読み込むまでの間に表示するプレースホルダーを作る。
+// なくても画像は表示できるため、失敗した場合は nil を返す
+func (uc *assetUseCase) generatePlaceholder(ctx context.Context, assetID uuid.UUID, src io.Reader) *entity.ImagePlaceholder {
+	placeholder, err := uc.imageProcessor.GeneratePlaceholder(ctx, src)
+	if err != nil {
+		log.Printf("プレースホルダーの作成に失敗しました (asset_id=%s): %v", assetID.String(), err)
+		return nil
+	}
+	return placeholder
+}
+
+// placeholderBackfillBatchSize はプレースホルダーを後から作成するときに一度に読み込むアセットの数
+const placeholderBackfillBatchSize = 100
+
+type IAssetPlaceholderUsecase interface {
+	// BackfillPlaceholders はプレースホルダーのない画像アセットに、元画像からプレースホルダーを作成して保存します。
+	// 元画像を読み込めないなどで作成できなかったアセットは飛ばし、結果の Failed に含めます
+	BackfillPlaceholders(ctx context.Context) (*entity.PlaceholderBackfill, error)
+}
+
+type assetPlaceholderUsecase struct {
+	assetRepo      repository.AssetRepository
+	imageProcessor repository.ImageProcessor
+}
+
+func NewAssetPlaceholderUsecase(assetRepo repository.AssetRepository, imageProcessor repository.ImageProcessor) IAssetPlaceholderUsecase {
+	return &assetPlaceholderUsecase{
+		assetRepo:      assetRepo,
+		imageProcessor: imageProcessor,
+	}
+}
+
+func (uc *assetPlaceholderUsecase) BackfillPlaceholders(ctx context.Context) (*entity.PlaceholderBackfill, error) {
+	result := &entity.PlaceholderBackfill{}
+	// 作成できなかったアセットもプレースホルダーがないまま残るため、ID の順に読み進める
+	afterID := uuid.Nil
+	for {
+		assets, err := uc.assetRepo.ListImagesWithoutPlaceholder(ctx, afterID, placeholderBackfillBatchSize)
+		if err != nil {
+			return result, fmt.Errorf("failed to list image assets: %w", err)
+		}
+		for _, asset := range assets {
+			if err := ctx.Err(); err != nil {
+				return result, err
+			}
+			placeholder, err := uc.generate(ctx, asset)
+			if err != nil {
+				result.Failed = append(result.Failed, &entity.PlaceholderBackfillFailure{AssetID: asset.ID, URL: asset.URL, Err: err})
+				continue
+			}
+			if err := uc.assetRepo.UpdatePlaceholder(ctx, asset.ID, placeholder); err != nil {
+				return result, fmt.Errorf("failed to update placeholder: %w", err)
+			}
+			asset.Placeholder = placeholder
+			result.Updated = append(result.Updated, asset)
+		}
+		if len(assets) < placeholderBackfillBatchSize {
+			return result, nil
+		}
+		afterID = assets[len(assets)-1].ID
+	}
+}
+
+func (uc *assetPlaceholderUsecase) generate(ctx context.Context, asset *entity.Asset) (*entity.ImagePlaceholder, error) {
+	body, err := uc.assetRepo.OpenURL(ctx, asset.URL)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return uc.imageProcessor.GeneratePlaceholder(ctx, body)
+}
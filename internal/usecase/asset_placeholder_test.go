@@ -0,0 +1,177 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/usecase"
+	"github.com/simesaba80/toybox-back/internal/usecase/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAssetUseCase_UploadFile_Placeholder(t *testing.T) {
+	t.Parallel()
+
+	placeholder := &entity.ImagePlaceholder{Width: 800, Height: 400, BlurHash: "LEHV6nWB2yk8pyo0adR*.7kCMdnj", DominantColor: "#c86432"}
+
+	tests := []struct {
+		name            string
+		setup           func(t *testing.T, processor *mock.MockImageProcessor)
+		wantPlaceholder *entity.ImagePlaceholder
+	}{
+		{
+			name: "正常系: プレースホルダーと一緒にアセットを登録する",
+			setup: func(t *testing.T, processor *mock.MockImageProcessor) {
+				// メタデータを取り除いた後の画像を先頭から読み取る
+				processor.EXPECT().
+					GeneratePlaceholder(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, src io.Reader) (*entity.ImagePlaceholder, error) {
+						data, err := io.ReadAll(src)
+						assert.NoError(t, err)
+						assert.Equal(t, pngHeader, data)
+						return placeholder, nil
+					})
+			},
+			wantPlaceholder: placeholder,
+		},
+		{
+			name: "正常系: プレースホルダーを作成できなくてもアセットは登録する",
+			setup: func(t *testing.T, processor *mock.MockImageProcessor) {
+				processor.EXPECT().
+					GeneratePlaceholder(gomock.Any(), gomock.Any()).
+					Return(nil, domainerrors.ErrImageTooLarge)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockAssetRepository(ctrl)
+			mockProcessor := mock.NewMockImageProcessor(ctrl)
+			expectPassthroughStripMetadata(mockProcessor)
+			mockProcessor.EXPECT().GenerateVariants(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+			tt.setup(t, mockProcessor)
+			mockRepo.EXPECT().
+				UploadFile(gomock.Any(), gomock.Any(), gomock.Any(), "png", "image/png", gomock.Any()).
+				DoAndReturn(func(ctx context.Context, body io.ReadSeeker, assetUUID uuid.UUID, extension string, contentType string, hash string) (*entity.UploadedFile, error) {
+					data, err := io.ReadAll(body)
+					assert.NoError(t, err)
+					return &entity.UploadedFile{URL: "https://example.com/image/origin.png", AssetType: entity.AssetTypeImage, Size: int64(len(data))}, nil
+				})
+			mockRepo.EXPECT().
+				Create(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, asset *entity.Asset) (*entity.Asset, error) {
+					assert.Equal(t, tt.wantPlaceholder, asset.Placeholder)
+					return asset, nil
+				})
+
+			uc := usecase.NewAssetUseCase(mockRepo, mock.NewMockAssetUploadRepository(ctrl), usecase.AssetProcessors{ImageProcessor: mockProcessor}, 15*time.Minute, 24*time.Hour, 0)
+
+			got, err := uc.UploadFile(context.Background(), newFileHeader(t, "photo.png", pngHeader), uuid.New())
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantPlaceholder, got.Placeholder)
+		})
+	}
+}
+
+func TestAssetPlaceholderUsecase_BackfillPlaceholders(t *testing.T) {
+	t.Parallel()
+
+	t.Run("正常系: 作成できなかったアセットは飛ばして残りに保存する", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		migrated := &entity.Asset{ID: uuid.New(), URL: "https://old.example.com/images/a.png"}
+		missing := &entity.Asset{ID: uuid.New(), URL: "https://old.example.com/images/b.png"}
+		placeholder := &entity.ImagePlaceholder{Width: 10, Height: 20, BlurHash: "LEHV6nWB2yk8pyo0adR*.7kCMdnj", DominantColor: "#ffffff"}
+
+		mockRepo := mock.NewMockAssetRepository(ctrl)
+		mockProcessor := mock.NewMockImageProcessor(ctrl)
+		mockRepo.EXPECT().
+			ListImagesWithoutPlaceholder(gomock.Any(), uuid.Nil, gomock.Any()).
+			Return([]*entity.Asset{migrated, missing}, nil)
+		mockRepo.EXPECT().
+			OpenURL(gomock.Any(), migrated.URL).
+			Return(io.NopCloser(bytes.NewReader(pngHeader)), nil)
+		mockRepo.EXPECT().
+			OpenURL(gomock.Any(), missing.URL).
+			Return(nil, domainerrors.ErrUploadedFileNotFound)
+		mockProcessor.EXPECT().GeneratePlaceholder(gomock.Any(), gomock.Any()).Return(placeholder, nil)
+		mockRepo.EXPECT().UpdatePlaceholder(gomock.Any(), migrated.ID, placeholder).Return(nil)
+
+		uc := usecase.NewAssetPlaceholderUsecase(mockRepo, mockProcessor)
+
+		got, err := uc.BackfillPlaceholders(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, []*entity.Asset{migrated}, got.Updated)
+		assert.Equal(t, placeholder, got.Updated[0].Placeholder)
+		if assert.Len(t, got.Failed, 1) {
+			assert.Equal(t, missing.ID, got.Failed[0].AssetID)
+			assert.ErrorIs(t, got.Failed[0].Err, domainerrors.ErrUploadedFileNotFound)
+		}
+	})
+
+	t.Run("正常系: 作成できなかったアセットが残っても次のページへ進む", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		page := make([]*entity.Asset, 100)
+		for i := range page {
+			page[i] = &entity.Asset{ID: uuid.New(), URL: fmt.Sprintf("https://old.example.com/images/%d.png", i)}
+		}
+
+		mockRepo := mock.NewMockAssetRepository(ctrl)
+		gomock.InOrder(
+			mockRepo.EXPECT().ListImagesWithoutPlaceholder(gomock.Any(), uuid.Nil, 100).Return(page, nil),
+			mockRepo.EXPECT().ListImagesWithoutPlaceholder(gomock.Any(), page[99].ID, 100).Return(nil, nil),
+		)
+		mockRepo.EXPECT().OpenURL(gomock.Any(), gomock.Any()).Return(nil, domainerrors.ErrUploadedFileNotFound).Times(100)
+
+		uc := usecase.NewAssetPlaceholderUsecase(mockRepo, mock.NewMockImageProcessor(ctrl))
+
+		got, err := uc.BackfillPlaceholders(context.Background())
+		assert.NoError(t, err)
+		assert.Empty(t, got.Updated)
+		assert.Len(t, got.Failed, 100)
+	})
+
+	t.Run("異常系: 保存に失敗したら止める", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		asset := &entity.Asset{ID: uuid.New(), URL: "https://example.com/image/origin.png"}
+
+		mockRepo := mock.NewMockAssetRepository(ctrl)
+		mockProcessor := mock.NewMockImageProcessor(ctrl)
+		mockRepo.EXPECT().ListImagesWithoutPlaceholder(gomock.Any(), uuid.Nil, gomock.Any()).Return([]*entity.Asset{asset}, nil)
+		mockRepo.EXPECT().OpenURL(gomock.Any(), asset.URL).Return(io.NopCloser(bytes.NewReader(pngHeader)), nil)
+		mockProcessor.EXPECT().GeneratePlaceholder(gomock.Any(), gomock.Any()).Return(&entity.ImagePlaceholder{BlurHash: "00"}, nil)
+		mockRepo.EXPECT().UpdatePlaceholder(gomock.Any(), asset.ID, gomock.Any()).Return(domainerrors.ErrFailedToUpdatePlaceholder)
+
+		uc := usecase.NewAssetPlaceholderUsecase(mockRepo, mockProcessor)
+
+		got, err := uc.BackfillPlaceholders(context.Background())
+		assert.ErrorIs(t, err, domainerrors.ErrFailedToUpdatePlaceholder)
+		assert.Empty(t, got.Updated)
+	})
+}
@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+)
+
+func (uc *assetUseCase) GetStorageUsage(ctx context.Context, userID uuid.UUID) (*entity.StorageUsage, error) {
+	byType, err := uc.assetRepo.GetStorageUsage(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	pendingBytes, err := uc.assetUploadRepo.SumPendingSize(ctx, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &entity.StorageUsage{
+		Quota:        uc.storageQuota,
+		PendingBytes: pendingBytes,
+		ByType:       byType,
+	}, nil
+}
+
+// reserveStorage はアップロードを記録し、その分の容量を確保する。
+// 上限がある場合は使用量の確認と記録を一度に行い、同時に始めたアップロードで上限を超えられないようにする
+func (uc *assetUseCase) reserveStorage(ctx context.Context, upload *entity.AssetUpload) error {
+	if uc.storageQuota <= 0 {
+		return uc.assetUploadRepo.Create(ctx, upload)
+	}
+	return uc.assetUploadRepo.CreateWithinQuota(ctx, upload, uc.storageQuota)
+}
@@ -0,0 +1,208 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/usecase"
+	"github.com/simesaba80/toybox-back/internal/usecase/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAssetUseCase_GetStorageUsage(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	byType := []*entity.AssetTypeUsage{
+		{AssetType: "image", Count: 3, Bytes: 300},
+		{AssetType: "zip", Count: 1, Bytes: 1000},
+	}
+	mockRepo := mock.NewMockAssetRepository(ctrl)
+	mockRepo.EXPECT().GetStorageUsage(gomock.Any(), userID).Return(byType, nil)
+	mockUploadRepo := mock.NewMockAssetUploadRepository(ctrl)
+	mockUploadRepo.EXPECT().SumPendingSize(gomock.Any(), userID, gomock.Any()).Return(int64(50), nil)
+
+	uc := usecase.NewAssetUseCase(mockRepo, mockUploadRepo, usecase.AssetProcessors{}, 15*time.Minute, 24*time.Hour, 2000)
+
+	usage, err := uc.GetStorageUsage(context.Background(), userID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2000), usage.Quota)
+	assert.Equal(t, int64(50), usage.PendingBytes)
+	assert.Equal(t, byType, usage.ByType)
+	assert.Equal(t, int64(1300), usage.UsedBytes())
+	assert.True(t, usage.CanStore(650))
+	assert.False(t, usage.CanStore(651))
+}
+
+func TestAssetUseCase_StorageQuota(t *testing.T) {
+	t.Parallel()
+
+	// 使用済みとアップロード中を合わせて上限まで残り 10 バイト
+	const quota = 1000
+	expectReserve := func(uploadRepo *mock.MockAssetUploadRepository, userID uuid.UUID) {
+		uploadRepo.EXPECT().
+			CreateWithinQuota(gomock.Any(), gomock.Any(), int64(quota)).
+			DoAndReturn(func(_ context.Context, upload *entity.AssetUpload, _ int64) error {
+				assert.Equal(t, userID, upload.UserID)
+				if upload.Size > 10 {
+					return domainerrors.ErrStorageQuotaExceeded
+				}
+				return nil
+			})
+	}
+
+	t.Run("上限を超えるファイルはアップロードできない", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userID := uuid.New()
+		mockRepo := mock.NewMockAssetRepository(ctrl)
+		mockUploadRepo := mock.NewMockAssetUploadRepository(ctrl)
+		expectReserve(mockUploadRepo, userID)
+		mockRepo.EXPECT().UploadFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		mockUploadRepo.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(0)
+
+		uc := usecase.NewAssetUseCase(mockRepo, mockUploadRepo, usecase.AssetProcessors{}, 15*time.Minute, 24*time.Hour, quota)
+
+		got, err := uc.UploadFile(context.Background(), newFileHeader(t, "test.png", pngHeader), userID)
+		assert.ErrorIs(t, err, domainerrors.ErrStorageQuotaExceeded)
+		assert.Nil(t, got)
+	})
+
+	t.Run("アップロードを終えたら確保した容量を解放する", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userID := uuid.New()
+		mockRepo := mock.NewMockAssetRepository(ctrl)
+		mockUploadRepo := mock.NewMockAssetUploadRepository(ctrl)
+		var reserved uuid.UUID
+		mockUploadRepo.EXPECT().
+			CreateWithinQuota(gomock.Any(), gomock.Any(), int64(quota)).
+			DoAndReturn(func(_ context.Context, upload *entity.AssetUpload, _ int64) error {
+				assert.Equal(t, int64(len(pngHeader)), upload.Size)
+				reserved = upload.ID
+				return nil
+			})
+		mockRepo.EXPECT().
+			UploadFile(gomock.Any(), gomock.Any(), gomock.Any(), "png", "image/png", gomock.Any()).
+			Return(nil, errors.New("upload failed"))
+		mockUploadRepo.EXPECT().
+			Delete(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, id uuid.UUID) error {
+				assert.Equal(t, reserved, id)
+				return nil
+			})
+
+		uc := usecase.NewAssetUseCase(mockRepo, mockUploadRepo, usecase.AssetProcessors{ImageProcessor: newNoVariantImageProcessor(ctrl)}, 15*time.Minute, 24*time.Hour, quota)
+
+		_, err := uc.UploadFile(context.Background(), newFileHeader(t, "test.png", pngHeader), userID)
+		assert.Error(t, err)
+	})
+
+	t.Run("上限を超えるサイズの直接アップロードは始められない", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userID := uuid.New()
+		mockRepo := mock.NewMockAssetRepository(ctrl)
+		mockUploadRepo := mock.NewMockAssetUploadRepository(ctrl)
+		expectReserve(mockUploadRepo, userID)
+		mockRepo.EXPECT().PresignUploadFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		uc := usecase.NewAssetUseCase(mockRepo, mockUploadRepo, usecase.AssetProcessors{}, 15*time.Minute, 24*time.Hour, quota)
+
+		_, _, err := uc.CreateUpload(context.Background(), userID, "movie.mp4", 11)
+		assert.ErrorIs(t, err, domainerrors.ErrStorageQuotaExceeded)
+	})
+
+	t.Run("上限を超えるサイズのマルチパートアップロードは始められない", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userID := uuid.New()
+		mockRepo := mock.NewMockAssetRepository(ctrl)
+		mockUploadRepo := mock.NewMockAssetUploadRepository(ctrl)
+		mockRepo.EXPECT().
+			CreateMultipartUpload(gomock.Any(), gomock.Any(), "zip", gomock.Any()).
+			Return("multipart-id", nil)
+		expectReserve(mockUploadRepo, userID)
+		mockRepo.EXPECT().
+			AbortMultipartUpload(gomock.Any(), gomock.Any(), "zip", "multipart-id").
+			Return(nil)
+
+		uc := usecase.NewAssetUseCase(mockRepo, mockUploadRepo, usecase.AssetProcessors{}, 15*time.Minute, 24*time.Hour, quota)
+
+		_, err := uc.CreateMultipartUpload(context.Background(), userID, "game.zip", 11)
+		assert.ErrorIs(t, err, domainerrors.ErrStorageQuotaExceeded)
+	})
+
+	t.Run("上限までのサイズなら直接アップロードを始められる", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userID := uuid.New()
+		mockRepo := mock.NewMockAssetRepository(ctrl)
+		mockUploadRepo := mock.NewMockAssetUploadRepository(ctrl)
+		expectReserve(mockUploadRepo, userID)
+		mockRepo.EXPECT().
+			PresignUploadFile(gomock.Any(), gomock.Any(), "mp4", "video/mp4", int64(10), 15*time.Minute).
+			Return("https://s3.example.com/presigned", nil)
+
+		uc := usecase.NewAssetUseCase(mockRepo, mockUploadRepo, usecase.AssetProcessors{}, 15*time.Minute, 24*time.Hour, quota)
+
+		_, _, err := uc.CreateUpload(context.Background(), userID, "movie.mp4", 10)
+		assert.NoError(t, err)
+	})
+
+	t.Run("URL を発行できなければ確保した容量を解放する", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		userID := uuid.New()
+		mockRepo := mock.NewMockAssetRepository(ctrl)
+		mockUploadRepo := mock.NewMockAssetUploadRepository(ctrl)
+		var reserved uuid.UUID
+		mockUploadRepo.EXPECT().
+			CreateWithinQuota(gomock.Any(), gomock.Any(), int64(quota)).
+			DoAndReturn(func(_ context.Context, upload *entity.AssetUpload, _ int64) error {
+				reserved = upload.ID
+				return nil
+			})
+		mockRepo.EXPECT().
+			PresignUploadFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return("", domainerrors.ErrFailedToPresignUpload)
+		mockUploadRepo.EXPECT().
+			Delete(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, id uuid.UUID) error {
+				assert.Equal(t, reserved, id)
+				return nil
+			})
+
+		uc := usecase.NewAssetUseCase(mockRepo, mockUploadRepo, usecase.AssetProcessors{}, 15*time.Minute, 24*time.Hour, quota)
+
+		_, _, err := uc.CreateUpload(context.Background(), userID, "movie.mp4", 10)
+		assert.ErrorIs(t, err, domainerrors.ErrFailedToPresignUpload)
+	})
+}
@@ -1,19 +1,78 @@
 package usecase_test
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"io"
 	"mime/multipart"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
 	"github.com/simesaba80/toybox-back/internal/usecase"
 	"github.com/simesaba80/toybox-back/internal/usecase/mock"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
 
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+// newNoVariantImageProcessor は派生画像とプレースホルダーを生成せず、メタデータの除去では内容を変えない画像処理のモックを返す
+func newNoVariantImageProcessor(ctrl *gomock.Controller) *mock.MockImageProcessor {
+	processor := mock.NewMockImageProcessor(ctrl)
+	processor.EXPECT().GenerateVariants(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	expectPassthroughStripMetadata(processor)
+	expectNoPlaceholder(processor)
+	return processor
+}
+
+func newValidModelInspector(ctrl *gomock.Controller) *mock.MockModelInspector {
+	inspector := mock.NewMockModelInspector(ctrl)
+	inspector.EXPECT().InspectModel(gomock.Any(), gomock.Any(), gomock.Any()).Return(&entity.ModelStats{}, nil).AnyTimes()
+	return inspector
+}
+
+func expectNoPlaceholder(processor *mock.MockImageProcessor) {
+	processor.EXPECT().GeneratePlaceholder(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+}
+
+func expectPassthroughStripMetadata(processor *mock.MockImageProcessor) {
+	processor.EXPECT().
+		StripMetadata(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, data []byte, contentType string) (*entity.StrippedImage, error) {
+			return &entity.StrippedImage{Data: data}, nil
+		}).
+		AnyTimes()
+}
+
+func newFileHeader(t *testing.T, filename string, content []byte) *multipart.FileHeader {
+	t.Helper()
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		t.Fatalf("failed to create form file: %v", err)
+	}
+	if _, err := part.Write(content); err != nil {
+		t.Fatalf("failed to write file content: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed to close writer: %v", err)
+	}
+
+	form, err := multipart.NewReader(body, writer.Boundary()).ReadForm(1 << 20)
+	if err != nil {
+		t.Fatalf("failed to read form: %v", err)
+	}
+	return form.File["file"][0]
+}
+
 func TestAssetUseCase_UploadFile(t *testing.T) {
 	t.Parallel()
 
@@ -32,12 +91,14 @@ func TestAssetUseCase_UploadFile(t *testing.T) {
 				var capturedAssetID uuid.UUID
 
 				repo.EXPECT().
-					UploadFile(gomock.Any(), file, gomock.AssignableToTypeOf(uuid.UUID{}), "png").
-					DoAndReturn(func(ctx context.Context, fh *multipart.FileHeader, assetUUID uuid.UUID, extension string) (*string, *string, error) {
-						assert.Equal(t, file, fh)
+					UploadFile(gomock.Any(), gomock.Any(), gomock.AssignableToTypeOf(uuid.UUID{}), "png", "image/png", gomock.Any()).
+					DoAndReturn(func(ctx context.Context, body io.ReadSeeker, assetUUID uuid.UUID, extension string, contentType string, hash string) (*entity.UploadedFile, error) {
+						data, err := io.ReadAll(body)
+						assert.NoError(t, err)
+						assert.Equal(t, pngHeader, data)
 						assert.Equal(t, "png", extension)
 						capturedAssetID = assetUUID
-						return &assetURL, &assetType, nil
+						return &entity.UploadedFile{URL: assetURL, AssetType: assetType, Hash: "hash", Size: int64(len(data))}, nil
 					}).
 					Times(1)
 
@@ -49,6 +110,8 @@ func TestAssetUseCase_UploadFile(t *testing.T) {
 						assert.Equal(t, "png", asset.Extension)
 						assert.Equal(t, assetURL, asset.URL)
 						assert.Equal(t, assetType, asset.AssetType)
+						assert.Equal(t, int64(len(pngHeader)), asset.Size)
+						assert.Equal(t, "hash", asset.Hash)
 						return asset, nil
 					}).
 					Times(1)
@@ -61,8 +124,8 @@ func TestAssetUseCase_UploadFile(t *testing.T) {
 				t.Helper()
 
 				repo.EXPECT().
-					UploadFile(gomock.Any(), file, gomock.AssignableToTypeOf(uuid.UUID{}), "png").
-					Return(nil, nil, errors.New("upload failed")).
+					UploadFile(gomock.Any(), gomock.Any(), gomock.AssignableToTypeOf(uuid.UUID{}), "png", "image/png", gomock.Any()).
+					Return(nil, errors.New("upload failed")).
 					Times(1)
 			},
 			wantErr: true,
@@ -76,14 +139,20 @@ func TestAssetUseCase_UploadFile(t *testing.T) {
 				assetType := "image"
 
 				repo.EXPECT().
-					UploadFile(gomock.Any(), file, gomock.AssignableToTypeOf(uuid.UUID{}), "png").
-					Return(&assetURL, &assetType, nil).
+					UploadFile(gomock.Any(), gomock.Any(), gomock.AssignableToTypeOf(uuid.UUID{}), "png", "image/png", gomock.Any()).
+					Return(&entity.UploadedFile{URL: assetURL, AssetType: assetType, Hash: "hash"}, nil).
 					Times(1)
 
 				repo.EXPECT().
 					Create(gomock.Any(), gomock.Any()).
 					Return(nil, errors.New("create failed")).
 					Times(1)
+
+				// 登録できなかったアセットの分の参照を手放す
+				repo.EXPECT().
+					ReleaseBlob(gomock.Any(), "hash").
+					Return(nil).
+					Times(1)
 			},
 			wantErr: true,
 		},
@@ -97,13 +166,13 @@ func TestAssetUseCase_UploadFile(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			file := &multipart.FileHeader{Filename: "test.png"}
+			file := newFileHeader(t, "test.png", pngHeader)
 			userID := uuid.New()
 
 			mockRepo := mock.NewMockAssetRepository(ctrl)
 			tt.setup(t, mockRepo, file, userID)
 
-			uc := usecase.NewAssetUseCase(mockRepo)
+			uc := usecase.NewAssetUseCase(mockRepo, mock.NewMockAssetUploadRepository(ctrl), usecase.AssetProcessors{ImageProcessor: newNoVariantImageProcessor(ctrl)}, 15*time.Minute, 24*time.Hour, 0)
 
 			got, err := uc.UploadFile(context.Background(), file, userID)
 
@@ -122,3 +191,449 @@ func TestAssetUseCase_UploadFile(t *testing.T) {
 		})
 	}
 }
+
+func TestAssetUseCase_UploadFile_Validation(t *testing.T) {
+	t.Parallel()
+
+	jpegHeader := []byte("\xff\xd8\xff\xe0\x00\x10JFIF")
+
+	tests := []struct {
+		name            string
+		filename        string
+		content         []byte
+		size            int64
+		wantExtension   string
+		wantContentType string
+		wantErr         error
+	}{
+		{
+			name:            "正常系: 拡張子の大文字は小文字に揃え、jpgはimage/jpegで保存する",
+			filename:        "photo.JPG",
+			content:         jpegHeader,
+			wantExtension:   "jpg",
+			wantContentType: "image/jpeg",
+		},
+		{
+			name:            "正常系: ドットを複数含むファイル名は最後の拡張子を使う",
+			filename:        "a.b.png",
+			content:         pngHeader,
+			wantExtension:   "png",
+			wantContentType: "image/png",
+		},
+		{
+			name:            "正常系: m4aはftypボックスで判定する",
+			filename:        "song.m4a",
+			content:         []byte("\x00\x00\x00\x20ftypM4A \x00\x00\x00\x00"),
+			wantExtension:   "m4a",
+			wantContentType: "audio/mp4",
+		},
+		{
+			name:            "正常系: gltfは先頭の空白を読み飛ばしてJSONとして判定する",
+			filename:        "scene.gltf",
+			content:         []byte("\n  {\"asset\":{\"version\":\"2.0\"}}"),
+			wantExtension:   "gltf",
+			wantContentType: "model/gltf+json",
+		},
+		{
+			name:            "正常系: glbはglTFのマジックで判定する",
+			filename:        "scene.glb",
+			content:         []byte("glTF\x02\x00\x00\x00"),
+			wantExtension:   "glb",
+			wantContentType: "model/gltf-binary",
+		},
+		{
+			name:     "異常系: 拡張子がない",
+			filename: "README",
+			content:  pngHeader,
+			wantErr:  domainerrors.ErrUnsupportedFileType,
+		},
+		{
+			name:     "異常系: 対応していない拡張子",
+			filename: "run.exe",
+			content:  []byte("MZ\x90\x00"),
+			wantErr:  domainerrors.ErrUnsupportedFileType,
+		},
+		{
+			name:     "異常系: 内容が拡張子と一致しない",
+			filename: "fake.png",
+			content:  jpegHeader,
+			wantErr:  domainerrors.ErrFileTypeMismatch,
+		},
+		{
+			name:     "異常系: 空ファイル",
+			filename: "empty.png",
+			content:  []byte{},
+			wantErr:  domainerrors.ErrFileTypeMismatch,
+		},
+		{
+			name:     "異常系: 形式ごとのサイズ上限を超えている",
+			filename: "large.png",
+			content:  pngHeader,
+			size:     21 << 20,
+			wantErr:  domainerrors.ErrFileTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			file := newFileHeader(t, tt.filename, tt.content)
+			if tt.size > 0 {
+				file.Size = tt.size
+			}
+			userID := uuid.New()
+
+			mockRepo := mock.NewMockAssetRepository(ctrl)
+			if tt.wantErr == nil {
+				assetURL := "https://example.com/assets/origin." + tt.wantExtension
+				assetType := "image"
+				mockRepo.EXPECT().
+					UploadFile(gomock.Any(), gomock.Any(), gomock.Any(), tt.wantExtension, tt.wantContentType, gomock.Any()).
+					Return(&entity.UploadedFile{URL: assetURL, AssetType: assetType}, nil).
+					Times(1)
+				mockRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, asset *entity.Asset) (*entity.Asset, error) {
+						return asset, nil
+					}).
+					Times(1)
+			} else {
+				mockRepo.EXPECT().UploadFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+			}
+
+			uc := usecase.NewAssetUseCase(mockRepo, mock.NewMockAssetUploadRepository(ctrl), usecase.AssetProcessors{ImageProcessor: newNoVariantImageProcessor(ctrl), ModelInspector: newValidModelInspector(ctrl)}, 15*time.Minute, 24*time.Hour, 0)
+
+			got, err := uc.UploadFile(context.Background(), file, userID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantExtension, got.Extension)
+		})
+	}
+}
+
+func TestAssetUseCase_UploadFile_Variants(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		assetType    string
+		setup        func(t *testing.T, repo *mock.MockAssetRepository, processor *mock.MockImageProcessor)
+		wantVariants []int
+	}{
+		{
+			name:      "正常系: 画像アセットの派生画像を保存する",
+			assetType: "image",
+			setup: func(t *testing.T, repo *mock.MockAssetRepository, processor *mock.MockImageProcessor) {
+				t.Helper()
+
+				processor.EXPECT().
+					GenerateVariants(gomock.Any(), gomock.Any(), entity.ImageVariantWidths).
+					Return([]*entity.ImageVariant{
+						{Width: 320, Height: 160, ContentType: "image/jpeg", Extension: "jpg", Data: []byte("320")},
+						{Width: 640, Height: 320, ContentType: "image/jpeg", Extension: "jpg", Data: []byte("640")},
+					}, nil).
+					Times(1)
+				repo.EXPECT().
+					UploadVariant(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "image/jpeg").
+					DoAndReturn(func(ctx context.Context, assetUUID uuid.UUID, fileName string, data []byte, contentType string) (*string, error) {
+						assert.Equal(t, string(data)+"w.jpg", fileName)
+						variantURL := "https://example.com/assets/" + assetUUID.String() + "/" + fileName
+						return &variantURL, nil
+					}).
+					Times(2)
+				repo.EXPECT().
+					CreateVariants(gomock.Any(), gomock.Len(2)).
+					Return(nil).
+					Times(1)
+			},
+			wantVariants: []int{320, 640},
+		},
+		{
+			name:      "正常系: 派生画像の生成に失敗してもアップロードは成功する",
+			assetType: "image",
+			setup: func(t *testing.T, repo *mock.MockAssetRepository, processor *mock.MockImageProcessor) {
+				t.Helper()
+
+				processor.EXPECT().
+					GenerateVariants(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, domainerrors.ErrImageTooLarge).
+					Times(1)
+				repo.EXPECT().UploadVariant(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				repo.EXPECT().CreateVariants(gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+		{
+			name:      "正常系: 画像以外のアセットは派生画像を作らない",
+			assetType: "zip",
+			setup: func(t *testing.T, repo *mock.MockAssetRepository, processor *mock.MockImageProcessor) {
+				t.Helper()
+
+				processor.EXPECT().GenerateVariants(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			file := newFileHeader(t, "test.png", pngHeader)
+			assetURL := "https://example.com/assets/origin.png"
+			assetType := tt.assetType
+
+			mockRepo := mock.NewMockAssetRepository(ctrl)
+			mockProcessor := mock.NewMockImageProcessor(ctrl)
+			expectPassthroughStripMetadata(mockProcessor)
+			expectNoPlaceholder(mockProcessor)
+			mockRepo.EXPECT().
+				UploadFile(gomock.Any(), gomock.Any(), gomock.Any(), "png", "image/png", gomock.Any()).
+				Return(&entity.UploadedFile{URL: assetURL, AssetType: assetType}, nil)
+			mockRepo.EXPECT().
+				Create(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, asset *entity.Asset) (*entity.Asset, error) {
+					return asset, nil
+				})
+			tt.setup(t, mockRepo, mockProcessor)
+
+			uc := usecase.NewAssetUseCase(mockRepo, mock.NewMockAssetUploadRepository(ctrl), usecase.AssetProcessors{ImageProcessor: mockProcessor}, 15*time.Minute, 24*time.Hour, 0)
+
+			got, err := uc.UploadFile(context.Background(), file, uuid.New())
+
+			assert.NoError(t, err)
+			widths := make([]int, 0, len(got.Variants))
+			for _, variant := range got.Variants {
+				assert.Equal(t, got.ID, variant.AssetID)
+				widths = append(widths, variant.Width)
+			}
+			assert.Equal(t, len(tt.wantVariants), len(widths))
+			if len(tt.wantVariants) > 0 {
+				assert.Equal(t, tt.wantVariants, widths)
+			}
+		})
+	}
+}
+
+func TestAssetUseCase_UploadFile_StripMetadata(t *testing.T) {
+	t.Parallel()
+
+	gifHeader := []byte("GIF89a\x01\x00\x01\x00")
+	webpHeader := []byte("RIFF\x04\x00\x00\x00WEBP")
+	strippedPNG := append(append([]byte{}, pngHeader...), []byte("stripped")...)
+
+	tests := []struct {
+		name         string
+		filename     string
+		content      []byte
+		setup        func(t *testing.T, repo *mock.MockAssetRepository, processor *mock.MockImageProcessor)
+		wantStripped bool
+		wantErr      error
+	}{
+		{
+			name:     "正常系: メタデータを取り除いた画像を保存する",
+			filename: "photo.png",
+			content:  pngHeader,
+			setup: func(t *testing.T, repo *mock.MockAssetRepository, processor *mock.MockImageProcessor) {
+				t.Helper()
+
+				processor.EXPECT().
+					StripMetadata(gomock.Any(), pngHeader, "image/png").
+					Return(&entity.StrippedImage{Data: strippedPNG}, nil).
+					Times(1)
+				processor.EXPECT().
+					GenerateVariants(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, src io.Reader, widths []int) ([]*entity.ImageVariant, error) {
+						// 派生画像もメタデータを取り除いた画像から作る
+						data, err := io.ReadAll(src)
+						assert.NoError(t, err)
+						assert.Equal(t, strippedPNG, data)
+						return nil, nil
+					}).
+					Times(1)
+				assetURL := "https://example.com/assets/origin.png"
+				assetType := "image"
+				repo.EXPECT().
+					UploadFile(gomock.Any(), gomock.Any(), gomock.Any(), "png", "image/png", gomock.Any()).
+					DoAndReturn(func(ctx context.Context, body io.ReadSeeker, assetUUID uuid.UUID, extension string, contentType string, hash string) (*entity.UploadedFile, error) {
+						data, err := io.ReadAll(body)
+						assert.NoError(t, err)
+						assert.Equal(t, strippedPNG, data)
+						return &entity.UploadedFile{URL: assetURL, AssetType: assetType, Size: int64(len(data))}, nil
+					}).
+					Times(1)
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, asset *entity.Asset) (*entity.Asset, error) {
+						assert.True(t, asset.MetadataStripped)
+						return asset, nil
+					}).
+					Times(1)
+			},
+			wantStripped: true,
+		},
+		{
+			name:     "正常系: 向きを反映するためにPNGへ変換したWebPはPNGとして保存する",
+			filename: "photo.webp",
+			content:  webpHeader,
+			setup: func(t *testing.T, repo *mock.MockAssetRepository, processor *mock.MockImageProcessor) {
+				t.Helper()
+
+				processor.EXPECT().
+					StripMetadata(gomock.Any(), webpHeader, "image/webp").
+					Return(&entity.StrippedImage{Data: strippedPNG, ConvertedExtension: "png"}, nil).
+					Times(1)
+				processor.EXPECT().GenerateVariants(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+				repo.EXPECT().
+					UploadFile(gomock.Any(), gomock.Any(), gomock.Any(), "png", "image/png", gomock.Any()).
+					Return(&entity.UploadedFile{URL: "https://example.com/assets/origin.png", AssetType: "image"}, nil).
+					Times(1)
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, asset *entity.Asset) (*entity.Asset, error) {
+						assert.Equal(t, "png", asset.Extension)
+						assert.True(t, asset.MetadataStripped)
+						return asset, nil
+					}).
+					Times(1)
+			},
+			wantStripped: true,
+		},
+		{
+			name:     "正常系: GIFはメタデータの除去を行わない",
+			filename: "anime.gif",
+			content:  gifHeader,
+			setup: func(t *testing.T, repo *mock.MockAssetRepository, processor *mock.MockImageProcessor) {
+				t.Helper()
+
+				processor.EXPECT().StripMetadata(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				processor.EXPECT().GenerateVariants(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+				assetURL := "https://example.com/assets/origin.gif"
+				assetType := "image"
+				repo.EXPECT().
+					UploadFile(gomock.Any(), gomock.Any(), gomock.Any(), "gif", "image/gif", gomock.Any()).
+					Return(&entity.UploadedFile{URL: assetURL, AssetType: assetType}, nil).
+					Times(1)
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, asset *entity.Asset) (*entity.Asset, error) {
+						return asset, nil
+					}).
+					Times(1)
+			},
+			wantStripped: false,
+		},
+		{
+			name:     "異常系: メタデータを取り除けない場合は保存しない",
+			filename: "broken.png",
+			content:  pngHeader,
+			setup: func(t *testing.T, repo *mock.MockAssetRepository, processor *mock.MockImageProcessor) {
+				t.Helper()
+
+				processor.EXPECT().
+					StripMetadata(gomock.Any(), gomock.Any(), "image/png").
+					Return(nil, errors.New("malformed png")).
+					Times(1)
+				repo.EXPECT().UploadFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domainerrors.ErrFailedToStripMetadata,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockAssetRepository(ctrl)
+			mockProcessor := mock.NewMockImageProcessor(ctrl)
+			expectNoPlaceholder(mockProcessor)
+			tt.setup(t, mockRepo, mockProcessor)
+
+			uc := usecase.NewAssetUseCase(mockRepo, mock.NewMockAssetUploadRepository(ctrl), usecase.AssetProcessors{ImageProcessor: mockProcessor}, 15*time.Minute, 24*time.Hour, 0)
+
+			got, err := uc.UploadFile(context.Background(), newFileHeader(t, tt.filename, tt.content), uuid.New())
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStripped, got.MetadataStripped)
+		})
+	}
+}
+
+func TestAssetUseCase_UploadFile_HashesOriginal(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// 撮影場所を含む EXIF を APP1 に持つ JPEG と、それを取り除いた JPEG
+	exifSegment := append([]byte("\xff\xe1\x00\x12Exif\x00\x00"), []byte("GPS 35N 139E")...)
+	original := append(append([]byte("\xff\xd8"), exifSegment...), []byte("\xff\xdb jpeg body")...)
+	stripped := []byte("\xff\xd8\xff\xdb jpeg body")
+	sum := sha256.Sum256(original)
+	originalHash := hex.EncodeToString(sum[:])
+
+	userID := uuid.New()
+	mockRepo := mock.NewMockAssetRepository(ctrl)
+	mockProcessor := mock.NewMockImageProcessor(ctrl)
+	expectNoPlaceholder(mockProcessor)
+	mockProcessor.EXPECT().StripMetadata(gomock.Any(), original, "image/jpeg").Return(&entity.StrippedImage{Data: stripped}, nil)
+	mockProcessor.EXPECT().GenerateVariants(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockRepo.EXPECT().
+		UploadFile(gomock.Any(), gomock.Any(), gomock.Any(), "jpg", "image/jpeg", originalHash).
+		DoAndReturn(func(ctx context.Context, body io.ReadSeeker, assetUUID uuid.UUID, extension string, contentType string, hash string) (*entity.UploadedFile, error) {
+			// 保存するのはメタデータを取り除いた内容で、重複の判定には受け取ったままの内容のハッシュを使う
+			data, err := io.ReadAll(body)
+			assert.NoError(t, err)
+			assert.Equal(t, stripped, data)
+			return &entity.UploadedFile{URL: "https://example.com/image/origin.jpg", AssetType: "image", Hash: hash, Size: int64(len(data))}, nil
+		})
+	var created *entity.Asset
+	mockRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, asset *entity.Asset) (*entity.Asset, error) {
+			assert.Equal(t, originalHash, asset.Hash)
+			created = asset
+			return asset, nil
+		})
+
+	uc := usecase.NewAssetUseCase(mockRepo, mock.NewMockAssetUploadRepository(ctrl), usecase.AssetProcessors{ImageProcessor: mockProcessor}, 15*time.Minute, 24*time.Hour, 0)
+
+	got, err := uc.UploadFile(context.Background(), newFileHeader(t, "photo.jpg", original), userID)
+	assert.NoError(t, err)
+	assert.Equal(t, originalHash, got.Hash)
+
+	// クライアントは手元にある元のファイルのハッシュで探せる
+	mockRepo.EXPECT().
+		FindByHash(gomock.Any(), userID, originalHash).
+		DoAndReturn(func(ctx context.Context, userID uuid.UUID, hash string) (*entity.Asset, error) {
+			return created, nil
+		})
+	found, err := uc.FindAssetByHash(context.Background(), userID, originalHash)
+	assert.NoError(t, err)
+	assert.Equal(t, got.ID, found.ID)
+}
@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+)
+
+func (uc *assetUseCase) CreateUpload(ctx context.Context, userID uuid.UUID, fileName string, size int64) (*entity.AssetUpload, string, error) {
+	fileType, err := validateFileType(fileName, size)
+	if err != nil {
+		return nil, "", err
+	}
+	upload := entity.NewAssetUpload(userID, fileType.Extension, fileType.ContentType, size, uc.uploadURLTTL)
+	if err := uc.reserveStorage(ctx, upload); err != nil {
+		return nil, "", fmt.Errorf("failed to create asset upload: %w", err)
+	}
+	uploadURL, err := uc.assetRepo.PresignUploadFile(ctx, upload.ID, upload.Extension, upload.ContentType, upload.Size, uc.uploadURLTTL)
+	if err != nil {
+		// 使われないアップロードの分まで容量を確保したままにしない
+		if deleteErr := uc.assetUploadRepo.Delete(ctx, upload.ID); deleteErr != nil {
+			log.Printf("アップロードの記録の削除に失敗しました (upload_id=%s): %v", upload.ID.String(), deleteErr)
+		}
+		return nil, "", fmt.Errorf("failed to presign upload: %w", err)
+	}
+	return upload, uploadURL, nil
+}
+
+func (uc *assetUseCase) FindAssetByHash(ctx context.Context, userID uuid.UUID, hash string) (*entity.Asset, error) {
+	// 保存しているハッシュは小文字のため、大文字で書いたハッシュでも探せるようにする
+	hash = strings.ToLower(hash)
+	if !isSHA256Hex(hash) {
+		return nil, domainerrors.ErrInvalidRequestBody
+	}
+	return uc.assetRepo.FindByHash(ctx, userID, hash)
+}
+
+// isSHA256Hex は s が小文字の 16 進数で書いた SHA-256 かを返す
+func isSHA256Hex(s string) bool {
+	if len(s) != sha256.Size*2 {
+		return false
+	}
+	for _, c := range s {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func (uc *assetUseCase) CompleteUpload(ctx context.Context, userID uuid.UUID, uploadID uuid.UUID) (*entity.Asset, error) {
+	upload, err := uc.getUpload(ctx, userID, uploadID, false)
+	if err != nil {
+		return nil, err
+	}
+	if upload.IsExpired(time.Now()) {
+		return nil, domainerrors.ErrAssetUploadExpired
+	}
+	return uc.registerUploadedAsset(ctx, upload)
+}
+
+// getUpload はユーザー自身のアップロードを取得する。
+// 他のユーザーのアップロードや、方式の異なるアップロードは存在しないものとして扱う
+func (uc *assetUseCase) getUpload(ctx context.Context, userID uuid.UUID, uploadID uuid.UUID, multipart bool) (*entity.AssetUpload, error) {
+	upload, err := uc.assetUploadRepo.GetByID(ctx, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	if upload.UserID != userID || upload.IsMultipart() != multipart {
+		return nil, domainerrors.ErrAssetUploadNotFound
+	}
+	return upload, nil
+}
+
+// registerUploadedAsset はアップロードされた元ファイルを検証し、アップロードと同じ ID でアセットを登録する
+func (uc *assetUseCase) registerUploadedAsset(ctx context.Context, upload *entity.AssetUpload) (*entity.Asset, error) {
+	fileType, ok := entity.LookupFileType(upload.Extension)
+	if !ok {
+		return nil, domainerrors.ErrUnsupportedFileType
+	}
+
+	if err := uc.verifyUploadedFile(ctx, upload, fileType); err != nil {
+		if errors.Is(err, domainerrors.ErrUploadedFileMismatch) || errors.Is(err, domainerrors.ErrFileTypeMismatch) {
+			// 申告と異なるファイルは残しておく理由がないため消す
+			uc.discardUploadedFile(ctx, upload)
+		}
+		return nil, err
+	}
+
+	asset := entity.NewAsset("", upload.UserID, upload.Extension, "")
+	asset.ID = upload.ID
+	if fileType.Extension == zipExtension {
+		manifest, err := uc.inspectUploadedZip(ctx, upload.ID)
+		if err != nil {
+			if isRejectedZip(err) {
+				uc.discardUploadedFile(ctx, upload)
+			}
+			return nil, err
+		}
+		asset.ZipManifest = manifest
+		asset.PlayURL = uc.extractUploadedPlayable(ctx, asset.ID, manifest)
+	}
+	if modelExtensions[fileType.Extension] {
+		stats, err := uc.inspectUploadedModel(ctx, upload.ID, upload.Extension)
+		if err != nil {
+			if isRejectedModel(err) {
+				uc.discardUploadedFile(ctx, upload)
+			}
+			return nil, err
+		}
+		asset.ModelStats = stats
+	}
+
+	var createdAsset *entity.Asset
+	assetURL, assetType := uc.assetRepo.FileURL(upload.ID, upload.Extension)
+	if assetType == entity.AssetTypeImage {
+		// 画像はメタデータの除去と派生画像の生成のため、読み込んでから保存し直す
+		data, err := uc.readUploadedFile(ctx, upload)
+		if err != nil {
+			return nil, err
+		}
+		createdAsset, err = uc.storeAsset(ctx, asset, fileType, bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		if createdAsset.URL != assetURL {
+			// 同じ内容の画像が既にあり保存し直さなかったため、メタデータが残ったままのファイルを消す
+			if err := uc.assetRepo.DeleteFile(ctx, upload.ID, upload.Extension); err != nil {
+				log.Printf("重複したファイルの削除に失敗しました (upload_id=%s): %v", upload.ID.String(), err)
+			}
+		}
+	} else {
+		if assetType == entity.AssetTypeMusic {
+			asset.AudioMetadata = uc.analyzeUploadedAudio(ctx, upload)
+		}
+		// 同じ内容のファイルが既にあれば、アップロードされたファイルの代わりにそちらを参照する
+		hash, err := uc.hashUploadedFile(ctx, upload)
+		if err != nil {
+			uc.discardPlayFiles(ctx, asset)
+			return nil, err
+		}
+		uploaded, err := uc.assetRepo.RegisterBlob(ctx, upload.ID, upload.Extension, hash, upload.Size)
+		if err != nil {
+			uc.discardPlayFiles(ctx, asset)
+			return nil, fmt.Errorf("failed to register file: %w", err)
+		}
+		asset.URL = uploaded.URL
+		asset.AssetType = uploaded.AssetType
+		asset.Hash = uploaded.Hash
+		asset.Size = uploaded.Size
+		createdAsset, err = uc.assetRepo.Create(ctx, asset)
+		if err != nil {
+			// 登録できなかったアセットの分の参照を手放す
+			if releaseErr := uc.assetRepo.ReleaseBlob(ctx, uploaded.Hash); releaseErr != nil {
+				log.Printf("ファイルの参照の解放に失敗しました (hash=%s): %v", uploaded.Hash, releaseErr)
+			}
+			uc.discardPlayFiles(ctx, asset)
+			return nil, fmt.Errorf("failed to create asset: %w", err)
+		}
+	}
+
+	if err := uc.assetUploadRepo.Delete(ctx, upload.ID); err != nil {
+		log.Printf("アップロード情報の削除に失敗しました (upload_id=%s): %v", upload.ID.String(), err)
+	}
+	return createdAsset, nil
+}
+
+// discardUploadedFile は検証に失敗したファイルを消す
+func (uc *assetUseCase) discardUploadedFile(ctx context.Context, upload *entity.AssetUpload) {
+	if err := uc.assetRepo.DeleteFile(ctx, upload.ID, upload.Extension); err != nil {
+		log.Printf("検証に失敗したファイルの削除に失敗しました (upload_id=%s): %v", upload.ID.String(), err)
+	}
+}
+
+// verifyUploadedFile はアップロードされたファイルのサイズ・Content-Type・先頭バイトが申告と一致するかを確かめる
+func (uc *assetUseCase) verifyUploadedFile(ctx context.Context, upload *entity.AssetUpload, fileType *entity.FileType) error {
+	object, err := uc.assetRepo.HeadFile(ctx, upload.ID, upload.Extension)
+	if err != nil {
+		return err
+	}
+	if object.Size != upload.Size || object.ContentType != upload.ContentType {
+		return domainerrors.ErrUploadedFileMismatch
+	}
+
+	body, err := uc.assetRepo.OpenFile(ctx, upload.ID, upload.Extension)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	return validateFileContent(body, fileType)
+}
+
+// hashUploadedFile はアップロードされたファイルを読みながら、内容の SHA-256 を 16 進数で返す
+func (uc *assetUseCase) hashUploadedFile(ctx context.Context, upload *entity.AssetUpload) (string, error) {
+	body, err := uc.assetRepo.OpenFile(ctx, upload.ID, upload.Extension)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+	return contentHash(body)
+}
+
+func (uc *assetUseCase) readUploadedFile(ctx context.Context, upload *entity.AssetUpload) ([]byte, error) {
+	body, err := uc.assetRepo.OpenFile(ctx, upload.ID, upload.Extension)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, domainerrors.ErrFailedToOpenFile
+	}
+	return data, nil
+}
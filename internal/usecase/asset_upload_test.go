@@ -0,0 +1,434 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/usecase"
+	"github.com/simesaba80/toybox-back/internal/usecase/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAssetUseCase_CreateUpload(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+
+	tests := []struct {
+		name     string
+		fileName string
+		size     int64
+		setup    func(repo *mock.MockAssetRepository, uploadRepo *mock.MockAssetUploadRepository)
+		wantErr  error
+	}{
+		{
+			name:     "正常系: 署名付きURLを発行してアップロードを記録する",
+			fileName: "movie.MP4",
+			size:     100 << 20,
+			setup: func(repo *mock.MockAssetRepository, uploadRepo *mock.MockAssetUploadRepository) {
+				repo.EXPECT().
+					PresignUploadFile(gomock.Any(), gomock.Any(), "mp4", "video/mp4", int64(100<<20), 15*time.Minute).
+					Return("https://s3.example.com/presigned", nil)
+				uploadRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, upload *entity.AssetUpload) error {
+						assert.Equal(t, userID, upload.UserID)
+						assert.Equal(t, "mp4", upload.Extension)
+						assert.Equal(t, int64(100<<20), upload.Size)
+						return nil
+					})
+			},
+		},
+		{
+			name:     "異常系: 対応していない拡張子",
+			fileName: "run.exe",
+			size:     10,
+			setup:    func(repo *mock.MockAssetRepository, uploadRepo *mock.MockAssetUploadRepository) {},
+			wantErr:  domainerrors.ErrUnsupportedFileType,
+		},
+		{
+			name:     "異常系: 形式ごとのサイズ上限を超えている",
+			fileName: "movie.mp4",
+			size:     501 << 20,
+			setup:    func(repo *mock.MockAssetRepository, uploadRepo *mock.MockAssetUploadRepository) {},
+			wantErr:  domainerrors.ErrFileTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockAssetRepository(ctrl)
+			mockUploadRepo := mock.NewMockAssetUploadRepository(ctrl)
+			tt.setup(mockRepo, mockUploadRepo)
+
+			uc := usecase.NewAssetUseCase(mockRepo, mockUploadRepo, usecase.AssetProcessors{}, 15*time.Minute, 24*time.Hour, 0)
+
+			upload, uploadURL, err := uc.CreateUpload(context.Background(), userID, tt.fileName, tt.size)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, upload)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "https://s3.example.com/presigned", uploadURL)
+			assert.Equal(t, "video/mp4", upload.ContentType)
+			assert.WithinDuration(t, time.Now().Add(15*time.Minute), upload.ExpiresAt, time.Minute)
+		})
+	}
+}
+
+func TestAssetUseCase_CompleteUpload(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	mp4Header := []byte("\x00\x00\x00\x18ftypisom\x00\x00\x02\x00")
+	mp4Sum := sha256.Sum256(mp4Header)
+	mp4Hash := hex.EncodeToString(mp4Sum[:])
+	// 先頭バイトの検証と、ハッシュの計算で 2 回開く
+	expectOpenMP4 := func(repo *mock.MockAssetRepository, upload *entity.AssetUpload) {
+		repo.EXPECT().
+			OpenFile(gomock.Any(), upload.ID, "mp4").
+			DoAndReturn(func(ctx context.Context, assetUUID uuid.UUID, extension string) (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(mp4Header)), nil
+			}).
+			Times(2)
+	}
+
+	newUpload := func(extension string, contentType string, size int64) *entity.AssetUpload {
+		upload := entity.NewAssetUpload(userID, extension, contentType, size, 15*time.Minute)
+		return upload
+	}
+
+	tests := []struct {
+		name    string
+		upload  *entity.AssetUpload
+		userID  uuid.UUID
+		setup   func(t *testing.T, upload *entity.AssetUpload, repo *mock.MockAssetRepository, uploadRepo *mock.MockAssetUploadRepository, processor *mock.MockImageProcessor)
+		wantErr error
+	}{
+		{
+			name:   "正常系: 動画はそのままアセットとして登録する",
+			upload: newUpload("mp4", "video/mp4", int64(len(mp4Header))),
+			userID: userID,
+			setup: func(t *testing.T, upload *entity.AssetUpload, repo *mock.MockAssetRepository, uploadRepo *mock.MockAssetUploadRepository, processor *mock.MockImageProcessor) {
+				repo.EXPECT().
+					HeadFile(gomock.Any(), upload.ID, "mp4").
+					Return(&entity.StoredObject{Size: upload.Size, ContentType: "video/mp4"}, nil)
+				expectOpenMP4(repo, upload)
+				repo.EXPECT().
+					FileURL(upload.ID, "mp4").
+					Return("https://example.com/video/origin.mp4", "video")
+				repo.EXPECT().UploadFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				repo.EXPECT().
+					RegisterBlob(gomock.Any(), upload.ID, "mp4", mp4Hash, upload.Size).
+					Return(&entity.UploadedFile{URL: "https://example.com/video/origin.mp4", AssetType: "video", Hash: mp4Hash, Size: upload.Size}, nil)
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, asset *entity.Asset) (*entity.Asset, error) {
+						assert.Equal(t, upload.ID, asset.ID)
+						assert.Equal(t, userID, asset.UserID)
+						assert.Equal(t, "video", asset.AssetType)
+						assert.Equal(t, "https://example.com/video/origin.mp4", asset.URL)
+						assert.Equal(t, mp4Hash, asset.Hash)
+						assert.Equal(t, upload.Size, asset.Size)
+						return asset, nil
+					})
+				uploadRepo.EXPECT().Delete(gomock.Any(), upload.ID).Return(nil)
+			},
+		},
+		{
+			name:   "正常系: 同じ内容の動画が既にある場合は既存のファイルを参照する",
+			upload: newUpload("mp4", "video/mp4", int64(len(mp4Header))),
+			userID: userID,
+			setup: func(t *testing.T, upload *entity.AssetUpload, repo *mock.MockAssetRepository, uploadRepo *mock.MockAssetUploadRepository, processor *mock.MockImageProcessor) {
+				repo.EXPECT().
+					HeadFile(gomock.Any(), upload.ID, "mp4").
+					Return(&entity.StoredObject{Size: upload.Size, ContentType: "video/mp4"}, nil)
+				expectOpenMP4(repo, upload)
+				repo.EXPECT().
+					FileURL(upload.ID, "mp4").
+					Return("https://example.com/video/"+upload.ID.String()+"/origin.mp4", "video")
+				repo.EXPECT().
+					RegisterBlob(gomock.Any(), upload.ID, "mp4", mp4Hash, upload.Size).
+					Return(&entity.UploadedFile{URL: "https://example.com/video/other/origin.mp4", AssetType: "video", Hash: mp4Hash, Size: upload.Size, Deduplicated: true}, nil)
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, asset *entity.Asset) (*entity.Asset, error) {
+						assert.Equal(t, "https://example.com/video/other/origin.mp4", asset.URL)
+						assert.Equal(t, mp4Hash, asset.Hash)
+						return asset, nil
+					})
+				uploadRepo.EXPECT().Delete(gomock.Any(), upload.ID).Return(nil)
+			},
+		},
+		{
+			name:   "異常系: アセットを登録できなければファイルの参照を手放す",
+			upload: newUpload("mp4", "video/mp4", int64(len(mp4Header))),
+			userID: userID,
+			setup: func(t *testing.T, upload *entity.AssetUpload, repo *mock.MockAssetRepository, uploadRepo *mock.MockAssetUploadRepository, processor *mock.MockImageProcessor) {
+				repo.EXPECT().
+					HeadFile(gomock.Any(), upload.ID, "mp4").
+					Return(&entity.StoredObject{Size: upload.Size, ContentType: "video/mp4"}, nil)
+				expectOpenMP4(repo, upload)
+				repo.EXPECT().
+					FileURL(upload.ID, "mp4").
+					Return("https://example.com/video/origin.mp4", "video")
+				repo.EXPECT().
+					RegisterBlob(gomock.Any(), upload.ID, "mp4", mp4Hash, upload.Size).
+					Return(&entity.UploadedFile{URL: "https://example.com/video/origin.mp4", AssetType: "video", Hash: mp4Hash, Size: upload.Size}, nil)
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, domainerrors.ErrFailedToCreateAsset)
+				repo.EXPECT().ReleaseBlob(gomock.Any(), mp4Hash).Return(nil)
+				uploadRepo.EXPECT().Delete(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domainerrors.ErrFailedToCreateAsset,
+		},
+		{
+			name:   "正常系: 画像はメタデータを取り除いて保存し直す",
+			upload: newUpload("png", "image/png", int64(len(pngHeader))),
+			userID: userID,
+			setup: func(t *testing.T, upload *entity.AssetUpload, repo *mock.MockAssetRepository, uploadRepo *mock.MockAssetUploadRepository, processor *mock.MockImageProcessor) {
+				repo.EXPECT().
+					HeadFile(gomock.Any(), upload.ID, "png").
+					Return(&entity.StoredObject{Size: upload.Size, ContentType: "image/png"}, nil)
+				repo.EXPECT().
+					OpenFile(gomock.Any(), upload.ID, "png").
+					DoAndReturn(func(ctx context.Context, assetUUID uuid.UUID, extension string) (io.ReadCloser, error) {
+						return io.NopCloser(bytes.NewReader(pngHeader)), nil
+					}).
+					Times(2)
+				repo.EXPECT().
+					FileURL(upload.ID, "png").
+					Return("https://example.com/image/origin.png", "image")
+				processor.EXPECT().StripMetadata(gomock.Any(), pngHeader, "image/png").Return(&entity.StrippedImage{Data: []byte("stripped")}, nil)
+				processor.EXPECT().GenerateVariants(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+				assetURL := "https://example.com/image/origin.png"
+				assetType := "image"
+				repo.EXPECT().
+					UploadFile(gomock.Any(), gomock.Any(), upload.ID, "png", "image/png", gomock.Any()).
+					Return(&entity.UploadedFile{URL: assetURL, AssetType: assetType}, nil)
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, asset *entity.Asset) (*entity.Asset, error) {
+						assert.True(t, asset.MetadataStripped)
+						return asset, nil
+					})
+				uploadRepo.EXPECT().Delete(gomock.Any(), upload.ID).Return(nil)
+			},
+		},
+		{
+			name:   "正常系: 同じ内容の画像が既にある場合はアップロードされたファイルを消す",
+			upload: newUpload("png", "image/png", int64(len(pngHeader))),
+			userID: userID,
+			setup: func(t *testing.T, upload *entity.AssetUpload, repo *mock.MockAssetRepository, uploadRepo *mock.MockAssetUploadRepository, processor *mock.MockImageProcessor) {
+				repo.EXPECT().
+					HeadFile(gomock.Any(), upload.ID, "png").
+					Return(&entity.StoredObject{Size: upload.Size, ContentType: "image/png"}, nil)
+				repo.EXPECT().
+					OpenFile(gomock.Any(), upload.ID, "png").
+					DoAndReturn(func(ctx context.Context, assetUUID uuid.UUID, extension string) (io.ReadCloser, error) {
+						return io.NopCloser(bytes.NewReader(pngHeader)), nil
+					}).
+					Times(2)
+				repo.EXPECT().
+					FileURL(upload.ID, "png").
+					Return("https://example.com/image/"+upload.ID.String()+"/origin.png", "image")
+				processor.EXPECT().StripMetadata(gomock.Any(), pngHeader, "image/png").Return(&entity.StrippedImage{Data: []byte("stripped")}, nil)
+				processor.EXPECT().GenerateVariants(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+				repo.EXPECT().
+					UploadFile(gomock.Any(), gomock.Any(), upload.ID, "png", "image/png", gomock.Any()).
+					Return(&entity.UploadedFile{URL: "https://example.com/image/other/origin.png", AssetType: "image", Hash: "hash", Deduplicated: true}, nil)
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, asset *entity.Asset) (*entity.Asset, error) {
+						assert.Equal(t, "https://example.com/image/other/origin.png", asset.URL)
+						assert.Equal(t, "hash", asset.Hash)
+						return asset, nil
+					})
+				repo.EXPECT().DeleteFile(gomock.Any(), upload.ID, "png").Return(nil)
+				uploadRepo.EXPECT().Delete(gomock.Any(), upload.ID).Return(nil)
+			},
+		},
+		{
+			name:   "異常系: 他のユーザーのアップロード",
+			upload: newUpload("mp4", "video/mp4", 10),
+			userID: uuid.New(),
+			setup: func(t *testing.T, upload *entity.AssetUpload, repo *mock.MockAssetRepository, uploadRepo *mock.MockAssetUploadRepository, processor *mock.MockImageProcessor) {
+			},
+			wantErr: domainerrors.ErrAssetUploadNotFound,
+		},
+		{
+			name: "異常系: 有効期限切れ",
+			upload: func() *entity.AssetUpload {
+				upload := newUpload("mp4", "video/mp4", 10)
+				upload.ExpiresAt = time.Now().Add(-time.Minute)
+				return upload
+			}(),
+			userID: userID,
+			setup: func(t *testing.T, upload *entity.AssetUpload, repo *mock.MockAssetRepository, uploadRepo *mock.MockAssetUploadRepository, processor *mock.MockImageProcessor) {
+			},
+			wantErr: domainerrors.ErrAssetUploadExpired,
+		},
+		{
+			name:   "異常系: まだアップロードされていない",
+			upload: newUpload("mp4", "video/mp4", 10),
+			userID: userID,
+			setup: func(t *testing.T, upload *entity.AssetUpload, repo *mock.MockAssetRepository, uploadRepo *mock.MockAssetUploadRepository, processor *mock.MockImageProcessor) {
+				repo.EXPECT().HeadFile(gomock.Any(), upload.ID, "mp4").Return(nil, domainerrors.ErrUploadedFileNotFound)
+				repo.EXPECT().DeleteFile(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domainerrors.ErrUploadedFileNotFound,
+		},
+		{
+			name:   "異常系: サイズが申告と異なる場合はファイルを消す",
+			upload: newUpload("mp4", "video/mp4", 10),
+			userID: userID,
+			setup: func(t *testing.T, upload *entity.AssetUpload, repo *mock.MockAssetRepository, uploadRepo *mock.MockAssetUploadRepository, processor *mock.MockImageProcessor) {
+				repo.EXPECT().
+					HeadFile(gomock.Any(), upload.ID, "mp4").
+					Return(&entity.StoredObject{Size: 11, ContentType: "video/mp4"}, nil)
+				repo.EXPECT().DeleteFile(gomock.Any(), upload.ID, "mp4").Return(nil)
+			},
+			wantErr: domainerrors.ErrUploadedFileMismatch,
+		},
+		{
+			name:   "異常系: 内容が拡張子と一致しない場合はファイルを消す",
+			upload: newUpload("mp4", "video/mp4", int64(len(pngHeader))),
+			userID: userID,
+			setup: func(t *testing.T, upload *entity.AssetUpload, repo *mock.MockAssetRepository, uploadRepo *mock.MockAssetUploadRepository, processor *mock.MockImageProcessor) {
+				repo.EXPECT().
+					HeadFile(gomock.Any(), upload.ID, "mp4").
+					Return(&entity.StoredObject{Size: upload.Size, ContentType: "video/mp4"}, nil)
+				repo.EXPECT().
+					OpenFile(gomock.Any(), upload.ID, "mp4").
+					Return(io.NopCloser(bytes.NewReader(pngHeader)), nil)
+				repo.EXPECT().DeleteFile(gomock.Any(), upload.ID, "mp4").Return(nil)
+			},
+			wantErr: domainerrors.ErrFileTypeMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockAssetRepository(ctrl)
+			mockUploadRepo := mock.NewMockAssetUploadRepository(ctrl)
+			mockProcessor := mock.NewMockImageProcessor(ctrl)
+			expectNoPlaceholder(mockProcessor)
+			mockUploadRepo.EXPECT().GetByID(gomock.Any(), tt.upload.ID).Return(tt.upload, nil)
+			tt.setup(t, tt.upload, mockRepo, mockUploadRepo, mockProcessor)
+
+			uc := usecase.NewAssetUseCase(mockRepo, mockUploadRepo, usecase.AssetProcessors{ImageProcessor: mockProcessor}, 15*time.Minute, 24*time.Hour, 0)
+
+			got, err := uc.CompleteUpload(context.Background(), tt.userID, tt.upload.ID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.upload.ID, got.ID)
+		})
+	}
+}
+
+func TestAssetUseCase_FindAssetByHash(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	sum := sha256.Sum256([]byte("content"))
+	hash := hex.EncodeToString(sum[:])
+
+	tests := []struct {
+		name    string
+		hash    string
+		setup   func(repo *mock.MockAssetRepository)
+		wantErr error
+	}{
+		{
+			name: "正常系: 同じ内容のアセットを返す",
+			hash: hash,
+			setup: func(repo *mock.MockAssetRepository) {
+				repo.EXPECT().FindByHash(gomock.Any(), userID, hash).Return(&entity.Asset{ID: uuid.New(), UserID: userID, Hash: hash}, nil)
+			},
+		},
+		{
+			name: "正常系: 大文字で書いたハッシュでも探せる",
+			hash: strings.ToUpper(hash),
+			setup: func(repo *mock.MockAssetRepository) {
+				repo.EXPECT().FindByHash(gomock.Any(), userID, hash).Return(&entity.Asset{ID: uuid.New(), UserID: userID, Hash: hash}, nil)
+			},
+		},
+		{
+			name: "異常系: アセットがない",
+			hash: hash,
+			setup: func(repo *mock.MockAssetRepository) {
+				repo.EXPECT().FindByHash(gomock.Any(), userID, hash).Return(nil, domainerrors.ErrAssetNotFound)
+			},
+			wantErr: domainerrors.ErrAssetNotFound,
+		},
+		{
+			name: "異常系: SHA-256 ではない",
+			hash: "not-a-hash",
+			setup: func(repo *mock.MockAssetRepository) {
+				repo.EXPECT().FindByHash(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domainerrors.ErrInvalidRequestBody,
+		},
+		{
+			name: "異常系: 空のハッシュは探さない",
+			hash: "",
+			setup: func(repo *mock.MockAssetRepository) {
+				repo.EXPECT().FindByHash(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domainerrors.ErrInvalidRequestBody,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockAssetRepository(ctrl)
+			tt.setup(mockRepo)
+
+			uc := usecase.NewAssetUseCase(mockRepo, mock.NewMockAssetUploadRepository(ctrl), usecase.AssetProcessors{}, 15*time.Minute, 24*time.Hour, 0)
+
+			got, err := uc.FindAssetByHash(context.Background(), userID, tt.hash)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, hash, got.Hash)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+)
+
+// zipExtension は中身を検査してから保存する ZIP の拡張子
+const zipExtension = "zip"
+
+func (uc *assetUseCase) GetZipManifest(ctx context.Context, assetID uuid.UUID) (*entity.ZipManifest, error) {
+	return uc.assetRepo.GetZipManifest(ctx, assetID)
+}
+
+// inspectZip は ZIP の中身の一覧を作る。読み終えたら src を先頭に戻す
+func (uc *assetUseCase) inspectZip(ctx context.Context, src io.ReadSeeker) (*entity.ZipManifest, error) {
+	manifest, err := uc.archiveInspector.InspectZip(ctx, src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect zip: %w", err)
+	}
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
+		return nil, domainerrors.ErrFailedToOpenFile
+	}
+	return manifest, nil
+}
+
+// inspectUploadedZip は直接アップロードされた ZIP の中身の一覧を作る
+func (uc *assetUseCase) inspectUploadedZip(ctx context.Context, assetID uuid.UUID) (*entity.ZipManifest, error) {
+	body, err := uc.assetRepo.OpenFile(ctx, assetID, zipExtension)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	manifest, err := uc.archiveInspector.InspectZip(ctx, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect zip: %w", err)
+	}
+	return manifest, nil
+}
+
+// extractPlayable は ZIP にブラウザで遊べるビルドが含まれる場合に展開して保存し、入口の URL を返す。
+// 遊べなくてもアセットは使えるため、展開に失敗した場合は展開した分を消して空を返す
+func (uc *assetUseCase) extractPlayable(ctx context.Context, assetID uuid.UUID, manifest *entity.ZipManifest, src io.Reader) string {
+	dir, ok := manifest.PlayRoot()
+	if !ok {
+		return ""
+	}
+	err := uc.archiveInspector.ExtractZip(ctx, src, dir, func(filePath string, body io.Reader) error {
+		return uc.assetRepo.UploadPlayFile(ctx, assetID, filePath, body)
+	})
+	if err != nil {
+		log.Printf("プレイ用ファイルの展開に失敗しました (asset_id=%s): %v", assetID.String(), err)
+		if err := uc.assetRepo.DeletePlayFiles(ctx, assetID); err != nil {
+			log.Printf("プレイ用ファイルの削除に失敗しました (asset_id=%s): %v", assetID.String(), err)
+		}
+		return ""
+	}
+	return uc.assetRepo.PlayURL(assetID, entity.PlayIndexFile)
+}
+
+// extractUploadedPlayable は直接アップロードされた ZIP からブラウザで遊べるビルドを展開する
+func (uc *assetUseCase) extractUploadedPlayable(ctx context.Context, assetID uuid.UUID, manifest *entity.ZipManifest) string {
+	if _, ok := manifest.PlayRoot(); !ok {
+		return ""
+	}
+	body, err := uc.assetRepo.OpenFile(ctx, assetID, zipExtension)
+	if err != nil {
+		log.Printf("プレイ用ファイルの展開に失敗しました (asset_id=%s): %v", assetID.String(), err)
+		return ""
+	}
+	defer body.Close()
+	return uc.extractPlayable(ctx, assetID, manifest, body)
+}
+
+// discardPlayFiles は登録できなかったアセットから展開したビルドのファイルを消す
+func (uc *assetUseCase) discardPlayFiles(ctx context.Context, asset *entity.Asset) {
+	if asset.PlayURL == "" {
+		return
+	}
+	if err := uc.assetRepo.DeletePlayFiles(ctx, asset.ID); err != nil {
+		log.Printf("プレイ用ファイルの削除に失敗しました (asset_id=%s): %v", asset.ID.String(), err)
+	}
+}
+
+// isRejectedZip は ZIP の中身が安全でないため受け付けられないエラーかを返す
+func isRejectedZip(err error) bool {
+	return errors.Is(err, domainerrors.ErrInvalidZip) ||
+		errors.Is(err, domainerrors.ErrZipBomb) ||
+		errors.Is(err, domainerrors.ErrUnsafeZipPath)
+}
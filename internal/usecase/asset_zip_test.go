@@ -0,0 +1,205 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/usecase"
+	"github.com/simesaba80/toybox-back/internal/usecase/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAssetUseCase_UploadFile_Zip(t *testing.T) {
+	t.Parallel()
+
+	zipContent := []byte("PK\x03\x04 zip content")
+	manifest := &entity.ZipManifest{
+		Entries:   []*entity.ZipEntry{{Path: "game/index.html", Size: 100}},
+		TotalSize: 100,
+		Contents:  []string{entity.ZipContentUnityWebGL},
+	}
+
+	tests := []struct {
+		name        string
+		setup       func(t *testing.T, repo *mock.MockAssetRepository, inspector *mock.MockArchiveInspector)
+		wantErr     error
+		wantPlayURL string
+	}{
+		{
+			name: "正常系: 中身の一覧と一緒にアセットを登録する",
+			setup: func(t *testing.T, repo *mock.MockAssetRepository, inspector *mock.MockArchiveInspector) {
+				inspector.EXPECT().
+					InspectZip(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, src io.Reader) (*entity.ZipManifest, error) {
+						_, err := io.ReadAll(src)
+						assert.NoError(t, err)
+						return manifest, nil
+					})
+				// 検査で読み終えたファイルも先頭から保存する
+				repo.EXPECT().
+					UploadFile(gomock.Any(), gomock.Any(), gomock.Any(), "zip", "application/zip", gomock.Any()).
+					DoAndReturn(func(ctx context.Context, body io.ReadSeeker, assetUUID uuid.UUID, extension string, contentType string, hash string) (*entity.UploadedFile, error) {
+						data, err := io.ReadAll(body)
+						assert.NoError(t, err)
+						assert.Equal(t, zipContent, data)
+						return &entity.UploadedFile{URL: "https://example.com/zip/origin.zip", AssetType: "zip", Size: int64(len(data))}, nil
+					})
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, asset *entity.Asset) (*entity.Asset, error) {
+						assert.Equal(t, manifest, asset.ZipManifest)
+						return asset, nil
+					})
+				// index.html を含むビルドはブラウザで遊べるよう展開する
+				inspector.EXPECT().
+					ExtractZip(gomock.Any(), gomock.Any(), "game", gomock.Any()).
+					DoAndReturn(func(ctx context.Context, src io.Reader, dir string, fn func(string, io.Reader) error) error {
+						return fn("index.html", bytes.NewReader([]byte("<html></html>")))
+					})
+				repo.EXPECT().UploadPlayFile(gomock.Any(), gomock.Any(), "index.html", gomock.Any()).Return(nil)
+				repo.EXPECT().PlayURL(gomock.Any(), "index.html").Return("https://example.com/play/index.html")
+			},
+			wantPlayURL: "https://example.com/play/index.html",
+		},
+		{
+			name: "正常系: ビルドの展開に失敗してもアセットは登録する",
+			setup: func(t *testing.T, repo *mock.MockAssetRepository, inspector *mock.MockArchiveInspector) {
+				inspector.EXPECT().InspectZip(gomock.Any(), gomock.Any()).Return(manifest, nil)
+				inspector.EXPECT().
+					ExtractZip(gomock.Any(), gomock.Any(), "game", gomock.Any()).
+					DoAndReturn(func(ctx context.Context, src io.Reader, dir string, fn func(string, io.Reader) error) error {
+						return fn("index.html", bytes.NewReader([]byte("<html></html>")))
+					})
+				repo.EXPECT().UploadPlayFile(gomock.Any(), gomock.Any(), "index.html", gomock.Any()).Return(fmt.Errorf("s3 unavailable"))
+				// 途中まで展開したファイルは消す
+				repo.EXPECT().DeletePlayFiles(gomock.Any(), gomock.Any()).Return(nil)
+				repo.EXPECT().
+					UploadFile(gomock.Any(), gomock.Any(), gomock.Any(), "zip", "application/zip", gomock.Any()).
+					Return(&entity.UploadedFile{URL: "https://example.com/zip/origin.zip", AssetType: "zip", Size: int64(len(zipContent))}, nil)
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, asset *entity.Asset) (*entity.Asset, error) {
+						return asset, nil
+					})
+			},
+		},
+		{
+			name: "正常系: index.html がない ZIP は展開しない",
+			setup: func(t *testing.T, repo *mock.MockAssetRepository, inspector *mock.MockArchiveInspector) {
+				inspector.EXPECT().
+					InspectZip(gomock.Any(), gomock.Any()).
+					Return(&entity.ZipManifest{Entries: []*entity.ZipEntry{{Path: "README.md", Size: 6}}, TotalSize: 6}, nil)
+				inspector.EXPECT().ExtractZip(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+				repo.EXPECT().
+					UploadFile(gomock.Any(), gomock.Any(), gomock.Any(), "zip", "application/zip", gomock.Any()).
+					Return(&entity.UploadedFile{URL: "https://example.com/zip/origin.zip", AssetType: "zip", Size: int64(len(zipContent))}, nil)
+				repo.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, asset *entity.Asset) (*entity.Asset, error) {
+						return asset, nil
+					})
+			},
+		},
+		{
+			name: "異常系: ZIP を保存できなければ展開したビルドも消す",
+			setup: func(t *testing.T, repo *mock.MockAssetRepository, inspector *mock.MockArchiveInspector) {
+				inspector.EXPECT().InspectZip(gomock.Any(), gomock.Any()).Return(manifest, nil)
+				inspector.EXPECT().
+					ExtractZip(gomock.Any(), gomock.Any(), "game", gomock.Any()).
+					DoAndReturn(func(ctx context.Context, src io.Reader, dir string, fn func(string, io.Reader) error) error {
+						return fn("index.html", bytes.NewReader([]byte("<html></html>")))
+					})
+				repo.EXPECT().UploadPlayFile(gomock.Any(), gomock.Any(), "index.html", gomock.Any()).Return(nil)
+				repo.EXPECT().PlayURL(gomock.Any(), "index.html").Return("https://example.com/play/index.html")
+				repo.EXPECT().
+					UploadFile(gomock.Any(), gomock.Any(), gomock.Any(), "zip", "application/zip", gomock.Any()).
+					Return(nil, domainerrors.ErrFailedToUploadFile)
+				repo.EXPECT().DeletePlayFiles(gomock.Any(), gomock.Any()).Return(nil)
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domainerrors.ErrFailedToUploadFile,
+		},
+		{
+			name: "異常系: ZIP爆弾は保存しない",
+			setup: func(t *testing.T, repo *mock.MockAssetRepository, inspector *mock.MockArchiveInspector) {
+				inspector.EXPECT().
+					InspectZip(gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("%w: more than 10 bytes", domainerrors.ErrZipBomb))
+				repo.EXPECT().UploadFile(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: domainerrors.ErrZipBomb,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockAssetRepository(ctrl)
+			mockInspector := mock.NewMockArchiveInspector(ctrl)
+			tt.setup(t, mockRepo, mockInspector)
+
+			uc := usecase.NewAssetUseCase(mockRepo, mock.NewMockAssetUploadRepository(ctrl), usecase.AssetProcessors{ArchiveInspector: mockInspector}, 15*time.Minute, 24*time.Hour, 0)
+
+			got, err := uc.UploadFile(context.Background(), newFileHeader(t, "game.zip", zipContent), uuid.New())
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "zip", got.AssetType)
+			assert.Equal(t, tt.wantPlayURL, got.PlayURL)
+		})
+	}
+}
+
+func TestAssetUseCase_CompleteUpload_UnsafeZip(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	zipContent := []byte("PK\x03\x04 zip content")
+	userID := uuid.New()
+	upload := entity.NewAssetUpload(userID, "zip", "application/zip", int64(len(zipContent)), 15*time.Minute)
+
+	mockRepo := mock.NewMockAssetRepository(ctrl)
+	mockUploadRepo := mock.NewMockAssetUploadRepository(ctrl)
+	mockInspector := mock.NewMockArchiveInspector(ctrl)
+	mockUploadRepo.EXPECT().GetByID(gomock.Any(), upload.ID).Return(upload, nil)
+	mockRepo.EXPECT().
+		HeadFile(gomock.Any(), upload.ID, "zip").
+		Return(&entity.StoredObject{Size: upload.Size, ContentType: "application/zip"}, nil)
+	mockRepo.EXPECT().
+		OpenFile(gomock.Any(), upload.ID, "zip").
+		DoAndReturn(func(ctx context.Context, assetUUID uuid.UUID, extension string) (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(zipContent)), nil
+		}).
+		Times(2)
+	mockInspector.EXPECT().
+		InspectZip(gomock.Any(), gomock.Any()).
+		Return(nil, fmt.Errorf("%w: \"../evil.sh\"", domainerrors.ErrUnsafeZipPath))
+	// 展開先の外を指すファイルは残しておく理由がないため消す
+	mockRepo.EXPECT().DeleteFile(gomock.Any(), upload.ID, "zip").Return(nil)
+	mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+
+	uc := usecase.NewAssetUseCase(mockRepo, mockUploadRepo, usecase.AssetProcessors{ArchiveInspector: mockInspector}, 15*time.Minute, 24*time.Hour, 0)
+
+	got, err := uc.CompleteUpload(context.Background(), userID, upload.ID)
+	assert.ErrorIs(t, err, domainerrors.ErrUnsafeZipPath)
+	assert.Nil(t, got)
+}
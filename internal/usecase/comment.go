@@ -2,34 +2,73 @@ package usecase
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
 	"github.com/simesaba80/toybox-back/internal/domain/repository"
 )
 
+// defaultAnonymousName は匿名コメントで名前が指定されなかったときの表示名です
+const defaultAnonymousName = "名無しさん"
+
+// defaultCommentTreeDepth はコメントを木で返すときに、深さが指定されなかった場合の階層数です
+const defaultCommentTreeDepth = 3
+
+// AnonymousCommentConfig はログインしていないユーザーのコメントの設定です。
+type AnonymousCommentConfig struct {
+	// Enabled がfalseの場合、匿名コメントは受け付けません
+	Enabled bool
+	// Salt は投稿元IPアドレスからフィンガープリントを作るときのソルトです。空や推測できる値だとフィンガープリントからIPアドレスを割り出せます
+	Salt string
+}
+
 type ICommentUsecase interface {
-	GetCommentsByWorkID(ctx context.Context, workID uuid.UUID) ([]*entity.Comment, error)
-	CreateComment(ctx context.Context, content string, workID, userID uuid.UUID, replyAt string) (*entity.Comment, error)
+	GetCommentsByWorkID(ctx context.Context, workID, viewerID uuid.UUID) ([]*entity.Comment, error)
+	GetCommentTree(ctx context.Context, workID, viewerID uuid.UUID, depth *int) ([]*entity.CommentNode, error)
+	GetReplies(ctx context.Context, workID, commentID, viewerID uuid.UUID, depth *int) ([]*entity.CommentNode, error)
+	CreateComment(ctx context.Context, content string, workID, userID uuid.UUID, clientIP, replyAt string) (*entity.Comment, error)
+	CreateAnonymousComment(ctx context.Context, content string, workID uuid.UUID, anonymousName, clientIP, replyAt string) (*entity.Comment, error)
+	UpdateComment(ctx context.Context, workID, commentID, userID uuid.UUID, content string) (*entity.Comment, error)
+	DeleteComment(ctx context.Context, workID, commentID, userID uuid.UUID) error
 }
 
 type commentUsecase struct {
-	commentRepo repository.CommentRepository
-	workRepo    repository.WorkRepository
-	timeout     time.Duration
+	commentRepo      repository.CommentRepository
+	workRepo         repository.WorkRepository
+	notificationRepo repository.NotificationRepository
+	eventBroker      repository.EventBroker
+	userRepo         repository.UserRepository
+	mentionRepo      repository.MentionRepository
+	reactionRepo     repository.ReactionRepository
+	filters          []CommentFilter
+	anonymousComment AnonymousCommentConfig
+	timeout          time.Duration
 }
 
-func NewCommentUsecase(commentRepo repository.CommentRepository, workRepo repository.WorkRepository, timeout time.Duration) ICommentUsecase {
+func NewCommentUsecase(commentRepo repository.CommentRepository, workRepo repository.WorkRepository, notificationRepo repository.NotificationRepository, eventBroker repository.EventBroker, userRepo repository.UserRepository, mentionRepo repository.MentionRepository, reactionRepo repository.ReactionRepository, filters []CommentFilter, anonymousComment AnonymousCommentConfig, timeout time.Duration) ICommentUsecase {
 	return &commentUsecase{
-		commentRepo: commentRepo,
-		workRepo:    workRepo,
-		timeout:     time.Second * 30,
+		commentRepo:      commentRepo,
+		workRepo:         workRepo,
+		notificationRepo: notificationRepo,
+		eventBroker:      eventBroker,
+		userRepo:         userRepo,
+		mentionRepo:      mentionRepo,
+		reactionRepo:     reactionRepo,
+		filters:          filters,
+		anonymousComment: anonymousComment,
+		timeout:          time.Second * 30,
 	}
 }
 
-func (uc *commentUsecase) GetCommentsByWorkID(ctx context.Context, workID uuid.UUID) ([]*entity.Comment, error) {
+// GetCommentsByWorkID は作品のコメントを取得します。viewerIDは閲覧しているユーザーで、ログインしていない場合はuuid.Nilです。
+func (uc *commentUsecase) GetCommentsByWorkID(ctx context.Context, workID, viewerID uuid.UUID) ([]*entity.Comment, error) {
 	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
 	defer cancel()
 
@@ -37,47 +76,278 @@ func (uc *commentUsecase) GetCommentsByWorkID(ctx context.Context, workID uuid.U
 	if err != nil {
 		return nil, fmt.Errorf("failed to get comments by work ID %s: %w", workID.String(), err)
 	}
+	attachMentions(ctx, uc.userRepo, comments)
+	attachCommentReactions(ctx, uc.reactionRepo, comments, viewerID)
 
 	return comments, nil
 }
 
-func (uc *commentUsecase) CreateComment(ctx context.Context, content string, workID, userID uuid.UUID, replyAt string) (*entity.Comment, error) {
+// GetCommentTree は作品のコメントを返信の木にして返します。depthより深い返信はGetRepliesで取得します。
+func (uc *commentUsecase) GetCommentTree(ctx context.Context, workID, viewerID uuid.UUID, depth *int) ([]*entity.CommentNode, error) {
+	comments, err := uc.GetCommentsByWorkID(ctx, workID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	return entity.BuildCommentTree(comments, "", commentTreeDepth(depth)), nil
+}
+
+// GetReplies はコメントに付いた返信をdepth階層まで木にして返します。
+func (uc *commentUsecase) GetReplies(ctx context.Context, workID, commentID, viewerID uuid.UUID, depth *int) ([]*entity.CommentNode, error) {
 	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
 	defer cancel()
 
-	if workID == uuid.Nil {
+	parent, err := uc.commentRepo.FindByID(ctx, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment %s: %w", commentID.String(), err)
+	}
+	if parent.WorkID != workID {
+		return nil, domainerrors.ErrCommentNotFound
+	}
+
+	comments, err := uc.commentRepo.FindByWorkID(ctx, workID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comments by work ID %s: %w", workID.String(), err)
+	}
+	attachMentions(ctx, uc.userRepo, comments)
+	attachCommentReactions(ctx, uc.reactionRepo, comments, viewerID)
+	return entity.BuildCommentTree(comments, commentID.String(), commentTreeDepth(depth)), nil
+}
+
+func commentTreeDepth(depth *int) int {
+	if depth == nil {
+		return defaultCommentTreeDepth
+	}
+	return *depth
+}
+
+func (uc *commentUsecase) CreateComment(ctx context.Context, content string, workID, userID uuid.UUID, clientIP, replyAt string) (*entity.Comment, error) {
+	comment := entity.NewComment(content, workID, userID, replyAt)
+	comment.ClientFingerprint = uc.fingerprint(clientIP)
+	return uc.createComment(ctx, comment)
+}
+
+func (uc *commentUsecase) CreateAnonymousComment(ctx context.Context, content string, workID uuid.UUID, anonymousName, clientIP, replyAt string) (*entity.Comment, error) {
+	if !uc.anonymousComment.Enabled {
+		return nil, domainerrors.ErrAnonymousCommentDisabled
+	}
+	if anonymousName == "" {
+		anonymousName = defaultAnonymousName
+	}
+	return uc.createComment(ctx, entity.NewAnonymousComment(content, workID, anonymousName, uc.fingerprint(clientIP), replyAt))
+}
+
+// fingerprint は投稿元IPアドレスをソルト付きでハッシュ化し、IPアドレスそのものを保存せずに同じ投稿者を見分けられるようにします。
+func (uc *commentUsecase) fingerprint(clientIP string) string {
+	mac := hmac.New(sha256.New, []byte(uc.anonymousComment.Salt))
+	mac.Write([]byte(clientIP))
+	return hex.EncodeToString(mac.Sum(nil))[:16]
+}
+
+func (uc *commentUsecase) createComment(ctx context.Context, comment *entity.Comment) (*entity.Comment, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	if comment.WorkID == uuid.Nil {
 		return nil, fmt.Errorf("work ID is required")
 	}
-	if content == "" {
+	if comment.Content == "" {
 		return nil, fmt.Errorf("content is required")
 	}
 
+	for i, filter := range uc.filters {
+		if err := filter.Check(ctx, comment); err != nil {
+			releaseComment(ctx, uc.filters[:i], comment)
+			return nil, err
+		}
+	}
+
+	createdComment, replyTarget, err := uc.saveComment(ctx, comment)
+	if err != nil {
+		releaseComment(ctx, uc.filters, comment)
+		return nil, err
+	}
+	for _, filter := range uc.filters {
+		filter.Accepted(ctx, createdComment)
+	}
+
+	uc.notifyComment(ctx, createdComment, replyTarget)
+	uc.mentionComment(ctx, createdComment)
+
+	return createdComment, nil
+}
+
+// releaseComment は保存しなかったコメントを、Checkを通過したフィルターに返します。
+func releaseComment(ctx context.Context, filters []CommentFilter, comment *entity.Comment) {
+	for _, filter := range filters {
+		filter.Released(ctx, comment)
+	}
+}
+
+// saveComment は作品と返信先を確認してからコメントを保存し、保存したコメントと返信先のコメントを返します。
+func (uc *commentUsecase) saveComment(ctx context.Context, comment *entity.Comment) (*entity.Comment, *entity.Comment, error) {
+	workID := comment.WorkID
+	replyAt := comment.ReplyAt
+
 	// Workの存在確認
 	exists, err := uc.workRepo.ExistsById(ctx, workID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check work existence: %w", err)
+		return nil, nil, fmt.Errorf("failed to check work existence: %w", err)
 	}
 	if !exists {
-		return nil, fmt.Errorf("work not found: %s", workID.String())
+		return nil, nil, fmt.Errorf("work not found: %s", workID.String())
 	}
 
-	// replyAtがある場合は返信先にコメントが存在するか確認
+	// replyAtがある場合は返信先に同じ作品のコメントが存在するか確認
+	var replyTarget *entity.Comment
 	if replyAt != "" {
 		replyID, err := uuid.Parse(replyAt)
 		if err != nil {
-			return nil, fmt.Errorf("invalid reply_at format: %w", err)
+			return nil, nil, fmt.Errorf("invalid reply_at format: %w", err)
 		}
-		_, err = uc.commentRepo.FindByID(ctx, replyID)
+		replyTarget, err = uc.commentRepo.FindByID(ctx, replyID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to validate reply target comment %s: %w", replyAt, err)
+			return nil, nil, fmt.Errorf("failed to validate reply target comment %s: %w", replyAt, err)
+		}
+		if replyTarget.WorkID != workID {
+			return nil, nil, domainerrors.ErrInvalidReplyTarget
 		}
 	}
-	comment := entity.NewComment(content, workID, userID, replyAt)
 
 	createdComment, err := uc.commentRepo.Create(ctx, comment)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create comment: %w", err)
+		return nil, nil, fmt.Errorf("failed to create comment: %w", err)
 	}
+	return createdComment, replyTarget, nil
+}
 
-	return createdComment, nil
+// UpdateComment はコメントの本文を編集します。編集できるのはコメントの投稿者だけです。
+// 編集後の本文はNGワードやリンクの数など、本文だけを見るフィルターで投稿時と同じように検査します。
+func (uc *commentUsecase) UpdateComment(ctx context.Context, workID, commentID, userID uuid.UUID, content string) (*entity.Comment, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	comment, err := uc.findCommentInWork(ctx, workID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if comment.Anonymous || comment.UserID != userID {
+		return nil, domainerrors.ErrCommentForbidden
+	}
+
+	comment.Edit(content)
+	for _, filter := range uc.filters {
+		if filter, ok := filter.(contentFilter); ok {
+			if err := filter.Check(ctx, comment); err != nil {
+				return nil, err
+			}
+		}
+	}
+	updatedComment, err := uc.commentRepo.Update(ctx, comment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update comment %s: %w", commentID.String(), err)
+	}
+	// 返却するコメントには投稿者の情報を付けたままにする
+	updatedComment.User = comment.User
+	uc.mentionComment(ctx, updatedComment)
+	// 編集で本文から消えたユーザーへのメンションは取り消す
+	uc.deleteMentions(ctx, commentID, mentionedUserIDs(updatedComment.Mentions))
+	return updatedComment, nil
+}
+
+// mentionComment はコメントの本文のメンションを解決してコメントに設定し、メンションされたユーザーに記録します。
+// 匿名コメントは誰からのメンションか示せないため、表示用に解決するだけで記録はしません。
+func (uc *commentUsecase) mentionComment(ctx context.Context, comment *entity.Comment) {
+	attachMentions(ctx, uc.userRepo, []*entity.Comment{comment})
+	if comment.Anonymous {
+		return
+	}
+	commentID := comment.ID
+	recordMentions(ctx, uc.mentionRepo, comment.Mentions, comment.UserID, comment.WorkID, &commentID)
+}
+
+// deleteMentions はコメントからのメンションのうち、keepUserIDs以外のユーザーへのメンションを削除します。
+// メンションはコメントに付随するものなので、失敗してもコメントの操作は失敗させずログに残すだけにします。
+func (uc *commentUsecase) deleteMentions(ctx context.Context, commentID uuid.UUID, keepUserIDs []uuid.UUID) {
+	if err := uc.mentionRepo.DeleteByCommentID(ctx, commentID, keepUserIDs); err != nil {
+		log.Printf("メンションの削除に失敗しました (comment_id=%s): %v", commentID.String(), err)
+	}
+}
+
+// mentionedUserIDs はメンションされたユーザーのIDを返します。
+func mentionedUserIDs(spans []*entity.MentionSpan) []uuid.UUID {
+	userIDs := make([]uuid.UUID, 0, len(spans))
+	for _, span := range spans {
+		userIDs = append(userIDs, span.UserID)
+	}
+	return userIDs
+}
+
+// DeleteComment はコメントを削除します。削除できるのはコメントの投稿者と作品の作者です。
+// 返信が付いているコメントはスレッドを保つため、削除済みの跡として残します。
+func (uc *commentUsecase) DeleteComment(ctx context.Context, workID, commentID, userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, uc.timeout)
+	defer cancel()
+
+	comment, err := uc.findCommentInWork(ctx, workID, commentID)
+	if err != nil {
+		return err
+	}
+	if comment.Anonymous || comment.UserID != userID {
+		work, err := uc.workRepo.GetByID(ctx, workID)
+		if err != nil {
+			return fmt.Errorf("failed to get work %s: %w", workID.String(), err)
+		}
+		if work.UserID != userID {
+			return domainerrors.ErrCommentForbidden
+		}
+	}
+
+	hasReplies, err := uc.commentRepo.HasReplies(ctx, commentID)
+	if err != nil {
+		return fmt.Errorf("failed to check replies of comment %s: %w", commentID.String(), err)
+	}
+	if hasReplies {
+		err = uc.commentRepo.Tombstone(ctx, commentID)
+	} else {
+		err = uc.commentRepo.Delete(ctx, commentID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete comment %s: %w", commentID.String(), err)
+	}
+	// 削除済みの跡として残したコメントも本文は消えるため、メンションを全て取り消す
+	uc.deleteMentions(ctx, commentID, nil)
+	return nil
+}
+
+// findCommentInWork は作品に付いたコメントを取得します。別の作品のコメントや削除済みのコメントは見つからないものとして扱います。
+func (uc *commentUsecase) findCommentInWork(ctx context.Context, workID, commentID uuid.UUID) (*entity.Comment, error) {
+	comment, err := uc.commentRepo.FindByID(ctx, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment %s: %w", commentID.String(), err)
+	}
+	if comment.WorkID != workID || comment.IsDeleted() {
+		return nil, domainerrors.ErrCommentNotFound
+	}
+	return comment, nil
+}
+
+// notifyComment は返信先のコメントの投稿者と作品の作者にコメントを通知し、作品の作者にイベントを配信します。
+// 両者が同じユーザーの場合は返信の通知だけを送ります。匿名コメントへの返信は通知先がいないため通知しません。
+func (uc *commentUsecase) notifyComment(ctx context.Context, comment *entity.Comment, replyTarget *entity.Comment) {
+	commentID := comment.ID
+	notified := uuid.Nil
+	if replyTarget != nil && !replyTarget.Anonymous {
+		emitNotification(ctx, uc.notificationRepo, entity.NewNotification(replyTarget.UserID, comment.UserID, entity.NotificationTypeReply, comment.WorkID, &commentID))
+		notified = replyTarget.UserID
+	}
+
+	work, err := uc.workRepo.GetByID(ctx, comment.WorkID)
+	if err != nil {
+		return
+	}
+	publishEvent(ctx, uc.eventBroker, entity.NewEvent(work.UserID, comment.UserID, entity.EventTypeCommentCreated, comment.WorkID, &commentID))
+	if work.UserID == notified {
+		return
+	}
+	emitNotification(ctx, uc.notificationRepo, entity.NewNotification(work.UserID, comment.UserID, entity.NotificationTypeComment, comment.WorkID, &commentID))
 }
@@ -0,0 +1,264 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+)
+
+// CommentFilter はコメントを保存する前に検査し、スパムや荒らしを拒否します。
+type CommentFilter interface {
+	// Check はコメントを保存してよいか判定します。拒否する場合は*domainerrors.CommentRejectedErrorを返します。
+	Check(ctx context.Context, comment *entity.Comment) error
+	// Accepted は全てのフィルターを通過して保存されたコメントを受け取ります。投稿履歴を使うフィルターはここで記録します。
+	Accepted(ctx context.Context, comment *entity.Comment)
+	// Released はCheckを通過したものの、後のフィルターで拒否されたり保存に失敗したりしたコメントを受け取ります。
+	// Checkで枠を確保するフィルターはここで枠を返します。
+	Released(ctx context.Context, comment *entity.Comment)
+}
+
+// contentFilter は本文だけを見て判定するフィルターです。投稿履歴を使わないため、編集されたコメントの検査にも使います。
+type contentFilter interface {
+	CommentFilter
+	checksContentOnly()
+}
+
+// commentHistoryMaxAuthors を超える投稿者の履歴が溜まったら、期間外の履歴をまとめて捨てる
+const commentHistoryMaxAuthors = 10000
+
+// ngWordFilter はNGワードを含むコメントを拒否します。
+// 全角・半角や大文字・小文字の違いで回避されないよう、タグ名と同じ正規化をしてから比較します。
+type ngWordFilter struct {
+	words []string
+}
+
+func NewNGWordFilter(words []string) CommentFilter {
+	normalized := make([]string, 0, len(words))
+	for _, word := range words {
+		word = entity.NormalizeText(strings.TrimSpace(word))
+		if word != "" {
+			normalized = append(normalized, word)
+		}
+	}
+	return &ngWordFilter{words: normalized}
+}
+
+func (f *ngWordFilter) Check(_ context.Context, comment *entity.Comment) error {
+	content := entity.NormalizeText(comment.Content)
+	for _, word := range f.words {
+		if strings.Contains(content, word) {
+			return domainerrors.NewCommentRejectedError(domainerrors.CommentRejectNGWord)
+		}
+	}
+	return nil
+}
+
+func (f *ngWordFilter) Accepted(context.Context, *entity.Comment) {}
+
+func (f *ngWordFilter) Released(context.Context, *entity.Comment) {}
+
+func (f *ngWordFilter) checksContentOnly() {}
+
+// linkLimitFilter はmaxLinksより多くのリンクを含むコメントを拒否します。
+type linkLimitFilter struct {
+	maxLinks int
+}
+
+func NewLinkLimitFilter(maxLinks int) CommentFilter {
+	return &linkLimitFilter{maxLinks: maxLinks}
+}
+
+func (f *linkLimitFilter) Check(_ context.Context, comment *entity.Comment) error {
+	content := entity.NormalizeText(comment.Content)
+	links := strings.Count(content, "http://") + strings.Count(content, "https://")
+	if links > f.maxLinks {
+		return domainerrors.NewCommentRejectedError(domainerrors.CommentRejectTooManyLinks)
+	}
+	return nil
+}
+
+func (f *linkLimitFilter) Accepted(context.Context, *entity.Comment) {}
+
+func (f *linkLimitFilter) Released(context.Context, *entity.Comment) {}
+
+func (f *linkLimitFilter) checksContentOnly() {}
+
+// duplicateFilter は同じ投稿者がwindow以内に同じ内容のコメントを繰り返し投稿するのを拒否します。
+// 作品が違っても同じ内容であれば重複とみなします。
+type duplicateFilter struct {
+	history *commentHistory
+}
+
+func NewDuplicateFilter(window time.Duration) CommentFilter {
+	return &duplicateFilter{history: newCommentHistory(window)}
+}
+
+func (f *duplicateFilter) Check(_ context.Context, comment *entity.Comment) error {
+	content := duplicateKey(comment.Content)
+	for _, key := range commentAuthorKeys(comment) {
+		for _, entry := range f.history.recent(key) {
+			if entry.content == content {
+				return domainerrors.NewCommentRejectedError(domainerrors.CommentRejectDuplicate)
+			}
+		}
+	}
+	return nil
+}
+
+func (f *duplicateFilter) Accepted(_ context.Context, comment *entity.Comment) {
+	for _, key := range commentAuthorKeys(comment) {
+		f.history.add(key, comment.ID, duplicateKey(comment.Content))
+	}
+}
+
+func (f *duplicateFilter) Released(context.Context, *entity.Comment) {}
+
+// duplicateKey は空白の入れ方だけを変えた投稿も重複とみなせるよう、正規化して空白を詰めた本文を返します。
+func duplicateKey(content string) string {
+	return strings.Join(strings.Fields(entity.NormalizeText(content)), " ")
+}
+
+// rateLimitFilter は同じ投稿者がwindow以内にlimit件より多く投稿するのを拒否します。
+// ログインしているユーザーはユーザーごととIPアドレスから作ったフィンガープリントごとの両方で、
+// 匿名の投稿者はフィンガープリントごとに数えます。
+// 同時に投稿されても上限を超えないよう、Checkで枠を確保し、保存しなかったコメントの枠はReleasedで返します。
+// 履歴はプロセスごとに持つため、複数のレプリカで動かす場合はレプリカごとに上限まで投稿できます。
+type rateLimitFilter struct {
+	limit   int
+	history *commentHistory
+}
+
+func NewRateLimitFilter(limit int, window time.Duration) CommentFilter {
+	return &rateLimitFilter{limit: limit, history: newCommentHistory(window)}
+}
+
+func (f *rateLimitFilter) Check(_ context.Context, comment *entity.Comment) error {
+	keys := commentAuthorKeys(comment)
+	for i, key := range keys {
+		if !f.history.reserve(key, comment.ID, f.limit) {
+			// どれかの上限に達していれば投稿させないため、確保済みの枠も返す
+			for _, reserved := range keys[:i] {
+				f.history.remove(reserved, comment.ID)
+			}
+			return domainerrors.NewCommentRejectedError(domainerrors.CommentRejectRateLimited)
+		}
+	}
+	return nil
+}
+
+func (f *rateLimitFilter) Accepted(context.Context, *entity.Comment) {}
+
+func (f *rateLimitFilter) Released(_ context.Context, comment *entity.Comment) {
+	for _, key := range commentAuthorKeys(comment) {
+		f.history.remove(key, comment.ID)
+	}
+}
+
+// commentAuthorKeys は投稿者ごとの履歴のキーです。匿名コメントはIPアドレスから作ったフィンガープリントで区別します。
+// ログインしているユーザーのコメントは、アカウントを替えたり匿名と混ぜたりした連投も止められるよう、
+// ユーザーとフィンガープリントの両方のキーで記録します。
+func commentAuthorKeys(comment *entity.Comment) []string {
+	if comment.Anonymous {
+		return []string{"ip:" + comment.Fingerprint}
+	}
+	keys := []string{"user:" + comment.UserID.String()}
+	if comment.ClientFingerprint != "" {
+		keys = append(keys, "ip:"+comment.ClientFingerprint)
+	}
+	return keys
+}
+
+// commentHistory は投稿者ごとに直近window以内の投稿を覚えておきます。
+// 履歴はメモリに持つため、プロセスを再起動すると消え、複数のレプリカの間では共有されません。
+type commentHistory struct {
+	mu      sync.Mutex
+	window  time.Duration
+	entries map[string][]commentHistoryEntry
+}
+
+type commentHistoryEntry struct {
+	commentID uuid.UUID
+	content   string
+	at        time.Time
+}
+
+func newCommentHistory(window time.Duration) *commentHistory {
+	return &commentHistory{
+		window:  window,
+		entries: make(map[string][]commentHistoryEntry),
+	}
+}
+
+// recent は投稿者の直近window以内の投稿を返します。
+func (h *commentHistory) recent(key string) []commentHistoryEntry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.prune(key, time.Now())
+}
+
+func (h *commentHistory) add(key string, commentID uuid.UUID, content string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.append(key, commentHistoryEntry{commentID: commentID, content: content}, time.Now())
+}
+
+// reserve は投稿者の直近window以内の投稿がlimit件未満の場合に限り、確認と同時に投稿を記録します。
+func (h *commentHistory) reserve(key string, commentID uuid.UUID, limit int) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	if len(h.prune(key, now)) >= limit {
+		return false
+	}
+	h.append(key, commentHistoryEntry{commentID: commentID}, now)
+	return true
+}
+
+// remove はreserveで記録した投稿を取り消します。
+func (h *commentHistory) remove(key string, commentID uuid.UUID) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	entries := h.entries[key]
+	for i, entry := range entries {
+		if entry.commentID == commentID {
+			h.entries[key] = append(entries[:i:i], entries[i+1:]...)
+			break
+		}
+	}
+	h.prune(key, time.Now())
+}
+
+func (h *commentHistory) append(key string, entry commentHistoryEntry, now time.Time) {
+	entry.at = now
+	h.entries[key] = append(h.prune(key, now), entry)
+	if len(h.entries) > commentHistoryMaxAuthors {
+		for k := range h.entries {
+			h.prune(k, now)
+		}
+	}
+}
+
+// prune はwindowより古い投稿を捨て、残った投稿を返します。投稿が残らない投稿者は履歴から消します。
+func (h *commentHistory) prune(key string, now time.Time) []commentHistoryEntry {
+	entries := h.entries[key]
+	i := 0
+	for i < len(entries) && now.Sub(entries[i].at) > h.window {
+		i++
+	}
+	entries = entries[i:]
+	if len(entries) == 0 {
+		delete(h.entries, key)
+		return nil
+	}
+	h.entries[key] = entries
+	return entries
+}
@@ -0,0 +1,290 @@
+package usecase_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/usecase"
+	"github.com/simesaba80/toybox-back/internal/usecase/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func assertRejected(t *testing.T, err error, reason domainerrors.CommentRejectReason) {
+	t.Helper()
+
+	var rejectedErr *domainerrors.CommentRejectedError
+	if assert.ErrorAs(t, err, &rejectedErr) {
+		assert.Equal(t, reason, rejectedErr.Reason)
+	}
+	assert.ErrorIs(t, err, domainerrors.ErrCommentRejected)
+}
+
+func TestNGWordFilter(t *testing.T) {
+	filter := usecase.NewNGWordFilter([]string{"Spam", " ", "バカ"})
+
+	tests := []struct {
+		name     string
+		content  string
+		rejected bool
+	}{
+		{name: "正常系: NGワードを含まない", content: "素敵な作品ですね"},
+		{name: "異常系: NGワードを含む", content: "this is spam", rejected: true},
+		{name: "異常系: 全角や大文字でもNGワードとみなす", content: "ＳＰＡＭです", rejected: true},
+		{name: "異常系: カタカナのNGワード", content: "バカじゃないの", rejected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := filter.Check(context.Background(), &entity.Comment{Content: tt.content})
+			if tt.rejected {
+				assertRejected(t, err, domainerrors.CommentRejectNGWord)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLinkLimitFilter(t *testing.T) {
+	filter := usecase.NewLinkLimitFilter(1)
+
+	assert.NoError(t, filter.Check(context.Background(), &entity.Comment{Content: "参考: https://example.com"}))
+	err := filter.Check(context.Background(), &entity.Comment{Content: "https://example.com ｈｔｔｐ://example.org"})
+	assertRejected(t, err, domainerrors.CommentRejectTooManyLinks)
+}
+
+func TestDuplicateFilter(t *testing.T) {
+	ctx := context.Background()
+	filter := usecase.NewDuplicateFilter(50 * time.Millisecond)
+	userID := uuid.New()
+	comment := &entity.Comment{UserID: userID, Content: "いい作品ですね"}
+
+	assert.NoError(t, filter.Check(ctx, comment))
+	filter.Accepted(ctx, comment)
+
+	// 空白の入れ方を変えただけの投稿も重複とみなす
+	err := filter.Check(ctx, &entity.Comment{UserID: userID, Content: " いい作品ですね  "})
+	assertRejected(t, err, domainerrors.CommentRejectDuplicate)
+	// 別の投稿者や匿名の投稿者は重複にならない
+	assert.NoError(t, filter.Check(ctx, &entity.Comment{UserID: uuid.New(), Content: "いい作品ですね"}))
+	assert.NoError(t, filter.Check(ctx, &entity.Comment{Anonymous: true, Fingerprint: "abc", Content: "いい作品ですね"}))
+
+	time.Sleep(60 * time.Millisecond)
+	assert.NoError(t, filter.Check(ctx, comment))
+}
+
+func TestRateLimitFilter(t *testing.T) {
+	ctx := context.Background()
+	filter := usecase.NewRateLimitFilter(2, 50*time.Millisecond)
+	anonymous := &entity.Comment{Anonymous: true, Fingerprint: "abc", Content: "コメント"}
+
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, filter.Check(ctx, anonymous))
+		filter.Accepted(ctx, anonymous)
+	}
+	assertRejected(t, filter.Check(ctx, anonymous), domainerrors.CommentRejectRateLimited)
+	// 別のIPアドレスからの投稿は数えない
+	assert.NoError(t, filter.Check(ctx, &entity.Comment{Anonymous: true, Fingerprint: "def", Content: "コメント"}))
+
+	time.Sleep(60 * time.Millisecond)
+	assert.NoError(t, filter.Check(ctx, anonymous))
+}
+
+func TestRateLimitFilter_SignedInCountsUserAndIP(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("同じIPアドレスからならアカウントを替えても数える", func(t *testing.T) {
+		filter := usecase.NewRateLimitFilter(2, time.Minute)
+		for i := 0; i < 2; i++ {
+			comment := &entity.Comment{ID: uuid.New(), UserID: uuid.New(), ClientFingerprint: "abc", Content: "コメント"}
+			assert.NoError(t, filter.Check(ctx, comment))
+			filter.Accepted(ctx, comment)
+		}
+		assertRejected(t, filter.Check(ctx, &entity.Comment{ID: uuid.New(), UserID: uuid.New(), ClientFingerprint: "abc"}), domainerrors.CommentRejectRateLimited)
+		// 匿名コメントとも同じ枠で数える
+		assertRejected(t, filter.Check(ctx, &entity.Comment{ID: uuid.New(), Anonymous: true, Fingerprint: "abc"}), domainerrors.CommentRejectRateLimited)
+	})
+
+	t.Run("IPアドレスを替えても同じユーザーなら数える", func(t *testing.T) {
+		filter := usecase.NewRateLimitFilter(1, time.Minute)
+		userID := uuid.New()
+		comment := &entity.Comment{ID: uuid.New(), UserID: userID, ClientFingerprint: "abc"}
+		assert.NoError(t, filter.Check(ctx, comment))
+		filter.Accepted(ctx, comment)
+		assertRejected(t, filter.Check(ctx, &entity.Comment{ID: uuid.New(), UserID: userID, ClientFingerprint: "def"}), domainerrors.CommentRejectRateLimited)
+		// 拒否された投稿が確保したIPアドレスの枠は返すため、同じIPアドレスの別のユーザーは投稿できる
+		assert.NoError(t, filter.Check(ctx, &entity.Comment{ID: uuid.New(), UserID: uuid.New(), ClientFingerprint: "def"}))
+	})
+}
+
+func TestDuplicateFilter_SignedInChecksIP(t *testing.T) {
+	ctx := context.Background()
+	filter := usecase.NewDuplicateFilter(time.Minute)
+
+	comment := &entity.Comment{ID: uuid.New(), UserID: uuid.New(), ClientFingerprint: "abc", Content: "宣伝です"}
+	assert.NoError(t, filter.Check(ctx, comment))
+	filter.Accepted(ctx, comment)
+	// 別のアカウントでも同じIPアドレスから同じ内容を投稿したら重複とみなす
+	assertRejected(t, filter.Check(ctx, &entity.Comment{ID: uuid.New(), UserID: uuid.New(), ClientFingerprint: "abc", Content: "宣伝です"}), domainerrors.CommentRejectDuplicate)
+	assert.NoError(t, filter.Check(ctx, &entity.Comment{ID: uuid.New(), UserID: uuid.New(), ClientFingerprint: "def", Content: "宣伝です"}))
+}
+
+func TestRateLimitFilter_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	filter := usecase.NewRateLimitFilter(3, time.Minute)
+	userID := uuid.New()
+
+	// 保存を待たずに同時に確認しても、上限を超えて通過させない
+	var wg sync.WaitGroup
+	var passed atomic.Int32
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if filter.Check(ctx, entity.NewComment("コメント", uuid.New(), userID, "")) == nil {
+				passed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(3), passed.Load())
+}
+
+func TestCommentUsecase_CreateComment_ReleaseRateLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workID := uuid.New()
+	userID := uuid.New()
+
+	mockRepo := mock.NewMockCommentRepository(ctrl)
+	mockWorkRepo := mock.NewMockWorkRepository(ctrl)
+	mockEventBroker := mock.NewMockEventBroker(ctrl)
+
+	mockWorkRepo.EXPECT().ExistsById(gomock.Any(), workID).Return(true, nil).Times(2)
+	mockWorkRepo.EXPECT().GetByID(gomock.Any(), workID).Return(&entity.Work{ID: workID, UserID: userID}, nil).Times(1)
+	gomock.InOrder(
+		mockRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, domainerrors.ErrFailedToCreateComment),
+		mockRepo.EXPECT().
+			Create(gomock.Any(), gomock.AssignableToTypeOf(&entity.Comment{})).
+			DoAndReturn(func(_ context.Context, c *entity.Comment) (*entity.Comment, error) {
+				return c, nil
+			}),
+	)
+	mockEventBroker.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	filters := []usecase.CommentFilter{
+		usecase.NewRateLimitFilter(1, time.Minute),
+		usecase.NewNGWordFilter([]string{"spam"}),
+	}
+	uc := usecase.NewCommentUsecase(mockRepo, mockWorkRepo, mock.NewMockNotificationRepository(ctrl), mockEventBroker, mock.NewMockUserRepository(ctrl), mock.NewMockMentionRepository(ctrl), newSummarizingReactionRepository(ctrl), filters, usecase.AnonymousCommentConfig{}, 30*time.Second)
+
+	// 後のフィルターで拒否されたコメントや保存に失敗したコメントは投稿数に数えない
+	_, err := uc.CreateComment(context.Background(), "buy SPAM now", workID, userID, "192.0.2.1", "")
+	assertRejected(t, err, domainerrors.CommentRejectNGWord)
+	_, err = uc.CreateComment(context.Background(), "素敵です", workID, userID, "192.0.2.1", "")
+	assert.ErrorIs(t, err, domainerrors.ErrFailedToCreateComment)
+
+	_, err = uc.CreateComment(context.Background(), "素敵です", workID, userID, "192.0.2.1", "")
+	assert.NoError(t, err)
+
+	_, err = uc.CreateComment(context.Background(), "素敵です!", workID, userID, "192.0.2.1", "")
+	assertRejected(t, err, domainerrors.CommentRejectRateLimited)
+}
+
+func TestCommentUsecase_CreateComment_Filters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workID := uuid.New()
+	userID := uuid.New()
+
+	mockRepo := mock.NewMockCommentRepository(ctrl)
+	mockWorkRepo := mock.NewMockWorkRepository(ctrl)
+	mockNotificationRepo := mock.NewMockNotificationRepository(ctrl)
+	mockEventBroker := mock.NewMockEventBroker(ctrl)
+
+	// 1件目だけが保存され、2件目は重複として保存前に拒否される
+	mockWorkRepo.EXPECT().ExistsById(gomock.Any(), workID).Return(true, nil).Times(1)
+	mockWorkRepo.EXPECT().GetByID(gomock.Any(), workID).Return(&entity.Work{ID: workID, UserID: userID}, nil).Times(1)
+	mockRepo.EXPECT().
+		Create(gomock.Any(), gomock.AssignableToTypeOf(&entity.Comment{})).
+		DoAndReturn(func(_ context.Context, c *entity.Comment) (*entity.Comment, error) {
+			return c, nil
+		}).
+		Times(1)
+	mockEventBroker.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	filters := []usecase.CommentFilter{
+		usecase.NewNGWordFilter([]string{"spam"}),
+		usecase.NewDuplicateFilter(time.Minute),
+	}
+	uc := usecase.NewCommentUsecase(mockRepo, mockWorkRepo, mockNotificationRepo, mockEventBroker, mock.NewMockUserRepository(ctrl), mock.NewMockMentionRepository(ctrl), newSummarizingReactionRepository(ctrl), filters, usecase.AnonymousCommentConfig{}, 30*time.Second)
+
+	_, err := uc.CreateComment(context.Background(), "buy SPAM now", workID, userID, "192.0.2.1", "")
+	assertRejected(t, err, domainerrors.CommentRejectNGWord)
+
+	_, err = uc.CreateComment(context.Background(), "素敵です", workID, userID, "192.0.2.1", "")
+	assert.NoError(t, err)
+
+	_, err = uc.CreateComment(context.Background(), "素敵です", workID, userID, "192.0.2.1", "")
+	assertRejected(t, err, domainerrors.CommentRejectDuplicate)
+}
+
+func TestCommentUsecase_UpdateComment_Filters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workID := uuid.New()
+	commentID := uuid.New()
+	userID := uuid.New()
+
+	mockRepo := mock.NewMockCommentRepository(ctrl)
+	mockRepo.EXPECT().
+		FindByID(gomock.Any(), commentID).
+		DoAndReturn(func(context.Context, uuid.UUID) (*entity.Comment, error) {
+			return &entity.Comment{ID: commentID, WorkID: workID, UserID: userID, Content: "素敵です"}, nil
+		}).
+		Times(3)
+	// 拒否された編集は保存しない
+	mockRepo.EXPECT().
+		Update(gomock.Any(), gomock.AssignableToTypeOf(&entity.Comment{})).
+		DoAndReturn(func(_ context.Context, c *entity.Comment) (*entity.Comment, error) {
+			return c, nil
+		}).
+		Times(1)
+
+	mockMentionRepo := mock.NewMockMentionRepository(ctrl)
+	mockMentionRepo.EXPECT().DeleteByCommentID(gomock.Any(), commentID, gomock.Any()).Return(nil).Times(1)
+
+	duplicateFilter := usecase.NewDuplicateFilter(time.Minute)
+	rateLimitFilter := usecase.NewRateLimitFilter(1, time.Minute)
+	posted := &entity.Comment{ID: commentID, WorkID: workID, UserID: userID, Content: "素敵です"}
+	duplicateFilter.Accepted(context.Background(), posted)
+	rateLimitFilter.Accepted(context.Background(), posted)
+	filters := []usecase.CommentFilter{
+		usecase.NewNGWordFilter([]string{"spam"}),
+		usecase.NewLinkLimitFilter(1),
+		duplicateFilter,
+		rateLimitFilter,
+	}
+	uc := usecase.NewCommentUsecase(mockRepo, mock.NewMockWorkRepository(ctrl), mock.NewMockNotificationRepository(ctrl), mock.NewMockEventBroker(ctrl), mock.NewMockUserRepository(ctrl), mockMentionRepo, newSummarizingReactionRepository(ctrl), filters, usecase.AnonymousCommentConfig{}, 30*time.Second)
+
+	_, err := uc.UpdateComment(context.Background(), workID, commentID, userID, "buy SPAM now")
+	assertRejected(t, err, domainerrors.CommentRejectNGWord)
+
+	_, err = uc.UpdateComment(context.Background(), workID, commentID, userID, "https://a.example https://b.example")
+	assertRejected(t, err, domainerrors.CommentRejectTooManyLinks)
+
+	// 投稿履歴を使うフィルターは編集には使わないため、投稿したばかりのコメントも同じ本文のまま編集できる
+	got, err := uc.UpdateComment(context.Background(), workID, commentID, userID, "素敵です")
+	assert.NoError(t, err)
+	assert.Equal(t, "素敵です", got.Content)
+}
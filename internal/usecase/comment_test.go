@@ -8,8 +8,10 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
 	"github.com/simesaba80/toybox-back/internal/usecase"
 	"github.com/simesaba80/toybox-back/internal/usecase/mock"
+	"github.com/simesaba80/toybox-back/internal/util"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -86,8 +88,10 @@ func TestCommentUsecase_GetCommentsByWorkID(t *testing.T) {
 			mockRepo := mock.NewMockCommentRepository(ctrl)
 			tt.setupMock(mockRepo, tt.workID)
 			mockWorkRepo := mock.NewMockWorkRepository(ctrl)
-			uc := usecase.NewCommentUsecase(mockRepo, mockWorkRepo, 30*time.Second)
-			got, err := uc.GetCommentsByWorkID(context.Background(), tt.workID)
+			mockNotificationRepo := mock.NewMockNotificationRepository(ctrl)
+			mockEventBroker := mock.NewMockEventBroker(ctrl)
+			uc := usecase.NewCommentUsecase(mockRepo, mockWorkRepo, mockNotificationRepo, mockEventBroker, mock.NewMockUserRepository(ctrl), mock.NewMockMentionRepository(ctrl), newSummarizingReactionRepository(ctrl), nil, usecase.AnonymousCommentConfig{}, 30*time.Second)
+			got, err := uc.GetCommentsByWorkID(context.Background(), tt.workID, uuid.Nil)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -100,3 +104,457 @@ func TestCommentUsecase_GetCommentsByWorkID(t *testing.T) {
 		})
 	}
 }
+
+func TestCommentUsecase_CreateComment_NotificationAndEvent(t *testing.T) {
+	workID := uuid.New()
+	commenterID := uuid.New()
+	ownerID := uuid.New()
+	parentAuthorID := uuid.New()
+
+	tests := []struct {
+		name         string
+		replyAt      func(parentID uuid.UUID) string
+		parentAuthor uuid.UUID
+		wantNotifyTo map[entity.NotificationType]uuid.UUID
+	}{
+		{
+			name:         "正常系: 作品の作者にコメントを通知する",
+			replyAt:      func(uuid.UUID) string { return "" },
+			wantNotifyTo: map[entity.NotificationType]uuid.UUID{entity.NotificationTypeComment: ownerID},
+		},
+		{
+			name:         "正常系: 返信は返信先の投稿者と作品の作者に通知する",
+			replyAt:      func(parentID uuid.UUID) string { return parentID.String() },
+			parentAuthor: parentAuthorID,
+			wantNotifyTo: map[entity.NotificationType]uuid.UUID{
+				entity.NotificationTypeReply:   parentAuthorID,
+				entity.NotificationTypeComment: ownerID,
+			},
+		},
+		{
+			name:         "正常系: 返信先が作品の作者の場合は返信の通知だけを送る",
+			replyAt:      func(parentID uuid.UUID) string { return parentID.String() },
+			parentAuthor: ownerID,
+			wantNotifyTo: map[entity.NotificationType]uuid.UUID{entity.NotificationTypeReply: ownerID},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			parentID := uuid.New()
+			mockRepo := mock.NewMockCommentRepository(ctrl)
+			mockWorkRepo := mock.NewMockWorkRepository(ctrl)
+			mockNotificationRepo := mock.NewMockNotificationRepository(ctrl)
+			mockEventBroker := mock.NewMockEventBroker(ctrl)
+
+			mockWorkRepo.EXPECT().ExistsById(gomock.Any(), workID).Return(true, nil)
+			mockWorkRepo.EXPECT().GetByID(gomock.Any(), workID).Return(&entity.Work{ID: workID, UserID: ownerID}, nil)
+			if tt.replyAt(parentID) != "" {
+				mockRepo.EXPECT().FindByID(gomock.Any(), parentID).Return(&entity.Comment{ID: parentID, WorkID: workID, UserID: tt.parentAuthor}, nil)
+			}
+			mockRepo.EXPECT().
+				Create(gomock.Any(), gomock.AssignableToTypeOf(&entity.Comment{})).
+				DoAndReturn(func(_ context.Context, c *entity.Comment) (*entity.Comment, error) {
+					return c, nil
+				})
+
+			notified := map[entity.NotificationType]uuid.UUID{}
+			mockNotificationRepo.EXPECT().
+				Create(gomock.Any(), gomock.AssignableToTypeOf(&entity.Notification{})).
+				DoAndReturn(func(_ context.Context, n *entity.Notification) (*entity.Notification, error) {
+					assert.Equal(t, commenterID, n.ActorID)
+					assert.NotNil(t, n.CommentID)
+					notified[n.Type] = n.UserID
+					return n, nil
+				}).
+				Times(len(tt.wantNotifyTo))
+			// 作品の作者には返信先かどうかに関わらずイベントを配信する
+			mockEventBroker.EXPECT().
+				Publish(gomock.Any(), gomock.AssignableToTypeOf(&entity.Event{})).
+				DoAndReturn(func(_ context.Context, e *entity.Event) error {
+					assert.Equal(t, ownerID, e.UserID)
+					assert.Equal(t, commenterID, e.ActorID)
+					assert.Equal(t, entity.EventTypeCommentCreated, e.Type)
+					assert.NotNil(t, e.CommentID)
+					return nil
+				})
+
+			uc := usecase.NewCommentUsecase(mockRepo, mockWorkRepo, mockNotificationRepo, mockEventBroker, mock.NewMockUserRepository(ctrl), mock.NewMockMentionRepository(ctrl), newSummarizingReactionRepository(ctrl), nil, usecase.AnonymousCommentConfig{}, 30*time.Second)
+			_, err := uc.CreateComment(context.Background(), "comment", workID, commenterID, "192.0.2.1", tt.replyAt(parentID))
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantNotifyTo, notified)
+		})
+	}
+}
+
+func TestCommentUsecase_CreateAnonymousComment(t *testing.T) {
+	workID := uuid.New()
+	ownerID := uuid.New()
+
+	tests := []struct {
+		name          string
+		config        usecase.AnonymousCommentConfig
+		anonymousName string
+		setupMock     func(*mock.MockCommentRepository, *mock.MockWorkRepository, *mock.MockNotificationRepository, *mock.MockEventBroker)
+		wantName      string
+		wantErr       bool
+		errIs         error
+	}{
+		{
+			name:          "正常系: 匿名コメントを作成できる",
+			config:        usecase.AnonymousCommentConfig{Enabled: true, Salt: "salt"},
+			anonymousName: "guest",
+			setupMock: func(cm *mock.MockCommentRepository, wm *mock.MockWorkRepository, nm *mock.MockNotificationRepository, em *mock.MockEventBroker) {
+				wm.EXPECT().ExistsById(gomock.Any(), workID).Return(true, nil)
+				cm.EXPECT().
+					Create(gomock.Any(), gomock.AssignableToTypeOf(&entity.Comment{})).
+					DoAndReturn(func(_ context.Context, c *entity.Comment) (*entity.Comment, error) {
+						return c, nil
+					})
+				wm.EXPECT().GetByID(gomock.Any(), workID).Return(&entity.Work{ID: workID, UserID: ownerID}, nil)
+				nm.EXPECT().Create(gomock.Any(), gomock.AssignableToTypeOf(&entity.Notification{})).
+					DoAndReturn(func(_ context.Context, n *entity.Notification) (*entity.Notification, error) {
+						return n, nil
+					})
+				em.EXPECT().Publish(gomock.Any(), gomock.AssignableToTypeOf(&entity.Event{})).Return(nil)
+			},
+			wantName: "guest",
+		},
+		{
+			name:   "正常系: 名前がない場合はデフォルトの表示名になる",
+			config: usecase.AnonymousCommentConfig{Enabled: true, Salt: "salt"},
+			setupMock: func(cm *mock.MockCommentRepository, wm *mock.MockWorkRepository, nm *mock.MockNotificationRepository, em *mock.MockEventBroker) {
+				wm.EXPECT().ExistsById(gomock.Any(), workID).Return(true, nil)
+				cm.EXPECT().
+					Create(gomock.Any(), gomock.AssignableToTypeOf(&entity.Comment{})).
+					DoAndReturn(func(_ context.Context, c *entity.Comment) (*entity.Comment, error) {
+						return c, nil
+					})
+				wm.EXPECT().GetByID(gomock.Any(), workID).Return(nil, domainerrors.ErrWorkNotFound)
+			},
+			wantName: "名無しさん",
+		},
+		{
+			name:   "異常系: 匿名コメントが無効になっている",
+			config: usecase.AnonymousCommentConfig{Enabled: false},
+			setupMock: func(cm *mock.MockCommentRepository, wm *mock.MockWorkRepository, nm *mock.MockNotificationRepository, em *mock.MockEventBroker) {
+			},
+			wantErr: true,
+			errIs:   domainerrors.ErrAnonymousCommentDisabled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockCommentRepository(ctrl)
+			mockWorkRepo := mock.NewMockWorkRepository(ctrl)
+			mockNotificationRepo := mock.NewMockNotificationRepository(ctrl)
+			mockEventBroker := mock.NewMockEventBroker(ctrl)
+			tt.setupMock(mockRepo, mockWorkRepo, mockNotificationRepo, mockEventBroker)
+
+			uc := usecase.NewCommentUsecase(mockRepo, mockWorkRepo, mockNotificationRepo, mockEventBroker, mock.NewMockUserRepository(ctrl), mock.NewMockMentionRepository(ctrl), newSummarizingReactionRepository(ctrl), nil, tt.config, 30*time.Second)
+			got, err := uc.CreateAnonymousComment(context.Background(), "comment", workID, tt.anonymousName, "192.0.2.1", "")
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, tt.errIs)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, got.Anonymous)
+			assert.Equal(t, uuid.Nil, got.UserID)
+			assert.Equal(t, tt.wantName, got.AnonymousName)
+			// IPアドレスそのものは保存しない
+			assert.Len(t, got.Fingerprint, 16)
+			assert.NotContains(t, got.Fingerprint, "192.0.2.1")
+		})
+	}
+}
+
+func TestCommentUsecase_UpdateComment(t *testing.T) {
+	workID := uuid.New()
+	commentID := uuid.New()
+	authorID := uuid.New()
+	deletedAt := time.Now()
+
+	tests := []struct {
+		name      string
+		userID    uuid.UUID
+		setupMock func(*mock.MockCommentRepository)
+		wantErr   bool
+		errIs     error
+	}{
+		{
+			name:   "正常系: 投稿者はコメントを編集できる",
+			userID: authorID,
+			setupMock: func(m *mock.MockCommentRepository) {
+				m.EXPECT().FindByID(gomock.Any(), commentID).Return(&entity.Comment{ID: commentID, WorkID: workID, UserID: authorID, Content: "before"}, nil)
+				m.EXPECT().
+					Update(gomock.Any(), gomock.AssignableToTypeOf(&entity.Comment{})).
+					DoAndReturn(func(_ context.Context, c *entity.Comment) (*entity.Comment, error) {
+						assert.Equal(t, "after", c.Content)
+						assert.NotNil(t, c.EditedAt)
+						return c, nil
+					})
+			},
+		},
+		{
+			name:   "異常系: 投稿者以外は編集できない",
+			userID: uuid.New(),
+			setupMock: func(m *mock.MockCommentRepository) {
+				m.EXPECT().FindByID(gomock.Any(), commentID).Return(&entity.Comment{ID: commentID, WorkID: workID, UserID: authorID}, nil)
+			},
+			wantErr: true,
+			errIs:   domainerrors.ErrCommentForbidden,
+		},
+		{
+			name:   "異常系: 別の作品のコメント",
+			userID: authorID,
+			setupMock: func(m *mock.MockCommentRepository) {
+				m.EXPECT().FindByID(gomock.Any(), commentID).Return(&entity.Comment{ID: commentID, WorkID: uuid.New(), UserID: authorID}, nil)
+			},
+			wantErr: true,
+			errIs:   domainerrors.ErrCommentNotFound,
+		},
+		{
+			name:   "異常系: 削除済みのコメント",
+			userID: authorID,
+			setupMock: func(m *mock.MockCommentRepository) {
+				m.EXPECT().FindByID(gomock.Any(), commentID).Return(&entity.Comment{ID: commentID, WorkID: workID, UserID: authorID, DeletedAt: &deletedAt}, nil)
+			},
+			wantErr: true,
+			errIs:   domainerrors.ErrCommentNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockCommentRepository(ctrl)
+			mockMentionRepo := mock.NewMockMentionRepository(ctrl)
+			tt.setupMock(mockRepo)
+			if !tt.wantErr {
+				mockMentionRepo.EXPECT().DeleteByCommentID(gomock.Any(), commentID, gomock.Len(0)).Return(nil)
+			}
+
+			uc := usecase.NewCommentUsecase(mockRepo, mock.NewMockWorkRepository(ctrl), mock.NewMockNotificationRepository(ctrl), mock.NewMockEventBroker(ctrl), mock.NewMockUserRepository(ctrl), mockMentionRepo, newSummarizingReactionRepository(ctrl), nil, usecase.AnonymousCommentConfig{}, 30*time.Second)
+			got, err := uc.UpdateComment(context.Background(), workID, commentID, tt.userID, "after")
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, tt.errIs)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "after", got.Content)
+			assert.NotNil(t, got.EditedAt)
+		})
+	}
+}
+
+func TestCommentUsecase_DeleteComment(t *testing.T) {
+	workID := uuid.New()
+	commentID := uuid.New()
+	authorID := uuid.New()
+	ownerID := uuid.New()
+
+	tests := []struct {
+		name      string
+		userID    uuid.UUID
+		setupMock func(*mock.MockCommentRepository, *mock.MockWorkRepository)
+		wantErr   bool
+		errIs     error
+	}{
+		{
+			name:   "正常系: 返信がないコメントは削除する",
+			userID: authorID,
+			setupMock: func(cm *mock.MockCommentRepository, wm *mock.MockWorkRepository) {
+				cm.EXPECT().FindByID(gomock.Any(), commentID).Return(&entity.Comment{ID: commentID, WorkID: workID, UserID: authorID}, nil)
+				cm.EXPECT().HasReplies(gomock.Any(), commentID).Return(false, nil)
+				cm.EXPECT().Delete(gomock.Any(), commentID).Return(nil)
+			},
+		},
+		{
+			name:   "正常系: 返信があるコメントは削除済みの跡として残す",
+			userID: authorID,
+			setupMock: func(cm *mock.MockCommentRepository, wm *mock.MockWorkRepository) {
+				cm.EXPECT().FindByID(gomock.Any(), commentID).Return(&entity.Comment{ID: commentID, WorkID: workID, UserID: authorID}, nil)
+				cm.EXPECT().HasReplies(gomock.Any(), commentID).Return(true, nil)
+				cm.EXPECT().Tombstone(gomock.Any(), commentID).Return(nil)
+			},
+		},
+		{
+			name:   "正常系: 作品の作者は他人のコメントを削除できる",
+			userID: ownerID,
+			setupMock: func(cm *mock.MockCommentRepository, wm *mock.MockWorkRepository) {
+				cm.EXPECT().FindByID(gomock.Any(), commentID).Return(&entity.Comment{ID: commentID, WorkID: workID, UserID: authorID}, nil)
+				wm.EXPECT().GetByID(gomock.Any(), workID).Return(&entity.Work{ID: workID, UserID: ownerID}, nil)
+				cm.EXPECT().HasReplies(gomock.Any(), commentID).Return(false, nil)
+				cm.EXPECT().Delete(gomock.Any(), commentID).Return(nil)
+			},
+		},
+		{
+			name:   "正常系: 作品の作者は匿名コメントを削除できる",
+			userID: ownerID,
+			setupMock: func(cm *mock.MockCommentRepository, wm *mock.MockWorkRepository) {
+				cm.EXPECT().FindByID(gomock.Any(), commentID).Return(&entity.Comment{ID: commentID, WorkID: workID, Anonymous: true}, nil)
+				wm.EXPECT().GetByID(gomock.Any(), workID).Return(&entity.Work{ID: workID, UserID: ownerID}, nil)
+				cm.EXPECT().HasReplies(gomock.Any(), commentID).Return(false, nil)
+				cm.EXPECT().Delete(gomock.Any(), commentID).Return(nil)
+			},
+		},
+		{
+			name:   "異常系: 投稿者でも作品の作者でもない",
+			userID: uuid.New(),
+			setupMock: func(cm *mock.MockCommentRepository, wm *mock.MockWorkRepository) {
+				cm.EXPECT().FindByID(gomock.Any(), commentID).Return(&entity.Comment{ID: commentID, WorkID: workID, UserID: authorID}, nil)
+				wm.EXPECT().GetByID(gomock.Any(), workID).Return(&entity.Work{ID: workID, UserID: ownerID}, nil)
+			},
+			wantErr: true,
+			errIs:   domainerrors.ErrCommentForbidden,
+		},
+		{
+			name:   "異常系: コメントが存在しない",
+			userID: authorID,
+			setupMock: func(cm *mock.MockCommentRepository, wm *mock.MockWorkRepository) {
+				cm.EXPECT().FindByID(gomock.Any(), commentID).Return(nil, domainerrors.ErrCommentNotFound)
+			},
+			wantErr: true,
+			errIs:   domainerrors.ErrCommentNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockCommentRepository(ctrl)
+			mockWorkRepo := mock.NewMockWorkRepository(ctrl)
+			mockMentionRepo := mock.NewMockMentionRepository(ctrl)
+			tt.setupMock(mockRepo, mockWorkRepo)
+			if !tt.wantErr {
+				// 削除済みの跡として残す場合もメンションは全て取り消す
+				mockMentionRepo.EXPECT().DeleteByCommentID(gomock.Any(), commentID, gomock.Nil()).Return(nil)
+			}
+
+			uc := usecase.NewCommentUsecase(mockRepo, mockWorkRepo, mock.NewMockNotificationRepository(ctrl), mock.NewMockEventBroker(ctrl), mock.NewMockUserRepository(ctrl), mockMentionRepo, newSummarizingReactionRepository(ctrl), nil, usecase.AnonymousCommentConfig{}, 30*time.Second)
+			err := uc.DeleteComment(context.Background(), workID, commentID, tt.userID)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, tt.errIs)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCommentUsecase_GetCommentTree(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workID := uuid.New()
+	root := &entity.Comment{ID: uuid.New(), WorkID: workID}
+	child := &entity.Comment{ID: uuid.New(), WorkID: workID, ReplyAt: root.ID.String()}
+	grandchild := &entity.Comment{ID: uuid.New(), WorkID: workID, ReplyAt: child.ID.String()}
+	// 返信先が見つからないコメントはトップレベルとして扱う
+	orphan := &entity.Comment{ID: uuid.New(), WorkID: workID, ReplyAt: uuid.New().String()}
+
+	mockRepo := mock.NewMockCommentRepository(ctrl)
+	mockRepo.EXPECT().FindByWorkID(gomock.Any(), workID).Return([]*entity.Comment{root, child, grandchild, orphan}, nil)
+
+	uc := usecase.NewCommentUsecase(mockRepo, mock.NewMockWorkRepository(ctrl), mock.NewMockNotificationRepository(ctrl), mock.NewMockEventBroker(ctrl), mock.NewMockUserRepository(ctrl), mock.NewMockMentionRepository(ctrl), newSummarizingReactionRepository(ctrl), nil, usecase.AnonymousCommentConfig{}, 30*time.Second)
+	tree, err := uc.GetCommentTree(context.Background(), workID, uuid.Nil, util.IntPtr(2))
+
+	assert.NoError(t, err)
+	assert.Len(t, tree, 2)
+	assert.Equal(t, root.ID, tree[0].Comment.ID)
+	assert.Equal(t, 1, tree[0].ReplyCount)
+	assert.Equal(t, orphan.ID, tree[1].Comment.ID)
+
+	// 深さの上限に達したコメントは返信を含めず、件数だけを返す
+	assert.Len(t, tree[0].Replies, 1)
+	assert.Equal(t, child.ID, tree[0].Replies[0].Comment.ID)
+	assert.Equal(t, 1, tree[0].Replies[0].ReplyCount)
+	assert.Nil(t, tree[0].Replies[0].Replies)
+}
+
+func TestCommentUsecase_GetReplies(t *testing.T) {
+	workID := uuid.New()
+	parent := &entity.Comment{ID: uuid.New(), WorkID: workID}
+	reply := &entity.Comment{ID: uuid.New(), WorkID: workID, ReplyAt: parent.ID.String()}
+
+	tests := []struct {
+		name      string
+		setupMock func(*mock.MockCommentRepository)
+		wantLen   int
+		wantErr   bool
+		errIs     error
+	}{
+		{
+			name: "正常系: 返信を取得できる",
+			setupMock: func(m *mock.MockCommentRepository) {
+				m.EXPECT().FindByID(gomock.Any(), parent.ID).Return(parent, nil)
+				m.EXPECT().FindByWorkID(gomock.Any(), workID).Return([]*entity.Comment{parent, reply}, nil)
+			},
+			wantLen: 1,
+		},
+		{
+			name: "異常系: 別の作品のコメント",
+			setupMock: func(m *mock.MockCommentRepository) {
+				m.EXPECT().FindByID(gomock.Any(), parent.ID).Return(&entity.Comment{ID: parent.ID, WorkID: uuid.New()}, nil)
+			},
+			wantErr: true,
+			errIs:   domainerrors.ErrCommentNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockCommentRepository(ctrl)
+			tt.setupMock(mockRepo)
+
+			uc := usecase.NewCommentUsecase(mockRepo, mock.NewMockWorkRepository(ctrl), mock.NewMockNotificationRepository(ctrl), mock.NewMockEventBroker(ctrl), mock.NewMockUserRepository(ctrl), mock.NewMockMentionRepository(ctrl), newSummarizingReactionRepository(ctrl), nil, usecase.AnonymousCommentConfig{}, 30*time.Second)
+			got, err := uc.GetReplies(context.Background(), workID, parent.ID, uuid.Nil, nil)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, tt.errIs)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, got, tt.wantLen)
+			assert.Equal(t, reply.ID, got[0].Comment.ID)
+		})
+	}
+}
+
+func TestCommentUsecase_CreateComment_ReplyToOtherWork(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workID := uuid.New()
+	parentID := uuid.New()
+
+	mockRepo := mock.NewMockCommentRepository(ctrl)
+	mockWorkRepo := mock.NewMockWorkRepository(ctrl)
+	mockWorkRepo.EXPECT().ExistsById(gomock.Any(), workID).Return(true, nil)
+	mockRepo.EXPECT().FindByID(gomock.Any(), parentID).Return(&entity.Comment{ID: parentID, WorkID: uuid.New()}, nil)
+
+	uc := usecase.NewCommentUsecase(mockRepo, mockWorkRepo, mock.NewMockNotificationRepository(ctrl), mock.NewMockEventBroker(ctrl), mock.NewMockUserRepository(ctrl), mock.NewMockMentionRepository(ctrl), newSummarizingReactionRepository(ctrl), nil, usecase.AnonymousCommentConfig{}, 30*time.Second)
+	_, err := uc.CreateComment(context.Background(), "comment", workID, uuid.New(), "192.0.2.1", parentID.String())
+
+	assert.ErrorIs(t, err, domainerrors.ErrInvalidReplyTarget)
+}
@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	"github.com/simesaba80/toybox-back/internal/domain/repository"
+)
+
+type IEventUsecase interface {
+	Subscribe(ctx context.Context, userID uuid.UUID, lastEventID string) (<-chan *entity.Event, func(), error)
+}
+
+type eventUsecase struct {
+	eventBroker repository.EventBroker
+}
+
+func NewEventUsecase(eventBroker repository.EventBroker) IEventUsecase {
+	return &eventUsecase{
+		eventBroker: eventBroker,
+	}
+}
+
+func (uc *eventUsecase) Subscribe(ctx context.Context, userID uuid.UUID, lastEventID string) (<-chan *entity.Event, func(), error) {
+	events, unsubscribe, err := uc.eventBroker.Subscribe(ctx, userID, lastEventID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to subscribe events for user ID %s: %w", userID.String(), err)
+	}
+	return events, unsubscribe, nil
+}
+
+// publishEvent はイベントを配信します。自分自身の操作は配信しません。
+// 通知と同じく、配信に失敗しても呼び出し元の処理は失敗させずログに残すだけにします。
+func publishEvent(ctx context.Context, eventBroker repository.EventBroker, event *entity.Event) {
+	if event.UserID == event.ActorID {
+		return
+	}
+	if err := eventBroker.Publish(ctx, event); err != nil {
+		log.Printf("イベントの配信に失敗しました (type=%s, user_id=%s): %v", event.Type, event.UserID.String(), err)
+	}
+}
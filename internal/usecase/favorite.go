@@ -18,12 +18,18 @@ type IFavoriteUsecase interface {
 }
 
 type favoriteUsecase struct {
-	favoriteRepo repository.FavoriteRepository
+	favoriteRepo     repository.FavoriteRepository
+	workRepo         repository.WorkRepository
+	notificationRepo repository.NotificationRepository
+	eventBroker      repository.EventBroker
 }
 
-func NewFavoriteUsecase(favoriteRepo repository.FavoriteRepository) IFavoriteUsecase {
+func NewFavoriteUsecase(favoriteRepo repository.FavoriteRepository, workRepo repository.WorkRepository, notificationRepo repository.NotificationRepository, eventBroker repository.EventBroker) IFavoriteUsecase {
 	return &favoriteUsecase{
-		favoriteRepo: favoriteRepo,
+		favoriteRepo:     favoriteRepo,
+		workRepo:         workRepo,
+		notificationRepo: notificationRepo,
+		eventBroker:      eventBroker,
 	}
 }
 
@@ -38,6 +44,14 @@ func (uc *favoriteUsecase) CreateFavorite(ctx context.Context, workID uuid.UUID,
 	if err != nil {
 		return fmt.Errorf("failed to create favorite: %w", err)
 	}
+
+	// 作品の作者にいいねされたことを通知し、接続中であればイベントも届ける。通知できなくてもいいね自体は成功とする
+	work, err := uc.workRepo.GetByID(ctx, workID)
+	if err != nil {
+		return nil
+	}
+	emitNotification(ctx, uc.notificationRepo, entity.NewNotification(work.UserID, userID, entity.NotificationTypeFavorite, workID, nil))
+	publishEvent(ctx, uc.eventBroker, entity.NewEvent(work.UserID, userID, entity.EventTypeFavoriteCreated, workID, nil))
 	return nil
 }
 
@@ -14,11 +14,14 @@ import (
 )
 
 func TestFavoriteUsecase_CreateFavorite(t *testing.T) {
+	ownerID := uuid.New()
+
 	tests := []struct {
-		name      string
-		setupMock func(*mock.MockFavoriteRepository, uuid.UUID, uuid.UUID)
-		wantErr   bool
-		errIs     error
+		name            string
+		setupMock       func(*mock.MockFavoriteRepository, uuid.UUID, uuid.UUID)
+		setupNotifyMock func(*mock.MockWorkRepository, *mock.MockNotificationRepository, *mock.MockEventBroker, uuid.UUID, uuid.UUID)
+		wantErr         bool
+		errIs           error
 	}{
 		{
 			name: "正常系: 新規でいいねを作成できる",
@@ -38,6 +41,72 @@ func TestFavoriteUsecase_CreateFavorite(t *testing.T) {
 						return fav, nil
 					})
 			},
+			setupNotifyMock: func(wm *mock.MockWorkRepository, nm *mock.MockNotificationRepository, em *mock.MockEventBroker, workID, userID uuid.UUID) {
+				wm.EXPECT().
+					GetByID(gomock.Any(), workID).
+					Return(&entity.Work{ID: workID, UserID: ownerID}, nil)
+				nm.EXPECT().
+					Create(gomock.Any(), gomock.AssignableToTypeOf(&entity.Notification{})).
+					DoAndReturn(func(_ context.Context, n *entity.Notification) (*entity.Notification, error) {
+						assert.Equal(t, ownerID, n.UserID)
+						assert.Equal(t, userID, n.ActorID)
+						assert.Equal(t, entity.NotificationTypeFavorite, n.Type)
+						assert.Equal(t, workID, n.WorkID)
+						return n, nil
+					})
+				em.EXPECT().
+					Publish(gomock.Any(), gomock.AssignableToTypeOf(&entity.Event{})).
+					DoAndReturn(func(_ context.Context, e *entity.Event) error {
+						assert.Equal(t, ownerID, e.UserID)
+						assert.Equal(t, userID, e.ActorID)
+						assert.Equal(t, entity.EventTypeFavoriteCreated, e.Type)
+						return nil
+					})
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常系: 自分の作品へのいいねは通知しない",
+			setupMock: func(m *mock.MockFavoriteRepository, workID, userID uuid.UUID) {
+				m.EXPECT().
+					Exists(gomock.Any(), gomock.AssignableToTypeOf(&entity.Favorite{})).
+					Return(false)
+				m.EXPECT().
+					Create(gomock.Any(), gomock.AssignableToTypeOf(&entity.Favorite{})).
+					DoAndReturn(func(_ context.Context, fav *entity.Favorite) (*entity.Favorite, error) {
+						return fav, nil
+					})
+			},
+			setupNotifyMock: func(wm *mock.MockWorkRepository, nm *mock.MockNotificationRepository, em *mock.MockEventBroker, workID, userID uuid.UUID) {
+				wm.EXPECT().
+					GetByID(gomock.Any(), workID).
+					Return(&entity.Work{ID: workID, UserID: userID}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常系: 通知の作成やイベントの配信に失敗してもいいねは成功する",
+			setupMock: func(m *mock.MockFavoriteRepository, workID, userID uuid.UUID) {
+				m.EXPECT().
+					Exists(gomock.Any(), gomock.AssignableToTypeOf(&entity.Favorite{})).
+					Return(false)
+				m.EXPECT().
+					Create(gomock.Any(), gomock.AssignableToTypeOf(&entity.Favorite{})).
+					DoAndReturn(func(_ context.Context, fav *entity.Favorite) (*entity.Favorite, error) {
+						return fav, nil
+					})
+			},
+			setupNotifyMock: func(wm *mock.MockWorkRepository, nm *mock.MockNotificationRepository, em *mock.MockEventBroker, workID, userID uuid.UUID) {
+				wm.EXPECT().
+					GetByID(gomock.Any(), workID).
+					Return(&entity.Work{ID: workID, UserID: ownerID}, nil)
+				nm.EXPECT().
+					Create(gomock.Any(), gomock.AssignableToTypeOf(&entity.Notification{})).
+					Return(nil, domainerrors.ErrFailedToCreateNotification)
+				em.EXPECT().
+					Publish(gomock.Any(), gomock.AssignableToTypeOf(&entity.Event{})).
+					Return(domainerrors.ErrEventBrokerClosed)
+			},
 			wantErr: false,
 		},
 		{
@@ -78,9 +147,15 @@ func TestFavoriteUsecase_CreateFavorite(t *testing.T) {
 			userID := uuid.New()
 
 			mockRepo := mock.NewMockFavoriteRepository(ctrl)
+			mockWorkRepo := mock.NewMockWorkRepository(ctrl)
+			mockNotificationRepo := mock.NewMockNotificationRepository(ctrl)
+			mockEventBroker := mock.NewMockEventBroker(ctrl)
 			tt.setupMock(mockRepo, workID, userID)
+			if tt.setupNotifyMock != nil {
+				tt.setupNotifyMock(mockWorkRepo, mockNotificationRepo, mockEventBroker, workID, userID)
+			}
 
-			uc := usecase.NewFavoriteUsecase(mockRepo)
+			uc := usecase.NewFavoriteUsecase(mockRepo, mockWorkRepo, mockNotificationRepo, mockEventBroker)
 
 			err := uc.CreateFavorite(context.Background(), workID, userID)
 
@@ -151,7 +226,7 @@ func TestFavoriteUsecase_DeleteFavorite(t *testing.T) {
 			mockRepo := mock.NewMockFavoriteRepository(ctrl)
 			tt.setupMock(mockRepo, workID, userID)
 
-			uc := usecase.NewFavoriteUsecase(mockRepo)
+			uc := usecase.NewFavoriteUsecase(mockRepo, mock.NewMockWorkRepository(ctrl), mock.NewMockNotificationRepository(ctrl), mock.NewMockEventBroker(ctrl))
 
 			err := uc.DeleteFavorite(context.Background(), workID, userID)
 
@@ -184,7 +259,7 @@ func TestFavoriteUsecase_CountFavoritesByWorkID(t *testing.T) {
 			Return(0, domainerrors.ErrFailedToCountFavoritesByWorkID),
 	)
 
-	uc := usecase.NewFavoriteUsecase(mockRepo)
+	uc := usecase.NewFavoriteUsecase(mockRepo, mock.NewMockWorkRepository(ctrl), mock.NewMockNotificationRepository(ctrl), mock.NewMockEventBroker(ctrl))
 
 	total, err := uc.CountFavoritesByWorkID(context.Background(), workID)
 	assert.NoError(t, err)
@@ -211,7 +286,7 @@ func TestFavoriteUsecase_IsFavorite(t *testing.T) {
 		Exists(gomock.Any(), gomock.AssignableToTypeOf(&entity.Favorite{})).
 		Return(false)
 
-	uc := usecase.NewFavoriteUsecase(mockRepo)
+	uc := usecase.NewFavoriteUsecase(mockRepo, mock.NewMockWorkRepository(ctrl), mock.NewMockNotificationRepository(ctrl), mock.NewMockEventBroker(ctrl))
 
 	isFavorite := uc.IsFavorite(context.Background(), workID, userID)
 	assert.True(t, isFavorite)
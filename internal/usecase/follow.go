@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/domain/repository"
+)
+
+type IFollowUsecase interface {
+	FollowUser(ctx context.Context, followerID uuid.UUID, followeeID uuid.UUID) error
+	UnfollowUser(ctx context.Context, followerID uuid.UUID, followeeID uuid.UUID) error
+	GetFollowers(ctx context.Context, userID uuid.UUID) ([]*entity.User, error)
+	GetFollowing(ctx context.Context, userID uuid.UUID) ([]*entity.User, error)
+}
+
+type followUsecase struct {
+	followRepo repository.FollowRepository
+	userRepo   repository.UserRepository
+}
+
+func NewFollowUsecase(followRepo repository.FollowRepository, userRepo repository.UserRepository) IFollowUsecase {
+	return &followUsecase{
+		followRepo: followRepo,
+		userRepo:   userRepo,
+	}
+}
+
+func (uc *followUsecase) FollowUser(ctx context.Context, followerID uuid.UUID, followeeID uuid.UUID) error {
+	if followerID == followeeID {
+		return domainerrors.ErrCannotFollowYourself
+	}
+	if _, err := uc.userRepo.GetByID(ctx, followeeID); err != nil {
+		return fmt.Errorf("failed to get user by ID %s: %w", followeeID.String(), err)
+	}
+
+	follow := entity.NewFollow(followerID, followeeID)
+	if uc.followRepo.Exists(ctx, follow) {
+		return domainerrors.ErrFollowAlreadyExists
+	}
+
+	_, err := uc.followRepo.Create(ctx, follow)
+	if err != nil {
+		return fmt.Errorf("failed to create follow: %w", err)
+	}
+	return nil
+}
+
+func (uc *followUsecase) UnfollowUser(ctx context.Context, followerID uuid.UUID, followeeID uuid.UUID) error {
+	follow := entity.NewFollow(followerID, followeeID)
+	if !uc.followRepo.Exists(ctx, follow) {
+		return domainerrors.ErrFollowNotFound
+	}
+	return uc.followRepo.Delete(ctx, follow)
+}
+
+func (uc *followUsecase) GetFollowers(ctx context.Context, userID uuid.UUID) ([]*entity.User, error) {
+	if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to get user by ID %s: %w", userID.String(), err)
+	}
+	users, err := uc.followRepo.GetFollowers(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get followers of user ID %s: %w", userID.String(), err)
+	}
+	return users, nil
+}
+
+func (uc *followUsecase) GetFollowing(ctx context.Context, userID uuid.UUID) ([]*entity.User, error) {
+	if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to get user by ID %s: %w", userID.String(), err)
+	}
+	users, err := uc.followRepo.GetFollowing(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get following of user ID %s: %w", userID.String(), err)
+	}
+	return users, nil
+}
@@ -0,0 +1,210 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/usecase"
+	"github.com/simesaba80/toybox-back/internal/usecase/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFollowUsecase_FollowUser(t *testing.T) {
+	followerID := uuid.New()
+	followeeID := uuid.New()
+
+	tests := []struct {
+		name       string
+		followeeID uuid.UUID
+		setupMock  func(*mock.MockFollowRepository, *mock.MockUserRepository)
+		wantErr    bool
+		errIs      error
+	}{
+		{
+			name:       "正常系: ユーザーをフォローできる",
+			followeeID: followeeID,
+			setupMock: func(fm *mock.MockFollowRepository, um *mock.MockUserRepository) {
+				um.EXPECT().GetByID(gomock.Any(), followeeID).Return(&entity.User{ID: followeeID}, nil)
+				fm.EXPECT().Exists(gomock.Any(), gomock.AssignableToTypeOf(&entity.Follow{})).Return(false)
+				fm.EXPECT().
+					Create(gomock.Any(), gomock.AssignableToTypeOf(&entity.Follow{})).
+					DoAndReturn(func(_ context.Context, follow *entity.Follow) (*entity.Follow, error) {
+						assert.Equal(t, followerID, follow.FollowerID)
+						assert.Equal(t, followeeID, follow.FolloweeID)
+						return follow, nil
+					})
+			},
+			wantErr: false,
+		},
+		{
+			name:       "異常系: 自分自身はフォローできない",
+			followeeID: followerID,
+			setupMock:  func(fm *mock.MockFollowRepository, um *mock.MockUserRepository) {},
+			wantErr:    true,
+			errIs:      domainerrors.ErrCannotFollowYourself,
+		},
+		{
+			name:       "異常系: 存在しないユーザーはフォローできない",
+			followeeID: followeeID,
+			setupMock: func(fm *mock.MockFollowRepository, um *mock.MockUserRepository) {
+				um.EXPECT().GetByID(gomock.Any(), followeeID).Return(nil, domainerrors.ErrUserNotFound)
+			},
+			wantErr: true,
+			errIs:   domainerrors.ErrUserNotFound,
+		},
+		{
+			name:       "異常系: 既にフォローしている",
+			followeeID: followeeID,
+			setupMock: func(fm *mock.MockFollowRepository, um *mock.MockUserRepository) {
+				um.EXPECT().GetByID(gomock.Any(), followeeID).Return(&entity.User{ID: followeeID}, nil)
+				fm.EXPECT().Exists(gomock.Any(), gomock.AssignableToTypeOf(&entity.Follow{})).Return(true)
+			},
+			wantErr: true,
+			errIs:   domainerrors.ErrFollowAlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockFollowRepo := mock.NewMockFollowRepository(ctrl)
+			mockUserRepo := mock.NewMockUserRepository(ctrl)
+			tt.setupMock(mockFollowRepo, mockUserRepo)
+
+			uc := usecase.NewFollowUsecase(mockFollowRepo, mockUserRepo)
+			err := uc.FollowUser(context.Background(), followerID, tt.followeeID)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errIs != nil {
+					assert.ErrorIs(t, err, tt.errIs)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFollowUsecase_UnfollowUser(t *testing.T) {
+	followerID := uuid.New()
+	followeeID := uuid.New()
+
+	tests := []struct {
+		name      string
+		setupMock func(*mock.MockFollowRepository)
+		wantErr   bool
+		errIs     error
+	}{
+		{
+			name: "正常系: フォローを解除できる",
+			setupMock: func(m *mock.MockFollowRepository) {
+				m.EXPECT().Exists(gomock.Any(), gomock.AssignableToTypeOf(&entity.Follow{})).Return(true)
+				m.EXPECT().Delete(gomock.Any(), gomock.AssignableToTypeOf(&entity.Follow{})).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "異常系: フォローしていない",
+			setupMock: func(m *mock.MockFollowRepository) {
+				m.EXPECT().Exists(gomock.Any(), gomock.AssignableToTypeOf(&entity.Follow{})).Return(false)
+			},
+			wantErr: true,
+			errIs:   domainerrors.ErrFollowNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockFollowRepo := mock.NewMockFollowRepository(ctrl)
+			mockUserRepo := mock.NewMockUserRepository(ctrl)
+			tt.setupMock(mockFollowRepo)
+
+			uc := usecase.NewFollowUsecase(mockFollowRepo, mockUserRepo)
+			err := uc.UnfollowUser(context.Background(), followerID, followeeID)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errIs != nil {
+					assert.ErrorIs(t, err, tt.errIs)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFollowUsecase_GetFollowers(t *testing.T) {
+	userID := uuid.New()
+	followers := []*entity.User{{ID: uuid.New()}, {ID: uuid.New()}}
+
+	tests := []struct {
+		name      string
+		setupMock func(*mock.MockFollowRepository, *mock.MockUserRepository)
+		wantCount int
+		wantErr   bool
+		errIs     error
+	}{
+		{
+			name: "正常系: フォロワー一覧を取得できる",
+			setupMock: func(fm *mock.MockFollowRepository, um *mock.MockUserRepository) {
+				um.EXPECT().GetByID(gomock.Any(), userID).Return(&entity.User{ID: userID}, nil)
+				fm.EXPECT().GetFollowers(gomock.Any(), userID).Return(followers, nil)
+			},
+			wantCount: 2,
+			wantErr:   false,
+		},
+		{
+			name: "異常系: ユーザーが存在しない",
+			setupMock: func(fm *mock.MockFollowRepository, um *mock.MockUserRepository) {
+				um.EXPECT().GetByID(gomock.Any(), userID).Return(nil, domainerrors.ErrUserNotFound)
+			},
+			wantErr: true,
+			errIs:   domainerrors.ErrUserNotFound,
+		},
+		{
+			name: "異常系: リポジトリエラー",
+			setupMock: func(fm *mock.MockFollowRepository, um *mock.MockUserRepository) {
+				um.EXPECT().GetByID(gomock.Any(), userID).Return(&entity.User{ID: userID}, nil)
+				fm.EXPECT().GetFollowers(gomock.Any(), userID).Return(nil, domainerrors.ErrFailedToGetFollowers)
+			},
+			wantErr: true,
+			errIs:   domainerrors.ErrFailedToGetFollowers,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockFollowRepo := mock.NewMockFollowRepository(ctrl)
+			mockUserRepo := mock.NewMockUserRepository(ctrl)
+			tt.setupMock(mockFollowRepo, mockUserRepo)
+
+			uc := usecase.NewFollowUsecase(mockFollowRepo, mockUserRepo)
+			got, err := uc.GetFollowers(context.Background(), userID)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errIs != nil {
+					assert.ErrorIs(t, err, tt.errIs)
+				}
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, got, tt.wantCount)
+			}
+		})
+	}
+}
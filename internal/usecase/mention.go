@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	"github.com/simesaba80/toybox-back/internal/domain/repository"
+)
+
+type IMentionUsecase interface {
+	GetMentions(ctx context.Context, userID uuid.UUID, limit, page *int) ([]*entity.Mention, int, int, int, error)
+}
+
+type mentionUsecase struct {
+	mentionRepo repository.MentionRepository
+}
+
+func NewMentionUsecase(mentionRepo repository.MentionRepository) IMentionUsecase {
+	return &mentionUsecase{
+		mentionRepo: mentionRepo,
+	}
+}
+
+func (uc *mentionUsecase) GetMentions(ctx context.Context, userID uuid.UUID, limit, page *int) ([]*entity.Mention, int, int, int, error) {
+	actualLimit := 20
+	actualPage := 1
+	if limit != nil {
+		actualLimit = *limit
+	}
+	if page != nil {
+		actualPage = *page
+	}
+	offset := (actualPage - 1) * actualLimit
+
+	mentions, total, err := uc.mentionRepo.GetByUserID(ctx, userID, actualLimit, offset)
+	if err != nil {
+		return nil, 0, 0, 0, fmt.Errorf("failed to get mentions for user ID %s: %w", userID.String(), err)
+	}
+	return mentions, total, actualLimit, actualPage, nil
+}
+
+// resolveMentions は本文中の@nameを存在するユーザーに解決します。@nameが無い場合はユーザーを検索しません。
+func resolveMentions(ctx context.Context, userRepo repository.UserRepository, text string) ([]*entity.MentionSpan, error) {
+	spans := entity.ParseMentions(text)
+	if len(spans) == 0 {
+		return []*entity.MentionSpan{}, nil
+	}
+	users, err := userRepo.GetByNames(ctx, entity.MentionNames(spans))
+	if err != nil {
+		return nil, err
+	}
+	return entity.ResolveMentionSpans(spans, users), nil
+}
+
+// attachMentions はコメントの本文からメンションを解決して設定します。
+// 全てのコメントの@nameをまとめて1回で検索します。メンションは表示を補うものなので、失敗してもログに残すだけにします。
+func attachMentions(ctx context.Context, userRepo repository.UserRepository, comments []*entity.Comment) {
+	spansByComment := make([][]*entity.MentionSpan, len(comments))
+	var allSpans []*entity.MentionSpan
+	for i, comment := range comments {
+		comment.Mentions = []*entity.MentionSpan{}
+		spansByComment[i] = entity.ParseMentions(comment.Content)
+		allSpans = append(allSpans, spansByComment[i]...)
+	}
+	if len(allSpans) == 0 {
+		return
+	}
+
+	users, err := userRepo.GetByNames(ctx, entity.MentionNames(allSpans))
+	if err != nil {
+		log.Printf("メンションの解決に失敗しました: %v", err)
+		return
+	}
+	for i, comment := range comments {
+		comment.Mentions = entity.ResolveMentionSpans(spansByComment[i], users)
+	}
+}
+
+// recordMentions はメンションされたユーザーごとにメンションを記録します。自分自身へのメンションは記録しません。
+// メンションは元の操作に付随するものなので、失敗しても呼び出し元の処理は失敗させずログに残すだけにします。
+func recordMentions(ctx context.Context, mentionRepo repository.MentionRepository, spans []*entity.MentionSpan, actorID, workID uuid.UUID, commentID *uuid.UUID) {
+	seen := make(map[uuid.UUID]bool, len(spans))
+	mentions := make([]*entity.Mention, 0, len(spans))
+	for _, span := range spans {
+		if span.UserID == actorID || seen[span.UserID] {
+			continue
+		}
+		seen[span.UserID] = true
+		mentions = append(mentions, entity.NewMention(span.UserID, actorID, workID, commentID))
+	}
+	if len(mentions) == 0 {
+		return
+	}
+	if err := mentionRepo.CreateMany(ctx, mentions); err != nil {
+		log.Printf("メンションの記録に失敗しました (work_id=%s): %v", workID.String(), err)
+	}
+}
@@ -0,0 +1,288 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/usecase"
+	"github.com/simesaba80/toybox-back/internal/usecase/mock"
+	"github.com/simesaba80/toybox-back/internal/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMentionUsecase_GetMentions(t *testing.T) {
+	userID := uuid.New()
+	mentions := []*entity.Mention{
+		entity.NewMention(userID, uuid.New(), uuid.New(), nil),
+	}
+
+	tests := []struct {
+		name      string
+		limit     *int
+		page      *int
+		setupMock func(*mock.MockMentionRepository)
+		wantLimit int
+		wantPage  int
+		wantErr   bool
+		errIs     error
+	}{
+		{
+			name: "正常系: デフォルトのページングで取得できる",
+			setupMock: func(m *mock.MockMentionRepository) {
+				m.EXPECT().GetByUserID(gomock.Any(), userID, 20, 0).Return(mentions, 1, nil)
+			},
+			wantLimit: 20,
+			wantPage:  1,
+		},
+		{
+			name:  "正常系: 指定したページで取得できる",
+			limit: util.IntPtr(5),
+			page:  util.IntPtr(2),
+			setupMock: func(m *mock.MockMentionRepository) {
+				m.EXPECT().GetByUserID(gomock.Any(), userID, 5, 5).Return(mentions, 1, nil)
+			},
+			wantLimit: 5,
+			wantPage:  2,
+		},
+		{
+			name: "異常系: リポジトリエラー",
+			setupMock: func(m *mock.MockMentionRepository) {
+				m.EXPECT().GetByUserID(gomock.Any(), userID, 20, 0).Return(nil, 0, domainerrors.ErrFailedToGetMentions)
+			},
+			wantErr: true,
+			errIs:   domainerrors.ErrFailedToGetMentions,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockMentionRepository(ctrl)
+			tt.setupMock(mockRepo)
+
+			uc := usecase.NewMentionUsecase(mockRepo)
+			result, total, limit, page, err := uc.GetMentions(context.Background(), userID, tt.limit, tt.page)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errIs != nil {
+					assert.ErrorIs(t, err, tt.errIs)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, mentions, result)
+			assert.Equal(t, 1, total)
+			assert.Equal(t, tt.wantLimit, limit)
+			assert.Equal(t, tt.wantPage, page)
+		})
+	}
+}
+
+func TestCommentUsecase_CreateComment_Mentions(t *testing.T) {
+	workID := uuid.New()
+	commenterID := uuid.New()
+	ownerID := uuid.New()
+	mentionedID := uuid.New()
+
+	tests := []struct {
+		name         string
+		content      string
+		wantNames    []string
+		wantSpans    []*entity.MentionSpan
+		wantRecorded []uuid.UUID
+	}{
+		{
+			name:      "正常系: 存在するユーザーへのメンションを解決して記録する",
+			content:   "@simesaba80 このシェーダーどうやって作ったの？ @unknown",
+			wantNames: []string{"simesaba80", "unknown"},
+			wantSpans: []*entity.MentionSpan{
+				{Start: 0, End: 11, Name: "simesaba80", UserID: mentionedID},
+			},
+			wantRecorded: []uuid.UUID{mentionedID},
+		},
+		{
+			name:      "正常系: 同じユーザーへの複数のメンションは一度だけ記録し、自分自身へのメンションは記録しない",
+			content:   "すごい!@simesaba80. @simesaba80 @me",
+			wantNames: []string{"simesaba80", "me"},
+			wantSpans: []*entity.MentionSpan{
+				{Start: 4, End: 15, Name: "simesaba80", UserID: mentionedID},
+				{Start: 17, End: 28, Name: "simesaba80", UserID: mentionedID},
+				{Start: 29, End: 32, Name: "me", UserID: commenterID},
+			},
+			wantRecorded: []uuid.UUID{mentionedID},
+		},
+		{
+			name:      "正常系: メールアドレスはメンションとして扱わない",
+			content:   "連絡先はtest@example.comです",
+			wantSpans: []*entity.MentionSpan{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockCommentRepository(ctrl)
+			mockWorkRepo := mock.NewMockWorkRepository(ctrl)
+			mockNotificationRepo := mock.NewMockNotificationRepository(ctrl)
+			mockEventBroker := mock.NewMockEventBroker(ctrl)
+			mockUserRepo := mock.NewMockUserRepository(ctrl)
+			mockMentionRepo := mock.NewMockMentionRepository(ctrl)
+
+			mockWorkRepo.EXPECT().ExistsById(gomock.Any(), workID).Return(true, nil)
+			mockWorkRepo.EXPECT().GetByID(gomock.Any(), workID).Return(&entity.Work{ID: workID, UserID: ownerID}, nil)
+			mockRepo.EXPECT().
+				Create(gomock.Any(), gomock.AssignableToTypeOf(&entity.Comment{})).
+				DoAndReturn(func(_ context.Context, c *entity.Comment) (*entity.Comment, error) {
+					return c, nil
+				})
+			mockNotificationRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, nil)
+			mockEventBroker.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil)
+
+			if tt.wantNames != nil {
+				mockUserRepo.EXPECT().
+					GetByNames(gomock.Any(), tt.wantNames).
+					Return([]*entity.User{
+						{ID: mentionedID, Name: "simesaba80"},
+						{ID: commenterID, Name: "me"},
+					}, nil)
+			}
+			var recorded []uuid.UUID
+			if len(tt.wantRecorded) > 0 {
+				mockMentionRepo.EXPECT().
+					CreateMany(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, mentions []*entity.Mention) error {
+						for _, m := range mentions {
+							assert.Equal(t, commenterID, m.ActorID)
+							assert.Equal(t, workID, m.WorkID)
+							assert.NotNil(t, m.CommentID)
+							recorded = append(recorded, m.UserID)
+						}
+						return nil
+					})
+			}
+
+			uc := usecase.NewCommentUsecase(mockRepo, mockWorkRepo, mockNotificationRepo, mockEventBroker, mockUserRepo, mockMentionRepo, newSummarizingReactionRepository(ctrl), nil, usecase.AnonymousCommentConfig{}, 30*time.Second)
+			got, err := uc.CreateComment(context.Background(), tt.content, workID, commenterID, "192.0.2.1", "")
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantSpans, got.Mentions)
+			assert.Equal(t, tt.wantRecorded, recorded)
+		})
+	}
+}
+
+func TestCommentUsecase_UpdateComment_Mentions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workID := uuid.New()
+	commentID := uuid.New()
+	authorID := uuid.New()
+	keptID := uuid.New()
+	addedID := uuid.New()
+
+	mockRepo := mock.NewMockCommentRepository(ctrl)
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockMentionRepo := mock.NewMockMentionRepository(ctrl)
+
+	mockRepo.EXPECT().
+		FindByID(gomock.Any(), commentID).
+		Return(&entity.Comment{ID: commentID, WorkID: workID, UserID: authorID, Content: "@removed @kept"}, nil)
+	mockRepo.EXPECT().
+		Update(gomock.Any(), gomock.AssignableToTypeOf(&entity.Comment{})).
+		DoAndReturn(func(_ context.Context, c *entity.Comment) (*entity.Comment, error) {
+			return c, nil
+		})
+	mockUserRepo.EXPECT().
+		GetByNames(gomock.Any(), []string{"kept", "added"}).
+		Return([]*entity.User{{ID: keptID, Name: "kept"}, {ID: addedID, Name: "added"}}, nil)
+	// 残ったメンションは記録済みなので無視され、新しいメンションだけが増える
+	mockMentionRepo.EXPECT().
+		CreateMany(gomock.Any(), gomock.Len(2)).
+		Return(nil)
+	// 本文から消えたユーザーへのメンションは取り消す
+	mockMentionRepo.EXPECT().
+		DeleteByCommentID(gomock.Any(), commentID, []uuid.UUID{keptID, addedID}).
+		Return(nil)
+
+	uc := usecase.NewCommentUsecase(mockRepo, mock.NewMockWorkRepository(ctrl), mock.NewMockNotificationRepository(ctrl), mock.NewMockEventBroker(ctrl), mockUserRepo, mockMentionRepo, newSummarizingReactionRepository(ctrl), nil, usecase.AnonymousCommentConfig{}, 30*time.Second)
+	got, err := uc.UpdateComment(context.Background(), workID, commentID, authorID, "@kept @added")
+
+	assert.NoError(t, err)
+	assert.Len(t, got.Mentions, 2)
+}
+
+func TestWorkUseCase_CreateWork_DescriptionMentions(t *testing.T) {
+	tests := []struct {
+		name         string
+		visibility   string
+		wantRecorded bool
+	}{
+		{name: "正常系: 公開した作品の説明文のメンションを記録する", visibility: "public", wantRecorded: true},
+		{name: "正常系: 下書きの作品の説明文のメンションは記録しない", visibility: "draft", wantRecorded: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			authorID := uuid.New()
+			mentionedID := uuid.New()
+			tagIDs := []uuid.UUID{uuid.New()}
+
+			mockWorkRepo := mock.NewMockWorkRepository(ctrl)
+			mockTagRepo := mock.NewMockTagRepository(ctrl)
+			mockTagFollowRepo := mock.NewMockTagFollowRepository(ctrl)
+			mockUserRepo := mock.NewMockUserRepository(ctrl)
+			mockMentionRepo := mock.NewMockMentionRepository(ctrl)
+
+			mockTagRepo.EXPECT().ExistAll(gomock.Any(), tagIDs).Return(true, nil)
+			mockTagRepo.EXPECT().FindAllByIDs(gomock.Any(), tagIDs).Return([]*entity.Tag{{ID: tagIDs[0], Name: "Tag1"}}, nil)
+			mockWorkRepo.EXPECT().
+				Create(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, work *entity.Work) (*entity.Work, error) {
+					return work, nil
+				})
+			mockTagFollowRepo.EXPECT().CreateNewWorkEntries(gomock.Any(), gomock.Any()).Return(nil)
+
+			var recorded []uuid.UUID
+			if tt.wantRecorded {
+				mockUserRepo.EXPECT().
+					GetByNames(gomock.Any(), []string{"simesaba80"}).
+					Return([]*entity.User{{ID: mentionedID, Name: "simesaba80"}}, nil)
+				mockMentionRepo.EXPECT().
+					CreateMany(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, mentions []*entity.Mention) error {
+						for _, m := range mentions {
+							assert.Equal(t, authorID, m.ActorID)
+							assert.Nil(t, m.CommentID)
+							recorded = append(recorded, m.UserID)
+						}
+						return nil
+					})
+			} else {
+				mockUserRepo.EXPECT().GetByNames(gomock.Any(), gomock.Any()).Times(0)
+				mockMentionRepo.EXPECT().CreateMany(gomock.Any(), gomock.Any()).Times(0)
+			}
+
+			uc := usecase.NewWorkUseCase(mockWorkRepo, mockTagRepo, mockTagFollowRepo, mockUserRepo, mockMentionRepo, newSummarizingReactionRepository(ctrl), newSyncTaskRunner(ctrl))
+			_, err := uc.CreateWork(context.Background(), "New Work", "@simesaba80 さんと作りました", tt.visibility, uuid.New(), []uuid.UUID{uuid.New()}, nil, authorID, tagIDs)
+
+			assert.NoError(t, err)
+			if tt.wantRecorded {
+				assert.Equal(t, []uuid.UUID{mentionedID}, recorded)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/repository/archive.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/repository/archive.go -destination=internal/usecase/mock/mock_archive_repository.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	entity "github.com/simesaba80/toybox-back/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArchiveInspector is a mock of ArchiveInspector interface.
+type MockArchiveInspector struct {
+	ctrl     *gomock.Controller
+	recorder *MockArchiveInspectorMockRecorder
+	isgomock struct{}
+}
+
+// MockArchiveInspectorMockRecorder is the mock recorder for MockArchiveInspector.
+type MockArchiveInspectorMockRecorder struct {
+	mock *MockArchiveInspector
+}
+
+// NewMockArchiveInspector creates a new mock instance.
+func NewMockArchiveInspector(ctrl *gomock.Controller) *MockArchiveInspector {
+	mock := &MockArchiveInspector{ctrl: ctrl}
+	mock.recorder = &MockArchiveInspectorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArchiveInspector) EXPECT() *MockArchiveInspectorMockRecorder {
+	return m.recorder
+}
+
+// ExtractZip mocks base method.
+func (m *MockArchiveInspector) ExtractZip(ctx context.Context, src io.Reader, dir string, fn func(string, io.Reader) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExtractZip", ctx, src, dir, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExtractZip indicates an expected call of ExtractZip.
+func (mr *MockArchiveInspectorMockRecorder) ExtractZip(ctx, src, dir, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtractZip", reflect.TypeOf((*MockArchiveInspector)(nil).ExtractZip), ctx, src, dir, fn)
+}
+
+// InspectZip mocks base method.
+func (m *MockArchiveInspector) InspectZip(ctx context.Context, src io.Reader) (*entity.ZipManifest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InspectZip", ctx, src)
+	ret0, _ := ret[0].(*entity.ZipManifest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InspectZip indicates an expected call of InspectZip.
+func (mr *MockArchiveInspectorMockRecorder) InspectZip(ctx, src any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectZip", reflect.TypeOf((*MockArchiveInspector)(nil).InspectZip), ctx, src)
+}
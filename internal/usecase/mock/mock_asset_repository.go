@@ -11,8 +11,9 @@ package mock
 
 import (
 	context "context"
-	multipart "mime/multipart"
+	io "io"
 	reflect "reflect"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	entity "github.com/simesaba80/toybox-back/internal/domain/entity"
@@ -43,6 +44,34 @@ func (m *MockAssetRepository) EXPECT() *MockAssetRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AbortMultipartUpload mocks base method.
+func (m *MockAssetRepository) AbortMultipartUpload(ctx context.Context, assetUUID uuid.UUID, extension, multipartUploadID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AbortMultipartUpload", ctx, assetUUID, extension, multipartUploadID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AbortMultipartUpload indicates an expected call of AbortMultipartUpload.
+func (mr *MockAssetRepositoryMockRecorder) AbortMultipartUpload(ctx, assetUUID, extension, multipartUploadID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbortMultipartUpload", reflect.TypeOf((*MockAssetRepository)(nil).AbortMultipartUpload), ctx, assetUUID, extension, multipartUploadID)
+}
+
+// CompleteMultipartUpload mocks base method.
+func (m *MockAssetRepository) CompleteMultipartUpload(ctx context.Context, assetUUID uuid.UUID, extension, multipartUploadID string, parts []*entity.UploadedPart) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteMultipartUpload", ctx, assetUUID, extension, multipartUploadID, parts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteMultipartUpload indicates an expected call of CompleteMultipartUpload.
+func (mr *MockAssetRepositoryMockRecorder) CompleteMultipartUpload(ctx, assetUUID, extension, multipartUploadID, parts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteMultipartUpload", reflect.TypeOf((*MockAssetRepository)(nil).CompleteMultipartUpload), ctx, assetUUID, extension, multipartUploadID, parts)
+}
+
 // Create mocks base method.
 func (m *MockAssetRepository) Create(ctx context.Context, asset *entity.Asset) (*entity.Asset, error) {
 	m.ctrl.T.Helper()
@@ -58,6 +87,359 @@ func (mr *MockAssetRepositoryMockRecorder) Create(ctx, asset any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAssetRepository)(nil).Create), ctx, asset)
 }
 
+// CreateMultipartUpload mocks base method.
+func (m *MockAssetRepository) CreateMultipartUpload(ctx context.Context, assetUUID uuid.UUID, extension, contentType string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMultipartUpload", ctx, assetUUID, extension, contentType)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMultipartUpload indicates an expected call of CreateMultipartUpload.
+func (mr *MockAssetRepositoryMockRecorder) CreateMultipartUpload(ctx, assetUUID, extension, contentType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMultipartUpload", reflect.TypeOf((*MockAssetRepository)(nil).CreateMultipartUpload), ctx, assetUUID, extension, contentType)
+}
+
+// CreateVariants mocks base method.
+func (m *MockAssetRepository) CreateVariants(ctx context.Context, variants []*entity.AssetVariant) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVariants", ctx, variants)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateVariants indicates an expected call of CreateVariants.
+func (mr *MockAssetRepositoryMockRecorder) CreateVariants(ctx, variants any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVariants", reflect.TypeOf((*MockAssetRepository)(nil).CreateVariants), ctx, variants)
+}
+
+// DeleteFile mocks base method.
+func (m *MockAssetRepository) DeleteFile(ctx context.Context, assetUUID uuid.UUID, extension string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFile", ctx, assetUUID, extension)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFile indicates an expected call of DeleteFile.
+func (mr *MockAssetRepositoryMockRecorder) DeleteFile(ctx, assetUUID, extension any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFile", reflect.TypeOf((*MockAssetRepository)(nil).DeleteFile), ctx, assetUUID, extension)
+}
+
+// DeleteFiles mocks base method.
+func (m *MockAssetRepository) DeleteFiles(ctx context.Context, keys []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFiles", ctx, keys)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFiles indicates an expected call of DeleteFiles.
+func (mr *MockAssetRepositoryMockRecorder) DeleteFiles(ctx, keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFiles", reflect.TypeOf((*MockAssetRepository)(nil).DeleteFiles), ctx, keys)
+}
+
+// DeleteOrphans mocks base method.
+func (m *MockAssetRepository) DeleteOrphans(ctx context.Context, ids []uuid.UUID) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrphans", ctx, ids)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteOrphans indicates an expected call of DeleteOrphans.
+func (mr *MockAssetRepositoryMockRecorder) DeleteOrphans(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrphans", reflect.TypeOf((*MockAssetRepository)(nil).DeleteOrphans), ctx, ids)
+}
+
+// DeletePlayFiles mocks base method.
+func (m *MockAssetRepository) DeletePlayFiles(ctx context.Context, assetUUID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePlayFiles", ctx, assetUUID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePlayFiles indicates an expected call of DeletePlayFiles.
+func (mr *MockAssetRepositoryMockRecorder) DeletePlayFiles(ctx, assetUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlayFiles", reflect.TypeOf((*MockAssetRepository)(nil).DeletePlayFiles), ctx, assetUUID)
+}
+
+// FileURL mocks base method.
+func (m *MockAssetRepository) FileURL(assetUUID uuid.UUID, extension string) (string, string) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FileURL", assetUUID, extension)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	return ret0, ret1
+}
+
+// FileURL indicates an expected call of FileURL.
+func (mr *MockAssetRepositoryMockRecorder) FileURL(assetUUID, extension any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FileURL", reflect.TypeOf((*MockAssetRepository)(nil).FileURL), assetUUID, extension)
+}
+
+// FindBlobKeys mocks base method.
+func (m *MockAssetRepository) FindBlobKeys(ctx context.Context, keys []string) (map[string]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBlobKeys", ctx, keys)
+	ret0, _ := ret[0].(map[string]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBlobKeys indicates an expected call of FindBlobKeys.
+func (mr *MockAssetRepositoryMockRecorder) FindBlobKeys(ctx, keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBlobKeys", reflect.TypeOf((*MockAssetRepository)(nil).FindBlobKeys), ctx, keys)
+}
+
+// FindByHash mocks base method.
+func (m *MockAssetRepository) FindByHash(ctx context.Context, userID uuid.UUID, hash string) (*entity.Asset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByHash", ctx, userID, hash)
+	ret0, _ := ret[0].(*entity.Asset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByHash indicates an expected call of FindByHash.
+func (mr *MockAssetRepositoryMockRecorder) FindByHash(ctx, userID, hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByHash", reflect.TypeOf((*MockAssetRepository)(nil).FindByHash), ctx, userID, hash)
+}
+
+// FindExistingIDs mocks base method.
+func (m *MockAssetRepository) FindExistingIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindExistingIDs", ctx, ids)
+	ret0, _ := ret[0].(map[uuid.UUID]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindExistingIDs indicates an expected call of FindExistingIDs.
+func (mr *MockAssetRepositoryMockRecorder) FindExistingIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindExistingIDs", reflect.TypeOf((*MockAssetRepository)(nil).FindExistingIDs), ctx, ids)
+}
+
+// GetStorageUsage mocks base method.
+func (m *MockAssetRepository) GetStorageUsage(ctx context.Context, userID uuid.UUID) ([]*entity.AssetTypeUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStorageUsage", ctx, userID)
+	ret0, _ := ret[0].([]*entity.AssetTypeUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStorageUsage indicates an expected call of GetStorageUsage.
+func (mr *MockAssetRepositoryMockRecorder) GetStorageUsage(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStorageUsage", reflect.TypeOf((*MockAssetRepository)(nil).GetStorageUsage), ctx, userID)
+}
+
+// GetZipManifest mocks base method.
+func (m *MockAssetRepository) GetZipManifest(ctx context.Context, assetID uuid.UUID) (*entity.ZipManifest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetZipManifest", ctx, assetID)
+	ret0, _ := ret[0].(*entity.ZipManifest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetZipManifest indicates an expected call of GetZipManifest.
+func (mr *MockAssetRepositoryMockRecorder) GetZipManifest(ctx, assetID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetZipManifest", reflect.TypeOf((*MockAssetRepository)(nil).GetZipManifest), ctx, assetID)
+}
+
+// HeadFile mocks base method.
+func (m *MockAssetRepository) HeadFile(ctx context.Context, assetUUID uuid.UUID, extension string) (*entity.StoredObject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HeadFile", ctx, assetUUID, extension)
+	ret0, _ := ret[0].(*entity.StoredObject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HeadFile indicates an expected call of HeadFile.
+func (mr *MockAssetRepositoryMockRecorder) HeadFile(ctx, assetUUID, extension any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HeadFile", reflect.TypeOf((*MockAssetRepository)(nil).HeadFile), ctx, assetUUID, extension)
+}
+
+// ListImagesWithoutPlaceholder mocks base method.
+func (m *MockAssetRepository) ListImagesWithoutPlaceholder(ctx context.Context, afterID uuid.UUID, limit int) ([]*entity.Asset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListImagesWithoutPlaceholder", ctx, afterID, limit)
+	ret0, _ := ret[0].([]*entity.Asset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListImagesWithoutPlaceholder indicates an expected call of ListImagesWithoutPlaceholder.
+func (mr *MockAssetRepositoryMockRecorder) ListImagesWithoutPlaceholder(ctx, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListImagesWithoutPlaceholder", reflect.TypeOf((*MockAssetRepository)(nil).ListImagesWithoutPlaceholder), ctx, afterID, limit)
+}
+
+// ListOrphans mocks base method.
+func (m *MockAssetRepository) ListOrphans(ctx context.Context, createdBefore time.Time) ([]*entity.Asset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrphans", ctx, createdBefore)
+	ret0, _ := ret[0].([]*entity.Asset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrphans indicates an expected call of ListOrphans.
+func (mr *MockAssetRepositoryMockRecorder) ListOrphans(ctx, createdBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrphans", reflect.TypeOf((*MockAssetRepository)(nil).ListOrphans), ctx, createdBefore)
+}
+
+// ListUploadedParts mocks base method.
+func (m *MockAssetRepository) ListUploadedParts(ctx context.Context, assetUUID uuid.UUID, extension, multipartUploadID string) ([]*entity.UploadedPart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUploadedParts", ctx, assetUUID, extension, multipartUploadID)
+	ret0, _ := ret[0].([]*entity.UploadedPart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUploadedParts indicates an expected call of ListUploadedParts.
+func (mr *MockAssetRepositoryMockRecorder) ListUploadedParts(ctx, assetUUID, extension, multipartUploadID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUploadedParts", reflect.TypeOf((*MockAssetRepository)(nil).ListUploadedParts), ctx, assetUUID, extension, multipartUploadID)
+}
+
+// OpenFile mocks base method.
+func (m *MockAssetRepository) OpenFile(ctx context.Context, assetUUID uuid.UUID, extension string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenFile", ctx, assetUUID, extension)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenFile indicates an expected call of OpenFile.
+func (mr *MockAssetRepositoryMockRecorder) OpenFile(ctx, assetUUID, extension any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenFile", reflect.TypeOf((*MockAssetRepository)(nil).OpenFile), ctx, assetUUID, extension)
+}
+
+// OpenURL mocks base method.
+func (m *MockAssetRepository) OpenURL(ctx context.Context, assetURL string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenURL", ctx, assetURL)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenURL indicates an expected call of OpenURL.
+func (mr *MockAssetRepositoryMockRecorder) OpenURL(ctx, assetURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenURL", reflect.TypeOf((*MockAssetRepository)(nil).OpenURL), ctx, assetURL)
+}
+
+// PlayURL mocks base method.
+func (m *MockAssetRepository) PlayURL(assetUUID uuid.UUID, filePath string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlayURL", assetUUID, filePath)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// PlayURL indicates an expected call of PlayURL.
+func (mr *MockAssetRepositoryMockRecorder) PlayURL(assetUUID, filePath any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlayURL", reflect.TypeOf((*MockAssetRepository)(nil).PlayURL), assetUUID, filePath)
+}
+
+// PresignUploadFile mocks base method.
+func (m *MockAssetRepository) PresignUploadFile(ctx context.Context, assetUUID uuid.UUID, extension, contentType string, size int64, expires time.Duration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PresignUploadFile", ctx, assetUUID, extension, contentType, size, expires)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PresignUploadFile indicates an expected call of PresignUploadFile.
+func (mr *MockAssetRepositoryMockRecorder) PresignUploadFile(ctx, assetUUID, extension, contentType, size, expires any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresignUploadFile", reflect.TypeOf((*MockAssetRepository)(nil).PresignUploadFile), ctx, assetUUID, extension, contentType, size, expires)
+}
+
+// PresignUploadPart mocks base method.
+func (m *MockAssetRepository) PresignUploadPart(ctx context.Context, assetUUID uuid.UUID, extension, multipartUploadID string, partNumber int32, size int64, expires time.Duration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PresignUploadPart", ctx, assetUUID, extension, multipartUploadID, partNumber, size, expires)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PresignUploadPart indicates an expected call of PresignUploadPart.
+func (mr *MockAssetRepositoryMockRecorder) PresignUploadPart(ctx, assetUUID, extension, multipartUploadID, partNumber, size, expires any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresignUploadPart", reflect.TypeOf((*MockAssetRepository)(nil).PresignUploadPart), ctx, assetUUID, extension, multipartUploadID, partNumber, size, expires)
+}
+
+// RegisterBlob mocks base method.
+func (m *MockAssetRepository) RegisterBlob(ctx context.Context, assetUUID uuid.UUID, extension, hash string, size int64) (*entity.UploadedFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterBlob", ctx, assetUUID, extension, hash, size)
+	ret0, _ := ret[0].(*entity.UploadedFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterBlob indicates an expected call of RegisterBlob.
+func (mr *MockAssetRepositoryMockRecorder) RegisterBlob(ctx, assetUUID, extension, hash, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterBlob", reflect.TypeOf((*MockAssetRepository)(nil).RegisterBlob), ctx, assetUUID, extension, hash, size)
+}
+
+// ReleaseBlob mocks base method.
+func (m *MockAssetRepository) ReleaseBlob(ctx context.Context, hash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseBlob", ctx, hash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseBlob indicates an expected call of ReleaseBlob.
+func (mr *MockAssetRepositoryMockRecorder) ReleaseBlob(ctx, hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseBlob", reflect.TypeOf((*MockAssetRepository)(nil).ReleaseBlob), ctx, hash)
+}
+
+// UpdatePlaceholder mocks base method.
+func (m *MockAssetRepository) UpdatePlaceholder(ctx context.Context, assetID uuid.UUID, placeholder *entity.ImagePlaceholder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePlaceholder", ctx, assetID, placeholder)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePlaceholder indicates an expected call of UpdatePlaceholder.
+func (mr *MockAssetRepositoryMockRecorder) UpdatePlaceholder(ctx, assetID, placeholder any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePlaceholder", reflect.TypeOf((*MockAssetRepository)(nil).UpdatePlaceholder), ctx, assetID, placeholder)
+}
+
 // UploadAvatar mocks base method.
 func (m *MockAssetRepository) UploadAvatar(ctx context.Context, discordUserID, avatarHash string) (*string, error) {
 	m.ctrl.T.Helper()
@@ -74,17 +456,59 @@ func (mr *MockAssetRepositoryMockRecorder) UploadAvatar(ctx, discordUserID, avat
 }
 
 // UploadFile mocks base method.
-func (m *MockAssetRepository) UploadFile(ctx context.Context, file *multipart.FileHeader, assetUUID uuid.UUID, extension string) (*string, *string, error) {
+func (m *MockAssetRepository) UploadFile(ctx context.Context, body io.ReadSeeker, assetUUID uuid.UUID, extension, contentType, hash string) (*entity.UploadedFile, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UploadFile", ctx, file, assetUUID, extension)
-	ret0, _ := ret[0].(*string)
-	ret1, _ := ret[1].(*string)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "UploadFile", ctx, body, assetUUID, extension, contentType, hash)
+	ret0, _ := ret[0].(*entity.UploadedFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // UploadFile indicates an expected call of UploadFile.
-func (mr *MockAssetRepositoryMockRecorder) UploadFile(ctx, file, assetUUID, extension any) *gomock.Call {
+func (mr *MockAssetRepositoryMockRecorder) UploadFile(ctx, body, assetUUID, extension, contentType, hash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadFile", reflect.TypeOf((*MockAssetRepository)(nil).UploadFile), ctx, body, assetUUID, extension, contentType, hash)
+}
+
+// UploadPlayFile mocks base method.
+func (m *MockAssetRepository) UploadPlayFile(ctx context.Context, assetUUID uuid.UUID, filePath string, body io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadPlayFile", ctx, assetUUID, filePath, body)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UploadPlayFile indicates an expected call of UploadPlayFile.
+func (mr *MockAssetRepositoryMockRecorder) UploadPlayFile(ctx, assetUUID, filePath, body any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadPlayFile", reflect.TypeOf((*MockAssetRepository)(nil).UploadPlayFile), ctx, assetUUID, filePath, body)
+}
+
+// UploadVariant mocks base method.
+func (m *MockAssetRepository) UploadVariant(ctx context.Context, assetUUID uuid.UUID, fileName string, data []byte, contentType string) (*string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadVariant", ctx, assetUUID, fileName, data, contentType)
+	ret0, _ := ret[0].(*string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadVariant indicates an expected call of UploadVariant.
+func (mr *MockAssetRepositoryMockRecorder) UploadVariant(ctx, assetUUID, fileName, data, contentType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadVariant", reflect.TypeOf((*MockAssetRepository)(nil).UploadVariant), ctx, assetUUID, fileName, data, contentType)
+}
+
+// WalkFiles mocks base method.
+func (m *MockAssetRepository) WalkFiles(ctx context.Context, fn func([]*entity.StoredFile) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalkFiles", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WalkFiles indicates an expected call of WalkFiles.
+func (mr *MockAssetRepositoryMockRecorder) WalkFiles(ctx, fn any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadFile", reflect.TypeOf((*MockAssetRepository)(nil).UploadFile), ctx, file, assetUUID, extension)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalkFiles", reflect.TypeOf((*MockAssetRepository)(nil).WalkFiles), ctx, fn)
 }
@@ -0,0 +1,146 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/repository/asset_upload.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/repository/asset_upload.go -destination=internal/usecase/mock/mock_asset_upload_repository.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	entity "github.com/simesaba80/toybox-back/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAssetUploadRepository is a mock of AssetUploadRepository interface.
+type MockAssetUploadRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAssetUploadRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAssetUploadRepositoryMockRecorder is the mock recorder for MockAssetUploadRepository.
+type MockAssetUploadRepositoryMockRecorder struct {
+	mock *MockAssetUploadRepository
+}
+
+// NewMockAssetUploadRepository creates a new mock instance.
+func NewMockAssetUploadRepository(ctrl *gomock.Controller) *MockAssetUploadRepository {
+	mock := &MockAssetUploadRepository{ctrl: ctrl}
+	mock.recorder = &MockAssetUploadRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAssetUploadRepository) EXPECT() *MockAssetUploadRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAssetUploadRepository) Create(ctx context.Context, upload *entity.AssetUpload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, upload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAssetUploadRepositoryMockRecorder) Create(ctx, upload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAssetUploadRepository)(nil).Create), ctx, upload)
+}
+
+// CreateWithinQuota mocks base method.
+func (m *MockAssetUploadRepository) CreateWithinQuota(ctx context.Context, upload *entity.AssetUpload, quota int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWithinQuota", ctx, upload, quota)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateWithinQuota indicates an expected call of CreateWithinQuota.
+func (mr *MockAssetUploadRepositoryMockRecorder) CreateWithinQuota(ctx, upload, quota any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWithinQuota", reflect.TypeOf((*MockAssetUploadRepository)(nil).CreateWithinQuota), ctx, upload, quota)
+}
+
+// Delete mocks base method.
+func (m *MockAssetUploadRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAssetUploadRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAssetUploadRepository)(nil).Delete), ctx, id)
+}
+
+// FindExistingIDs mocks base method.
+func (m *MockAssetUploadRepository) FindExistingIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindExistingIDs", ctx, ids)
+	ret0, _ := ret[0].(map[uuid.UUID]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindExistingIDs indicates an expected call of FindExistingIDs.
+func (mr *MockAssetUploadRepositoryMockRecorder) FindExistingIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindExistingIDs", reflect.TypeOf((*MockAssetUploadRepository)(nil).FindExistingIDs), ctx, ids)
+}
+
+// GetByID mocks base method.
+func (m *MockAssetUploadRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.AssetUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*entity.AssetUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockAssetUploadRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockAssetUploadRepository)(nil).GetByID), ctx, id)
+}
+
+// ListExpired mocks base method.
+func (m *MockAssetUploadRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*entity.AssetUpload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpired", ctx, now, limit)
+	ret0, _ := ret[0].([]*entity.AssetUpload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpired indicates an expected call of ListExpired.
+func (mr *MockAssetUploadRepositoryMockRecorder) ListExpired(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpired", reflect.TypeOf((*MockAssetUploadRepository)(nil).ListExpired), ctx, now, limit)
+}
+
+// SumPendingSize mocks base method.
+func (m *MockAssetUploadRepository) SumPendingSize(ctx context.Context, userID uuid.UUID, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumPendingSize", ctx, userID, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumPendingSize indicates an expected call of SumPendingSize.
+func (mr *MockAssetUploadRepositoryMockRecorder) SumPendingSize(ctx, userID, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumPendingSize", reflect.TypeOf((*MockAssetUploadRepository)(nil).SumPendingSize), ctx, userID, now)
+}
@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/repository/audio.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/repository/audio.go -destination=internal/usecase/mock/mock_audio_repository.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	entity "github.com/simesaba80/toybox-back/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAudioAnalyzer is a mock of AudioAnalyzer interface.
+type MockAudioAnalyzer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAudioAnalyzerMockRecorder
+	isgomock struct{}
+}
+
+// MockAudioAnalyzerMockRecorder is the mock recorder for MockAudioAnalyzer.
+type MockAudioAnalyzerMockRecorder struct {
+	mock *MockAudioAnalyzer
+}
+
+// NewMockAudioAnalyzer creates a new mock instance.
+func NewMockAudioAnalyzer(ctrl *gomock.Controller) *MockAudioAnalyzer {
+	mock := &MockAudioAnalyzer{ctrl: ctrl}
+	mock.recorder = &MockAudioAnalyzerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAudioAnalyzer) EXPECT() *MockAudioAnalyzerMockRecorder {
+	return m.recorder
+}
+
+// AnalyzeAudio mocks base method.
+func (m *MockAudioAnalyzer) AnalyzeAudio(ctx context.Context, src io.Reader, extension string) (*entity.AudioMetadata, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnalyzeAudio", ctx, src, extension)
+	ret0, _ := ret[0].(*entity.AudioMetadata)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnalyzeAudio indicates an expected call of AnalyzeAudio.
+func (mr *MockAudioAnalyzerMockRecorder) AnalyzeAudio(ctx, src, extension any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnalyzeAudio", reflect.TypeOf((*MockAudioAnalyzer)(nil).AnalyzeAudio), ctx, src, extension)
+}
@@ -0,0 +1,300 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/repository/blob.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/repository/blob.go -destination=internal/usecase/mock/mock_blob_repository.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/simesaba80/toybox-back/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBlobStore is a mock of BlobStore interface.
+type MockBlobStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlobStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockBlobStoreMockRecorder is the mock recorder for MockBlobStore.
+type MockBlobStoreMockRecorder struct {
+	mock *MockBlobStore
+}
+
+// NewMockBlobStore creates a new mock instance.
+func NewMockBlobStore(ctrl *gomock.Controller) *MockBlobStore {
+	mock := &MockBlobStore{ctrl: ctrl}
+	mock.recorder = &MockBlobStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlobStore) EXPECT() *MockBlobStoreMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockBlobStore) Delete(ctx context.Context, keys ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range keys {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Delete", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBlobStoreMockRecorder) Delete(ctx any, keys ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, keys...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBlobStore)(nil).Delete), varargs...)
+}
+
+// Get mocks base method.
+func (m *MockBlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, key)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockBlobStoreMockRecorder) Get(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockBlobStore)(nil).Get), ctx, key)
+}
+
+// Head mocks base method.
+func (m *MockBlobStore) Head(ctx context.Context, key string) (*entity.Blob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Head", ctx, key)
+	ret0, _ := ret[0].(*entity.Blob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Head indicates an expected call of Head.
+func (mr *MockBlobStoreMockRecorder) Head(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Head", reflect.TypeOf((*MockBlobStore)(nil).Head), ctx, key)
+}
+
+// List mocks base method.
+func (m *MockBlobStore) List(ctx context.Context, prefix string, fn func([]*entity.Blob) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, prefix, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// List indicates an expected call of List.
+func (mr *MockBlobStoreMockRecorder) List(ctx, prefix, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockBlobStore)(nil).List), ctx, prefix, fn)
+}
+
+// PresignGet mocks base method.
+func (m *MockBlobStore) PresignGet(ctx context.Context, key string, expires time.Duration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PresignGet", ctx, key, expires)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PresignGet indicates an expected call of PresignGet.
+func (mr *MockBlobStoreMockRecorder) PresignGet(ctx, key, expires any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresignGet", reflect.TypeOf((*MockBlobStore)(nil).PresignGet), ctx, key, expires)
+}
+
+// Put mocks base method.
+func (m *MockBlobStore) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", ctx, key, body, contentType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockBlobStoreMockRecorder) Put(ctx, key, body, contentType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockBlobStore)(nil).Put), ctx, key, body, contentType)
+}
+
+// PutEncoded mocks base method.
+func (m *MockBlobStore) PutEncoded(ctx context.Context, key string, body io.Reader, contentType, contentEncoding string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutEncoded", ctx, key, body, contentType, contentEncoding)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutEncoded indicates an expected call of PutEncoded.
+func (mr *MockBlobStoreMockRecorder) PutEncoded(ctx, key, body, contentType, contentEncoding any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutEncoded", reflect.TypeOf((*MockBlobStore)(nil).PutEncoded), ctx, key, body, contentType, contentEncoding)
+}
+
+// URL mocks base method.
+func (m *MockBlobStore) URL(key string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "URL", key)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// URL indicates an expected call of URL.
+func (mr *MockBlobStoreMockRecorder) URL(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "URL", reflect.TypeOf((*MockBlobStore)(nil).URL), key)
+}
+
+// MockUploadPresigner is a mock of UploadPresigner interface.
+type MockUploadPresigner struct {
+	ctrl     *gomock.Controller
+	recorder *MockUploadPresignerMockRecorder
+	isgomock struct{}
+}
+
+// MockUploadPresignerMockRecorder is the mock recorder for MockUploadPresigner.
+type MockUploadPresignerMockRecorder struct {
+	mock *MockUploadPresigner
+}
+
+// NewMockUploadPresigner creates a new mock instance.
+func NewMockUploadPresigner(ctrl *gomock.Controller) *MockUploadPresigner {
+	mock := &MockUploadPresigner{ctrl: ctrl}
+	mock.recorder = &MockUploadPresignerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUploadPresigner) EXPECT() *MockUploadPresignerMockRecorder {
+	return m.recorder
+}
+
+// PresignPut mocks base method.
+func (m *MockUploadPresigner) PresignPut(ctx context.Context, key, contentType string, size int64, expires time.Duration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PresignPut", ctx, key, contentType, size, expires)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PresignPut indicates an expected call of PresignPut.
+func (mr *MockUploadPresignerMockRecorder) PresignPut(ctx, key, contentType, size, expires any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresignPut", reflect.TypeOf((*MockUploadPresigner)(nil).PresignPut), ctx, key, contentType, size, expires)
+}
+
+// MockMultipartBlobStore is a mock of MultipartBlobStore interface.
+type MockMultipartBlobStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockMultipartBlobStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockMultipartBlobStoreMockRecorder is the mock recorder for MockMultipartBlobStore.
+type MockMultipartBlobStoreMockRecorder struct {
+	mock *MockMultipartBlobStore
+}
+
+// NewMockMultipartBlobStore creates a new mock instance.
+func NewMockMultipartBlobStore(ctrl *gomock.Controller) *MockMultipartBlobStore {
+	mock := &MockMultipartBlobStore{ctrl: ctrl}
+	mock.recorder = &MockMultipartBlobStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMultipartBlobStore) EXPECT() *MockMultipartBlobStoreMockRecorder {
+	return m.recorder
+}
+
+// AbortMultipart mocks base method.
+func (m *MockMultipartBlobStore) AbortMultipart(ctx context.Context, key, multipartUploadID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AbortMultipart", ctx, key, multipartUploadID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AbortMultipart indicates an expected call of AbortMultipart.
+func (mr *MockMultipartBlobStoreMockRecorder) AbortMultipart(ctx, key, multipartUploadID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbortMultipart", reflect.TypeOf((*MockMultipartBlobStore)(nil).AbortMultipart), ctx, key, multipartUploadID)
+}
+
+// CompleteMultipart mocks base method.
+func (m *MockMultipartBlobStore) CompleteMultipart(ctx context.Context, key, multipartUploadID string, parts []*entity.UploadedPart) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteMultipart", ctx, key, multipartUploadID, parts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteMultipart indicates an expected call of CompleteMultipart.
+func (mr *MockMultipartBlobStoreMockRecorder) CompleteMultipart(ctx, key, multipartUploadID, parts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteMultipart", reflect.TypeOf((*MockMultipartBlobStore)(nil).CompleteMultipart), ctx, key, multipartUploadID, parts)
+}
+
+// CreateMultipart mocks base method.
+func (m *MockMultipartBlobStore) CreateMultipart(ctx context.Context, key, contentType string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMultipart", ctx, key, contentType)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMultipart indicates an expected call of CreateMultipart.
+func (mr *MockMultipartBlobStoreMockRecorder) CreateMultipart(ctx, key, contentType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMultipart", reflect.TypeOf((*MockMultipartBlobStore)(nil).CreateMultipart), ctx, key, contentType)
+}
+
+// ListParts mocks base method.
+func (m *MockMultipartBlobStore) ListParts(ctx context.Context, key, multipartUploadID string) ([]*entity.UploadedPart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListParts", ctx, key, multipartUploadID)
+	ret0, _ := ret[0].([]*entity.UploadedPart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListParts indicates an expected call of ListParts.
+func (mr *MockMultipartBlobStoreMockRecorder) ListParts(ctx, key, multipartUploadID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListParts", reflect.TypeOf((*MockMultipartBlobStore)(nil).ListParts), ctx, key, multipartUploadID)
+}
+
+// PresignPart mocks base method.
+func (m *MockMultipartBlobStore) PresignPart(ctx context.Context, key, multipartUploadID string, partNumber int32, size int64, expires time.Duration) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PresignPart", ctx, key, multipartUploadID, partNumber, size, expires)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PresignPart indicates an expected call of PresignPart.
+func (mr *MockMultipartBlobStoreMockRecorder) PresignPart(ctx, key, multipartUploadID, partNumber, size, expires any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresignPart", reflect.TypeOf((*MockMultipartBlobStore)(nil).PresignPart), ctx, key, multipartUploadID, partNumber, size, expires)
+}
@@ -0,0 +1,88 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/repository/image.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/repository/image.go -destination=internal/usecase/mock/mock_image_repository.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	entity "github.com/simesaba80/toybox-back/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockImageProcessor is a mock of ImageProcessor interface.
+type MockImageProcessor struct {
+	ctrl     *gomock.Controller
+	recorder *MockImageProcessorMockRecorder
+	isgomock struct{}
+}
+
+// MockImageProcessorMockRecorder is the mock recorder for MockImageProcessor.
+type MockImageProcessorMockRecorder struct {
+	mock *MockImageProcessor
+}
+
+// NewMockImageProcessor creates a new mock instance.
+func NewMockImageProcessor(ctrl *gomock.Controller) *MockImageProcessor {
+	mock := &MockImageProcessor{ctrl: ctrl}
+	mock.recorder = &MockImageProcessorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImageProcessor) EXPECT() *MockImageProcessorMockRecorder {
+	return m.recorder
+}
+
+// GeneratePlaceholder mocks base method.
+func (m *MockImageProcessor) GeneratePlaceholder(ctx context.Context, src io.Reader) (*entity.ImagePlaceholder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GeneratePlaceholder", ctx, src)
+	ret0, _ := ret[0].(*entity.ImagePlaceholder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GeneratePlaceholder indicates an expected call of GeneratePlaceholder.
+func (mr *MockImageProcessorMockRecorder) GeneratePlaceholder(ctx, src any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GeneratePlaceholder", reflect.TypeOf((*MockImageProcessor)(nil).GeneratePlaceholder), ctx, src)
+}
+
+// GenerateVariants mocks base method.
+func (m *MockImageProcessor) GenerateVariants(ctx context.Context, src io.Reader, widths []int) ([]*entity.ImageVariant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateVariants", ctx, src, widths)
+	ret0, _ := ret[0].([]*entity.ImageVariant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateVariants indicates an expected call of GenerateVariants.
+func (mr *MockImageProcessorMockRecorder) GenerateVariants(ctx, src, widths any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateVariants", reflect.TypeOf((*MockImageProcessor)(nil).GenerateVariants), ctx, src, widths)
+}
+
+// StripMetadata mocks base method.
+func (m *MockImageProcessor) StripMetadata(ctx context.Context, data []byte, contentType string) (*entity.StrippedImage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StripMetadata", ctx, data, contentType)
+	ret0, _ := ret[0].(*entity.StrippedImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StripMetadata indicates an expected call of StripMetadata.
+func (mr *MockImageProcessorMockRecorder) StripMetadata(ctx, data, contentType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StripMetadata", reflect.TypeOf((*MockImageProcessor)(nil).StripMetadata), ctx, data, contentType)
+}
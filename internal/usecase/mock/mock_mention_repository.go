@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/repository/mention.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/repository/mention.go -destination=internal/usecase/mock/mock_mention_repository.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	entity "github.com/simesaba80/toybox-back/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMentionRepository is a mock of MentionRepository interface.
+type MockMentionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMentionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockMentionRepositoryMockRecorder is the mock recorder for MockMentionRepository.
+type MockMentionRepositoryMockRecorder struct {
+	mock *MockMentionRepository
+}
+
+// NewMockMentionRepository creates a new mock instance.
+func NewMockMentionRepository(ctrl *gomock.Controller) *MockMentionRepository {
+	mock := &MockMentionRepository{ctrl: ctrl}
+	mock.recorder = &MockMentionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMentionRepository) EXPECT() *MockMentionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateMany mocks base method.
+func (m *MockMentionRepository) CreateMany(ctx context.Context, mentions []*entity.Mention) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMany", ctx, mentions)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMany indicates an expected call of CreateMany.
+func (mr *MockMentionRepositoryMockRecorder) CreateMany(ctx, mentions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMany", reflect.TypeOf((*MockMentionRepository)(nil).CreateMany), ctx, mentions)
+}
+
+// DeleteByCommentID mocks base method.
+func (m *MockMentionRepository) DeleteByCommentID(ctx context.Context, commentID uuid.UUID, keepUserIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByCommentID", ctx, commentID, keepUserIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByCommentID indicates an expected call of DeleteByCommentID.
+func (mr *MockMentionRepositoryMockRecorder) DeleteByCommentID(ctx, commentID, keepUserIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByCommentID", reflect.TypeOf((*MockMentionRepository)(nil).DeleteByCommentID), ctx, commentID, keepUserIDs)
+}
+
+// GetByUserID mocks base method.
+func (m *MockMentionRepository) GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*entity.Mention, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", ctx, userID, limit, offset)
+	ret0, _ := ret[0].([]*entity.Mention)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockMentionRepositoryMockRecorder) GetByUserID(ctx, userID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockMentionRepository)(nil).GetByUserID), ctx, userID, limit, offset)
+}
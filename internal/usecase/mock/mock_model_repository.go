@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/repository/model.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/repository/model.go -destination=internal/usecase/mock/mock_model_repository.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	entity "github.com/simesaba80/toybox-back/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockModelInspector is a mock of ModelInspector interface.
+type MockModelInspector struct {
+	ctrl     *gomock.Controller
+	recorder *MockModelInspectorMockRecorder
+	isgomock struct{}
+}
+
+// MockModelInspectorMockRecorder is the mock recorder for MockModelInspector.
+type MockModelInspectorMockRecorder struct {
+	mock *MockModelInspector
+}
+
+// NewMockModelInspector creates a new mock instance.
+func NewMockModelInspector(ctrl *gomock.Controller) *MockModelInspector {
+	mock := &MockModelInspector{ctrl: ctrl}
+	mock.recorder = &MockModelInspectorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockModelInspector) EXPECT() *MockModelInspectorMockRecorder {
+	return m.recorder
+}
+
+// InspectModel mocks base method.
+func (m *MockModelInspector) InspectModel(ctx context.Context, src io.Reader, extension string) (*entity.ModelStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InspectModel", ctx, src, extension)
+	ret0, _ := ret[0].(*entity.ModelStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InspectModel indicates an expected call of InspectModel.
+func (mr *MockModelInspectorMockRecorder) InspectModel(ctx, src, extension any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectModel", reflect.TypeOf((*MockModelInspector)(nil).InspectModel), ctx, src, extension)
+}
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/repository/reaction.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/repository/reaction.go -destination=internal/usecase/mock/mock_reaction_repository.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	entity "github.com/simesaba80/toybox-back/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReactionRepository is a mock of ReactionRepository interface.
+type MockReactionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReactionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockReactionRepositoryMockRecorder is the mock recorder for MockReactionRepository.
+type MockReactionRepositoryMockRecorder struct {
+	mock *MockReactionRepository
+}
+
+// NewMockReactionRepository creates a new mock instance.
+func NewMockReactionRepository(ctrl *gomock.Controller) *MockReactionRepository {
+	mock := &MockReactionRepository{ctrl: ctrl}
+	mock.recorder = &MockReactionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReactionRepository) EXPECT() *MockReactionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockReactionRepository) Create(ctx context.Context, reaction *entity.Reaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, reaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockReactionRepositoryMockRecorder) Create(ctx, reaction any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReactionRepository)(nil).Create), ctx, reaction)
+}
+
+// Delete mocks base method.
+func (m *MockReactionRepository) Delete(ctx context.Context, reaction *entity.Reaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, reaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockReactionRepositoryMockRecorder) Delete(ctx, reaction any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockReactionRepository)(nil).Delete), ctx, reaction)
+}
+
+// Summarize mocks base method.
+func (m *MockReactionRepository) Summarize(ctx context.Context, targetType entity.ReactionTargetType, targetIDs []uuid.UUID, viewerID uuid.UUID) (map[uuid.UUID][]*entity.ReactionSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Summarize", ctx, targetType, targetIDs, viewerID)
+	ret0, _ := ret[0].(map[uuid.UUID][]*entity.ReactionSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Summarize indicates an expected call of Summarize.
+func (mr *MockReactionRepositoryMockRecorder) Summarize(ctx, targetType, targetIDs, viewerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Summarize", reflect.TypeOf((*MockReactionRepository)(nil).Summarize), ctx, targetType, targetIDs, viewerID)
+}
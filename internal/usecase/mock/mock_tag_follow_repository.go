@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/repository/tag_follow.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/repository/tag_follow.go -destination=internal/usecase/mock/mock_tag_follow_repository.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	entity "github.com/simesaba80/toybox-back/internal/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTagFollowRepository is a mock of TagFollowRepository interface.
+type MockTagFollowRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagFollowRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTagFollowRepositoryMockRecorder is the mock recorder for MockTagFollowRepository.
+type MockTagFollowRepositoryMockRecorder struct {
+	mock *MockTagFollowRepository
+}
+
+// NewMockTagFollowRepository creates a new mock instance.
+func NewMockTagFollowRepository(ctrl *gomock.Controller) *MockTagFollowRepository {
+	mock := &MockTagFollowRepository{ctrl: ctrl}
+	mock.recorder = &MockTagFollowRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagFollowRepository) EXPECT() *MockTagFollowRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTagFollowRepository) Create(ctx context.Context, tagFollow *entity.TagFollow) (*entity.TagFollow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, tagFollow)
+	ret0, _ := ret[0].(*entity.TagFollow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTagFollowRepositoryMockRecorder) Create(ctx, tagFollow any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTagFollowRepository)(nil).Create), ctx, tagFollow)
+}
+
+// CreateNewWorkEntries mocks base method.
+func (m *MockTagFollowRepository) CreateNewWorkEntries(ctx context.Context, workID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNewWorkEntries", ctx, workID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateNewWorkEntries indicates an expected call of CreateNewWorkEntries.
+func (mr *MockTagFollowRepositoryMockRecorder) CreateNewWorkEntries(ctx, workID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNewWorkEntries", reflect.TypeOf((*MockTagFollowRepository)(nil).CreateNewWorkEntries), ctx, workID)
+}
+
+// Delete mocks base method.
+func (m *MockTagFollowRepository) Delete(ctx context.Context, tagFollow *entity.TagFollow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, tagFollow)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTagFollowRepositoryMockRecorder) Delete(ctx, tagFollow any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTagFollowRepository)(nil).Delete), ctx, tagFollow)
+}
+
+// Exists mocks base method.
+func (m *MockTagFollowRepository) Exists(ctx context.Context, tagFollow *entity.TagFollow) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", ctx, tagFollow)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockTagFollowRepositoryMockRecorder) Exists(ctx, tagFollow any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockTagFollowRepository)(nil).Exists), ctx, tagFollow)
+}
+
+// GetNewWorks mocks base method.
+func (m *MockTagFollowRepository) GetNewWorks(ctx context.Context, userID uuid.UUID, unseenOnly bool, limit, offset int) ([]*entity.TagNewWork, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNewWorks", ctx, userID, unseenOnly, limit, offset)
+	ret0, _ := ret[0].([]*entity.TagNewWork)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetNewWorks indicates an expected call of GetNewWorks.
+func (mr *MockTagFollowRepositoryMockRecorder) GetNewWorks(ctx, userID, unseenOnly, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNewWorks", reflect.TypeOf((*MockTagFollowRepository)(nil).GetNewWorks), ctx, userID, unseenOnly, limit, offset)
+}
+
+// MarkNewWorksAsSeen mocks base method.
+func (m *MockTagFollowRepository) MarkNewWorksAsSeen(ctx context.Context, userID uuid.UUID, workIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNewWorksAsSeen", ctx, userID, workIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNewWorksAsSeen indicates an expected call of MarkNewWorksAsSeen.
+func (mr *MockTagFollowRepositoryMockRecorder) MarkNewWorksAsSeen(ctx, userID, workIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNewWorksAsSeen", reflect.TypeOf((*MockTagFollowRepository)(nil).MarkNewWorksAsSeen), ctx, userID, workIDs)
+}
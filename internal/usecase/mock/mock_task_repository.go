@@ -0,0 +1,53 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/repository/task.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/domain/repository/task.go -destination=internal/usecase/mock/mock_task_repository.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTaskRunner is a mock of TaskRunner interface.
+type MockTaskRunner struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskRunnerMockRecorder
+	isgomock struct{}
+}
+
+// MockTaskRunnerMockRecorder is the mock recorder for MockTaskRunner.
+type MockTaskRunnerMockRecorder struct {
+	mock *MockTaskRunner
+}
+
+// NewMockTaskRunner creates a new mock instance.
+func NewMockTaskRunner(ctrl *gomock.Controller) *MockTaskRunner {
+	mock := &MockTaskRunner{ctrl: ctrl}
+	mock.recorder = &MockTaskRunnerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskRunner) EXPECT() *MockTaskRunnerMockRecorder {
+	return m.recorder
+}
+
+// Go mocks base method.
+func (m *MockTaskRunner) Go(ctx context.Context, task func(context.Context)) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Go", ctx, task)
+}
+
+// Go indicates an expected call of Go.
+func (mr *MockTaskRunnerMockRecorder) Go(ctx, task any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Go", reflect.TypeOf((*MockTaskRunner)(nil).Go), ctx, task)
+}
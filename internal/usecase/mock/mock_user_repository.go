@@ -87,6 +87,21 @@ func (mr *MockUserRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUserRepository)(nil).GetByID), ctx, id)
 }
 
+// GetByNames mocks base method.
+func (m *MockUserRepository) GetByNames(ctx context.Context, names []string) ([]*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByNames", ctx, names)
+	ret0, _ := ret[0].([]*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByNames indicates an expected call of GetByNames.
+func (mr *MockUserRepositoryMockRecorder) GetByNames(ctx, names any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByNames", reflect.TypeOf((*MockUserRepository)(nil).GetByNames), ctx, names)
+}
+
 // GetUserByDiscordUserID mocks base method.
 func (m *MockUserRepository) GetUserByDiscordUserID(ctx context.Context, discordUserID string) (*entity.User, error) {
 	m.ctrl.T.Helper()
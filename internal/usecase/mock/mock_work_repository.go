@@ -12,6 +12,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	entity "github.com/simesaba80/toybox-back/internal/domain/entity"
@@ -133,3 +134,33 @@ func (mr *MockWorkRepositoryMockRecorder) GetByUserID(ctx, userID, public any) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockWorkRepository)(nil).GetByUserID), ctx, userID, public)
 }
+
+// GetFeed mocks base method.
+func (m *MockWorkRepository) GetFeed(ctx context.Context, userID uuid.UUID, cursor *entity.FeedCursor, limit int, trendingSince time.Time, trendingMinFavorites int) ([]*entity.Work, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeed", ctx, userID, cursor, limit, trendingSince, trendingMinFavorites)
+	ret0, _ := ret[0].([]*entity.Work)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeed indicates an expected call of GetFeed.
+func (mr *MockWorkRepositoryMockRecorder) GetFeed(ctx, userID, cursor, limit, trendingSince, trendingMinFavorites any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeed", reflect.TypeOf((*MockWorkRepository)(nil).GetFeed), ctx, userID, cursor, limit, trendingSince, trendingMinFavorites)
+}
+
+// GetFollowingFeed mocks base method.
+func (m *MockWorkRepository) GetFollowingFeed(ctx context.Context, userID uuid.UUID, cursor *entity.FeedCursor, limit int) ([]*entity.Work, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFollowingFeed", ctx, userID, cursor, limit)
+	ret0, _ := ret[0].([]*entity.Work)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFollowingFeed indicates an expected call of GetFollowingFeed.
+func (mr *MockWorkRepositoryMockRecorder) GetFollowingFeed(ctx, userID, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFollowingFeed", reflect.TypeOf((*MockWorkRepository)(nil).GetFollowingFeed), ctx, userID, cursor, limit)
+}
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	"github.com/simesaba80/toybox-back/internal/domain/repository"
+)
+
+type INotificationUsecase interface {
+	GetNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, page *int) ([]*entity.Notification, int, int, int, error)
+	CountUnread(ctx context.Context, userID uuid.UUID) (int, error)
+	MarkAsRead(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) error
+}
+
+type notificationUsecase struct {
+	notificationRepo repository.NotificationRepository
+}
+
+func NewNotificationUsecase(notificationRepo repository.NotificationRepository) INotificationUsecase {
+	return &notificationUsecase{
+		notificationRepo: notificationRepo,
+	}
+}
+
+func (uc *notificationUsecase) GetNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, limit, page *int) ([]*entity.Notification, int, int, int, error) {
+	actualLimit := 20
+	actualPage := 1
+	if limit != nil {
+		actualLimit = *limit
+	}
+	if page != nil {
+		actualPage = *page
+	}
+	offset := (actualPage - 1) * actualLimit
+
+	notifications, total, err := uc.notificationRepo.GetByUserID(ctx, userID, unreadOnly, actualLimit, offset)
+	if err != nil {
+		return nil, 0, 0, 0, fmt.Errorf("failed to get notifications for user ID %s: %w", userID.String(), err)
+	}
+	return notifications, total, actualLimit, actualPage, nil
+}
+
+func (uc *notificationUsecase) CountUnread(ctx context.Context, userID uuid.UUID) (int, error) {
+	count, err := uc.notificationRepo.CountUnread(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications for user ID %s: %w", userID.String(), err)
+	}
+	return count, nil
+}
+
+func (uc *notificationUsecase) MarkAsRead(ctx context.Context, userID uuid.UUID, ids []uuid.UUID) error {
+	err := uc.notificationRepo.MarkAsRead(ctx, userID, ids)
+	if err != nil {
+		return fmt.Errorf("failed to mark notifications as read for user ID %s: %w", userID.String(), err)
+	}
+	return nil
+}
+
+// emitNotification は通知を保存します。自分自身の操作は通知しません。
+// 通知は元の操作に付随するものなので、失敗しても呼び出し元の処理は失敗させずログに残すだけにします。
+func emitNotification(ctx context.Context, notificationRepo repository.NotificationRepository, notification *entity.Notification) {
+	if notification.UserID == notification.ActorID {
+		return
+	}
+	if _, err := notificationRepo.Create(ctx, notification); err != nil {
+		log.Printf("通知の作成に失敗しました (type=%s, user_id=%s): %v", notification.Type, notification.UserID.String(), err)
+	}
+}
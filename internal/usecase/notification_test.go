@@ -0,0 +1,136 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/usecase"
+	"github.com/simesaba80/toybox-back/internal/usecase/mock"
+	"github.com/simesaba80/toybox-back/internal/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNotificationUsecase_GetNotifications(t *testing.T) {
+	userID := uuid.New()
+	notifications := []*entity.Notification{
+		entity.NewNotification(userID, uuid.New(), entity.NotificationTypeFavorite, uuid.New(), nil),
+	}
+
+	tests := []struct {
+		name       string
+		unreadOnly bool
+		limit      *int
+		page       *int
+		setupMock  func(*mock.MockNotificationRepository)
+		wantLimit  int
+		wantPage   int
+		wantErr    bool
+		errIs      error
+	}{
+		{
+			name:       "正常系: デフォルトのページングで取得できる",
+			unreadOnly: false,
+			setupMock: func(m *mock.MockNotificationRepository) {
+				m.EXPECT().GetByUserID(gomock.Any(), userID, false, 20, 0).Return(notifications, 1, nil)
+			},
+			wantLimit: 20,
+			wantPage:  1,
+		},
+		{
+			name:       "正常系: 未読のみを指定したページで取得できる",
+			unreadOnly: true,
+			limit:      util.IntPtr(10),
+			page:       util.IntPtr(3),
+			setupMock: func(m *mock.MockNotificationRepository) {
+				m.EXPECT().GetByUserID(gomock.Any(), userID, true, 10, 20).Return(notifications, 1, nil)
+			},
+			wantLimit: 10,
+			wantPage:  3,
+		},
+		{
+			name: "異常系: リポジトリエラー",
+			setupMock: func(m *mock.MockNotificationRepository) {
+				m.EXPECT().GetByUserID(gomock.Any(), userID, false, 20, 0).Return(nil, 0, domainerrors.ErrFailedToGetNotifications)
+			},
+			wantErr: true,
+			errIs:   domainerrors.ErrFailedToGetNotifications,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockNotificationRepository(ctrl)
+			tt.setupMock(mockRepo)
+
+			uc := usecase.NewNotificationUsecase(mockRepo)
+			result, total, limit, page, err := uc.GetNotifications(context.Background(), userID, tt.unreadOnly, tt.limit, tt.page)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errIs != nil {
+					assert.ErrorIs(t, err, tt.errIs)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, notifications, result)
+			assert.Equal(t, 1, total)
+			assert.Equal(t, tt.wantLimit, limit)
+			assert.Equal(t, tt.wantPage, page)
+		})
+	}
+}
+
+func TestNotificationUsecase_MarkAsRead(t *testing.T) {
+	userID := uuid.New()
+	ids := []uuid.UUID{uuid.New()}
+
+	tests := []struct {
+		name      string
+		setupMock func(*mock.MockNotificationRepository)
+		wantErr   bool
+		errIs     error
+	}{
+		{
+			name: "正常系: 通知を既読にできる",
+			setupMock: func(m *mock.MockNotificationRepository) {
+				m.EXPECT().MarkAsRead(gomock.Any(), userID, ids).Return(nil)
+			},
+		},
+		{
+			name: "異常系: リポジトリエラー",
+			setupMock: func(m *mock.MockNotificationRepository) {
+				m.EXPECT().MarkAsRead(gomock.Any(), userID, ids).Return(domainerrors.ErrFailedToMarkNotificationsAsRead)
+			},
+			wantErr: true,
+			errIs:   domainerrors.ErrFailedToMarkNotificationsAsRead,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := mock.NewMockNotificationRepository(ctrl)
+			tt.setupMock(mockRepo)
+
+			uc := usecase.NewNotificationUsecase(mockRepo)
+			err := uc.MarkAsRead(context.Background(), userID, ids)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.ErrorIs(t, err, tt.errIs)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
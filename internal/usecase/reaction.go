@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/domain/repository"
+)
+
+type IReactionUsecase interface {
+	AddReaction(ctx context.Context, userID uuid.UUID, targetType entity.ReactionTargetType, targetID uuid.UUID, emoji string) error
+	RemoveReaction(ctx context.Context, userID uuid.UUID, targetType entity.ReactionTargetType, targetID uuid.UUID, emoji string) error
+}
+
+type reactionUsecase struct {
+	reactionRepo  repository.ReactionRepository
+	workRepo      repository.WorkRepository
+	commentRepo   repository.CommentRepository
+	allowedEmojis []string
+}
+
+// NewReactionUsecase はallowedEmojisに含まれる絵文字だけをリアクションとして受け付けるReactionUsecaseを作成します。
+func NewReactionUsecase(reactionRepo repository.ReactionRepository, workRepo repository.WorkRepository, commentRepo repository.CommentRepository, allowedEmojis []string) IReactionUsecase {
+	return &reactionUsecase{
+		reactionRepo:  reactionRepo,
+		workRepo:      workRepo,
+		commentRepo:   commentRepo,
+		allowedEmojis: allowedEmojis,
+	}
+}
+
+func (uc *reactionUsecase) AddReaction(ctx context.Context, userID uuid.UUID, targetType entity.ReactionTargetType, targetID uuid.UUID, emoji string) error {
+	if !slices.Contains(uc.allowedEmojis, emoji) {
+		return domainerrors.ErrReactionEmojiNotAllowed
+	}
+	if err := uc.checkTarget(ctx, targetType, targetID); err != nil {
+		return err
+	}
+
+	if err := uc.reactionRepo.Create(ctx, entity.NewReaction(targetType, targetID, userID, emoji)); err != nil {
+		return fmt.Errorf("failed to create reaction: %w", err)
+	}
+	return nil
+}
+
+// RemoveReaction はリアクションを取り消します。付けていないリアクションを取り消しても成功として扱います。
+func (uc *reactionUsecase) RemoveReaction(ctx context.Context, userID uuid.UUID, targetType entity.ReactionTargetType, targetID uuid.UUID, emoji string) error {
+	if err := uc.reactionRepo.Delete(ctx, entity.NewReaction(targetType, targetID, userID, emoji)); err != nil {
+		return fmt.Errorf("failed to delete reaction: %w", err)
+	}
+	return nil
+}
+
+// checkTarget はリアクションを付ける作品やコメントが存在するか確認します。削除済みのコメントには付けられません。
+func (uc *reactionUsecase) checkTarget(ctx context.Context, targetType entity.ReactionTargetType, targetID uuid.UUID) error {
+	switch targetType {
+	case entity.ReactionTargetWork:
+		exists, err := uc.workRepo.ExistsById(ctx, targetID)
+		if err != nil {
+			return fmt.Errorf("failed to check work existence: %w", err)
+		}
+		if !exists {
+			return domainerrors.ErrWorkNotFound
+		}
+	case entity.ReactionTargetComment:
+		comment, err := uc.commentRepo.FindByID(ctx, targetID)
+		if err != nil {
+			return fmt.Errorf("failed to get comment %s: %w", targetID.String(), err)
+		}
+		if comment.IsDeleted() {
+			return domainerrors.ErrCommentNotFound
+		}
+	default:
+		return domainerrors.ErrInvalidRequestBody
+	}
+	return nil
+}
+
+// attachWorkReactions は作品にリアクションの集計を設定します。
+// リアクションは表示を補うものなので、失敗してもログに残すだけにします。
+func attachWorkReactions(ctx context.Context, reactionRepo repository.ReactionRepository, works []*entity.Work, viewerID uuid.UUID) {
+	workIDs := make([]uuid.UUID, len(works))
+	for i, work := range works {
+		workIDs[i] = work.ID
+	}
+	summaries := summarizeReactions(ctx, reactionRepo, entity.ReactionTargetWork, workIDs, viewerID)
+	for _, work := range works {
+		work.Reactions = summaries[work.ID]
+	}
+}
+
+// attachCommentReactions はコメントにリアクションの集計を設定します。
+// リアクションは表示を補うものなので、失敗してもログに残すだけにします。
+func attachCommentReactions(ctx context.Context, reactionRepo repository.ReactionRepository, comments []*entity.Comment, viewerID uuid.UUID) {
+	commentIDs := make([]uuid.UUID, len(comments))
+	for i, comment := range comments {
+		commentIDs[i] = comment.ID
+	}
+	summaries := summarizeReactions(ctx, reactionRepo, entity.ReactionTargetComment, commentIDs, viewerID)
+	for _, comment := range comments {
+		comment.Reactions = summaries[comment.ID]
+	}
+}
+
+func summarizeReactions(ctx context.Context, reactionRepo repository.ReactionRepository, targetType entity.ReactionTargetType, targetIDs []uuid.UUID, viewerID uuid.UUID) map[uuid.UUID][]*entity.ReactionSummary {
+	if len(targetIDs) == 0 {
+		return nil
+	}
+	summaries, err := reactionRepo.Summarize(ctx, targetType, targetIDs, viewerID)
+	if err != nil {
+		log.Printf("リアクションの集計に失敗しました (target_type=%s): %v", targetType, err)
+		return nil
+	}
+	return summaries
+}
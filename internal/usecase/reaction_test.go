@@ -0,0 +1,211 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/usecase"
+	"github.com/simesaba80/toybox-back/internal/usecase/mock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+var allowedEmojis = []string{"👍", "🔥"}
+
+// newSummarizingReactionRepository はリアクションが1件も付いていないものとして集計を返すモックです。
+// リアクションの集計を主題にしないテストで使います。
+func newSummarizingReactionRepository(ctrl *gomock.Controller) *mock.MockReactionRepository {
+	m := mock.NewMockReactionRepository(ctrl)
+	m.EXPECT().
+		Summarize(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(map[uuid.UUID][]*entity.ReactionSummary{}, nil).
+		AnyTimes()
+	return m
+}
+
+func TestReactionUsecase_AddReaction(t *testing.T) {
+	userID := uuid.New()
+	targetID := uuid.New()
+	deletedAt := time.Now()
+
+	tests := []struct {
+		name       string
+		targetType entity.ReactionTargetType
+		emoji      string
+		setupMock  func(*mock.MockReactionRepository, *mock.MockWorkRepository, *mock.MockCommentRepository)
+		errIs      error
+		wantErr    bool
+	}{
+		{
+			name:       "正常系: 作品にリアクションを付けられる",
+			targetType: entity.ReactionTargetWork,
+			emoji:      "👍",
+			setupMock: func(rm *mock.MockReactionRepository, wm *mock.MockWorkRepository, cm *mock.MockCommentRepository) {
+				wm.EXPECT().ExistsById(gomock.Any(), targetID).Return(true, nil)
+				rm.EXPECT().
+					Create(gomock.Any(), gomock.AssignableToTypeOf(&entity.Reaction{})).
+					DoAndReturn(func(_ context.Context, r *entity.Reaction) error {
+						assert.Equal(t, entity.ReactionTargetWork, r.TargetType)
+						assert.Equal(t, targetID, r.TargetID)
+						assert.Equal(t, userID, r.UserID)
+						assert.Equal(t, "👍", r.Emoji)
+						return nil
+					})
+			},
+		},
+		{
+			name:       "正常系: コメントにリアクションを付けられる",
+			targetType: entity.ReactionTargetComment,
+			emoji:      "🔥",
+			setupMock: func(rm *mock.MockReactionRepository, wm *mock.MockWorkRepository, cm *mock.MockCommentRepository) {
+				cm.EXPECT().FindByID(gomock.Any(), targetID).Return(&entity.Comment{ID: targetID}, nil)
+				rm.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:       "異常系: 許可されていない絵文字",
+			targetType: entity.ReactionTargetWork,
+			emoji:      "💩",
+			setupMock: func(rm *mock.MockReactionRepository, wm *mock.MockWorkRepository, cm *mock.MockCommentRepository) {
+				rm.EXPECT().Create(gomock.Any(), gomock.Any()).Times(0)
+			},
+			wantErr: true,
+			errIs:   domainerrors.ErrReactionEmojiNotAllowed,
+		},
+		{
+			name:       "異常系: 作品が存在しない",
+			targetType: entity.ReactionTargetWork,
+			emoji:      "👍",
+			setupMock: func(rm *mock.MockReactionRepository, wm *mock.MockWorkRepository, cm *mock.MockCommentRepository) {
+				wm.EXPECT().ExistsById(gomock.Any(), targetID).Return(false, nil)
+			},
+			wantErr: true,
+			errIs:   domainerrors.ErrWorkNotFound,
+		},
+		{
+			name:       "異常系: 削除済みのコメント",
+			targetType: entity.ReactionTargetComment,
+			emoji:      "👍",
+			setupMock: func(rm *mock.MockReactionRepository, wm *mock.MockWorkRepository, cm *mock.MockCommentRepository) {
+				cm.EXPECT().FindByID(gomock.Any(), targetID).Return(&entity.Comment{ID: targetID, DeletedAt: &deletedAt}, nil)
+			},
+			wantErr: true,
+			errIs:   domainerrors.ErrCommentNotFound,
+		},
+		{
+			name:       "異常系: リポジトリエラー",
+			targetType: entity.ReactionTargetWork,
+			emoji:      "👍",
+			setupMock: func(rm *mock.MockReactionRepository, wm *mock.MockWorkRepository, cm *mock.MockCommentRepository) {
+				wm.EXPECT().ExistsById(gomock.Any(), targetID).Return(true, nil)
+				rm.EXPECT().Create(gomock.Any(), gomock.Any()).Return(domainerrors.ErrFailedToCreateReaction)
+			},
+			wantErr: true,
+			errIs:   domainerrors.ErrFailedToCreateReaction,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockReactionRepo := mock.NewMockReactionRepository(ctrl)
+			mockWorkRepo := mock.NewMockWorkRepository(ctrl)
+			mockCommentRepo := mock.NewMockCommentRepository(ctrl)
+			tt.setupMock(mockReactionRepo, mockWorkRepo, mockCommentRepo)
+
+			uc := usecase.NewReactionUsecase(mockReactionRepo, mockWorkRepo, mockCommentRepo, allowedEmojis)
+			err := uc.AddReaction(context.Background(), userID, tt.targetType, targetID, tt.emoji)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errIs != nil {
+					assert.ErrorIs(t, err, tt.errIs)
+				}
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestReactionUsecase_RemoveReaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := uuid.New()
+	targetID := uuid.New()
+	mockReactionRepo := mock.NewMockReactionRepository(ctrl)
+	mockReactionRepo.EXPECT().Delete(gomock.Any(), gomock.AssignableToTypeOf(&entity.Reaction{})).Return(nil)
+	mockReactionRepo.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(errors.New("database error"))
+
+	uc := usecase.NewReactionUsecase(mockReactionRepo, mock.NewMockWorkRepository(ctrl), mock.NewMockCommentRepository(ctrl), allowedEmojis)
+	assert.NoError(t, uc.RemoveReaction(context.Background(), userID, entity.ReactionTargetWork, targetID, "👍"))
+	assert.Error(t, uc.RemoveReaction(context.Background(), userID, entity.ReactionTargetWork, targetID, "👍"))
+}
+
+func TestWorkUseCase_GetByID_Reactions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workID := uuid.New()
+	viewerID := uuid.New()
+	summaries := []*entity.ReactionSummary{
+		{Emoji: "🔥", Count: 3, Reacted: true},
+		{Emoji: "👍", Count: 1},
+	}
+
+	mockWorkRepo := mock.NewMockWorkRepository(ctrl)
+	mockWorkRepo.EXPECT().GetByID(gomock.Any(), workID).Return(&entity.Work{ID: workID}, nil)
+	mockReactionRepo := mock.NewMockReactionRepository(ctrl)
+	mockReactionRepo.EXPECT().
+		Summarize(gomock.Any(), entity.ReactionTargetWork, []uuid.UUID{workID}, viewerID).
+		Return(map[uuid.UUID][]*entity.ReactionSummary{workID: summaries}, nil)
+
+	uc := usecase.NewWorkUseCase(mockWorkRepo, mock.NewMockTagRepository(ctrl), mock.NewMockTagFollowRepository(ctrl), mock.NewMockUserRepository(ctrl), mock.NewMockMentionRepository(ctrl), mockReactionRepo, newSyncTaskRunner(ctrl))
+	got, err := uc.GetByID(context.Background(), workID, viewerID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, summaries, got.Reactions)
+}
+
+func TestCommentUsecase_GetCommentsByWorkID_Reactions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workID := uuid.New()
+	reacted := &entity.Comment{ID: uuid.New(), WorkID: workID, Content: "コメント"}
+	notReacted := &entity.Comment{ID: uuid.New(), WorkID: workID, Content: "コメント"}
+	summaries := []*entity.ReactionSummary{{Emoji: "👍", Count: 2}}
+
+	mockCommentRepo := mock.NewMockCommentRepository(ctrl)
+	mockCommentRepo.EXPECT().FindByWorkID(gomock.Any(), workID).Return([]*entity.Comment{reacted, notReacted}, nil)
+	mockReactionRepo := mock.NewMockReactionRepository(ctrl)
+	// 集計に失敗してもコメントは返す
+	mockReactionRepo.EXPECT().
+		Summarize(gomock.Any(), entity.ReactionTargetComment, []uuid.UUID{reacted.ID, notReacted.ID}, uuid.Nil).
+		Return(map[uuid.UUID][]*entity.ReactionSummary{reacted.ID: summaries}, nil)
+	mockReactionRepo.EXPECT().
+		Summarize(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, domainerrors.ErrFailedToGetReactions)
+
+	uc := usecase.NewCommentUsecase(mockCommentRepo, mock.NewMockWorkRepository(ctrl), mock.NewMockNotificationRepository(ctrl), mock.NewMockEventBroker(ctrl), mock.NewMockUserRepository(ctrl), mock.NewMockMentionRepository(ctrl), mockReactionRepo, nil, usecase.AnonymousCommentConfig{}, 30*time.Second)
+	got, err := uc.GetCommentsByWorkID(context.Background(), workID, uuid.Nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, summaries, got[0].Reactions)
+	assert.Nil(t, got[1].Reactions)
+
+	mockCommentRepo.EXPECT().FindByWorkID(gomock.Any(), workID).Return([]*entity.Comment{reacted}, nil)
+	got, err = uc.GetCommentsByWorkID(context.Background(), workID, uuid.Nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, got, 1)
+	assert.Nil(t, got[0].Reactions)
+}
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/domain/repository"
+)
+
+type ITagFollowUsecase interface {
+	FollowTag(ctx context.Context, userID uuid.UUID, tagID uuid.UUID) error
+	UnfollowTag(ctx context.Context, userID uuid.UUID, tagID uuid.UUID) error
+	GetNewWorks(ctx context.Context, userID uuid.UUID, unseenOnly bool, limit, page *int) ([]*entity.TagNewWork, int, int, int, error)
+	MarkNewWorksAsSeen(ctx context.Context, userID uuid.UUID, workIDs []uuid.UUID) error
+}
+
+type tagFollowUsecase struct {
+	tagFollowRepo repository.TagFollowRepository
+	tagRepo       repository.TagRepository
+}
+
+func NewTagFollowUsecase(tagFollowRepo repository.TagFollowRepository, tagRepo repository.TagRepository) ITagFollowUsecase {
+	return &tagFollowUsecase{
+		tagFollowRepo: tagFollowRepo,
+		tagRepo:       tagRepo,
+	}
+}
+
+func (uc *tagFollowUsecase) FollowTag(ctx context.Context, userID uuid.UUID, tagID uuid.UUID) error {
+	exists, err := uc.tagRepo.ExistAll(ctx, []uuid.UUID{tagID})
+	if err != nil {
+		return fmt.Errorf("failed to check tag existence: %w", err)
+	}
+	if !exists {
+		return domainerrors.ErrTagNotFound
+	}
+
+	tagFollow := entity.NewTagFollow(userID, tagID)
+	if uc.tagFollowRepo.Exists(ctx, tagFollow) {
+		return domainerrors.ErrTagFollowAlreadyExists
+	}
+
+	_, err = uc.tagFollowRepo.Create(ctx, tagFollow)
+	if err != nil {
+		return fmt.Errorf("failed to create tag follow: %w", err)
+	}
+	return nil
+}
+
+func (uc *tagFollowUsecase) UnfollowTag(ctx context.Context, userID uuid.UUID, tagID uuid.UUID) error {
+	tagFollow := entity.NewTagFollow(userID, tagID)
+	if !uc.tagFollowRepo.Exists(ctx, tagFollow) {
+		return domainerrors.ErrTagFollowNotFound
+	}
+	return uc.tagFollowRepo.Delete(ctx, tagFollow)
+}
+
+func (uc *tagFollowUsecase) GetNewWorks(ctx context.Context, userID uuid.UUID, unseenOnly bool, limit, page *int) ([]*entity.TagNewWork, int, int, int, error) {
+	actualLimit := 20
+	actualPage := 1
+	if limit != nil {
+		actualLimit = *limit
+	}
+	if page != nil {
+		actualPage = *page
+	}
+	offset := (actualPage - 1) * actualLimit
+
+	newWorks, total, err := uc.tagFollowRepo.GetNewWorks(ctx, userID, unseenOnly, actualLimit, offset)
+	if err != nil {
+		return nil, 0, 0, 0, fmt.Errorf("failed to get tag new works for user ID %s: %w", userID.String(), err)
+	}
+	return newWorks, total, actualLimit, actualPage, nil
+}
+
+func (uc *tagFollowUsecase) MarkNewWorksAsSeen(ctx context.Context, userID uuid.UUID, workIDs []uuid.UUID) error {
+	err := uc.tagFollowRepo.MarkNewWorksAsSeen(ctx, userID, workIDs)
+	if err != nil {
+		return fmt.Errorf("failed to mark tag new works as seen for user ID %s: %w", userID.String(), err)
+	}
+	return nil
+}
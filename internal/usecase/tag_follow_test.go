@@ -0,0 +1,213 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
+	"github.com/simesaba80/toybox-back/internal/usecase"
+	"github.com/simesaba80/toybox-back/internal/usecase/mock"
+	"github.com/simesaba80/toybox-back/internal/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTagFollowUsecase_FollowTag(t *testing.T) {
+	userID := uuid.New()
+	tagID := uuid.New()
+
+	tests := []struct {
+		name      string
+		setupMock func(*mock.MockTagFollowRepository, *mock.MockTagRepository)
+		wantErr   bool
+		errIs     error
+	}{
+		{
+			name: "正常系: タグをフォローできる",
+			setupMock: func(fm *mock.MockTagFollowRepository, tm *mock.MockTagRepository) {
+				tm.EXPECT().ExistAll(gomock.Any(), []uuid.UUID{tagID}).Return(true, nil)
+				fm.EXPECT().Exists(gomock.Any(), gomock.AssignableToTypeOf(&entity.TagFollow{})).Return(false)
+				fm.EXPECT().
+					Create(gomock.Any(), gomock.AssignableToTypeOf(&entity.TagFollow{})).
+					DoAndReturn(func(_ context.Context, tagFollow *entity.TagFollow) (*entity.TagFollow, error) {
+						assert.Equal(t, userID, tagFollow.UserID)
+						assert.Equal(t, tagID, tagFollow.TagID)
+						return tagFollow, nil
+					})
+			},
+			wantErr: false,
+		},
+		{
+			name: "異常系: タグが存在しない",
+			setupMock: func(fm *mock.MockTagFollowRepository, tm *mock.MockTagRepository) {
+				tm.EXPECT().ExistAll(gomock.Any(), []uuid.UUID{tagID}).Return(false, nil)
+			},
+			wantErr: true,
+			errIs:   domainerrors.ErrTagNotFound,
+		},
+		{
+			name: "異常系: 既にフォローしている",
+			setupMock: func(fm *mock.MockTagFollowRepository, tm *mock.MockTagRepository) {
+				tm.EXPECT().ExistAll(gomock.Any(), []uuid.UUID{tagID}).Return(true, nil)
+				fm.EXPECT().Exists(gomock.Any(), gomock.AssignableToTypeOf(&entity.TagFollow{})).Return(true)
+			},
+			wantErr: true,
+			errIs:   domainerrors.ErrTagFollowAlreadyExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTagFollowRepo := mock.NewMockTagFollowRepository(ctrl)
+			mockTagRepo := mock.NewMockTagRepository(ctrl)
+			tt.setupMock(mockTagFollowRepo, mockTagRepo)
+
+			uc := usecase.NewTagFollowUsecase(mockTagFollowRepo, mockTagRepo)
+			err := uc.FollowTag(context.Background(), userID, tagID)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errIs != nil {
+					assert.ErrorIs(t, err, tt.errIs)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTagFollowUsecase_UnfollowTag(t *testing.T) {
+	userID := uuid.New()
+	tagID := uuid.New()
+
+	tests := []struct {
+		name      string
+		setupMock func(*mock.MockTagFollowRepository)
+		wantErr   bool
+		errIs     error
+	}{
+		{
+			name: "正常系: タグのフォローを解除できる",
+			setupMock: func(m *mock.MockTagFollowRepository) {
+				m.EXPECT().Exists(gomock.Any(), gomock.AssignableToTypeOf(&entity.TagFollow{})).Return(true)
+				m.EXPECT().Delete(gomock.Any(), gomock.AssignableToTypeOf(&entity.TagFollow{})).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "異常系: フォローしていない",
+			setupMock: func(m *mock.MockTagFollowRepository) {
+				m.EXPECT().Exists(gomock.Any(), gomock.AssignableToTypeOf(&entity.TagFollow{})).Return(false)
+			},
+			wantErr: true,
+			errIs:   domainerrors.ErrTagFollowNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTagFollowRepo := mock.NewMockTagFollowRepository(ctrl)
+			mockTagRepo := mock.NewMockTagRepository(ctrl)
+			tt.setupMock(mockTagFollowRepo)
+
+			uc := usecase.NewTagFollowUsecase(mockTagFollowRepo, mockTagRepo)
+			err := uc.UnfollowTag(context.Background(), userID, tagID)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errIs != nil {
+					assert.ErrorIs(t, err, tt.errIs)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTagFollowUsecase_GetNewWorks(t *testing.T) {
+	userID := uuid.New()
+	newWorks := []*entity.TagNewWork{
+		{UserID: userID, WorkID: uuid.New(), Work: &entity.Work{}},
+	}
+
+	tests := []struct {
+		name      string
+		limit     *int
+		page      *int
+		setupMock func(*mock.MockTagFollowRepository)
+		wantLimit int
+		wantPage  int
+		wantErr   bool
+		errIs     error
+	}{
+		{
+			name:  "正常系: デフォルトのページングで取得できる",
+			limit: nil,
+			page:  nil,
+			setupMock: func(m *mock.MockTagFollowRepository) {
+				m.EXPECT().GetNewWorks(gomock.Any(), userID, true, 20, 0).Return(newWorks, 1, nil)
+			},
+			wantLimit: 20,
+			wantPage:  1,
+			wantErr:   false,
+		},
+		{
+			name:  "正常系: ページ指定でオフセットが計算される",
+			limit: util.IntPtr(10),
+			page:  util.IntPtr(3),
+			setupMock: func(m *mock.MockTagFollowRepository) {
+				m.EXPECT().GetNewWorks(gomock.Any(), userID, true, 10, 20).Return(newWorks, 21, nil)
+			},
+			wantLimit: 10,
+			wantPage:  3,
+			wantErr:   false,
+		},
+		{
+			name:  "異常系: リポジトリエラー",
+			limit: nil,
+			page:  nil,
+			setupMock: func(m *mock.MockTagFollowRepository) {
+				m.EXPECT().GetNewWorks(gomock.Any(), userID, true, 20, 0).Return(nil, 0, domainerrors.ErrFailedToGetTagNewWorks)
+			},
+			wantErr: true,
+			errIs:   domainerrors.ErrFailedToGetTagNewWorks,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTagFollowRepo := mock.NewMockTagFollowRepository(ctrl)
+			mockTagRepo := mock.NewMockTagRepository(ctrl)
+			tt.setupMock(mockTagFollowRepo)
+
+			uc := usecase.NewTagFollowUsecase(mockTagFollowRepo, mockTagRepo)
+			got, _, limit, page, err := uc.GetNewWorks(context.Background(), userID, true, tt.limit, tt.page)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errIs != nil {
+					assert.ErrorIs(t, err, tt.errIs)
+				}
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, got, len(newWorks))
+				assert.Equal(t, tt.wantLimit, limit)
+				assert.Equal(t, tt.wantPage, page)
+			}
+		})
+	}
+}
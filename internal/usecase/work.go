@@ -3,6 +3,8 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"log"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/simesaba80/toybox-back/internal/domain/entity"
@@ -12,20 +14,45 @@ import (
 
 type IWorkUseCase interface {
 	GetAll(ctx context.Context, limit, page *int, userID uuid.UUID, tagIDs []uuid.UUID) ([]*entity.Work, int, int, int, error)
-	GetByID(ctx context.Context, id uuid.UUID) (*entity.Work, error)
+	GetByID(ctx context.Context, id uuid.UUID, viewerID uuid.UUID) (*entity.Work, error)
 	GetByUserID(ctx context.Context, userID uuid.UUID, authenticatedUserID uuid.UUID) ([]*entity.Work, error)
 	CreateWork(ctx context.Context, title, description, visibility string, thumbnailAssetID uuid.UUID, assetIDs []uuid.UUID, urls []string, userID uuid.UUID, tagIDs []uuid.UUID) (*entity.Work, error)
+	GetFeed(ctx context.Context, userID uuid.UUID, limit *int, cursor string) ([]*entity.Work, string, error)
+	GetFollowingFeed(ctx context.Context, userID uuid.UUID, limit *int, cursor string) ([]*entity.Work, string, error)
 }
 
+const (
+	// フィードで「人気」とみなす作品を集計する期間といいね数の下限
+	feedTrendingWindow       = 7 * 24 * time.Hour
+	feedTrendingMinFavorites = 3
+)
+
+// タグフォロワー向けの新着の記録はリクエストとは切り離して行うため、独自のタイムアウトを持たせる
+const tagNewWorksTimeout = 30 * time.Second
+
+// workVisibilityDraft は作者以外には見せない下書きの公開範囲
+const workVisibilityDraft = "draft"
+
 type workUseCase struct {
-	workRepo repository.WorkRepository
-	tagRepo  repository.TagRepository
+	workRepo      repository.WorkRepository
+	tagRepo       repository.TagRepository
+	tagFollowRepo repository.TagFollowRepository
+	userRepo      repository.UserRepository
+	mentionRepo   repository.MentionRepository
+	reactionRepo  repository.ReactionRepository
+	// tasks はタグフォロワーへの新着の記録のように、応答した後も続ける処理を実行する
+	tasks repository.TaskRunner
 }
 
-func NewWorkUseCase(workRepo repository.WorkRepository, tagRepo repository.TagRepository) IWorkUseCase {
+func NewWorkUseCase(workRepo repository.WorkRepository, tagRepo repository.TagRepository, tagFollowRepo repository.TagFollowRepository, userRepo repository.UserRepository, mentionRepo repository.MentionRepository, reactionRepo repository.ReactionRepository, tasks repository.TaskRunner) IWorkUseCase {
 	return &workUseCase{
-		workRepo: workRepo,
-		tagRepo:  tagRepo,
+		workRepo:      workRepo,
+		tagRepo:       tagRepo,
+		tagFollowRepo: tagFollowRepo,
+		userRepo:      userRepo,
+		mentionRepo:   mentionRepo,
+		reactionRepo:  reactionRepo,
+		tasks:         tasks,
 	}
 }
 
@@ -44,6 +71,7 @@ func (uc *workUseCase) GetAll(ctx context.Context, limit, page *int, userID uuid
 		if err != nil {
 			return nil, 0, 0, 0, fmt.Errorf("failed to get all works by user ID %s: %w", userID.String(), err)
 		}
+		attachWorkReactions(ctx, uc.reactionRepo, works, userID)
 		return works, total, actualLimit, actualPage, nil
 	}
 
@@ -51,14 +79,17 @@ func (uc *workUseCase) GetAll(ctx context.Context, limit, page *int, userID uuid
 	if err != nil {
 		return nil, 0, 0, 0, fmt.Errorf("failed to get all works: %w", err)
 	}
+	attachWorkReactions(ctx, uc.reactionRepo, works, userID)
 	return works, total, actualLimit, actualPage, nil
 }
 
-func (uc *workUseCase) GetByID(ctx context.Context, id uuid.UUID) (*entity.Work, error) {
+// GetByID は作品を取得します。viewerIDは閲覧しているユーザーで、ログインしていない場合はuuid.Nilです。
+func (uc *workUseCase) GetByID(ctx context.Context, id uuid.UUID, viewerID uuid.UUID) (*entity.Work, error) {
 	work, err := uc.workRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get work by ID %s: %w", id.String(), err)
 	}
+	attachWorkReactions(ctx, uc.reactionRepo, []*entity.Work{work}, viewerID)
 	return work, nil
 }
 
@@ -74,6 +105,7 @@ func (uc *workUseCase) GetByUserID(ctx context.Context, userID uuid.UUID, authen
 	if err != nil {
 		return nil, fmt.Errorf("failed to get works by user ID %s: %w", userID.String(), err)
 	}
+	attachWorkReactions(ctx, uc.reactionRepo, works, authenticatedUserID)
 	return works, nil
 }
 
@@ -125,5 +157,95 @@ func (uc *workUseCase) CreateWork(ctx context.Context, title, description, visib
 	if err != nil {
 		return nil, fmt.Errorf("failed to create work: %w", err)
 	}
+
+	workID := createdWork.ID
+	uc.tasks.Go(ctx, func(ctx context.Context) {
+		uc.recordTagNewWorks(ctx, workID)
+	})
+	uc.recordDescriptionMentions(ctx, createdWork)
+
 	return createdWork, nil
 }
+
+// recordDescriptionMentions は作品の説明文でメンションされたユーザーに記録します。下書きの作品は記録しません。
+// 作品の作成自体は完了しているため、失敗してもログに残すだけにします。
+func (uc *workUseCase) recordDescriptionMentions(ctx context.Context, work *entity.Work) {
+	// 下書きはメンションされたユーザーも開けないため、記録しない
+	if work.Visibility == workVisibilityDraft {
+		return
+	}
+	spans, err := resolveMentions(ctx, uc.userRepo, work.Description)
+	if err != nil {
+		log.Printf("メンションの解決に失敗しました (work_id=%s): %v", work.ID.String(), err)
+		return
+	}
+	recordMentions(ctx, uc.mentionRepo, spans, work.UserID, work.ID, nil)
+}
+
+// recordTagNewWorks は作品に付いたタグのフォロワーへ新着を記録します。
+// 作品の作成自体は完了しているため、失敗してもログに残すだけにします。
+func (uc *workUseCase) recordTagNewWorks(ctx context.Context, workID uuid.UUID) {
+	ctx, cancel := context.WithTimeout(ctx, tagNewWorksTimeout)
+	defer cancel()
+
+	if err := uc.tagFollowRepo.CreateNewWorkEntries(ctx, workID); err != nil {
+		log.Printf("タグフォロワーへの新着の記録に失敗しました (work_id=%s): %v", workID.String(), err)
+	}
+}
+
+func (uc *workUseCase) GetFeed(ctx context.Context, userID uuid.UUID, limit *int, cursor string) ([]*entity.Work, string, error) {
+	actualLimit, feedCursor, err := parseFeedParams(limit, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// 次のページの有無を判定するために1件多く取得する
+	works, err := uc.workRepo.GetFeed(ctx, userID, feedCursor, actualLimit+1, time.Now().Add(-feedTrendingWindow), feedTrendingMinFavorites)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get feed for user ID %s: %w", userID.String(), err)
+	}
+
+	works, nextCursor := trimFeedPage(works, actualLimit)
+	attachWorkReactions(ctx, uc.reactionRepo, works, userID)
+	return works, nextCursor, nil
+}
+
+func (uc *workUseCase) GetFollowingFeed(ctx context.Context, userID uuid.UUID, limit *int, cursor string) ([]*entity.Work, string, error) {
+	actualLimit, feedCursor, err := parseFeedParams(limit, cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	works, err := uc.workRepo.GetFollowingFeed(ctx, userID, feedCursor, actualLimit+1)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get following feed for user ID %s: %w", userID.String(), err)
+	}
+
+	works, nextCursor := trimFeedPage(works, actualLimit)
+	attachWorkReactions(ctx, uc.reactionRepo, works, userID)
+	return works, nextCursor, nil
+}
+
+func parseFeedParams(limit *int, cursor string) (int, *entity.FeedCursor, error) {
+	actualLimit := 20
+	if limit != nil {
+		actualLimit = *limit
+	}
+	if cursor == "" {
+		return actualLimit, nil, nil
+	}
+	feedCursor, err := entity.DecodeFeedCursor(cursor)
+	if err != nil {
+		return 0, nil, err
+	}
+	return actualLimit, feedCursor, nil
+}
+
+// trimFeedPage はlimitより1件多く取得した結果をlimit件に切り詰め、続きがあれば次のカーソルを返します。
+func trimFeedPage(works []*entity.Work, limit int) ([]*entity.Work, string) {
+	if len(works) <= limit {
+		return works, ""
+	}
+	works = works[:limit]
+	return works, entity.NewFeedCursor(works[len(works)-1]).Encode()
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/simesaba80/toybox-back/internal/domain/entity"
+	domainerrors "github.com/simesaba80/toybox-back/internal/domain/errors"
 	"github.com/simesaba80/toybox-back/internal/usecase"
 	"github.com/simesaba80/toybox-back/internal/usecase/mock"
 	"github.com/simesaba80/toybox-back/internal/util"
@@ -15,6 +16,19 @@ import (
 	"go.uber.org/mock/gomock"
 )
 
+// newSyncTaskRunner はバックグラウンドの処理をその場で実行するモックです。
+// 処理が終わってから CreateWork などが戻るため、テストで結果を待ち合わせずに確かめられます。
+func newSyncTaskRunner(ctrl *gomock.Controller) *mock.MockTaskRunner {
+	m := mock.NewMockTaskRunner(ctrl)
+	m.EXPECT().
+		Go(gomock.Any(), gomock.Any()).
+		Do(func(ctx context.Context, task func(ctx context.Context)) {
+			task(context.WithoutCancel(ctx))
+		}).
+		AnyTimes()
+	return m
+}
+
 func TestWorkUseCase_GetAll(t *testing.T) {
 	author := entity.NewUser("test", "test@test.com", "test", "test", "test")
 	tests := []struct {
@@ -249,10 +263,11 @@ func TestWorkUseCase_GetAll(t *testing.T) {
 
 			mockWorkRepo := mock.NewMockWorkRepository(ctrl)
 			mockTagRepo := mock.NewMockTagRepository(ctrl)
+			mockTagFollowRepo := mock.NewMockTagFollowRepository(ctrl)
 			tt.setupWorkMock(mockWorkRepo)
 			tt.setupTagMock(mockTagRepo)
 
-			uc := usecase.NewWorkUseCase(mockWorkRepo, mockTagRepo)
+			uc := usecase.NewWorkUseCase(mockWorkRepo, mockTagRepo, mockTagFollowRepo, mock.NewMockUserRepository(ctrl), mock.NewMockMentionRepository(ctrl), newSummarizingReactionRepository(ctrl), newSyncTaskRunner(ctrl))
 
 			got, total, limit, page, err := uc.GetAll(context.Background(), tt.limit, tt.page, tt.userID, tt.tagIDs)
 
@@ -322,12 +337,13 @@ func TestWorkUseCase_GetByID(t *testing.T) {
 
 			mockWorkRepo := mock.NewMockWorkRepository(ctrl)
 			mockTagRepo := mock.NewMockTagRepository(ctrl)
+			mockTagFollowRepo := mock.NewMockTagFollowRepository(ctrl)
 			tt.setupWorkMock(mockWorkRepo, tt.workID)
 			tt.setupTagMock(mockTagRepo)
 
-			uc := usecase.NewWorkUseCase(mockWorkRepo, mockTagRepo)
+			uc := usecase.NewWorkUseCase(mockWorkRepo, mockTagRepo, mockTagFollowRepo, mock.NewMockUserRepository(ctrl), mock.NewMockMentionRepository(ctrl), newSummarizingReactionRepository(ctrl), newSyncTaskRunner(ctrl))
 
-			got, err := uc.GetByID(context.Background(), tt.workID)
+			got, err := uc.GetByID(context.Background(), tt.workID, uuid.Nil)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -464,9 +480,10 @@ func TestWorkUseCase_GetByUserID(t *testing.T) {
 
 			mockRepo := mock.NewMockWorkRepository(ctrl)
 			mockTagRepo := mock.NewMockTagRepository(ctrl)
+			mockTagFollowRepo := mock.NewMockTagFollowRepository(ctrl)
 			tt.setupMock(mockRepo, tt.userID)
 
-			uc := usecase.NewWorkUseCase(mockRepo, mockTagRepo)
+			uc := usecase.NewWorkUseCase(mockRepo, mockTagRepo, mockTagFollowRepo, mock.NewMockUserRepository(ctrl), mock.NewMockMentionRepository(ctrl), newSummarizingReactionRepository(ctrl), newSyncTaskRunner(ctrl))
 
 			got, err := uc.GetByUserID(context.Background(), tt.userID, tt.authenticatedUserID)
 
@@ -670,11 +687,23 @@ func TestWorkUseCase_CreateWork(t *testing.T) {
 
 			mockWorkRepo := mock.NewMockWorkRepository(ctrl)
 			mockTagRepo := mock.NewMockTagRepository(ctrl)
+			mockTagFollowRepo := mock.NewMockTagFollowRepository(ctrl)
 
 			tt.setupWorkMock(mockWorkRepo)
 			tt.setupTagMock(mockTagRepo, tt.tagIDs)
 
-			uc := usecase.NewWorkUseCase(mockWorkRepo, mockTagRepo)
+			var recorded uuid.UUID
+			if !tt.wantErr {
+				mockTagFollowRepo.EXPECT().
+					CreateNewWorkEntries(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, workID uuid.UUID) error {
+						recorded = workID
+						return nil
+					}).
+					Times(1)
+			}
+
+			uc := usecase.NewWorkUseCase(mockWorkRepo, mockTagRepo, mockTagFollowRepo, mock.NewMockUserRepository(ctrl), mock.NewMockMentionRepository(ctrl), newSummarizingReactionRepository(ctrl), newSyncTaskRunner(ctrl))
 			got, err := uc.CreateWork(context.Background(), tt.title, tt.description, tt.visibility, tt.thumbnailAssetID, tt.assetIDs, tt.urls, tt.userID, tt.tagIDs)
 
 			if tt.wantErr {
@@ -682,6 +711,7 @@ func TestWorkUseCase_CreateWork(t *testing.T) {
 				assert.Nil(t, got)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, got.ID, recorded)
 				assert.NotNil(t, got)
 				assert.Equal(t, tt.title, got.Title)
 				assert.Equal(t, tt.description, got.Description)
@@ -690,3 +720,197 @@ func TestWorkUseCase_CreateWork(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkUseCase_GetFeed(t *testing.T) {
+	userID := uuid.New()
+	now := time.Now()
+	works := []*entity.Work{
+		{ID: uuid.New(), Title: "Work1", CreatedAt: now},
+		{ID: uuid.New(), Title: "Work2", CreatedAt: now.Add(-time.Minute)},
+		{ID: uuid.New(), Title: "Work3", CreatedAt: now.Add(-2 * time.Minute)},
+	}
+	cursor := entity.NewFeedCursor(works[0])
+
+	tests := []struct {
+		name           string
+		limit          *int
+		cursor         string
+		setupWorkMock  func(*mock.MockWorkRepository)
+		wantCount      int
+		wantNextCursor string
+		wantErr        bool
+		errIs          error
+	}{
+		{
+			name:   "正常系: 次のページがある場合はカーソルを返す",
+			limit:  util.IntPtr(2),
+			cursor: "",
+			setupWorkMock: func(m *mock.MockWorkRepository) {
+				m.EXPECT().
+					GetFeed(gomock.Any(), userID, gomock.Nil(), 3, gomock.Any(), gomock.Any()).
+					Return(works, nil).
+					Times(1)
+			},
+			wantCount:      2,
+			wantNextCursor: entity.NewFeedCursor(works[1]).Encode(),
+			wantErr:        false,
+		},
+		{
+			name:   "正常系: 最後のページではカーソルを返さない",
+			limit:  nil,
+			cursor: cursor.Encode(),
+			setupWorkMock: func(m *mock.MockWorkRepository) {
+				m.EXPECT().
+					GetFeed(gomock.Any(), userID, gomock.Any(), 21, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ uuid.UUID, c *entity.FeedCursor, _ int, _ time.Time, _ int) ([]*entity.Work, error) {
+						assert.Equal(t, cursor.ID, c.ID)
+						assert.True(t, cursor.CreatedAt.Equal(c.CreatedAt))
+						return works[1:], nil
+					}).
+					Times(1)
+			},
+			wantCount:      2,
+			wantNextCursor: "",
+			wantErr:        false,
+		},
+		{
+			name:   "異常系: 不正なカーソル",
+			limit:  nil,
+			cursor: "invalid-cursor",
+			setupWorkMock: func(m *mock.MockWorkRepository) {
+				m.EXPECT().
+					GetFeed(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			wantErr: true,
+			errIs:   domainerrors.ErrInvalidFeedCursor,
+		},
+		{
+			name:   "異常系: リポジトリエラー",
+			limit:  nil,
+			cursor: "",
+			setupWorkMock: func(m *mock.MockWorkRepository) {
+				m.EXPECT().
+					GetFeed(gomock.Any(), userID, gomock.Nil(), 21, gomock.Any(), gomock.Any()).
+					Return(nil, domainerrors.ErrFailedToGetFeed).
+					Times(1)
+			},
+			wantErr: true,
+			errIs:   domainerrors.ErrFailedToGetFeed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockWorkRepo := mock.NewMockWorkRepository(ctrl)
+			mockTagRepo := mock.NewMockTagRepository(ctrl)
+			mockTagFollowRepo := mock.NewMockTagFollowRepository(ctrl)
+			tt.setupWorkMock(mockWorkRepo)
+
+			uc := usecase.NewWorkUseCase(mockWorkRepo, mockTagRepo, mockTagFollowRepo, mock.NewMockUserRepository(ctrl), mock.NewMockMentionRepository(ctrl), newSummarizingReactionRepository(ctrl), newSyncTaskRunner(ctrl))
+			got, nextCursor, err := uc.GetFeed(context.Background(), userID, tt.limit, tt.cursor)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errIs != nil {
+					assert.ErrorIs(t, err, tt.errIs)
+				}
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, got, tt.wantCount)
+				assert.Equal(t, tt.wantNextCursor, nextCursor)
+			}
+		})
+	}
+}
+
+func TestWorkUseCase_GetFollowingFeed(t *testing.T) {
+	userID := uuid.New()
+	now := time.Now()
+	works := []*entity.Work{
+		{ID: uuid.New(), Title: "Work1", CreatedAt: now},
+		{ID: uuid.New(), Title: "Work2", CreatedAt: now.Add(-time.Minute)},
+	}
+
+	tests := []struct {
+		name           string
+		limit          *int
+		cursor         string
+		setupWorkMock  func(*mock.MockWorkRepository)
+		wantCount      int
+		wantNextCursor string
+		wantErr        bool
+		errIs          error
+	}{
+		{
+			name:   "正常系: 次のページがある場合はカーソルを返す",
+			limit:  util.IntPtr(1),
+			cursor: "",
+			setupWorkMock: func(m *mock.MockWorkRepository) {
+				m.EXPECT().
+					GetFollowingFeed(gomock.Any(), userID, gomock.Nil(), 2).
+					Return(works, nil).
+					Times(1)
+			},
+			wantCount:      1,
+			wantNextCursor: entity.NewFeedCursor(works[0]).Encode(),
+			wantErr:        false,
+		},
+		{
+			name:   "異常系: 不正なカーソル",
+			limit:  nil,
+			cursor: "invalid-cursor",
+			setupWorkMock: func(m *mock.MockWorkRepository) {
+				m.EXPECT().
+					GetFollowingFeed(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			wantErr: true,
+			errIs:   domainerrors.ErrInvalidFeedCursor,
+		},
+		{
+			name:   "異常系: リポジトリエラー",
+			limit:  nil,
+			cursor: "",
+			setupWorkMock: func(m *mock.MockWorkRepository) {
+				m.EXPECT().
+					GetFollowingFeed(gomock.Any(), userID, gomock.Nil(), 21).
+					Return(nil, domainerrors.ErrFailedToGetFollowingFeed).
+					Times(1)
+			},
+			wantErr: true,
+			errIs:   domainerrors.ErrFailedToGetFollowingFeed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockWorkRepo := mock.NewMockWorkRepository(ctrl)
+			mockTagRepo := mock.NewMockTagRepository(ctrl)
+			mockTagFollowRepo := mock.NewMockTagFollowRepository(ctrl)
+			tt.setupWorkMock(mockWorkRepo)
+
+			uc := usecase.NewWorkUseCase(mockWorkRepo, mockTagRepo, mockTagFollowRepo, mock.NewMockUserRepository(ctrl), mock.NewMockMentionRepository(ctrl), newSummarizingReactionRepository(ctrl), newSyncTaskRunner(ctrl))
+			got, nextCursor, err := uc.GetFollowingFeed(context.Background(), userID, tt.limit, tt.cursor)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errIs != nil {
+					assert.ErrorIs(t, err, tt.errIs)
+				}
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, got, tt.wantCount)
+				assert.Equal(t, tt.wantNextCursor, nextCursor)
+			}
+		})
+	}
+}
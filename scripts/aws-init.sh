@@ -2,3 +2,16 @@
 export AWS_ACCESS_KEY_ID=test AWS_SECRET_ACCESS_KEY=test
 
 awslocal s3 mb s3://toybox
+
+# ブラウザから署名付きURLで直接PUTできるようにする
+awslocal s3api put-bucket-cors --bucket toybox --cors-configuration '{
+  "CORSRules": [
+    {
+      "AllowedOrigins": ["*"],
+      "AllowedMethods": ["GET", "PUT"],
+      "AllowedHeaders": ["*"],
+      "ExposeHeaders": ["ETag"],
+      "MaxAgeSeconds": 3000
+    }
+  ]
+}'
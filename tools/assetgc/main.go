@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/simesaba80/toybox-back/internal/di"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/config"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/asset"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/assetupload"
+	"github.com/simesaba80/toybox-back/internal/usecase"
+	"github.com/simesaba80/toybox-back/pkg/db"
+)
+
+// 作品に使われないまま放置されたアセットと、対応するアセットのないストレージのファイルを削除します。
+//
+//	go run ./tools/assetgc --dry-run
+//	go run ./tools/assetgc --older-than 72h
+func main() {
+	config.LoadEnv()
+
+	dryRun := flag.Bool("dry-run", false, "削除せずに対象だけを表示する")
+	olderThan := flag.Duration("older-than", config.ASSET_ORPHAN_TTL, "この期間より前から使われていないものを対象にする")
+	flag.Parse()
+
+	if *olderThan <= 0 {
+		fmt.Fprintln(os.Stderr, "ERROR: --older-than must be positive")
+		os.Exit(2)
+	}
+
+	db.Init()
+	defer db.DB.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	gc := usecase.NewAssetGCUsecase(
+		asset.NewAssetRepository(db.DB, di.ProvideBlobStore()),
+		assetupload.NewAssetUploadRepository(db.DB),
+	)
+	report, err := gc.CollectOrphans(ctx, *olderThan, *dryRun)
+	if report != nil {
+		verb := "Deleted"
+		if report.DryRun {
+			verb = "Would delete"
+		}
+		for _, orphan := range report.Assets {
+			fmt.Printf("%s asset %s (user=%s, created_at=%s, url=%s)\n", verb, orphan.ID, orphan.UserID, orphan.CreatedAt.Format(time.RFC3339), orphan.URL)
+		}
+		for _, file := range report.Files {
+			fmt.Printf("%s file %s (%d bytes)\n", verb, file.Key, file.Size)
+		}
+		fmt.Printf("%s %d assets and %d files (%d bytes).\n", verb, len(report.Assets), len(report.Files), report.FileBytes())
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/simesaba80/toybox-back/internal/di"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/config"
+	"github.com/simesaba80/toybox-back/internal/infrastructure/database/asset"
+	"github.com/simesaba80/toybox-back/internal/usecase"
+	"github.com/simesaba80/toybox-back/pkg/db"
+)
+
+// プレースホルダーのない画像アセット (移行前からあるアセットなど) に、BlurHash・主要な色・大きさを作成して保存します。
+// 何度実行しても、まだプレースホルダーのない画像だけを対象にします。
+//
+//	go run ./tools/assetplaceholder
+func main() {
+	config.LoadEnv()
+
+	db.Init()
+	defer db.DB.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	backfill := usecase.NewAssetPlaceholderUsecase(
+		asset.NewAssetRepository(db.DB, di.ProvideBlobStore()),
+		di.ProvideImageProcessor(),
+	)
+	result, err := backfill.BackfillPlaceholders(ctx)
+	if result != nil {
+		for _, updated := range result.Updated {
+			fmt.Printf("Updated asset %s (%dx%d, %s, url=%s)\n", updated.ID, updated.Placeholder.Width, updated.Placeholder.Height, updated.Placeholder.DominantColor, updated.URL)
+		}
+		for _, failed := range result.Failed {
+			fmt.Printf("Skipped asset %s (url=%s): %v\n", failed.AssetID, failed.URL, failed.Err)
+		}
+		fmt.Printf("Updated %d assets, skipped %d assets.\n", len(result.Updated), len(result.Failed))
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+		os.Exit(1)
+	}
+}